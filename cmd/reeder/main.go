@@ -1,11 +1,16 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -17,41 +22,166 @@ import (
 	article "github.com/glabrego/reeder-cli/internal/render/article"
 	"github.com/glabrego/reeder-cli/internal/storage"
 	"github.com/glabrego/reeder-cli/internal/tui"
+	tuiplatform "github.com/glabrego/reeder-cli/internal/tui/platform"
 )
 
+// version is reported in the diagnostic info; release builds set it with
+// -ldflags "-X main.version=...".
+var version = "dev"
+
 func main() {
 	cfg, err := config.LoadFromEnv()
 	if err != nil {
 		log.Fatalf("config error: %v", err)
 	}
+	muteRules, err := tui.ParseMuteRules(cfg.MuteRules)
+	if err != nil {
+		log.Fatalf("config error: FEEDBIN_MUTE_KEYWORDS: %v", err)
+	}
+	quietHours, err := tui.ParseQuietHours(cfg.QuietHours)
+	if err != nil {
+		log.Fatalf("config error: FEEDBIN_QUIET_HOURS: %v", err)
+	}
 	nerdMode := flag.Bool("nerd", false, "show verbose keybindings and diagnostics in the UI")
 	articleStyleLinks := flag.Bool("article-style-links", cfg.ArticleStyleLinks, "style article links in the detail renderer")
 	articlePostprocess := flag.Bool("article-postprocess", cfg.ArticlePostprocess, "apply postprocessing rules to article text")
 	articleImageMode := flag.String("article-image-mode", cfg.ArticleImageModeRaw, "article image rendering mode: label|none")
+	articleASCIIPunctuation := flag.Bool("article-ascii-punctuation", cfg.ArticleASCIIPunctuation, "convert smart quotes, dashes and ellipses in articles to ASCII")
+	articleFooter := flag.Bool("article-footer", cfg.ArticleFooter, "append a \"Read more at\" line with the URL, feed and date to articles and Markdown exports")
+	articleLineBreaks := flag.String("article-line-breaks", cfg.ArticleLineBreaks, "where article text wraps: auto (between characters for CJK text), words or characters")
+	hyperlinks := flag.Bool("hyperlinks", cfg.Hyperlinks, "render article links and list titles as clickable OSC 8 hyperlinks")
+	shortenURLs := flag.Bool("shorten-urls", cfg.ShortenURLs, "elide the middle of long URLs in the detail header and list titles")
+	articleMaxLines := flag.Int("article-max-lines", cfg.ArticleMaxLines, "maximum rendered lines per article (0 disables the limit)")
+	syncOnly := flag.Bool("sync", false, "warm the local cache from Feedbin and exit; an interrupted sync resumes at the page it stopped on")
+	fullSync := flag.Bool("full", false, "with --sync, start again at page 1 instead of resuming an interrupted sync")
+	exportStarred := flag.String("export-starred", "", "write every starred entry as a Markdown file into this directory and exit")
+	exportJSONL := flag.String("export-jsonl", "", "stream every cached entry as one JSON object per line to this file (- for stdout) and exit")
+	exportReadingList := flag.String("export-reading-list", "", "write cached entries as a Pocket/Instapaper import CSV to this file (- for stdout) and exit")
+	readingListFilter := flag.String("reading-list-filter", "starred", "entries --export-reading-list writes: starred, unread or all")
+	exportPrefs := flag.String("export-prefs", "", "write the UI preferences as JSON to this file (- for stdout) and exit")
+	importPrefs := flag.String("import-prefs", "", "replace the UI preferences with a file written by --export-prefs (- for stdin) and exit")
+	offline := flag.Bool("offline", cfg.Offline, "read the local cache only; queue read/star changes until the next online refresh")
+	readOnly := flag.Bool("read-only", false, "browse a cache database that may not be writable; read/star changes, feed management and preference saving are refused")
+	syncPages := flag.Int("sync-pages", cfg.SyncPages, "number of entry pages to fetch when warming the cache")
+	stateFile := flag.String("state-file", cfg.StateFile, "JSON file to restore reading position and preferences from on start and save them to on exit")
+	syncConcurrency := flag.Int("sync-concurrency", cfg.SyncConcurrency, fmt.Sprintf("concurrent page fetches when warming the cache (max %d)", app.MaxWarmConcurrency))
+	repairDB := flag.Bool("repair-db", false, "if the cache database is corrupt, move it aside and start a fresh cache")
 	flag.Parse()
 	imageMode, ok := parseArticleImageMode(*articleImageMode)
 	if !ok {
 		log.Fatalf("invalid --article-image-mode %q (expected label or none)", *articleImageMode)
 	}
-
-	repo, err := storage.NewRepositoryWithSearch(cfg.DBPath, cfg.SearchMode)
-	if err != nil {
-		log.Fatalf("storage init error: %v", err)
+	lineBreaking, ok := article.ParseLineBreaking(*articleLineBreaks)
+	if !ok {
+		log.Fatalf("invalid --article-line-breaks %q (expected auto, words or characters)", *articleLineBreaks)
+	}
+	blankLines, _ := article.ParseBlankLines(cfg.ArticleBlankLines)
+	if *readingListFilter != "starred" && *readingListFilter != "unread" && *readingListFilter != "all" {
+		log.Fatalf("invalid --reading-list-filter %q (expected starred, unread or all)", *readingListFilter)
+	}
+	if *articleMaxLines < 0 {
+		log.Fatalf("invalid --article-max-lines %d (expected >= 0)", *articleMaxLines)
+	}
+	if *readOnly && (*syncOnly || *importPrefs != "" || *repairDB) {
+		log.Fatal("--read-only cannot be combined with --sync, --import-prefs or --repair-db")
 	}
-	defer repo.Close()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
-	if err := repo.Init(ctx); err != nil {
-		log.Fatalf("storage schema error: %v", err)
+	repo, err := openRepository(ctx, cfg.DBPath, cfg.SearchMode, *readOnly)
+	if storage.IsCorrupt(err) && !*readOnly {
+		if !*repairDB {
+			log.Fatalf("storage error: %v\nThe cache database %s looks corrupt. Run again with --repair-db to move it aside and start a fresh cache; entries re-sync from Feedbin.", err, cfg.DBPath)
+		}
+		backup, backupErr := storage.BackupCorruptDatabase(cfg.DBPath, time.Now())
+		if backupErr != nil {
+			log.Fatalf("repair database: %v", backupErr)
+		}
+		fmt.Fprintf(os.Stderr, "warning: cache database was corrupt and has been moved to %s; cached entries, marks and preferences were reset and re-sync from Feedbin\n", backup)
+		repo, err = openRepository(ctx, cfg.DBPath, cfg.SearchMode, false)
 	}
-	if err := repo.CheckWritable(ctx); err != nil {
-		log.Fatalf("storage write check failed (%v). Verify FEEDBIN_DB_PATH is writable: %s", err, cfg.DBPath)
+	if err != nil {
+		log.Fatal(err)
 	}
+	defer repo.Close()
 
 	client := feedbin.NewClient(cfg.APIBaseURL, cfg.Email, cfg.Password, nil)
+	client.SetMaxRequests(cfg.MaxRequests)
 	service := app.NewService(client, repo)
+	service.SetWarmConcurrency(*syncConcurrency)
+	service.SetOffline(*offline)
+	service.SetReadOnly(*readOnly)
+	service.SetMaxAge(time.Duration(cfg.MaxAgeDays) * 24 * time.Hour)
+	service.SetPostSyncCommand(cfg.PostSyncCmd)
+	service.SetBuildInfo(version, cfg.Email, cfg.Password)
+	service.SetFolderResolution(app.FolderResolution{Strategy: cfg.FolderResolution, Priority: cfg.FolderPriority})
+	if err := service.RestoreSearchMode(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not restore search mode (%v), using %s\n", err, service.SearchMode())
+	}
+
+	if *syncOnly {
+		if *fullSync {
+			if err := service.ResetWarmProgress(ctx); err != nil {
+				log.Fatalf("sync failed: %v", err)
+			}
+		}
+		result, err := warmCache(service, *syncPages)
+		if err != nil {
+			log.Fatalf("sync failed: %v", err)
+		}
+		fmt.Println(formatWarmCacheResult(result))
+		if result.Warning != "" {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", result.Warning)
+		}
+		service.WaitPostSync()
+		return
+	}
+	if *exportStarred != "" {
+		result, err := exportStarredEntries(service, *exportStarred, *articleFooter)
+		if err != nil {
+			log.Fatalf("export failed: %v", err)
+		}
+		fmt.Printf("exported %d starred entries to %s (%d skipped without content)\n", result.Written, *exportStarred, result.Skipped)
+		return
+	}
+	if *exportJSONL != "" {
+		written, err := exportEntriesJSONL(service, *exportJSONL)
+		if err != nil {
+			log.Fatalf("export failed: %v", err)
+		}
+		if *exportJSONL != "-" {
+			fmt.Printf("exported %d entries to %s\n", written, *exportJSONL)
+		}
+		return
+	}
+	if *exportReadingList != "" {
+		written, err := exportReadingListCSV(service, *exportReadingList, *readingListFilter)
+		if err != nil {
+			log.Fatalf("export failed: %v", err)
+		}
+		if *exportReadingList != "-" {
+			fmt.Printf("exported %d %s entries to %s\n", written, *readingListFilter, *exportReadingList)
+		}
+		return
+	}
+
+	if *exportPrefs != "" {
+		if err := exportPreferencesFile(service, *exportPrefs); err != nil {
+			log.Fatalf("export failed: %v", err)
+		}
+		if *exportPrefs != "-" {
+			fmt.Printf("exported preferences to %s\n", *exportPrefs)
+		}
+		return
+	}
+	if *importPrefs != "" {
+		if err := importPreferencesFile(service, *importPrefs); err != nil {
+			log.Fatalf("import failed: %v", err)
+		}
+		fmt.Printf("imported preferences from %s\n", *importPrefs)
+		return
+	}
 
 	cacheLoadStart := time.Now()
 	entries, err := service.ListCached(ctx, app.DefaultCacheLimit)
@@ -59,14 +189,110 @@ func main() {
 		log.Fatalf("cannot load cached entries: %v", err)
 	}
 	cacheLoadDuration := time.Since(cacheLoadStart)
+	if len(entries) == 0 && cfg.WarmOnFirstRun && !service.Offline() {
+		if result, err := warmCache(service, *syncPages); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: initial cache warm-up failed (%v)\n", err)
+		} else {
+			fmt.Fprintln(os.Stderr, formatWarmCacheResult(result))
+			entries, err = listCachedEntries(service)
+			if err != nil {
+				log.Fatalf("cannot load cached entries: %v", err)
+			}
+		}
+	}
+
+	var restoredView *app.ViewState
+	// saveStateFile is cleared when the file must be left as is on exit: it
+	// may hold another device's position that this run could not read.
+	saveStateFile := *stateFile != "" && !*readOnly
+	if *stateFile != "" && *readOnly {
+		// A read-only session can neither apply the file nor save it back.
+		fmt.Fprintln(os.Stderr, "warning: --state-file is ignored in --read-only mode")
+	} else if *stateFile != "" {
+		view, err := importStateFile(service, *stateFile)
+		if err != nil {
+			saveStateFile = false
+			fmt.Fprintf(os.Stderr, "warning: could not import state file (%v); it will not be overwritten on exit\n", err)
+		} else if view != nil {
+			restoredView = view
+			if entries, err = listCachedEntries(service); err != nil {
+				log.Fatalf("cannot load cached entries: %v", err)
+			}
+		}
+	}
 
 	model := tui.NewModel(service, entries)
 	model.SetNerdMode(*nerdMode)
+	model.SetDefaultFolder(cfg.DefaultFolder)
+	model.SetFeedLimit(cfg.TreeFeedLimit, cfg.TreeFeedOrder)
+	model.SetFeedManager(service)
+	model.SetSnoozer(service)
+	model.SetFolderAssigner(service)
+	model.SetDismisser(service)
+	model.SetSearchModeSwitcher(service)
+	model.SetLocalReader(service)
+	model.SetDiagnosticsSource(service)
+	// The startup context may be spent by a long first-run warm-up.
+	loadCtx, loadCancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer loadCancel()
+	if progress, err := service.ReadProgress(loadCtx); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not load read progress (%v)\n", err)
+	} else {
+		model.SetReadProgress(service, progress)
+	}
+	if anchors, err := service.FilterAnchors(loadCtx); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not load filter positions (%v)\n", err)
+	} else {
+		model.SetFilterAnchors(service, anchors)
+	}
+	if pinned, err := service.PinnedFeeds(loadCtx); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not load pinned feeds (%v)\n", err)
+	} else {
+		model.SetPinnedFeeds(service, pinned)
+	}
+	model.SetAutoOpenFirstUnread(cfg.AutoOpenFirstUnread)
+	model.SetAutoNextFeed(cfg.AutoNextFeed)
+	model.SetInfiniteScroll(cfg.InfiniteScroll)
+	model.SetEscClearsSearch(cfg.EscClearsSearch)
+	model.SetStickySearch(cfg.StickySearch)
+	model.SetSearchExpand(cfg.SearchExpand)
+	model.SetPreferCanonicalURL(cfg.PreferCanonicalURL)
+	model.SetContentExtractor(service)
+	model.SetQueueRepeatRefresh(cfg.RepeatRefresh == "queue")
+	model.SetReadAdvanceBackward(cfg.ReadAdvance == "previous")
+	model.SetDetailBoundary(cfg.DetailBoundary)
+	model.SetKeepScrollPosition(cfg.KeepScrollPosition)
+	model.SetMaxAge(time.Duration(cfg.MaxAgeDays)*24*time.Hour, service)
+	model.SetAutoRefresh(cfg.RefreshInterval)
+	model.SetQuietHours(quietHours)
+	model.SetInitialRefreshRetries(cfg.InitRefreshRetries)
+	model.SetEntryWindow(service, cfg.MaxEntriesInMemory)
+	if synced, err := service.LastSyncedAt(loadCtx); err == nil {
+		model.SetLastSynced(synced)
+	}
+	model.SetMuteRules(muteRules)
+	if cfg.Bell {
+		model.SetNewEntryBell(cfg.BellCmd)
+	}
+	model.SetOffline(*offline)
+	model.SetLocation(cfg.Location())
+	model.SetOpenURLMode(cfg.OpenURLMode)
 	model.SetArticleOptions(article.Options{
 		StyleLinks:          *articleStyleLinks,
 		ApplyPostprocessing: *articlePostprocess,
 		ImageMode:           imageMode,
+		MaxLines:            *articleMaxLines,
+		ASCIIPunctuation:    *articleASCIIPunctuation,
+		Footer:              *articleFooter,
+		Hyperlinks:          *hyperlinks,
+		LineBreaking:        lineBreaking,
+		ShortenURLs:         *shortenURLs,
+		CommentCounts:       cfg.CommentCounts,
+		RightToLeft:         cfg.ArticleRTL,
+		BlankLines:          blankLines,
 	})
+	model.SetArticleIndent(cfg.ArticleIndent)
+	model.SetStackedListWidth(cfg.StackedListWidth)
 	model.SetStartupCacheStats(cacheLoadDuration, len(entries))
 
 	prefCtx, prefCancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -76,30 +302,310 @@ func main() {
 		fmt.Fprintf(os.Stderr, "warning: could not load UI preferences (%v), using defaults\n", err)
 	} else {
 		model.ApplyPreferences(tui.Preferences{
-			Compact:         prefs.Compact,
-			MarkReadOnOpen:  prefs.MarkReadOnOpen,
-			ConfirmOpenRead: prefs.ConfirmOpenRead,
-			RelativeTime:    prefs.RelativeTime,
-			ShowNumbers:     prefs.ShowNumbers,
+			Compact:              prefs.Compact,
+			MarkReadOnOpen:       prefs.MarkReadOnOpen,
+			ConfirmOpenRead:      prefs.ConfirmOpenRead,
+			TimeFormat:           prefs.TimeFormat,
+			ShowNumbers:          prefs.ShowNumbers,
+			AutoPreview:          prefs.AutoPreview,
+			ConfirmBulkActions:   prefs.ConfirmBulkActions,
+			DateColumn:           prefs.DateColumn,
+			IncrementalSearch:    prefs.IncrementalSearch,
+			CompactCounts:        prefs.CompactCounts,
+			MergePages:           prefs.MergePages,
+			CompactTree:          prefs.CompactTree,
+			TagsAsFolders:        prefs.TagsAsFolders,
+			HideRead:             prefs.HideRead,
+			CompactUnreadFirst:   prefs.CompactUnreadFirst,
+			PreviewLines:         prefs.PreviewLines,
+			PreviewSource:        prefs.PreviewSource,
+			PreferSummary:        prefs.PreferSummary,
+			ShowReadToday:        prefs.ShowReadToday,
+			MarkReadOnDetailOpen: prefs.MarkReadOnDetailOpen,
+			StripFeedPrefix:      prefs.StripFeedPrefix,
 		})
 	}
 
+	model.SetTitleStyles(cfg.ReadStyle, cfg.UnreadStyle)
+	readCtx, readCancel := context.WithTimeout(context.Background(), 2*time.Second)
+	if readToday, err := service.ReadToday(readCtx, time.Now()); err == nil {
+		model.SetReadToday(readToday, time.Now())
+	}
+	readCancel()
+
 	model.SetPreferencesSaver(func(p tui.Preferences) error {
 		saveCtx, saveCancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer saveCancel()
 		return service.SaveUIPreferences(saveCtx, app.UIPreferences{
-			Compact:         p.Compact,
-			MarkReadOnOpen:  p.MarkReadOnOpen,
-			ConfirmOpenRead: p.ConfirmOpenRead,
-			RelativeTime:    p.RelativeTime,
-			ShowNumbers:     p.ShowNumbers,
+			Compact:              p.Compact,
+			MarkReadOnOpen:       p.MarkReadOnOpen,
+			ConfirmOpenRead:      p.ConfirmOpenRead,
+			TimeFormat:           p.TimeFormat,
+			ShowNumbers:          p.ShowNumbers,
+			AutoPreview:          p.AutoPreview,
+			ConfirmBulkActions:   p.ConfirmBulkActions,
+			DateColumn:           p.DateColumn,
+			IncrementalSearch:    p.IncrementalSearch,
+			CompactCounts:        p.CompactCounts,
+			MergePages:           p.MergePages,
+			CompactTree:          p.CompactTree,
+			TagsAsFolders:        p.TagsAsFolders,
+			HideRead:             p.HideRead,
+			CompactUnreadFirst:   p.CompactUnreadFirst,
+			PreviewLines:         p.PreviewLines,
+			PreviewSource:        p.PreviewSource,
+			PreferSummary:        p.PreferSummary,
+			ShowReadToday:        p.ShowReadToday,
+			MarkReadOnDetailOpen: p.MarkReadOnDetailOpen,
+			StripFeedPrefix:      p.StripFeedPrefix,
 		})
 	})
 
-	program := tea.NewProgram(model, tea.WithAltScreen())
-	if _, err := program.Run(); err != nil {
+	if restoredView != nil {
+		model.ApplyViewState(tui.ViewState{
+			LastEntryID:       restoredView.LastEntryID,
+			Filter:            restoredView.Filter,
+			CollapsedFolders:  restoredView.CollapsedFolders,
+			CollapsedFeeds:    restoredView.CollapsedFeeds,
+			CollapsedSections: restoredView.CollapsedSections,
+		})
+	}
+	model.SetTreeRules(cfg.AutoCollapse, cfg.AutoExpand)
+	// Last, since it drops the preference saver and stores set above.
+	model.SetReadOnly(*readOnly)
+
+	// Warnings logged while the UI owns the terminal would corrupt the screen,
+	// so they go to FEEDBIN_LOG_FILE when set and are dropped otherwise.
+	log.SetOutput(io.Discard)
+	if cfg.LogFile != "" {
+		logFile, err := os.OpenFile(cfg.LogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: could not open log file (%v)\n", err)
+		} else {
+			defer logFile.Close()
+			log.SetOutput(logFile)
+		}
+	}
+	program := tea.NewProgram(model, tea.WithAltScreen(), tea.WithOutput(tuiplatform.Output))
+	finalModel, err := program.Run()
+	// Let a post-sync command from the last refresh finish; it is bounded by
+	// app.PostSyncTimeout.
+	service.WaitPostSync()
+	log.SetOutput(os.Stderr)
+	if err != nil {
 		log.Fatalf("tui error: %v", err)
 	}
+	if saveStateFile {
+		if final, ok := finalModel.(tui.Model); ok {
+			view := final.ViewState()
+			if err := exportStateFile(service, *stateFile, app.ViewState{
+				LastEntryID:       view.LastEntryID,
+				Filter:            view.Filter,
+				CollapsedFolders:  view.CollapsedFolders,
+				CollapsedFeeds:    view.CollapsedFeeds,
+				CollapsedSections: view.CollapsedSections,
+			}); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: could not save state file (%v)\n", err)
+			}
+		}
+	}
+}
+
+// openRepository opens and initializes the cache database. Errors keep the
+// underlying SQLite error so storage.IsCorrupt can still recognize it. A
+// read-only cache is opened as is, without schema upgrades or the write
+// check.
+func openRepository(ctx context.Context, path, searchMode string, readOnly bool) (*storage.Repository, error) {
+	if readOnly {
+		repo, err := storage.NewReadOnlyRepository(path)
+		if err != nil {
+			return nil, fmt.Errorf("storage init error: %w", err)
+		}
+		return repo, nil
+	}
+	repo, err := storage.NewRepositoryWithSearch(path, searchMode)
+	if err != nil {
+		return nil, fmt.Errorf("storage init error: %w", err)
+	}
+	if err := repo.Init(ctx); err != nil {
+		_ = repo.Close()
+		return nil, fmt.Errorf("storage schema error: %w", err)
+	}
+	if err := repo.CheckWritable(ctx); err != nil {
+		_ = repo.Close()
+		return nil, fmt.Errorf("storage write check failed (%w). Verify FEEDBIN_DB_PATH is writable: %s", err, path)
+	}
+	return repo, nil
+}
+
+// listCachedEntries reloads the cached entries on a fresh context, for use
+// after steps that may outlast the startup timeout.
+func listCachedEntries(service *app.Service) ([]feedbin.Entry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	return service.ListCached(ctx, app.DefaultCacheLimit)
+}
+
+// importStateFile loads a state file written by a previous run, possibly on
+// another device. A missing file is not an error.
+func importStateFile(service *app.Service, path string) (*app.ViewState, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	view, err := service.ImportState(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// exportStateFile writes through a temp file and rename so a sync client
+// never picks up a half-written state.
+func exportStateFile(service *app.Service, path string, view app.ViewState) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".reeder-state-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := service.ExportState(ctx, tmp, view); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// warmCache stops on Ctrl-C like on a timeout, so the pages fetched so far
+// are kept and the next sync resumes after them.
+func warmCache(service *app.Service, pages int) (app.WarmCacheResult, error) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	return service.WarmCache(ctx, pages)
+}
+
+func exportStarredEntries(service *app.Service, dir string, footer bool) (app.ExportStarredResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	toMarkdown := article.MarkdownFromEntry
+	if footer {
+		toMarkdown = article.MarkdownFromEntryWithFooter
+	}
+	return service.ExportStarred(ctx, dir, toMarkdown)
+}
+
+// exportEntriesJSONL streams the cache to path, or stdout for "-", through a
+// buffered writer; it has no overall timeout since large caches take a while.
+func exportEntriesJSONL(service *app.Service, path string) (int, error) {
+	out := os.Stdout
+	if path != "-" {
+		file, err := os.Create(path)
+		if err != nil {
+			return 0, err
+		}
+		defer file.Close()
+		out = file
+	}
+	w := bufio.NewWriter(out)
+	written, err := service.ExportJSONL(context.Background(), w)
+	if err != nil {
+		return written, err
+	}
+	if err := w.Flush(); err != nil {
+		return written, err
+	}
+	if path != "-" {
+		return written, out.Close()
+	}
+	return written, nil
+}
+
+// exportReadingListCSV writes the reading list to path, or stdout for "-".
+func exportReadingListCSV(service *app.Service, path, filter string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	if path == "-" {
+		return service.ExportReadingList(ctx, os.Stdout, filter)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	written, err := service.ExportReadingList(ctx, file, filter)
+	if err != nil {
+		return written, err
+	}
+	return written, file.Close()
+}
+
+// exportPreferencesFile writes the UI preferences to path, or stdout for "-".
+func exportPreferencesFile(service *app.Service, path string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if path == "-" {
+		return service.ExportPreferences(ctx, os.Stdout)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := service.ExportPreferences(ctx, file); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// importPreferencesFile stores the UI preferences read from path, or stdin
+// for "-".
+func importPreferencesFile(service *app.Service, path string) error {
+	in := os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := service.ImportPreferences(ctx, in)
+	return err
+}
+
+func formatWarmCacheResult(result app.WarmCacheResult) string {
+	speedup := 1.0
+	if result.Duration > 0 {
+		speedup = float64(result.FetchTime) / float64(result.Duration)
+	}
+	summary := fmt.Sprintf(
+		"synced %d entries from %d pages in %s (sequential fetch time %s, %.1fx speedup)",
+		result.Entries,
+		result.Pages,
+		result.Duration.Round(time.Millisecond),
+		result.FetchTime.Round(time.Millisecond),
+		speedup,
+	)
+	if result.StartPage > 1 {
+		summary += fmt.Sprintf(", resumed at page %d", result.StartPage)
+	}
+	return summary
 }
 
 func parseArticleImageMode(raw string) (article.ImageMode, bool) {
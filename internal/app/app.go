@@ -5,9 +5,12 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"log"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/glabrego/reeder-cli/internal/feedbin"
@@ -17,6 +20,7 @@ type FeedbinClient interface {
 	ListEntries(ctx context.Context, page, perPage int) ([]feedbin.Entry, error)
 	ListEntriesByIDs(ctx context.Context, ids []int64) ([]feedbin.Entry, error)
 	ListSubscriptions(ctx context.Context) ([]feedbin.Subscription, error)
+	GetFeed(ctx context.Context, feedID int64) (feedbin.Subscription, error)
 	ListUnreadEntryIDs(ctx context.Context) ([]int64, error)
 	ListStarredEntryIDs(ctx context.Context) ([]int64, error)
 	ListTaggings(ctx context.Context) ([]feedbin.Tagging, error)
@@ -25,29 +29,105 @@ type FeedbinClient interface {
 	MarkEntriesRead(ctx context.Context, entryIDs []int64) error
 	StarEntries(ctx context.Context, entryIDs []int64) error
 	UnstarEntries(ctx context.Context, entryIDs []int64) error
+	MarkEntriesUnreadBatch(ctx context.Context, entryIDs []int64) ([]int64, []int64, error)
+	MarkEntriesReadBatch(ctx context.Context, entryIDs []int64) ([]int64, []int64, error)
+	StarEntriesBatch(ctx context.Context, entryIDs []int64) ([]int64, []int64, error)
+	UnstarEntriesBatch(ctx context.Context, entryIDs []int64) ([]int64, []int64, error)
+	ListFeedEntries(ctx context.Context, feedID int64, page, perPage int) ([]feedbin.Entry, error)
+	RenameSubscription(ctx context.Context, subscriptionID int64, title string) error
+	CreateTagging(ctx context.Context, feedID int64, name string) (feedbin.Tagging, error)
+	Unsubscribe(ctx context.Context, subscriptionID int64) error
+	ExtractContent(ctx context.Context, extractURL string) (string, error)
 }
 
 type Repository interface {
 	SaveSubscriptions(ctx context.Context, subscriptions []feedbin.Subscription) error
+	ListOrphanedFeedIDs(ctx context.Context) ([]int64, error)
 	SaveEntries(ctx context.Context, entries []feedbin.Entry) error
 	SaveEntryStates(ctx context.Context, unreadIDs, starredIDs []int64) error
+	ListEntryMarks(ctx context.Context) ([]feedbin.EntryMark, error)
+	SaveEntryMarks(ctx context.Context, marks []feedbin.EntryMark) error
 	GetSyncCursor(ctx context.Context, key string) (time.Time, error)
 	SetSyncCursor(ctx context.Context, key string, value time.Time) error
 	GetAppState(ctx context.Context, key string) (string, error)
 	SetAppState(ctx context.Context, key, value string) error
 	SetEntryUnread(ctx context.Context, entryID int64, unread bool) error
 	SetEntryStarred(ctx context.Context, entryID int64, starred bool) error
+	GetEntry(ctx context.Context, entryID int64) (feedbin.Entry, error)
 	ListEntries(ctx context.Context, limit int) ([]feedbin.Entry, error)
 	ListEntriesByFilter(ctx context.Context, limit int, filter string) ([]feedbin.Entry, error)
+	ListEntriesByFilterSince(ctx context.Context, limit int, filter string, since time.Time) ([]feedbin.Entry, error)
+	ListEntriesByFilterOffset(ctx context.Context, limit, offset int, filter string, since time.Time) ([]feedbin.Entry, error)
+	EachEntry(ctx context.Context, fn func(feedbin.Entry) error) error
 	SearchEntriesByFilter(ctx context.Context, limit int, filter, query string) ([]feedbin.Entry, error)
+	MarkFeedReadLocally(ctx context.Context, feedID, throughEntryID int64) error
+	ClearFeedLocalRead(ctx context.Context, feedID int64) (bool, error)
+	SearchMode() string
+	Stats(ctx context.Context) (feedbin.CacheStats, error)
+	NewestEntryID(ctx context.Context) (int64, error)
+	SetSearchMode(ctx context.Context, mode string) error
+	ListFeeds(ctx context.Context) ([]feedbin.FeedSummary, error)
+	SetFeedMuted(ctx context.Context, feedID int64, muted bool) error
+	RenameFeed(ctx context.Context, feedID int64, title string) error
+	SetFeedFolders(ctx context.Context, feedID int64, folders []string) error
+	DeleteFeed(ctx context.Context, feedID int64) error
+	SnoozeEntry(ctx context.Context, entryID int64, wakeAt time.Time) error
+	ListDueSnoozedEntryIDs(ctx context.Context, now time.Time) ([]int64, error)
+	UnsnoozeEntries(ctx context.Context, entryIDs []int64) error
+	Dismiss(ctx context.Context, entryID int64) error
+	Undismiss(ctx context.Context, entryID int64) error
+	SetReadProgress(ctx context.Context, entryID int64, fraction float64) error
+	ListReadProgress(ctx context.Context) (map[int64]float64, error)
 }
 
 type UIPreferences struct {
 	Compact         bool
 	MarkReadOnOpen  bool
 	ConfirmOpenRead bool
-	RelativeTime    bool
-	ShowNumbers     bool
+	// TimeFormat is "relative", "absolute" or "both".
+	TimeFormat  string
+	ShowNumbers bool
+	AutoPreview bool
+	// ConfirmBulkActions defaults to true when no value is stored.
+	ConfirmBulkActions bool
+	// DateColumn is "full", "short" or "hidden"; empty when never saved.
+	DateColumn        string
+	IncrementalSearch bool
+	CompactCounts     bool
+	MergePages        bool
+	CompactTree       bool
+	TagsAsFolders     bool
+	HideRead          bool
+	// CompactUnreadFirst sorts the compact list unread first, then by date.
+	CompactUnreadFirst bool
+	// PreviewLines is the number of snippet lines under list entries (0-3).
+	PreviewLines int
+	// PreviewSource is "summary" or "content"; empty when never saved.
+	PreviewSource string
+	// PreferSummary shows an entry's summary instead of its full content.
+	PreferSummary bool
+	// ShowReadToday adds the count of entries read today to the footer.
+	ShowReadToday bool
+	// MarkReadOnDetailOpen marks an unread entry read when its detail view
+	// opens.
+	MarkReadOnDetailOpen bool
+	// StripFeedPrefix drops a leading "Feed name:" from list titles.
+	StripFeedPrefix bool
+}
+
+// WarmCacheResult summarizes a WarmCache run. FetchTime is the sum of the
+// individual page requests, i.e. roughly what a sequential sync would take.
+type WarmCacheResult struct {
+	Pages     int
+	Entries   int
+	Duration  time.Duration
+	FetchTime time.Duration
+	// StartPage is the first page fetched; above 1 when the run resumed
+	// where an interrupted one stopped.
+	StartPage int
+	// Warning describes a non-fatal sync failure, such as folders that
+	// could not be refreshed.
+	Warning string
 }
 
 type Service struct {
@@ -55,34 +135,266 @@ type Service struct {
 	repo            Repository
 	lastStateSyncAt time.Time
 	syncCursorKey   string
+	warmConcurrency int
+	offline         bool
+	readOnly        bool
+
+	postSyncCmd string
+	postSyncWG  sync.WaitGroup
+	// runCommand runs the post-sync command; nil uses the shell.
+	runCommand func(ctx context.Context, command string, env []string) error
+
+	warningMu   sync.Mutex
+	syncWarning string
+
+	maxAge      time.Duration
+	showAllAges atomic.Bool
+
+	readTodayMu sync.Mutex
+
+	version string
+	secrets []string
+
+	folderResolution FolderResolution
 }
 
 const (
-	uiPrefCompactKey        = "ui_pref_compact"
-	uiPrefMarkReadOnOpenKey = "ui_pref_mark_read_on_open"
-	uiPrefConfirmOpenKey    = "ui_pref_confirm_open_read"
-	uiPrefRelativeTimeKey   = "ui_pref_relative_time"
-	uiPrefShowNumbersKey    = "ui_pref_show_numbers"
-	DefaultCacheLimit       = 1000
+	uiPrefCompactKey          = "ui_pref_compact"
+	uiPrefMarkReadOnOpenKey   = "ui_pref_mark_read_on_open"
+	uiPrefConfirmOpenKey      = "ui_pref_confirm_open_read"
+	uiPrefRelativeTimeKey     = "ui_pref_relative_time"
+	uiPrefTimeFormatKey       = "ui_pref_time_format"
+	uiPrefShowNumbersKey      = "ui_pref_show_numbers"
+	uiPrefAutoPreviewKey      = "ui_pref_auto_preview"
+	uiPrefConfirmBulkKey      = "ui_pref_confirm_bulk_actions"
+	uiPrefDateColumnKey       = "ui_pref_date_column"
+	uiPrefIncrementalKey      = "ui_pref_incremental_search"
+	uiPrefCompactCountsKey    = "ui_pref_compact_counts"
+	uiPrefMergePagesKey       = "ui_pref_merge_pages"
+	uiPrefCompactTreeKey      = "ui_pref_compact_tree"
+	uiPrefTagsAsFoldersKey    = "ui_pref_tags_as_folders"
+	uiPrefHideReadKey         = "ui_pref_hide_read"
+	uiPrefUnreadFirstKey      = "ui_pref_compact_unread_first"
+	uiPrefPreviewLinesKey     = "ui_pref_preview_lines"
+	uiPrefPreviewSourceKey    = "ui_pref_preview_source"
+	uiPrefPreferSummaryKey    = "ui_pref_prefer_summary"
+	uiPrefShowReadTodayKey    = "ui_pref_show_read_today"
+	uiPrefMarkReadOnDetailKey = "ui_pref_mark_read_on_detail_open"
+	uiPrefStripFeedPrefixKey  = "ui_pref_strip_feed_prefix"
+	DefaultCacheLimit         = 1000
+
+	// DefaultWarmConcurrency and MaxWarmConcurrency bound the page-fetch worker
+	// pool used by WarmCache; the cap keeps us clear of Feedbin rate limits.
+	DefaultWarmConcurrency = 4
+	MaxWarmConcurrency     = 8
+	warmCachePerPage       = 100
+	feedRefreshPerPage     = 100
+	// maxOrphanFeedLookups bounds per-sync feed lookups for entries whose feed
+	// is missing from the subscription list.
+	maxOrphanFeedLookups = 20
 )
 
 func NewService(client FeedbinClient, repo Repository) *Service {
 	return &Service{
-		client:        client,
-		repo:          repo,
-		syncCursorKey: "updated_entries_since",
+		client:          client,
+		repo:            repo,
+		syncCursorKey:   "updated_entries_since",
+		warmConcurrency: DefaultWarmConcurrency,
+	}
+}
+
+// SetWarmConcurrency sets how many pages WarmCache fetches in parallel,
+// clamped to [1, MaxWarmConcurrency].
+func (s *Service) SetWarmConcurrency(n int) {
+	if n < 1 {
+		n = 1
+	}
+	if n > MaxWarmConcurrency {
+		n = MaxWarmConcurrency
+	}
+	s.warmConcurrency = n
+}
+
+// WarmCache fetches the first pages of entries concurrently, dedupes them by
+// entry ID in page order and saves them, followed by a full state sync.
+// When a page fails or ctx is canceled, the pages fetched before it are
+// saved and the next WarmCache resumes after them; ResetWarmProgress starts
+// over.
+func (s *Service) WarmCache(ctx context.Context, pages int) (WarmCacheResult, error) {
+	if pages < 1 {
+		return WarmCacheResult{}, nil
+	}
+	if s.Offline() {
+		return WarmCacheResult{}, ErrOffline
+	}
+	start := time.Now()
+	newestBefore := s.newestCachedID(ctx)
+	firstPage, err := s.warmResumePage(ctx)
+	if err != nil {
+		return WarmCacheResult{}, err
+	}
+	remaining := max(pages-firstPage+1, 0)
+	parent := ctx
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		results   = make([][]feedbin.Entry, remaining)
+		durations = make([]time.Duration, remaining)
+		done      = make([]bool, remaining)
+		firstErr  error
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+	)
+	setErr := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+
+	workers := s.warmConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > remaining {
+		workers = remaining
+	}
+	jobs := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				pageStart := time.Now()
+				entries, err := s.client.ListEntries(ctx, firstPage+i, warmCachePerPage)
+				durations[i] = time.Since(pageStart)
+				if err != nil {
+					setErr(fmt.Errorf("fetch entries page %d from feedbin: %w", firstPage+i, err))
+					continue
+				}
+				results[i] = entries
+				done[i] = true
+			}
+		}()
+	}
+feed:
+	for i := 0; i < remaining; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if firstErr == nil {
+		firstErr = ctx.Err()
 	}
+	if firstErr != nil {
+		if err := s.saveWarmPrefix(parent, firstPage, results, done); err != nil {
+			return WarmCacheResult{}, errors.Join(firstErr, err)
+		}
+		return WarmCacheResult{}, firstErr
+	}
+
+	result := WarmCacheResult{StartPage: firstPage}
+	seen := make(map[int64]struct{})
+	merged := make([]feedbin.Entry, 0, remaining*warmCachePerPage)
+	for i, entries := range results {
+		result.FetchTime += durations[i]
+		if len(entries) == 0 {
+			continue
+		}
+		result.Pages++
+		for _, entry := range entries {
+			if _, ok := seen[entry.ID]; ok {
+				continue
+			}
+			seen[entry.ID] = struct{}{}
+			merged = append(merged, entry)
+		}
+	}
+	result.Entries = len(merged)
+
+	if len(merged) > 0 {
+		if err := s.repo.SaveEntries(ctx, merged); err != nil {
+			return WarmCacheResult{}, fmt.Errorf("save entries to cache: %w", err)
+		}
+	}
+	if err := s.syncFullState(ctx); err != nil {
+		return WarmCacheResult{}, err
+	}
+	if firstPage > 1 {
+		if err := s.ResetWarmProgress(ctx); err != nil {
+			return WarmCacheResult{}, err
+		}
+	}
+	result.Warning = s.SyncWarning()
+
+	result.Duration = time.Since(start)
+	s.runPostSync(SyncSummary{
+		Source:   "sync",
+		Fetched:  result.Entries,
+		New:      countNewerThan(merged, newestBefore),
+		Duration: result.Duration,
+	})
+	return result, nil
 }
 
 func (s *Service) Refresh(ctx context.Context, page, perPage int) ([]feedbin.Entry, error) {
-	entries, _, err := s.syncPage(ctx, page, perPage, true)
+	if s.Offline() {
+		return nil, s.offlineErr()
+	}
+	// Send offline changes first so the state sync below does not undo them.
+	// Changes Feedbin rejects stay queued, and snoozed entries it rejects stay
+	// snoozed, for the next refresh; this one goes on with a warning.
+	var warnings []string
+	if _, err := s.FlushQueuedChanges(ctx); err != nil {
+		warnings = append(warnings, "queued changes not sent: "+err.Error())
+		log.Printf("warning: flush queued changes: %v", err)
+	}
+	if _, err := s.WakeSnoozedEntries(ctx, time.Now()); err != nil {
+		warnings = append(warnings, "snoozed entries not woken: "+err.Error())
+		log.Printf("warning: wake snoozed entries: %v", err)
+	}
+	s.setSyncWarning("")
+	start := time.Now()
+	newestBefore := s.newestCachedID(ctx)
+	entries, fetched, err := s.syncPage(ctx, page, perPage, true)
 	if err != nil {
 		return nil, err
 	}
+	if warning := s.SyncWarning(); warning != "" {
+		warnings = append(warnings, warning)
+	}
+	s.setSyncWarning(strings.Join(warnings, "; "))
+	s.runPostSync(SyncSummary{
+		Source:   "refresh",
+		Fetched:  fetched,
+		New:      countNewerThan(entries, newestBefore),
+		Duration: time.Since(start),
+	})
 	return entries, nil
 }
 
+// LastSyncedAt is when read and starred state last synced with Feedbin,
+// zero when it never has.
+func (s *Service) LastSyncedAt(ctx context.Context) (time.Time, error) {
+	synced, err := s.repo.GetSyncCursor(ctx, s.syncCursorKey)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("load last sync time: %w", err)
+	}
+	return synced, nil
+}
+
 func (s *Service) LoadMore(ctx context.Context, page, perPage int, filter string, limit int) ([]feedbin.Entry, int, error) {
+	if s.Offline() {
+		return nil, 0, s.offlineErr()
+	}
 	_, fetchedCount, err := s.syncPage(ctx, page, perPage, false)
 	if err != nil {
 		return nil, 0, err
@@ -154,6 +466,7 @@ func (s *Service) syncFullState(ctx context.Context) error {
 		unreadIDs     []int64
 		starredIDs    []int64
 		firstErr      error
+		taggingsErr   error
 		mu            sync.Mutex
 		wg            sync.WaitGroup
 	)
@@ -184,12 +497,8 @@ func (s *Service) syncFullState(ctx context.Context) error {
 	go func() {
 		defer wg.Done()
 		t, err := s.client.ListTaggings(ctx)
-		if err != nil {
-			setErr(fmt.Errorf("fetch taggings from feedbin: %w", err))
-			return
-		}
 		mu.Lock()
-		taggings = t
+		taggings, taggingsErr = t, err
 		mu.Unlock()
 	}()
 	go func() {
@@ -220,11 +529,25 @@ func (s *Service) syncFullState(ctx context.Context) error {
 		return firstErr
 	}
 
-	applyTaggingsToSubscriptions(subscriptions, taggings)
+	// Folders only come from taggings, so a failed taggings fetch keeps the
+	// cached folders instead of failing the whole sync.
+	s.setSyncWarning("")
+	if taggingsErr != nil {
+		s.setSyncWarning("folders not updated: " + taggingsErr.Error())
+		log.Printf("warning: fetch taggings from feedbin: %v", taggingsErr)
+		if err := s.keepCachedFolders(ctx, subscriptions); err != nil {
+			return err
+		}
+	} else {
+		applyTaggingsToSubscriptions(subscriptions, taggings, s.folderResolution)
+	}
 
 	if err := s.repo.SaveSubscriptions(ctx, subscriptions); err != nil {
 		return fmt.Errorf("save subscriptions to cache: %w", err)
 	}
+	if err := s.reconcileOrphanedFeeds(ctx, time.Now()); err != nil {
+		return err
+	}
 	if err := s.hydrateStateEntries(ctx, unreadIDs, starredIDs); err != nil {
 		return err
 	}
@@ -239,6 +562,100 @@ func (s *Service) syncFullState(ctx context.Context) error {
 	return nil
 }
 
+// keepCachedFolders copies the cached folder assignments onto freshly
+// fetched subscriptions.
+func (s *Service) keepCachedFolders(ctx context.Context, subscriptions []feedbin.Subscription) error {
+	feeds, err := s.repo.ListFeeds(ctx)
+	if err != nil {
+		return fmt.Errorf("load feeds from cache: %w", err)
+	}
+	cached := make(map[int64]feedbin.Subscription, len(feeds))
+	for _, feed := range feeds {
+		cached[feed.ID] = feed.Subscription
+	}
+	for i := range subscriptions {
+		if feed, ok := cached[subscriptions[i].ID]; ok {
+			subscriptions[i].Folder = feed.Folder
+			subscriptions[i].Folders = feed.Folders
+		}
+	}
+	return nil
+}
+
+// SyncWarning returns the non-fatal problem from the latest full sync, or ""
+// when it completed cleanly.
+func (s *Service) SyncWarning() string {
+	s.warningMu.Lock()
+	defer s.warningMu.Unlock()
+	return s.syncWarning
+}
+
+func (s *Service) setSyncWarning(warning string) {
+	s.warningMu.Lock()
+	s.syncWarning = warning
+	s.warningMu.Unlock()
+}
+
+// reconcileOrphanedFeeds resolves feeds that cached entries reference but
+// that are missing from the subscription list, so their entries get a title
+// instead of falling into the unknown feed group. Feeds that cannot be fetched
+// (e.g. removed upstream) stay unknown and are retried by later syncs once a
+// backoff that grows with each failure has passed.
+func (s *Service) reconcileOrphanedFeeds(ctx context.Context, now time.Time) error {
+	orphanIDs, err := s.repo.ListOrphanedFeedIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("load orphaned feeds from cache: %w", err)
+	}
+	failures, err := s.orphanFeedFailures(ctx)
+	if err != nil {
+		return err
+	}
+	changed := false
+	orphaned := make(map[int64]bool, len(orphanIDs))
+	feedIDs := make([]int64, 0, min(len(orphanIDs), maxOrphanFeedLookups))
+	for _, feedID := range orphanIDs {
+		orphaned[feedID] = true
+		if failure, ok := failures[feedID]; ok && now.Before(failure.retryAt()) {
+			continue
+		}
+		if len(feedIDs) < maxOrphanFeedLookups {
+			feedIDs = append(feedIDs, feedID)
+		}
+	}
+	for feedID := range failures {
+		if !orphaned[feedID] {
+			delete(failures, feedID)
+			changed = true
+		}
+	}
+
+	resolved := make([]feedbin.Subscription, 0, len(feedIDs))
+	for _, feedID := range feedIDs {
+		feed, err := s.client.GetFeed(ctx, feedID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("fetch orphaned feeds from feedbin: %w", ctx.Err())
+			}
+			failures[feedID] = orphanFeedFailure{FailedAt: now, Attempts: failures[feedID].Attempts + 1}
+			changed = true
+			continue
+		}
+		if feed.ID == 0 {
+			feed.ID = feedID
+		}
+		resolved = append(resolved, feed)
+	}
+	if len(resolved) > 0 {
+		if err := s.repo.SaveSubscriptions(ctx, resolved); err != nil {
+			return fmt.Errorf("save orphaned feeds to cache: %w", err)
+		}
+	}
+	if changed {
+		return s.saveOrphanFeedFailures(ctx, failures)
+	}
+	return nil
+}
+
 func (s *Service) hydrateStateEntries(ctx context.Context, unreadIDs, starredIDs []int64) error {
 	idSet := make(map[int64]struct{}, len(unreadIDs)+len(starredIDs))
 	for _, id := range unreadIDs {
@@ -268,19 +685,27 @@ func (s *Service) hydrateStateEntries(ctx context.Context, unreadIDs, starredIDs
 	return nil
 }
 
-func applyTaggingsToSubscriptions(subscriptions []feedbin.Subscription, taggings []feedbin.Tagging) {
-	feedFolders := make(map[int64]string, len(taggings))
+// applyTaggingsToSubscriptions records every tag of each feed. The tag
+// picked by resolution is the feed's folder in the default tree.
+func applyTaggingsToSubscriptions(subscriptions []feedbin.Subscription, taggings []feedbin.Tagging, resolution FolderResolution) {
+	feedFolders := make(map[int64][]string, len(taggings))
 	for _, tagging := range taggings {
 		name := strings.TrimSpace(tagging.Name)
-		if name == "" {
+		if name == "" || slices.Contains(feedFolders[tagging.FeedID], name) {
 			continue
 		}
-		if existing, ok := feedFolders[tagging.FeedID]; !ok || strings.ToLower(name) < strings.ToLower(existing) {
-			feedFolders[tagging.FeedID] = name
-		}
+		feedFolders[tagging.FeedID] = append(feedFolders[tagging.FeedID], name)
+	}
+	for feedID, folders := range feedFolders {
+		feedFolders[feedID] = resolution.order(folders)
 	}
 	for i := range subscriptions {
-		subscriptions[i].Folder = feedFolders[subscriptions[i].ID]
+		folders := feedFolders[subscriptions[i].ID]
+		subscriptions[i].Folders = folders
+		subscriptions[i].Folder = ""
+		if len(folders) > 0 {
+			subscriptions[i].Folder = folders[0]
+		}
 	}
 }
 
@@ -331,7 +756,9 @@ func (s *Service) ListCachedByFilter(ctx context.Context, limit int, filter stri
 		entries []feedbin.Entry
 		err     error
 	)
-	if filter == "all" {
+	if since, ok := s.ageCutoff(filter); ok {
+		entries, err = s.repo.ListEntriesByFilterSince(ctx, limit, filter, since)
+	} else if filter == "all" {
 		entries, err = s.repo.ListEntries(ctx, limit)
 	} else {
 		entries, err = s.repo.ListEntriesByFilter(ctx, limit, filter)
@@ -351,8 +778,15 @@ func (s *Service) SearchCached(ctx context.Context, limit int, filter, query str
 }
 
 func (s *Service) ToggleUnread(ctx context.Context, entryID int64, currentUnread bool) (bool, error) {
+	if s.readOnly {
+		return currentUnread, ErrReadOnly
+	}
 	nextUnread := !currentUnread
-	if nextUnread {
+	if s.Offline() {
+		if err := s.queueChanges(ctx, queuedFieldUnread, []int64{entryID}, nextUnread); err != nil {
+			return currentUnread, err
+		}
+	} else if nextUnread {
 		if err := s.client.MarkEntriesUnread(ctx, []int64{entryID}); err != nil {
 			return currentUnread, fmt.Errorf("mark unread in feedbin: %w", err)
 		}
@@ -365,13 +799,23 @@ func (s *Service) ToggleUnread(ctx context.Context, entryID int64, currentUnread
 	if err := s.repo.SetEntryUnread(ctx, entryID, nextUnread); err != nil {
 		return currentUnread, fmt.Errorf("save unread state in cache: %w", err)
 	}
+	if !nextUnread {
+		s.addReadToday(ctx, time.Now(), 1)
+	}
 
 	return nextUnread, nil
 }
 
 func (s *Service) ToggleStarred(ctx context.Context, entryID int64, currentStarred bool) (bool, error) {
+	if s.readOnly {
+		return currentStarred, ErrReadOnly
+	}
 	nextStarred := !currentStarred
-	if nextStarred {
+	if s.Offline() {
+		if err := s.queueChanges(ctx, queuedFieldStarred, []int64{entryID}, nextStarred); err != nil {
+			return currentStarred, err
+		}
+	} else if nextStarred {
 		if err := s.client.StarEntries(ctx, []int64{entryID}); err != nil {
 			return currentStarred, fmt.Errorf("star entry in feedbin: %w", err)
 		}
@@ -384,10 +828,263 @@ func (s *Service) ToggleStarred(ctx context.Context, entryID int64, currentStarr
 	if err := s.repo.SetEntryStarred(ctx, entryID, nextStarred); err != nil {
 		return currentStarred, fmt.Errorf("save starred state in cache: %w", err)
 	}
+	if nextStarred && !s.Offline() {
+		// Starring means "keep this", so make the article readable offline.
+		// The star itself already succeeded; a failed fetch is retried by the
+		// next full-state sync, which hydrates starred entries by ID.
+		_ = s.ensureEntryContent(ctx, entryID)
+	}
 
 	return nextStarred, nil
 }
 
+// ensureEntryContent fetches and caches the full article when the cached copy
+// has no content, keeping the cached unread state.
+func (s *Service) ensureEntryContent(ctx context.Context, entryID int64) error {
+	cached, err := s.repo.GetEntry(ctx, entryID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("load entry %d from cache: %w", entryID, err)
+	}
+	if err == nil && strings.TrimSpace(cached.Content) != "" {
+		return nil
+	}
+	entries, err := s.client.ListEntriesByIDs(ctx, []int64{entryID})
+	if err != nil {
+		return fmt.Errorf("fetch entry %d from feedbin: %w", entryID, err)
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	entry := entries[0]
+	entry.IsUnread = cached.IsUnread
+	entry.IsStarred = true
+	if err := s.repo.SaveEntries(ctx, []feedbin.Entry{entry}); err != nil {
+		return fmt.Errorf("save entry %d to cache: %w", entryID, err)
+	}
+	return nil
+}
+
+// SetEntriesUnread applies an unread state to many entries at once. Entries
+// that Feedbin accepted are written to the cache even when other chunks
+// failed, so callers can report partial success and retry the failed IDs.
+func (s *Service) SetEntriesUnread(ctx context.Context, entryIDs []int64, unread bool) ([]int64, []int64, error) {
+	if s.readOnly {
+		return nil, entryIDs, ErrReadOnly
+	}
+	var (
+		succeeded, failed []int64
+		err               error
+	)
+	if s.Offline() {
+		if queueErr := s.queueChanges(ctx, queuedFieldUnread, entryIDs, unread); queueErr != nil {
+			return nil, entryIDs, queueErr
+		}
+		succeeded = entryIDs
+	} else if unread {
+		succeeded, failed, err = s.client.MarkEntriesUnreadBatch(ctx, entryIDs)
+	} else {
+		succeeded, failed, err = s.client.MarkEntriesReadBatch(ctx, entryIDs)
+	}
+	for _, id := range succeeded {
+		if saveErr := s.repo.SetEntryUnread(ctx, id, unread); saveErr != nil {
+			return succeeded, failed, fmt.Errorf("save unread state in cache: %w", saveErr)
+		}
+	}
+	if !unread {
+		s.addReadToday(ctx, time.Now(), len(succeeded))
+	}
+	if err != nil {
+		if unread {
+			return succeeded, failed, fmt.Errorf("mark unread in feedbin: %w", err)
+		}
+		return succeeded, failed, fmt.Errorf("mark read in feedbin: %w", err)
+	}
+	return succeeded, failed, nil
+}
+
+// SetEntriesStarred is the starred counterpart of SetEntriesUnread.
+func (s *Service) SetEntriesStarred(ctx context.Context, entryIDs []int64, starred bool) ([]int64, []int64, error) {
+	if s.readOnly {
+		return nil, entryIDs, ErrReadOnly
+	}
+	var (
+		succeeded, failed []int64
+		err               error
+	)
+	if s.Offline() {
+		if queueErr := s.queueChanges(ctx, queuedFieldStarred, entryIDs, starred); queueErr != nil {
+			return nil, entryIDs, queueErr
+		}
+		succeeded = entryIDs
+	} else if starred {
+		succeeded, failed, err = s.client.StarEntriesBatch(ctx, entryIDs)
+	} else {
+		succeeded, failed, err = s.client.UnstarEntriesBatch(ctx, entryIDs)
+	}
+	for _, id := range succeeded {
+		if saveErr := s.repo.SetEntryStarred(ctx, id, starred); saveErr != nil {
+			return succeeded, failed, fmt.Errorf("save starred state in cache: %w", saveErr)
+		}
+	}
+	if err != nil {
+		if starred {
+			return succeeded, failed, fmt.Errorf("star entries in feedbin: %w", err)
+		}
+		return succeeded, failed, fmt.Errorf("unstar entries in feedbin: %w", err)
+	}
+	return succeeded, failed, nil
+}
+
+func (s *Service) ListFeeds(ctx context.Context) ([]feedbin.FeedSummary, error) {
+	feeds, err := s.repo.ListFeeds(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load feeds from cache: %w", err)
+	}
+	return feeds, nil
+}
+
+// RenameFeed sets a subscription alias in Feedbin and mirrors it in the cache.
+func (s *Service) RenameFeed(ctx context.Context, feedID, subscriptionID int64, title string) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return errors.New("feed title must not be empty")
+	}
+	if subscriptionID == 0 {
+		return errors.New("subscription id unknown, refresh before renaming")
+	}
+	if s.Offline() {
+		return ErrOffline
+	}
+	if err := s.client.RenameSubscription(ctx, subscriptionID, title); err != nil {
+		return fmt.Errorf("rename subscription in feedbin: %w", err)
+	}
+	if err := s.repo.RenameFeed(ctx, feedID, title); err != nil {
+		return fmt.Errorf("save feed title in cache: %w", err)
+	}
+	return nil
+}
+
+// AssignFolder files a feed under folder by tagging it in Feedbin, then
+// mirrors the feed's new folders in the cache. An existing folder is reused
+// whatever the case the name was typed in.
+func (s *Service) AssignFolder(ctx context.Context, feedID int64, folder string) (feedbin.FolderAssignment, error) {
+	if s.readOnly {
+		return feedbin.FolderAssignment{}, ErrReadOnly
+	}
+	folder = strings.TrimSpace(folder)
+	if folder == "" {
+		return feedbin.FolderAssignment{}, errors.New("folder name must not be empty")
+	}
+	if s.Offline() {
+		return feedbin.FolderAssignment{}, ErrOffline
+	}
+	feeds, err := s.repo.ListFeeds(ctx)
+	if err != nil {
+		return feedbin.FolderAssignment{}, fmt.Errorf("load feeds from cache: %w", err)
+	}
+	var current []string
+	created := true
+	for _, feed := range feeds {
+		if feed.ID == feedID {
+			current = feed.Folders
+			if len(current) == 0 && feed.Folder != "" {
+				current = []string{feed.Folder}
+			}
+		}
+		for _, name := range append([]string{feed.Folder}, feed.Folders...) {
+			if created && name != "" && strings.EqualFold(name, folder) {
+				folder = name
+				created = false
+			}
+		}
+	}
+
+	if _, err := s.client.CreateTagging(ctx, feedID, folder); err != nil {
+		return feedbin.FolderAssignment{}, fmt.Errorf("tag feed in feedbin: %w", err)
+	}
+	folders := slices.Clone(current)
+	if !slices.Contains(folders, folder) {
+		folders = append(folders, folder)
+	}
+	folders = s.folderResolution.order(folders)
+	if err := s.repo.SetFeedFolders(ctx, feedID, folders); err != nil {
+		return feedbin.FolderAssignment{}, fmt.Errorf("save feed folders in cache: %w", err)
+	}
+	return feedbin.FolderAssignment{Folder: folder, Folders: folders, Created: created}, nil
+}
+
+// SetFeedMuted hides or shows a feed's entries locally; Feedbin is not told.
+func (s *Service) SetFeedMuted(ctx context.Context, feedID int64, muted bool) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	if err := s.repo.SetFeedMuted(ctx, feedID, muted); err != nil {
+		return fmt.Errorf("save feed muted state in cache: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) Unsubscribe(ctx context.Context, feedID, subscriptionID int64) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	if subscriptionID == 0 {
+		return errors.New("subscription id unknown, refresh before unsubscribing")
+	}
+	if s.Offline() {
+		return ErrOffline
+	}
+	if err := s.client.Unsubscribe(ctx, subscriptionID); err != nil {
+		return fmt.Errorf("unsubscribe in feedbin: %w", err)
+	}
+	if err := s.repo.DeleteFeed(ctx, feedID); err != nil {
+		return fmt.Errorf("remove feed from cache: %w", err)
+	}
+	return nil
+}
+
+// RefreshFeed fetches the newest entries of a single feed and saves them with
+// their current unread/starred state. It returns the number of entries saved.
+func (s *Service) RefreshFeed(ctx context.Context, feedID int64) (int, error) {
+	if s.Offline() {
+		return 0, ErrOffline
+	}
+	entries, err := s.client.ListFeedEntries(ctx, feedID, 1, feedRefreshPerPage)
+	if err != nil {
+		return 0, fmt.Errorf("fetch feed entries from feedbin: %w", err)
+	}
+	if len(entries) == 0 {
+		return 0, nil
+	}
+	unreadIDs, err := s.client.ListUnreadEntryIDs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("fetch unread entries from feedbin: %w", err)
+	}
+	starredIDs, err := s.client.ListStarredEntryIDs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("fetch starred entries from feedbin: %w", err)
+	}
+	unread := make(map[int64]struct{}, len(unreadIDs))
+	for _, id := range unreadIDs {
+		unread[id] = struct{}{}
+	}
+	starred := make(map[int64]struct{}, len(starredIDs))
+	for _, id := range starredIDs {
+		starred[id] = struct{}{}
+	}
+	for i := range entries {
+		_, entries[i].IsUnread = unread[entries[i].ID]
+		_, entries[i].IsStarred = starred[entries[i].ID]
+	}
+	if err := s.repo.SaveEntries(ctx, entries); err != nil {
+		return 0, fmt.Errorf("save entries to cache: %w", err)
+	}
+	return len(entries), nil
+}
+
 func (s *Service) LoadUIPreferences(ctx context.Context) (UIPreferences, error) {
 	compact, err := s.loadBoolPreference(ctx, uiPrefCompactKey)
 	if err != nil {
@@ -401,34 +1098,108 @@ func (s *Service) LoadUIPreferences(ctx context.Context) (UIPreferences, error)
 	if err != nil {
 		return UIPreferences{}, err
 	}
-	relativeTime := true
-	relativeValue, err := s.repo.GetAppState(ctx, uiPrefRelativeTimeKey)
+	timeFormat, err := s.loadTimeFormat(ctx)
 	if err != nil {
-		if !errors.Is(err, sql.ErrNoRows) {
-			return UIPreferences{}, fmt.Errorf("load preference %q: %w", uiPrefRelativeTimeKey, err)
-		}
-	} else {
-		parsed, parseErr := strconv.ParseBool(relativeValue)
-		if parseErr != nil {
-			return UIPreferences{}, fmt.Errorf("parse preference %q value %q: %w", uiPrefRelativeTimeKey, relativeValue, parseErr)
-		}
-		relativeTime = parsed
+		return UIPreferences{}, err
 	}
 	showNumbers, err := s.loadBoolPreference(ctx, uiPrefShowNumbersKey)
 	if err != nil {
 		return UIPreferences{}, err
 	}
+	autoPreview, err := s.loadBoolPreference(ctx, uiPrefAutoPreviewKey)
+	if err != nil {
+		return UIPreferences{}, err
+	}
+	confirmBulkActions, err := s.loadBoolPreferenceWithDefault(ctx, uiPrefConfirmBulkKey, true)
+	if err != nil {
+		return UIPreferences{}, err
+	}
+	incrementalSearch, err := s.loadBoolPreference(ctx, uiPrefIncrementalKey)
+	if err != nil {
+		return UIPreferences{}, err
+	}
+	compactCounts, err := s.loadBoolPreference(ctx, uiPrefCompactCountsKey)
+	if err != nil {
+		return UIPreferences{}, err
+	}
+	mergePages, err := s.loadBoolPreference(ctx, uiPrefMergePagesKey)
+	if err != nil {
+		return UIPreferences{}, err
+	}
+	compactTree, err := s.loadBoolPreference(ctx, uiPrefCompactTreeKey)
+	if err != nil {
+		return UIPreferences{}, err
+	}
+	tagsAsFolders, err := s.loadBoolPreference(ctx, uiPrefTagsAsFoldersKey)
+	if err != nil {
+		return UIPreferences{}, err
+	}
+	hideRead, err := s.loadBoolPreference(ctx, uiPrefHideReadKey)
+	if err != nil {
+		return UIPreferences{}, err
+	}
+	unreadFirst, err := s.loadBoolPreference(ctx, uiPrefUnreadFirstKey)
+	if err != nil {
+		return UIPreferences{}, err
+	}
+	preferSummary, err := s.loadBoolPreference(ctx, uiPrefPreferSummaryKey)
+	if err != nil {
+		return UIPreferences{}, err
+	}
+	showReadToday, err := s.loadBoolPreference(ctx, uiPrefShowReadTodayKey)
+	if err != nil {
+		return UIPreferences{}, err
+	}
+	markReadOnDetail, err := s.loadBoolPreference(ctx, uiPrefMarkReadOnDetailKey)
+	if err != nil {
+		return UIPreferences{}, err
+	}
+	stripFeedPrefix, err := s.loadBoolPreference(ctx, uiPrefStripFeedPrefixKey)
+	if err != nil {
+		return UIPreferences{}, err
+	}
+	previewLines, err := s.loadIntPreference(ctx, uiPrefPreviewLinesKey)
+	if err != nil {
+		return UIPreferences{}, err
+	}
+	dateColumn, err := s.repo.GetAppState(ctx, uiPrefDateColumnKey)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return UIPreferences{}, fmt.Errorf("load preference %q: %w", uiPrefDateColumnKey, err)
+	}
+	previewSource, err := s.repo.GetAppState(ctx, uiPrefPreviewSourceKey)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return UIPreferences{}, fmt.Errorf("load preference %q: %w", uiPrefPreviewSourceKey, err)
+	}
 
 	return UIPreferences{
-		Compact:         compact,
-		MarkReadOnOpen:  markReadOnOpen,
-		ConfirmOpenRead: confirmOpenRead,
-		RelativeTime:    relativeTime,
-		ShowNumbers:     showNumbers,
+		Compact:              compact,
+		MarkReadOnOpen:       markReadOnOpen,
+		ConfirmOpenRead:      confirmOpenRead,
+		TimeFormat:           timeFormat,
+		ShowNumbers:          showNumbers,
+		AutoPreview:          autoPreview,
+		ConfirmBulkActions:   confirmBulkActions,
+		DateColumn:           dateColumn,
+		IncrementalSearch:    incrementalSearch,
+		CompactCounts:        compactCounts,
+		MergePages:           mergePages,
+		CompactTree:          compactTree,
+		TagsAsFolders:        tagsAsFolders,
+		HideRead:             hideRead,
+		CompactUnreadFirst:   unreadFirst,
+		PreviewLines:         previewLines,
+		PreviewSource:        previewSource,
+		PreferSummary:        preferSummary,
+		ShowReadToday:        showReadToday,
+		MarkReadOnDetailOpen: markReadOnDetail,
+		StripFeedPrefix:      stripFeedPrefix,
 	}, nil
 }
 
 func (s *Service) SaveUIPreferences(ctx context.Context, prefs UIPreferences) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
 	if err := s.repo.SetAppState(ctx, uiPrefCompactKey, strconv.FormatBool(prefs.Compact)); err != nil {
 		return fmt.Errorf("save compact preference: %w", err)
 	}
@@ -438,20 +1209,114 @@ func (s *Service) SaveUIPreferences(ctx context.Context, prefs UIPreferences) er
 	if err := s.repo.SetAppState(ctx, uiPrefConfirmOpenKey, strconv.FormatBool(prefs.ConfirmOpenRead)); err != nil {
 		return fmt.Errorf("save confirm-open-read preference: %w", err)
 	}
-	if err := s.repo.SetAppState(ctx, uiPrefRelativeTimeKey, strconv.FormatBool(prefs.RelativeTime)); err != nil {
-		return fmt.Errorf("save relative-time preference: %w", err)
+	if prefs.TimeFormat != "" {
+		if err := s.repo.SetAppState(ctx, uiPrefTimeFormatKey, prefs.TimeFormat); err != nil {
+			return fmt.Errorf("save time-format preference: %w", err)
+		}
 	}
 	if err := s.repo.SetAppState(ctx, uiPrefShowNumbersKey, strconv.FormatBool(prefs.ShowNumbers)); err != nil {
 		return fmt.Errorf("save show-numbers preference: %w", err)
 	}
+	if err := s.repo.SetAppState(ctx, uiPrefAutoPreviewKey, strconv.FormatBool(prefs.AutoPreview)); err != nil {
+		return fmt.Errorf("save auto-preview preference: %w", err)
+	}
+	if err := s.repo.SetAppState(ctx, uiPrefConfirmBulkKey, strconv.FormatBool(prefs.ConfirmBulkActions)); err != nil {
+		return fmt.Errorf("save confirm-bulk-actions preference: %w", err)
+	}
+	if err := s.repo.SetAppState(ctx, uiPrefIncrementalKey, strconv.FormatBool(prefs.IncrementalSearch)); err != nil {
+		return fmt.Errorf("save incremental-search preference: %w", err)
+	}
+	if err := s.repo.SetAppState(ctx, uiPrefCompactCountsKey, strconv.FormatBool(prefs.CompactCounts)); err != nil {
+		return fmt.Errorf("save compact-counts preference: %w", err)
+	}
+	if err := s.repo.SetAppState(ctx, uiPrefMergePagesKey, strconv.FormatBool(prefs.MergePages)); err != nil {
+		return fmt.Errorf("save merge-pages preference: %w", err)
+	}
+	if err := s.repo.SetAppState(ctx, uiPrefCompactTreeKey, strconv.FormatBool(prefs.CompactTree)); err != nil {
+		return fmt.Errorf("save compact-tree preference: %w", err)
+	}
+	if err := s.repo.SetAppState(ctx, uiPrefTagsAsFoldersKey, strconv.FormatBool(prefs.TagsAsFolders)); err != nil {
+		return fmt.Errorf("save tags-as-folders preference: %w", err)
+	}
+	if err := s.repo.SetAppState(ctx, uiPrefHideReadKey, strconv.FormatBool(prefs.HideRead)); err != nil {
+		return fmt.Errorf("save hide-read preference: %w", err)
+	}
+	if err := s.repo.SetAppState(ctx, uiPrefUnreadFirstKey, strconv.FormatBool(prefs.CompactUnreadFirst)); err != nil {
+		return fmt.Errorf("save compact-unread-first preference: %w", err)
+	}
+	if err := s.repo.SetAppState(ctx, uiPrefPreferSummaryKey, strconv.FormatBool(prefs.PreferSummary)); err != nil {
+		return fmt.Errorf("save prefer-summary preference: %w", err)
+	}
+	if err := s.repo.SetAppState(ctx, uiPrefShowReadTodayKey, strconv.FormatBool(prefs.ShowReadToday)); err != nil {
+		return fmt.Errorf("save show-read-today preference: %w", err)
+	}
+	if err := s.repo.SetAppState(ctx, uiPrefMarkReadOnDetailKey, strconv.FormatBool(prefs.MarkReadOnDetailOpen)); err != nil {
+		return fmt.Errorf("save mark-read-on-detail-open preference: %w", err)
+	}
+	if err := s.repo.SetAppState(ctx, uiPrefStripFeedPrefixKey, strconv.FormatBool(prefs.StripFeedPrefix)); err != nil {
+		return fmt.Errorf("save strip-feed-prefix preference: %w", err)
+	}
+	if err := s.repo.SetAppState(ctx, uiPrefPreviewLinesKey, strconv.Itoa(prefs.PreviewLines)); err != nil {
+		return fmt.Errorf("save preview-lines preference: %w", err)
+	}
+	if prefs.PreviewSource != "" {
+		if err := s.repo.SetAppState(ctx, uiPrefPreviewSourceKey, prefs.PreviewSource); err != nil {
+			return fmt.Errorf("save preview-source preference: %w", err)
+		}
+	}
+	if prefs.DateColumn != "" {
+		if err := s.repo.SetAppState(ctx, uiPrefDateColumnKey, prefs.DateColumn); err != nil {
+			return fmt.Errorf("save date-column preference: %w", err)
+		}
+	}
 	return nil
 }
 
+// loadTimeFormat reads the time format, falling back to the relative-time
+// flag saved before the combined format existed, and to relative when
+// neither is stored.
+func (s *Service) loadTimeFormat(ctx context.Context) (string, error) {
+	format, err := s.repo.GetAppState(ctx, uiPrefTimeFormatKey)
+	if err == nil {
+		return format, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("load preference %q: %w", uiPrefTimeFormatKey, err)
+	}
+	relative, err := s.loadBoolPreferenceWithDefault(ctx, uiPrefRelativeTimeKey, true)
+	if err != nil {
+		return "", err
+	}
+	if relative {
+		return "relative", nil
+	}
+	return "absolute", nil
+}
+
 func (s *Service) loadBoolPreference(ctx context.Context, key string) (bool, error) {
+	return s.loadBoolPreferenceWithDefault(ctx, key, false)
+}
+
+func (s *Service) loadIntPreference(ctx context.Context, key string) (int, error) {
+	value, err := s.repo.GetAppState(ctx, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("load preference %q: %w", key, err)
+	}
+	parsed, parseErr := strconv.Atoi(value)
+	if parseErr != nil {
+		return 0, fmt.Errorf("parse preference %q value %q: %w", key, value, parseErr)
+	}
+	return parsed, nil
+}
+
+func (s *Service) loadBoolPreferenceWithDefault(ctx context.Context, key string, fallback bool) (bool, error) {
 	value, err := s.repo.GetAppState(ctx, key)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return false, nil
+			return fallback, nil
 		}
 		return false, fmt.Errorf("load preference %q: %w", key, err)
 	}
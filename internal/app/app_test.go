@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -23,7 +25,23 @@ type fakeClient struct {
 	markReadIDs   []int64
 	starIDs       []int64
 	unstarIDs     []int64
+	failIDs       map[int64]bool
+	feedEntries   []feedbin.Entry
+	renamed       map[int64]string
+	createdTags   []feedbin.Tagging
+	unsubscribed  []int64
+	feeds         map[int64]feedbin.Subscription
 	err           error
+	taggingsErr   error
+	subsErr       error
+	extracted     map[string]string
+}
+
+func (f fakeClient) ExtractContent(_ context.Context, extractURL string) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	return f.extracted[extractURL], nil
 }
 
 func (f fakeClient) ListEntries(context.Context, int, int) ([]feedbin.Entry, error) {
@@ -44,9 +62,20 @@ func (f fakeClient) ListSubscriptions(context.Context) ([]feedbin.Subscription,
 	if f.err != nil {
 		return nil, f.err
 	}
+	if f.subsErr != nil {
+		return nil, f.subsErr
+	}
 	return append([]feedbin.Subscription(nil), f.subscriptions...), nil
 }
 
+func (f fakeClient) GetFeed(_ context.Context, feedID int64) (feedbin.Subscription, error) {
+	feed, ok := f.feeds[feedID]
+	if !ok {
+		return feedbin.Subscription{}, errors.New("feed not found")
+	}
+	return feed, nil
+}
+
 func (f fakeClient) ListUnreadEntryIDs(context.Context) ([]int64, error) {
 	if f.err != nil {
 		return nil, f.err
@@ -65,6 +94,9 @@ func (f fakeClient) ListTaggings(context.Context) ([]feedbin.Tagging, error) {
 	if f.err != nil {
 		return nil, f.err
 	}
+	if f.taggingsErr != nil {
+		return nil, f.taggingsErr
+	}
 	return append([]feedbin.Tagging(nil), f.taggings...), nil
 }
 
@@ -107,7 +139,43 @@ func (f *fakeClient) UnstarEntries(_ context.Context, entryIDs []int64) error {
 	return nil
 }
 
+func (f *fakeClient) MarkEntriesUnreadBatch(_ context.Context, entryIDs []int64) ([]int64, []int64, error) {
+	return f.batch(entryIDs, &f.markUnreadIDs)
+}
+
+func (f *fakeClient) MarkEntriesReadBatch(_ context.Context, entryIDs []int64) ([]int64, []int64, error) {
+	return f.batch(entryIDs, &f.markReadIDs)
+}
+
+func (f *fakeClient) StarEntriesBatch(_ context.Context, entryIDs []int64) ([]int64, []int64, error) {
+	return f.batch(entryIDs, &f.starIDs)
+}
+
+func (f *fakeClient) UnstarEntriesBatch(_ context.Context, entryIDs []int64) ([]int64, []int64, error) {
+	return f.batch(entryIDs, &f.unstarIDs)
+}
+
+func (f *fakeClient) batch(entryIDs []int64, applied *[]int64) ([]int64, []int64, error) {
+	if f.err != nil {
+		return nil, append([]int64(nil), entryIDs...), f.err
+	}
+	var succeeded, failed []int64
+	for _, id := range entryIDs {
+		if f.failIDs[id] {
+			failed = append(failed, id)
+			continue
+		}
+		succeeded = append(succeeded, id)
+	}
+	*applied = append([]int64(nil), succeeded...)
+	if len(failed) > 0 {
+		return succeeded, failed, errors.New("chunk failed")
+	}
+	return succeeded, nil, nil
+}
+
 type fakeRepo struct {
+	marks      []feedbin.EntryMark
 	subs       []feedbin.Subscription
 	saved      []feedbin.Entry
 	unreadIDs  []int64
@@ -121,16 +189,221 @@ type fakeRepo struct {
 	setUnread  map[int64]bool
 	setStarred map[int64]bool
 	syncCursor map[string]time.Time
+	feeds      []feedbin.FeedSummary
+	mutedFeeds map[int64]bool
+	feedTitles map[int64]string
+	feedFolder map[int64][]string
+	deleted    []int64
+	snoozed    map[int64]time.Time
+	dismissed  map[int64]bool
+	progress   map[int64]float64
+	searchMode string
+	ftsErr     error
+	localReads map[int64]int64
+}
+
+func (f *fakeRepo) MarkFeedReadLocally(_ context.Context, feedID, throughEntryID int64) error {
+	if f.saveErr != nil {
+		return f.saveErr
+	}
+	if f.localReads == nil {
+		f.localReads = make(map[int64]int64)
+	}
+	f.localReads[feedID] = max(f.localReads[feedID], throughEntryID)
+	return nil
+}
+
+func (f *fakeRepo) ClearFeedLocalRead(_ context.Context, feedID int64) (bool, error) {
+	if f.saveErr != nil {
+		return false, f.saveErr
+	}
+	_, ok := f.localReads[feedID]
+	delete(f.localReads, feedID)
+	return ok, nil
+}
+
+func (f *fakeRepo) SearchMode() string {
+	if f.searchMode == "" {
+		return "like"
+	}
+	return f.searchMode
+}
+
+func (f *fakeRepo) SetSearchMode(_ context.Context, mode string) error {
+	if mode == "fts" && f.ftsErr != nil {
+		f.searchMode = "like"
+		return f.ftsErr
+	}
+	f.searchMode = mode
+	return nil
+}
+
+func (f *fakeRepo) SetReadProgress(_ context.Context, entryID int64, fraction float64) error {
+	if f.saveErr != nil {
+		return f.saveErr
+	}
+	if f.progress == nil {
+		f.progress = make(map[int64]float64)
+	}
+	if fraction <= 0 {
+		delete(f.progress, entryID)
+		return nil
+	}
+	f.progress[entryID] = fraction
+	return nil
+}
+
+func (f *fakeRepo) ListReadProgress(context.Context) (map[int64]float64, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	out := make(map[int64]float64, len(f.progress))
+	for id, fraction := range f.progress {
+		out[id] = fraction
+	}
+	return out, nil
+}
+
+func (f *fakeRepo) Dismiss(_ context.Context, entryID int64) error {
+	if f.saveErr != nil {
+		return f.saveErr
+	}
+	if f.dismissed == nil {
+		f.dismissed = make(map[int64]bool)
+	}
+	f.dismissed[entryID] = true
+	return nil
+}
+
+func (f *fakeRepo) Undismiss(_ context.Context, entryID int64) error {
+	if f.saveErr != nil {
+		return f.saveErr
+	}
+	delete(f.dismissed, entryID)
+	return nil
+}
+
+func (f *fakeRepo) SnoozeEntry(_ context.Context, entryID int64, wakeAt time.Time) error {
+	if f.saveErr != nil {
+		return f.saveErr
+	}
+	if f.snoozed == nil {
+		f.snoozed = make(map[int64]time.Time)
+	}
+	f.snoozed[entryID] = wakeAt
+	return nil
+}
+
+func (f *fakeRepo) ListDueSnoozedEntryIDs(_ context.Context, now time.Time) ([]int64, error) {
+	var ids []int64
+	for id, wakeAt := range f.snoozed {
+		if !wakeAt.After(now) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (f *fakeRepo) UnsnoozeEntries(_ context.Context, entryIDs []int64) error {
+	for _, id := range entryIDs {
+		delete(f.snoozed, id)
+	}
+	return nil
+}
+
+func (f *fakeRepo) ListFeeds(context.Context) ([]feedbin.FeedSummary, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	return append([]feedbin.FeedSummary(nil), f.feeds...), nil
+}
+
+func (f *fakeRepo) SetFeedMuted(_ context.Context, feedID int64, muted bool) error {
+	if f.saveErr != nil {
+		return f.saveErr
+	}
+	if f.mutedFeeds == nil {
+		f.mutedFeeds = make(map[int64]bool)
+	}
+	f.mutedFeeds[feedID] = muted
+	return nil
+}
+
+func (f *fakeRepo) RenameFeed(_ context.Context, feedID int64, title string) error {
+	if f.saveErr != nil {
+		return f.saveErr
+	}
+	if f.feedTitles == nil {
+		f.feedTitles = make(map[int64]string)
+	}
+	f.feedTitles[feedID] = title
+	return nil
+}
+
+func (f *fakeRepo) SetFeedFolders(_ context.Context, feedID int64, folders []string) error {
+	if f.saveErr != nil {
+		return f.saveErr
+	}
+	if f.feedFolder == nil {
+		f.feedFolder = make(map[int64][]string)
+	}
+	f.feedFolder[feedID] = folders
+	return nil
+}
+
+func (f *fakeRepo) DeleteFeed(_ context.Context, feedID int64) error {
+	if f.saveErr != nil {
+		return f.saveErr
+	}
+	f.deleted = append(f.deleted, feedID)
+	return nil
 }
 
 func (f *fakeRepo) SaveSubscriptions(_ context.Context, subscriptions []feedbin.Subscription) error {
 	if f.saveErr != nil {
 		return f.saveErr
 	}
-	f.subs = append([]feedbin.Subscription(nil), subscriptions...)
+	merged := make([]feedbin.Subscription, 0, len(f.subs)+len(subscriptions))
+	for _, existing := range f.subs {
+		replaced := false
+		for _, sub := range subscriptions {
+			if sub.ID == existing.ID {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, existing)
+		}
+	}
+	f.subs = append(merged, subscriptions...)
 	return nil
 }
 
+func (f *fakeRepo) ListOrphanedFeedIDs(context.Context) ([]int64, error) {
+	known := make(map[int64]bool, len(f.subs))
+	for _, sub := range f.subs {
+		known[sub.ID] = true
+	}
+	var ids []int64
+	for _, entry := range f.saved {
+		if !known[entry.FeedID] {
+			known[entry.FeedID] = true
+			ids = append(ids, entry.FeedID)
+		}
+	}
+	return ids, nil
+}
+
+func (f *fakeRepo) GetEntry(_ context.Context, entryID int64) (feedbin.Entry, error) {
+	for _, entry := range f.cached {
+		if entry.ID == entryID {
+			return entry, nil
+		}
+	}
+	return feedbin.Entry{}, sql.ErrNoRows
+}
+
 func (f *fakeRepo) SaveEntries(_ context.Context, entries []feedbin.Entry) error {
 	if f.saveErr != nil {
 		return f.saveErr
@@ -148,6 +421,18 @@ func (f *fakeRepo) SaveEntryStates(_ context.Context, unreadIDs, starredIDs []in
 	return nil
 }
 
+func (f *fakeRepo) ListEntryMarks(context.Context) ([]feedbin.EntryMark, error) {
+	return append([]feedbin.EntryMark(nil), f.marks...), nil
+}
+
+func (f *fakeRepo) SaveEntryMarks(_ context.Context, marks []feedbin.EntryMark) error {
+	if f.saveErr != nil {
+		return f.saveErr
+	}
+	f.marks = append([]feedbin.EntryMark(nil), marks...)
+	return nil
+}
+
 func (f *fakeRepo) SetEntryUnread(_ context.Context, entryID int64, unread bool) error {
 	if f.saveErr != nil {
 		return f.saveErr
@@ -218,6 +503,69 @@ func (f *fakeRepo) ListEntries(_ context.Context, limit int) ([]feedbin.Entry, e
 	return append([]feedbin.Entry(nil), f.cached...), nil
 }
 
+func (f *fakeRepo) ListEntriesByFilterSince(ctx context.Context, limit int, filter string, since time.Time) ([]feedbin.Entry, error) {
+	entries, err := f.ListEntriesByFilter(ctx, limit, filter)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]feedbin.Entry, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.PublishedAt.Before(since) {
+			out = append(out, entry)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeRepo) NewestEntryID(context.Context) (int64, error) {
+	if f.listErr != nil {
+		return 0, f.listErr
+	}
+	var newest int64
+	for _, entry := range f.cached {
+		newest = max(newest, entry.ID)
+	}
+	return newest, nil
+}
+
+func (f *fakeRepo) Stats(context.Context) (feedbin.CacheStats, error) {
+	if f.listErr != nil {
+		return feedbin.CacheStats{}, f.listErr
+	}
+	stats := feedbin.CacheStats{Entries: len(f.cached)}
+	for _, entry := range f.cached {
+		if entry.IsUnread {
+			stats.Unread++
+		}
+		if entry.IsStarred {
+			stats.Starred++
+		}
+	}
+	return stats, nil
+}
+
+func (f *fakeRepo) ListEntriesByFilterOffset(ctx context.Context, limit, offset int, filter string, since time.Time) ([]feedbin.Entry, error) {
+	entries, err := f.ListEntriesByFilterSince(ctx, limit, filter, since)
+	if err != nil {
+		return nil, err
+	}
+	f.listLimit = limit
+	entries = entries[min(offset, len(entries)):]
+	return entries[:min(limit, len(entries))], nil
+}
+
+func (f *fakeRepo) EachEntry(_ context.Context, fn func(feedbin.Entry) error) error {
+	if f.listErr != nil {
+		return f.listErr
+	}
+	for _, entry := range f.cached {
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (f *fakeRepo) ListEntriesByFilter(_ context.Context, _ int, filter string) ([]feedbin.Entry, error) {
 	if f.listErr != nil {
 		return nil, f.listErr
@@ -309,21 +657,172 @@ func TestService_Refresh_SavesMetadataAndStates(t *testing.T) {
 	}
 }
 
+func TestService_LastSyncedAt_ReadsSyncCursor(t *testing.T) {
+	repo := &fakeRepo{}
+	svc := NewService(&fakeClient{}, repo)
+
+	synced, err := svc.LastSyncedAt(context.Background())
+	if err != nil || !synced.IsZero() {
+		t.Fatalf("expected no sync yet, got %v (err %v)", synced, err)
+	}
+	want := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	repo.syncCursor = map[string]time.Time{"updated_entries_since": want}
+	if synced, err = svc.LastSyncedAt(context.Background()); err != nil || !synced.Equal(want) {
+		t.Fatalf("expected %v, got %v (err %v)", want, synced, err)
+	}
+}
+
+func TestService_Refresh_TaggingsFailureKeepsCachedFolders(t *testing.T) {
+	entry := feedbin.Entry{ID: 1, Title: "Hello", FeedID: 10, PublishedAt: time.Now().UTC()}
+	client := &fakeClient{
+		entries:       []feedbin.Entry{entry},
+		subscriptions: []feedbin.Subscription{{ID: 10, Title: "Feed A"}, {ID: 11, Title: "Feed B"}},
+		unreadIDs:     []int64{1},
+		starredIDs:    []int64{},
+		taggingsErr:   errors.New("taggings unavailable"),
+	}
+	repo := &fakeRepo{
+		cached: []feedbin.Entry{entry},
+		feeds: []feedbin.FeedSummary{{Subscription: feedbin.Subscription{
+			ID: 10, Title: "Feed A", Folder: "News", Folders: []string{"News", "Tech"},
+		}}},
+	}
+
+	svc := NewService(client, repo)
+	if _, err := svc.Refresh(context.Background(), 1, 20); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	if len(repo.subs) != 2 {
+		t.Fatalf("expected subscriptions to be saved, got %+v", repo.subs)
+	}
+	if repo.subs[0].Folder != "News" || !slices.Equal(repo.subs[0].Folders, []string{"News", "Tech"}) {
+		t.Fatalf("expected cached folders to be kept, got %+v", repo.subs[0])
+	}
+	if repo.subs[1].Folder != "" {
+		t.Fatalf("expected uncached feed to stay unfiled, got %+v", repo.subs[1])
+	}
+	if len(repo.unreadIDs) != 1 || repo.unreadIDs[0] != 1 {
+		t.Fatalf("unread state not saved: %+v", repo.unreadIDs)
+	}
+	if warning := svc.SyncWarning(); !strings.Contains(warning, "taggings unavailable") {
+		t.Fatalf("expected taggings warning, got %q", warning)
+	}
+
+	client.taggingsErr = nil
+	client.taggings = []feedbin.Tagging{{FeedID: 10, Name: "News"}}
+	if _, err := svc.Refresh(context.Background(), 1, 20); err != nil {
+		t.Fatalf("second Refresh returned error: %v", err)
+	}
+	if warning := svc.SyncWarning(); warning != "" {
+		t.Fatalf("expected warning to clear after a clean sync, got %q", warning)
+	}
+}
+
+func TestService_Refresh_SubscriptionsFailureAborts(t *testing.T) {
+	client := &fakeClient{
+		entries: []feedbin.Entry{{ID: 1, Title: "Hello", FeedID: 10, PublishedAt: time.Now().UTC()}},
+		subsErr: errors.New("subscriptions unavailable"),
+	}
+	repo := &fakeRepo{}
+
+	svc := NewService(client, repo)
+	if _, err := svc.Refresh(context.Background(), 1, 20); err == nil || !strings.Contains(err.Error(), "subscriptions unavailable") {
+		t.Fatalf("expected subscriptions error, got %v", err)
+	}
+}
+
+func TestService_WarmCache_ReportsTaggingsWarning(t *testing.T) {
+	client := &fakeClient{
+		entries:     []feedbin.Entry{{ID: 1, Title: "Hello", FeedID: 10, PublishedAt: time.Now().UTC()}},
+		taggingsErr: errors.New("taggings unavailable"),
+	}
+	repo := &fakeRepo{}
+
+	svc := NewService(client, repo)
+	result, err := svc.WarmCache(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("WarmCache returned error: %v", err)
+	}
+	if !strings.Contains(result.Warning, "folders not updated") {
+		t.Fatalf("expected folders warning, got %q", result.Warning)
+	}
+}
+
+func TestService_Refresh_BackfillsOrphanedFeeds(t *testing.T) {
+	now := time.Now().UTC()
+	client := &fakeClient{
+		entries: []feedbin.Entry{
+			{ID: 1, Title: "Subscribed", FeedID: 10, PublishedAt: now},
+			{ID: 2, Title: "Unsubscribed", FeedID: 20, PublishedAt: now},
+			{ID: 3, Title: "Removed", FeedID: 30, PublishedAt: now},
+		},
+		subscriptions: []feedbin.Subscription{{ID: 10, Title: "Feed A"}},
+		feeds:         map[int64]feedbin.Subscription{20: {ID: 20, Title: "Old Feed"}},
+	}
+	repo := &fakeRepo{}
+
+	svc := NewService(client, repo)
+	if _, err := svc.Refresh(context.Background(), 1, 20); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+
+	titles := make(map[int64]string, len(repo.subs))
+	for _, sub := range repo.subs {
+		titles[sub.ID] = sub.Title
+	}
+	if titles[10] != "Feed A" || titles[20] != "Old Feed" {
+		t.Fatalf("expected orphaned feed 20 back-filled, got %+v", repo.subs)
+	}
+	if _, ok := titles[30]; ok {
+		t.Fatalf("expected unresolvable feed 30 to stay unknown, got %+v", repo.subs)
+	}
+}
+
 func TestApplyTaggingsToSubscriptions(t *testing.T) {
 	subs := []feedbin.Subscription{{ID: 1, Title: "A"}, {ID: 2, Title: "B"}}
 	taggings := []feedbin.Tagging{
 		{FeedID: 1, Name: "Z"},
 		{FeedID: 1, Name: "Formula 1"},
 	}
-	applyTaggingsToSubscriptions(subs, taggings)
+	applyTaggingsToSubscriptions(subs, taggings, FolderResolution{})
 	if subs[0].Folder != "Formula 1" {
 		t.Fatalf("expected deterministic smallest tag name, got %q", subs[0].Folder)
 	}
+	if !slices.Equal(subs[0].Folders, []string{"Formula 1", "Z"}) {
+		t.Fatalf("expected every tag kept in order, got %v", subs[0].Folders)
+	}
 	if subs[1].Folder != "" {
 		t.Fatalf("expected empty folder for untagged feed, got %q", subs[1].Folder)
 	}
 }
 
+func TestApplyTaggingsToSubscriptions_Strategies(t *testing.T) {
+	taggings := []feedbin.Tagging{
+		{FeedID: 1, Name: "Sports"},
+		{FeedID: 1, Name: "Formula 1"},
+		{FeedID: 1, Name: "Motors"},
+	}
+	tests := []struct {
+		name       string
+		resolution FolderResolution
+		want       []string
+	}{
+		{name: "alphabetical", resolution: FolderResolution{Strategy: FolderByName}, want: []string{"Formula 1", "Motors", "Sports"}},
+		{name: "first seen", resolution: FolderResolution{Strategy: FolderFirstSeen}, want: []string{"Sports", "Formula 1", "Motors"}},
+		{name: "priority", resolution: FolderResolution{Strategy: FolderByPriority, Priority: []string{"news", "motors", "Sports"}}, want: []string{"Motors", "Formula 1", "Sports"}},
+		{name: "priority without a match", resolution: FolderResolution{Strategy: FolderByPriority, Priority: []string{"News"}}, want: []string{"Formula 1", "Motors", "Sports"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subs := []feedbin.Subscription{{ID: 1, Title: "A"}}
+			applyTaggingsToSubscriptions(subs, taggings, tt.resolution)
+			if subs[0].Folder != tt.want[0] || !slices.Equal(subs[0].Folders, tt.want) {
+				t.Fatalf("expected folder %q with %v, got %q with %v", tt.want[0], tt.want, subs[0].Folder, subs[0].Folders)
+			}
+		})
+	}
+}
+
 func TestService_LoadMore_UsesIncrementalUpdatedEntries(t *testing.T) {
 	client := &fakeClient{
 		entries:      []feedbin.Entry{{ID: 50, Title: "Page 2", FeedID: 10, PublishedAt: time.Now().UTC()}},
@@ -516,6 +1015,356 @@ func TestService_ToggleStarred(t *testing.T) {
 	}
 }
 
+func TestService_ToggleStarred_HydratesMissingContent(t *testing.T) {
+	client := &fakeClient{entriesByIDs: []feedbin.Entry{{ID: 7, Title: "Keeper", Content: "<p>Full article</p>"}}}
+	repo := &fakeRepo{cached: []feedbin.Entry{{ID: 7, Title: "Keeper", IsUnread: true}}}
+	svc := NewService(client, repo)
+
+	if _, err := svc.ToggleStarred(context.Background(), 7, false); err != nil {
+		t.Fatalf("ToggleStarred returned error: %v", err)
+	}
+	if len(repo.saved) != 1 || repo.saved[0].Content != "<p>Full article</p>" {
+		t.Fatalf("expected starred entry content cached, got %+v", repo.saved)
+	}
+	if !repo.saved[0].IsStarred || !repo.saved[0].IsUnread {
+		t.Fatalf("expected hydrated entry to stay starred and unread, got %+v", repo.saved[0])
+	}
+
+	repo.saved = nil
+	repo.cached[0].Content = "<p>Full article</p>"
+	if _, err := svc.ToggleStarred(context.Background(), 7, false); err != nil {
+		t.Fatalf("ToggleStarred returned error: %v", err)
+	}
+	if _, err := svc.ToggleStarred(context.Background(), 7, true); err != nil {
+		t.Fatalf("ToggleStarred unstar returned error: %v", err)
+	}
+	if repo.saved != nil {
+		t.Fatalf("expected no refetch when content is cached or when unstarring, got %+v", repo.saved)
+	}
+}
+
+func TestService_SetEntriesUnread_CachesOnlySucceededIDs(t *testing.T) {
+	client := &fakeClient{failIDs: map[int64]bool{3: true}}
+	repo := &fakeRepo{}
+	svc := NewService(client, repo)
+
+	succeeded, failed, err := svc.SetEntriesUnread(context.Background(), []int64{1, 2, 3}, false)
+	if err == nil || !strings.Contains(err.Error(), "mark read in feedbin") {
+		t.Fatalf("expected wrapped partial failure, got %v", err)
+	}
+	if len(succeeded) != 2 || len(failed) != 1 || failed[0] != 3 {
+		t.Fatalf("unexpected result: succeeded=%v failed=%v", succeeded, failed)
+	}
+	if len(repo.setUnread) != 2 || repo.setUnread[1] || repo.setUnread[2] {
+		t.Fatalf("expected only succeeded IDs cached as read, got %+v", repo.setUnread)
+	}
+	if _, ok := repo.setUnread[3]; ok {
+		t.Fatalf("failed ID must not be cached, got %+v", repo.setUnread)
+	}
+}
+
+func TestService_SetEntriesStarred(t *testing.T) {
+	client := &fakeClient{}
+	repo := &fakeRepo{}
+	svc := NewService(client, repo)
+
+	succeeded, failed, err := svc.SetEntriesStarred(context.Background(), []int64{4, 5}, true)
+	if err != nil {
+		t.Fatalf("SetEntriesStarred returned error: %v", err)
+	}
+	if len(succeeded) != 2 || len(failed) != 0 {
+		t.Fatalf("unexpected result: succeeded=%v failed=%v", succeeded, failed)
+	}
+	if !repo.setStarred[4] || !repo.setStarred[5] {
+		t.Fatalf("expected cache starred state true, got %+v", repo.setStarred)
+	}
+}
+
+func (f *fakeClient) ListFeedEntries(context.Context, int64, int, int) ([]feedbin.Entry, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return append([]feedbin.Entry(nil), f.feedEntries...), nil
+}
+
+func (f *fakeClient) RenameSubscription(_ context.Context, subscriptionID int64, title string) error {
+	if f.err != nil {
+		return f.err
+	}
+	if f.renamed == nil {
+		f.renamed = make(map[int64]string)
+	}
+	f.renamed[subscriptionID] = title
+	return nil
+}
+
+func (f *fakeClient) CreateTagging(_ context.Context, feedID int64, name string) (feedbin.Tagging, error) {
+	if f.err != nil {
+		return feedbin.Tagging{}, f.err
+	}
+	tagging := feedbin.Tagging{ID: int64(len(f.createdTags) + 1), FeedID: feedID, Name: name}
+	f.createdTags = append(f.createdTags, tagging)
+	return tagging, nil
+}
+
+func (f *fakeClient) Unsubscribe(_ context.Context, subscriptionID int64) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.unsubscribed = append(f.unsubscribed, subscriptionID)
+	return nil
+}
+
+type pagedClient struct {
+	*fakeClient
+	pages       map[int][]feedbin.Entry
+	failPage    int
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	requested   []int
+}
+
+func (c *pagedClient) ListEntries(_ context.Context, page, _ int) ([]feedbin.Entry, error) {
+	c.mu.Lock()
+	c.requested = append(c.requested, page)
+	c.inFlight++
+	if c.inFlight > c.maxInFlight {
+		c.maxInFlight = c.inFlight
+	}
+	c.mu.Unlock()
+	time.Sleep(5 * time.Millisecond)
+	c.mu.Lock()
+	c.inFlight--
+	c.mu.Unlock()
+
+	if page == c.failPage {
+		return nil, errors.New("boom")
+	}
+	return append([]feedbin.Entry(nil), c.pages[page]...), nil
+}
+
+func TestService_WarmCache_DedupesInPageOrder(t *testing.T) {
+	client := &pagedClient{
+		fakeClient: &fakeClient{},
+		pages: map[int][]feedbin.Entry{
+			1: {{ID: 10}, {ID: 9}},
+			2: {{ID: 9}, {ID: 8}},
+			3: {{ID: 7}},
+		},
+	}
+	repo := &fakeRepo{}
+	svc := NewService(client, repo)
+	svc.SetWarmConcurrency(2)
+
+	result, err := svc.WarmCache(context.Background(), 4)
+	if err != nil {
+		t.Fatalf("WarmCache returned error: %v", err)
+	}
+	if result.Pages != 3 || result.Entries != 4 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	got := make([]int64, 0, len(repo.saved))
+	for _, entry := range repo.saved {
+		got = append(got, entry.ID)
+	}
+	if len(got) != 4 || got[0] != 10 || got[1] != 9 || got[2] != 8 || got[3] != 7 {
+		t.Fatalf("unexpected saved order: %v", got)
+	}
+	if client.maxInFlight > 2 {
+		t.Fatalf("expected at most 2 concurrent fetches, got %d", client.maxInFlight)
+	}
+	if result.FetchTime <= 0 || result.Duration <= 0 {
+		t.Fatalf("expected timings to be recorded, got %+v", result)
+	}
+}
+
+func TestService_WarmCache_PropagatesPageError(t *testing.T) {
+	client := &pagedClient{fakeClient: &fakeClient{}, failPage: 2}
+	repo := &fakeRepo{}
+	svc := NewService(client, repo)
+
+	_, err := svc.WarmCache(context.Background(), 3)
+	if err == nil || !strings.Contains(err.Error(), "fetch entries page 2") {
+		t.Fatalf("expected page error, got %v", err)
+	}
+	if repo.saved != nil {
+		t.Fatalf("expected nothing saved on failure, got %+v", repo.saved)
+	}
+}
+
+func TestService_WarmCache_ResumesAfterInterruption(t *testing.T) {
+	client := &pagedClient{
+		fakeClient: &fakeClient{},
+		pages: map[int][]feedbin.Entry{
+			1: {{ID: 10}},
+			2: {{ID: 9}},
+			3: {{ID: 8}},
+			4: {{ID: 7}},
+		},
+		failPage: 3,
+	}
+	repo := &fakeRepo{}
+	svc := NewService(client, repo)
+	svc.SetWarmConcurrency(1)
+
+	if _, err := svc.WarmCache(context.Background(), 4); err == nil || !strings.Contains(err.Error(), "fetch entries page 3") {
+		t.Fatalf("expected page 3 to fail, got %v", err)
+	}
+	if len(repo.saved) != 2 || repo.saved[0].ID != 10 || repo.saved[1].ID != 9 {
+		t.Fatalf("expected pages 1 and 2 saved, got %+v", repo.saved)
+	}
+	if got := repo.appState[warmProgressKey]; got != "2" {
+		t.Fatalf("expected progress through page 2, got %q", got)
+	}
+
+	client.failPage = 0
+	client.requested = nil
+	result, err := svc.WarmCache(context.Background(), 4)
+	if err != nil {
+		t.Fatalf("WarmCache returned error: %v", err)
+	}
+	if len(client.requested) != 2 || client.requested[0] != 3 || client.requested[1] != 4 {
+		t.Fatalf("expected the resumed sync to fetch pages 3 and 4, got %v", client.requested)
+	}
+	if result.StartPage != 3 || result.Pages != 2 || result.Entries != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if got := repo.appState[warmProgressKey]; got != "0" {
+		t.Fatalf("expected progress cleared after a complete sync, got %q", got)
+	}
+
+	client.requested = nil
+	if _, err := svc.WarmCache(context.Background(), 2); err != nil {
+		t.Fatalf("WarmCache returned error: %v", err)
+	}
+	if len(client.requested) != 2 || client.requested[0] != 1 {
+		t.Fatalf("expected the next sync to start at page 1, got %v", client.requested)
+	}
+}
+
+func TestService_ResetWarmProgress_StartsOver(t *testing.T) {
+	client := &pagedClient{fakeClient: &fakeClient{}}
+	repo := &fakeRepo{appState: map[string]string{warmProgressKey: "5"}}
+	svc := NewService(client, repo)
+	svc.SetWarmConcurrency(1)
+
+	if err := svc.ResetWarmProgress(context.Background()); err != nil {
+		t.Fatalf("ResetWarmProgress returned error: %v", err)
+	}
+	result, err := svc.WarmCache(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("WarmCache returned error: %v", err)
+	}
+	if result.StartPage != 1 || len(client.requested) != 2 || client.requested[0] != 1 {
+		t.Fatalf("expected a full sync from page 1, got result=%+v requested=%v", result, client.requested)
+	}
+}
+
+func TestService_SetWarmConcurrency_Clamps(t *testing.T) {
+	svc := NewService(&fakeClient{}, &fakeRepo{})
+	svc.SetWarmConcurrency(100)
+	if svc.warmConcurrency != MaxWarmConcurrency {
+		t.Fatalf("expected concurrency capped at %d, got %d", MaxWarmConcurrency, svc.warmConcurrency)
+	}
+	svc.SetWarmConcurrency(0)
+	if svc.warmConcurrency != 1 {
+		t.Fatalf("expected concurrency floor of 1, got %d", svc.warmConcurrency)
+	}
+}
+
+func TestService_FeedManagement(t *testing.T) {
+	client := &fakeClient{}
+	repo := &fakeRepo{}
+	svc := NewService(client, repo)
+	ctx := context.Background()
+
+	if err := svc.RenameFeed(ctx, 10, 100, "  Alias  "); err != nil {
+		t.Fatalf("RenameFeed returned error: %v", err)
+	}
+	if client.renamed[100] != "Alias" || repo.feedTitles[10] != "Alias" {
+		t.Fatalf("expected trimmed alias saved remotely and locally, got %+v / %+v", client.renamed, repo.feedTitles)
+	}
+	if err := svc.RenameFeed(ctx, 10, 0, "Alias"); err == nil {
+		t.Fatal("expected error without subscription id")
+	}
+
+	if err := svc.SetFeedMuted(ctx, 10, true); err != nil {
+		t.Fatalf("SetFeedMuted returned error: %v", err)
+	}
+	if !repo.mutedFeeds[10] {
+		t.Fatalf("expected feed muted in cache, got %+v", repo.mutedFeeds)
+	}
+
+	if err := svc.Unsubscribe(ctx, 10, 100); err != nil {
+		t.Fatalf("Unsubscribe returned error: %v", err)
+	}
+	if len(client.unsubscribed) != 1 || client.unsubscribed[0] != 100 || len(repo.deleted) != 1 || repo.deleted[0] != 10 {
+		t.Fatalf("expected remote unsubscribe and cache delete, got %v / %v", client.unsubscribed, repo.deleted)
+	}
+}
+
+func TestService_AssignFolder_ReusesOrCreatesFolder(t *testing.T) {
+	client := &fakeClient{}
+	repo := &fakeRepo{feeds: []feedbin.FeedSummary{
+		{Subscription: feedbin.Subscription{ID: 10, Title: "Feed A", Folder: "News", Folders: []string{"News"}}},
+		{Subscription: feedbin.Subscription{ID: 20, Title: "Feed B"}},
+	}}
+	svc := NewService(client, repo)
+	ctx := context.Background()
+
+	got, err := svc.AssignFolder(ctx, 20, "  news ")
+	if err != nil {
+		t.Fatalf("AssignFolder returned error: %v", err)
+	}
+	if got.Folder != "News" || got.Created || !slices.Equal(got.Folders, []string{"News"}) {
+		t.Fatalf("expected existing folder reused, got %+v", got)
+	}
+	if len(client.createdTags) != 1 || client.createdTags[0].FeedID != 20 || client.createdTags[0].Name != "News" {
+		t.Fatalf("expected tagging with the existing name, got %+v", client.createdTags)
+	}
+
+	got, err = svc.AssignFolder(ctx, 10, "Apps")
+	if err != nil {
+		t.Fatalf("AssignFolder returned error: %v", err)
+	}
+	if got.Folder != "Apps" || !got.Created || !slices.Equal(got.Folders, []string{"Apps", "News"}) {
+		t.Fatalf("expected new folder added beside the old one, got %+v", got)
+	}
+	if !slices.Equal(repo.feedFolder[10], []string{"Apps", "News"}) {
+		t.Fatalf("expected cache updated, got %+v", repo.feedFolder)
+	}
+
+	if _, err := svc.AssignFolder(ctx, 10, " "); err == nil {
+		t.Fatal("expected error for an empty folder name")
+	}
+	svc.SetOffline(true)
+	if _, err := svc.AssignFolder(ctx, 10, "Tech"); !errors.Is(err, ErrOffline) {
+		t.Fatalf("expected ErrOffline, got %v", err)
+	}
+}
+
+func TestService_RefreshFeed_KeepsEntryStates(t *testing.T) {
+	client := &fakeClient{
+		feedEntries: []feedbin.Entry{{ID: 1, FeedID: 10}, {ID: 2, FeedID: 10}},
+		unreadIDs:   []int64{1},
+		starredIDs:  []int64{2},
+	}
+	repo := &fakeRepo{}
+	svc := NewService(client, repo)
+
+	count, err := svc.RefreshFeed(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("RefreshFeed returned error: %v", err)
+	}
+	if count != 2 || len(repo.saved) != 2 {
+		t.Fatalf("expected 2 saved entries, got count=%d saved=%d", count, len(repo.saved))
+	}
+	if !repo.saved[0].IsUnread || repo.saved[0].IsStarred || repo.saved[1].IsUnread || !repo.saved[1].IsStarred {
+		t.Fatalf("unexpected saved states: %+v", repo.saved)
+	}
+}
+
 func TestService_UIPreferences_DefaultFalseWhenMissing(t *testing.T) {
 	svc := NewService(&fakeClient{}, &fakeRepo{})
 
@@ -523,8 +1372,21 @@ func TestService_UIPreferences_DefaultFalseWhenMissing(t *testing.T) {
 	if err != nil {
 		t.Fatalf("LoadUIPreferences returned error: %v", err)
 	}
-	if prefs.Compact || prefs.MarkReadOnOpen || prefs.ConfirmOpenRead || !prefs.RelativeTime || prefs.ShowNumbers {
-		t.Fatalf("expected compact/mark/confirm/showNumbers=false and relative=true by default, got %+v", prefs)
+	if prefs.Compact || prefs.MarkReadOnOpen || prefs.ConfirmOpenRead || prefs.TimeFormat != "relative" || prefs.ShowNumbers || prefs.AutoPreview || !prefs.ConfirmBulkActions {
+		t.Fatalf("expected compact/mark/confirm/showNumbers/autoPreview=false and relative/confirmBulk=true by default, got %+v", prefs)
+	}
+}
+
+func TestService_UIPreferences_TimeFormatFallsBackToRelativeTimeFlag(t *testing.T) {
+	repo := &fakeRepo{appState: map[string]string{uiPrefRelativeTimeKey: "false"}}
+	svc := NewService(&fakeClient{}, repo)
+
+	prefs, err := svc.LoadUIPreferences(context.Background())
+	if err != nil {
+		t.Fatalf("LoadUIPreferences returned error: %v", err)
+	}
+	if prefs.TimeFormat != "absolute" {
+		t.Fatalf("expected a saved relative-time false to load as absolute, got %q", prefs.TimeFormat)
 	}
 }
 
@@ -536,8 +1398,17 @@ func TestService_UIPreferences_SaveAndLoadRoundTrip(t *testing.T) {
 		Compact:         true,
 		MarkReadOnOpen:  true,
 		ConfirmOpenRead: true,
-		RelativeTime:    false,
+		TimeFormat:      "both",
 		ShowNumbers:     true,
+		AutoPreview:     true,
+
+		CompactUnreadFirst:   true,
+		PreviewLines:         2,
+		PreviewSource:        "content",
+		PreferSummary:        true,
+		ShowReadToday:        true,
+		MarkReadOnDetailOpen: true,
+		StripFeedPrefix:      true,
 	}
 	if err := svc.SaveUIPreferences(context.Background(), want); err != nil {
 		t.Fatalf("SaveUIPreferences returned error: %v", err)
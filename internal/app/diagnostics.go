@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+var (
+	reEmailAddress = regexp.MustCompile(`[^\s@/:\[\]]+@[^\s@/:]+\.[^\s@/:]+`)
+	reURLUserInfo  = regexp.MustCompile(`://[^/\s]*@`)
+)
+
+// SetBuildInfo records the version reported in diagnostics and the
+// credentials diagnostics must never contain.
+func (s *Service) SetBuildInfo(version string, secrets ...string) {
+	s.version = version
+	s.secrets = s.secrets[:0]
+	for _, secret := range secrets {
+		if secret != "" {
+			s.secrets = append(s.secrets, secret)
+		}
+	}
+}
+
+// Diagnostics renders the environment, cache and last error as plain text
+// for a bug report. Credentials and anything resembling an email address are
+// redacted; a failed cache read is reported in place of the stats.
+func (s *Service) Diagnostics(ctx context.Context, lastErr error) string {
+	version := s.version
+	if version == "" {
+		version = "dev"
+	}
+	term := os.Getenv("TERM")
+	if term == "" {
+		term = "unset"
+	}
+	chafa := "not installed"
+	if _, err := exec.LookPath("chafa"); err == nil {
+		chafa = "available"
+	}
+	cache := "unavailable"
+	if stats, err := s.repo.Stats(ctx); err != nil {
+		cache += " (" + err.Error() + ")"
+	} else {
+		cache = fmt.Sprintf("%d entries (%d unread, %d starred), %d feeds", stats.Entries, stats.Unread, stats.Starred, stats.Feeds)
+	}
+	lastError := "none"
+	if lastErr != nil {
+		lastError = lastErr.Error()
+	}
+
+	var b strings.Builder
+	b.WriteString("reeder diagnostics\n")
+	fmt.Fprintf(&b, "version: %s\n", version)
+	fmt.Fprintf(&b, "os: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "term: %s\n", term)
+	fmt.Fprintf(&b, "chafa: %s\n", chafa)
+	fmt.Fprintf(&b, "search mode: %s\n", s.repo.SearchMode())
+	fmt.Fprintf(&b, "offline: %t\n", s.Offline())
+	if budget, ok := s.client.(requestBudget); ok {
+		requests := strconv.Itoa(budget.RequestCount())
+		if limit := budget.MaxRequests(); limit > 0 {
+			requests += fmt.Sprintf(" of %d", limit)
+		}
+		fmt.Fprintf(&b, "api requests: %s\n", requests)
+	}
+	fmt.Fprintf(&b, "cache: %s\n", cache)
+	fmt.Fprintf(&b, "last error: %s\n", lastError)
+	return s.redact(b.String())
+}
+
+func (s *Service) redact(text string) string {
+	text = reURLUserInfo.ReplaceAllString(text, "://[redacted]@")
+	for _, secret := range s.secrets {
+		text = strings.ReplaceAll(text, secret, "[redacted]")
+	}
+	return reEmailAddress.ReplaceAllString(text, "[redacted]")
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+func TestService_Diagnostics_ExcludesCredentials(t *testing.T) {
+	repo := &fakeRepo{cached: []feedbin.Entry{{ID: 1, IsUnread: true}, {ID: 2, IsStarred: true}}}
+	svc := NewService(&fakeClient{}, repo)
+	svc.SetBuildInfo("1.2.3", "reader@example.com", "hunter2-secret")
+
+	lastErr := errors.New(`fetch https://reader@example.com:hunter2-secret@api.feedbin.com/v2/entries.json: login for reader@example.com with password hunter2-secret failed; other@mail.example.org`)
+	got := svc.Diagnostics(context.Background(), lastErr)
+
+	for _, leaked := range []string{"reader@example.com", "hunter2-secret", "other@mail.example.org", "@example.com"} {
+		if strings.Contains(got, leaked) {
+			t.Fatalf("diagnostics leaked %q:\n%s", leaked, got)
+		}
+	}
+	for _, want := range []string{"version: 1.2.3", "search mode: like", "cache: 2 entries (1 unread, 1 starred), 0 feeds", "last error: fetch https://[redacted]@api.feedbin.com"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in diagnostics:\n%s", want, got)
+		}
+	}
+}
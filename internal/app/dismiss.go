@@ -0,0 +1,25 @@
+package app
+
+import (
+	"context"
+	"fmt"
+)
+
+// SetEntryDismissed hides an entry from the all, unread, starred and feed
+// views, or shows it again. Dismissing is local only: the entry keeps its
+// read state and nothing is sent to Feedbin, even when online.
+func (s *Service) SetEntryDismissed(ctx context.Context, entryID int64, dismissed bool) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	if dismissed {
+		if err := s.repo.Dismiss(ctx, entryID); err != nil {
+			return fmt.Errorf("save dismissal in cache: %w", err)
+		}
+		return nil
+	}
+	if err := s.repo.Undismiss(ctx, entryID); err != nil {
+		return fmt.Errorf("clear dismissal in cache: %w", err)
+	}
+	return nil
+}
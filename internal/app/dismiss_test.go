@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+	"testing"
+)
+
+func TestService_SetEntryDismissed_StaysLocal(t *testing.T) {
+	client := &fakeClient{}
+	repo := &fakeRepo{}
+	svc := NewService(client, repo)
+
+	if err := svc.SetEntryDismissed(context.Background(), 7, true); err != nil {
+		t.Fatalf("SetEntryDismissed returned error: %v", err)
+	}
+	if !repo.dismissed[7] {
+		t.Fatalf("expected entry dismissed in cache, got %v", repo.dismissed)
+	}
+	if len(client.markReadIDs) != 0 || len(repo.setUnread) != 0 {
+		t.Fatalf("expected read state untouched, got feedbin=%v cache=%v", client.markReadIDs, repo.setUnread)
+	}
+
+	if err := svc.SetEntryDismissed(context.Background(), 7, false); err != nil {
+		t.Fatalf("SetEntryDismissed returned error: %v", err)
+	}
+	if repo.dismissed[7] {
+		t.Fatalf("expected dismissal cleared, got %v", repo.dismissed)
+	}
+}
@@ -0,0 +1,246 @@
+package app
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+// maxStarredExport bounds how many entries one starred or reading-list
+// export reads from the cache.
+const maxStarredExport = 100000
+
+// maxExportSlugRunes caps the title part of exported file names.
+const maxExportSlugRunes = 60
+
+// ExportStarredResult counts the files written by ExportStarred and the
+// entries skipped for having no content.
+type ExportStarredResult struct {
+	Written int
+	Skipped int
+}
+
+// ExportStarred writes every cached starred entry to dir as a Markdown file
+// with title, URL, date and feed front matter and a body from toMarkdown.
+// Entries cached without content are fetched from Feedbin first unless the
+// service is offline; those still empty are skipped. Files are named from
+// the publish date and title, so exporting again overwrites them.
+func (s *Service) ExportStarred(ctx context.Context, dir string, toMarkdown func(feedbin.Entry) string) (ExportStarredResult, error) {
+	entries, err := s.repo.ListEntriesByFilter(ctx, maxStarredExport, "starred")
+	if err != nil {
+		return ExportStarredResult{}, fmt.Errorf("load starred entries from cache: %w", err)
+	}
+	if !s.Offline() {
+		hydrated, err := s.hydrateMissingContent(ctx, entries)
+		if err != nil {
+			return ExportStarredResult{}, err
+		}
+		if hydrated {
+			if entries, err = s.repo.ListEntriesByFilter(ctx, maxStarredExport, "starred"); err != nil {
+				return ExportStarredResult{}, fmt.Errorf("load starred entries from cache: %w", err)
+			}
+		}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return ExportStarredResult{}, fmt.Errorf("create export directory: %w", err)
+	}
+
+	var result ExportStarredResult
+	used := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		body := strings.TrimSpace(toMarkdown(entry))
+		if body == "" {
+			result.Skipped++
+			continue
+		}
+		name := exportFileName(entry)
+		if used[name] {
+			name = strings.TrimSuffix(name, ".md") + "-" + strconv.FormatInt(entry.ID, 10) + ".md"
+		}
+		used[name] = true
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(markdownDocument(entry, body)), 0o644); err != nil {
+			return result, fmt.Errorf("write %s: %w", name, err)
+		}
+		result.Written++
+	}
+	return result, nil
+}
+
+// jsonlEntry is one line of ExportJSONL: the entry with its feed and state,
+// which feedbin.Entry leaves out of its JSON.
+type jsonlEntry struct {
+	ID          int64     `json:"id"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	Author      string    `json:"author,omitempty"`
+	Summary     string    `json:"summary,omitempty"`
+	Content     string    `json:"content,omitempty"`
+	PublishedAt time.Time `json:"published"`
+	FeedID      int64     `json:"feed_id"`
+	FeedTitle   string    `json:"feed_title,omitempty"`
+	FeedURL     string    `json:"feed_url,omitempty"`
+	Folders     []string  `json:"folders,omitempty"`
+	Unread      bool      `json:"unread"`
+	Starred     bool      `json:"starred"`
+}
+
+// ExportJSONL writes every cached entry to w as one JSON object per line,
+// newest first. Rows are encoded as they are read from the cache, so memory
+// stays flat however large the library is. It returns how many entries were
+// written.
+func (s *Service) ExportJSONL(ctx context.Context, w io.Writer) (int, error) {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	written := 0
+	err := s.repo.EachEntry(ctx, func(entry feedbin.Entry) error {
+		if err := enc.Encode(jsonlEntry{
+			ID:          entry.ID,
+			Title:       entry.Title,
+			URL:         entry.URL,
+			Author:      entry.Author,
+			Summary:     entry.Summary,
+			Content:     entry.Content,
+			PublishedAt: entry.PublishedAt.UTC(),
+			FeedID:      entry.FeedID,
+			FeedTitle:   entry.FeedTitle,
+			FeedURL:     entry.FeedURL,
+			Folders:     entry.FeedFolders,
+			Unread:      entry.IsUnread,
+			Starred:     entry.IsStarred,
+		}); err != nil {
+			return fmt.Errorf("write entry %d: %w", entry.ID, err)
+		}
+		written++
+		return nil
+	})
+	if err != nil {
+		return written, fmt.Errorf("export entries: %w", err)
+	}
+	return written, nil
+}
+
+// readingListHeader is the column layout Pocket and Instapaper import.
+var readingListHeader = []string{"url", "title", "time_added", "tags"}
+
+// ExportReadingList writes the cached entries matching filter ("starred",
+// "unread" or "all") to w as a Pocket/Instapaper import CSV: URL, title,
+// publish time in Unix seconds and "|"-separated tags, where starred entries
+// carry the tag "starred". Entries without a URL are left out. It returns
+// how many rows were written.
+func (s *Service) ExportReadingList(ctx context.Context, w io.Writer, filter string) (int, error) {
+	if filter != "starred" && filter != "unread" && filter != "all" {
+		return 0, fmt.Errorf("reading list filter must be starred, unread or all: %s", filter)
+	}
+	entries, err := s.repo.ListEntriesByFilter(ctx, maxStarredExport, filter)
+	if err != nil {
+		return 0, fmt.Errorf("load %s entries from cache: %w", filter, err)
+	}
+
+	out := csv.NewWriter(w)
+	if err := out.Write(readingListHeader); err != nil {
+		return 0, fmt.Errorf("write reading list header: %w", err)
+	}
+	written := 0
+	for _, entry := range entries {
+		url := strings.TrimSpace(entry.URL)
+		if url == "" {
+			continue
+		}
+		var tags []string
+		if entry.IsStarred {
+			tags = append(tags, "starred")
+		}
+		record := []string{url, entry.Title, strconv.FormatInt(entry.PublishedAt.Unix(), 10), strings.Join(tags, "|")}
+		if err := out.Write(record); err != nil {
+			return written, fmt.Errorf("write entry %d: %w", entry.ID, err)
+		}
+		written++
+	}
+	out.Flush()
+	if err := out.Error(); err != nil {
+		return written, fmt.Errorf("write reading list: %w", err)
+	}
+	return written, nil
+}
+
+// hydrateMissingContent fetches entries cached without content and saves
+// them, keeping their read/star state. It reports whether anything changed.
+func (s *Service) hydrateMissingContent(ctx context.Context, entries []feedbin.Entry) (bool, error) {
+	cached := make(map[int64]feedbin.Entry)
+	var ids []int64
+	for _, entry := range entries {
+		if strings.TrimSpace(entry.Content) == "" {
+			cached[entry.ID] = entry
+			ids = append(ids, entry.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return false, nil
+	}
+	fetched, err := s.client.ListEntriesByIDs(ctx, ids)
+	if err != nil {
+		return false, fmt.Errorf("fetch starred entries from feedbin: %w", err)
+	}
+	if len(fetched) == 0 {
+		return false, nil
+	}
+	for i := range fetched {
+		fetched[i].IsUnread = cached[fetched[i].ID].IsUnread
+		fetched[i].IsStarred = true
+	}
+	if err := s.repo.SaveEntries(ctx, fetched); err != nil {
+		return false, fmt.Errorf("save starred entries to cache: %w", err)
+	}
+	return true, nil
+}
+
+func markdownDocument(entry feedbin.Entry, body string) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	b.WriteString("title: " + strconv.Quote(strings.TrimSpace(entry.Title)) + "\n")
+	b.WriteString("url: " + strconv.Quote(strings.TrimSpace(entry.URL)) + "\n")
+	b.WriteString("date: " + entry.PublishedAt.UTC().Format(time.RFC3339) + "\n")
+	b.WriteString("feed: " + strconv.Quote(strings.TrimSpace(entry.FeedTitle)) + "\n")
+	b.WriteString("---\n\n")
+	b.WriteString(body)
+	b.WriteString("\n")
+	return b.String()
+}
+
+// exportFileName is "2026-02-10-entry-title.md": lower-case letters and
+// digits from the title joined by dashes, or the entry ID when the title has
+// none.
+func exportFileName(entry feedbin.Entry) string {
+	var slug []rune
+	dash := false
+	for _, r := range strings.ToLower(entry.Title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && len(slug) > 0 {
+				slug = append(slug, '-')
+			}
+			dash = false
+			slug = append(slug, r)
+			if len(slug) >= maxExportSlugRunes {
+				break
+			}
+			continue
+		}
+		dash = true
+	}
+	slug = slug[:min(len(slug), maxExportSlugRunes)]
+	name := strings.TrimRight(string(slug), "-")
+	if name == "" {
+		name = "entry-" + strconv.FormatInt(entry.ID, 10)
+	}
+	return entry.PublishedAt.UTC().Format("2006-01-02") + "-" + name + ".md"
+}
@@ -0,0 +1,161 @@
+package app
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+func TestService_ExportStarred_WritesMarkdownFiles(t *testing.T) {
+	published := time.Date(2026, 2, 10, 8, 30, 0, 0, time.UTC)
+	repo := &fakeRepo{cached: []feedbin.Entry{
+		{ID: 1, Title: "Go 1.24: What's new?", URL: "https://example.com/go", FeedTitle: "Go Blog", Content: "<p>Body</p>", PublishedAt: published, IsStarred: true},
+		{ID: 2, Title: "Go 1.24: what's new", FeedTitle: "Mirror", Content: "<p>Copy</p>", PublishedAt: published, IsStarred: true},
+		{ID: 3, Title: "Not starred", Content: "<p>Skip</p>", PublishedAt: published},
+		{ID: 4, Title: "Empty", PublishedAt: published, IsStarred: true},
+	}}
+	client := &fakeClient{entriesByIDs: []feedbin.Entry{{ID: 4, Title: "Empty", Content: "<p>Fetched</p>", PublishedAt: published}}}
+	svc := NewService(client, repo)
+	dir := t.TempDir()
+
+	result, err := svc.ExportStarred(context.Background(), dir, func(entry feedbin.Entry) string { return entry.Content })
+	if err != nil {
+		t.Fatalf("ExportStarred returned error: %v", err)
+	}
+	// The fake cache does not store hydrated content, so entry 4 stays empty.
+	if result.Written != 2 || result.Skipped != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(repo.saved) != 1 || repo.saved[0].ID != 4 || !repo.saved[0].IsStarred {
+		t.Fatalf("expected empty starred entry hydrated into the cache, got %+v", repo.saved)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "2026-02-10-go-1-24-what-s-new.md"))
+	if err != nil {
+		t.Fatalf("expected file named from date and title: %v", err)
+	}
+	want := "---\ntitle: \"Go 1.24: What's new?\"\nurl: \"https://example.com/go\"\ndate: 2026-02-10T08:30:00Z\nfeed: \"Go Blog\"\n---\n\n<p>Body</p>\n"
+	if string(data) != want {
+		t.Fatalf("unexpected file:\n%s", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "2026-02-10-go-1-24-what-s-new-2.md")); err != nil {
+		t.Fatalf("expected colliding title to get the entry ID appended: %v", err)
+	}
+}
+
+func TestService_ExportStarred_OfflineSkipsHydration(t *testing.T) {
+	repo := &fakeRepo{cached: []feedbin.Entry{{ID: 1, Title: "Empty", IsStarred: true}}}
+	svc := NewService(&fakeClient{entriesByIDs: []feedbin.Entry{{ID: 1, Content: "x"}}}, repo)
+	svc.SetOffline(true)
+
+	result, err := svc.ExportStarred(context.Background(), t.TempDir(), func(entry feedbin.Entry) string { return entry.Content })
+	if err != nil {
+		t.Fatalf("ExportStarred returned error: %v", err)
+	}
+	if result.Written != 0 || result.Skipped != 1 || len(repo.saved) != 0 {
+		t.Fatalf("expected nothing fetched or written offline, got %+v saved=%+v", result, repo.saved)
+	}
+}
+
+func TestExportFileName_FallsBackToEntryID(t *testing.T) {
+	entry := feedbin.Entry{ID: 42, Title: "!!!", PublishedAt: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}
+	if got := exportFileName(entry); got != "2026-01-02-entry-42.md" {
+		t.Fatalf("unexpected file name %q", got)
+	}
+	long := feedbin.Entry{Title: strings.Repeat("word ", 40)}
+	if got := exportFileName(long); len(got) > len("2006-01-02-")+maxExportSlugRunes+len(".md") || strings.HasSuffix(got, "-.md") {
+		t.Fatalf("expected capped slug, got %q", got)
+	}
+}
+
+func TestService_ExportJSONL_WritesOneObjectPerLine(t *testing.T) {
+	published := time.Date(2026, 2, 10, 8, 30, 0, 0, time.UTC)
+	repo := &fakeRepo{cached: []feedbin.Entry{
+		{ID: 1, Title: "First <b>", URL: "https://example.com/1", Content: "line one\nline two", FeedTitle: "Blog", FeedFolders: []string{"Tech"}, PublishedAt: published, IsUnread: true},
+		{ID: 2, Title: "Second", URL: "https://example.com/2", PublishedAt: published.Add(-time.Hour), IsStarred: true},
+	}}
+	svc := NewService(&fakeClient{}, repo)
+	var out strings.Builder
+
+	written, err := svc.ExportJSONL(context.Background(), &out)
+	if err != nil {
+		t.Fatalf("ExportJSONL returned error: %v", err)
+	}
+	if written != 2 {
+		t.Fatalf("expected 2 entries written, got %d", written)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one line per entry, got %d:\n%s", len(lines), out.String())
+	}
+	for i, line := range lines {
+		var got jsonlEntry
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v\n%s", i+1, err, line)
+		}
+		if got.ID != repo.cached[i].ID {
+			t.Fatalf("line %d: expected entry %d, got %d", i+1, repo.cached[i].ID, got.ID)
+		}
+	}
+	var first jsonlEntry
+	_ = json.Unmarshal([]byte(lines[0]), &first)
+	if first.Title != "First <b>" || first.Content != "line one\nline two" || first.FeedTitle != "Blog" || !first.Unread || first.Starred || len(first.Folders) != 1 {
+		t.Fatalf("unexpected first entry: %+v", first)
+	}
+}
+
+func TestService_ExportReadingList_WritesImportCSV(t *testing.T) {
+	published := time.Date(2026, 2, 10, 8, 30, 0, 0, time.UTC)
+	repo := &fakeRepo{cached: []feedbin.Entry{
+		{ID: 1, Title: `Quotes "and", commas`, URL: "https://example.com/1", PublishedAt: published, IsStarred: true},
+		{ID: 2, Title: "Line\nbreak", URL: "https://example.com/2", PublishedAt: published.Add(-time.Hour)},
+		{ID: 3, Title: "No link", PublishedAt: published, IsStarred: true},
+	}}
+	svc := NewService(&fakeClient{}, repo)
+
+	var out strings.Builder
+	written, err := svc.ExportReadingList(context.Background(), &out, "all")
+	if err != nil {
+		t.Fatalf("ExportReadingList returned error: %v", err)
+	}
+	if written != 2 {
+		t.Fatalf("expected the entries with a URL written, got %d", written)
+	}
+	records, err := csv.NewReader(strings.NewReader(out.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v\n%s", err, out.String())
+	}
+	want := [][]string{
+		{"url", "title", "time_added", "tags"},
+		{"https://example.com/1", `Quotes "and", commas`, "1770712200", "starred"},
+		{"https://example.com/2", "Line\nbreak", "1770708600", ""},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Fatalf("unexpected records:\n got %q\nwant %q", records, want)
+	}
+
+	out.Reset()
+	if written, err := svc.ExportReadingList(context.Background(), &out, "starred"); err != nil || written != 1 {
+		t.Fatalf("expected only the starred entry with a URL, got %d (%v)", written, err)
+	}
+	if _, err := svc.ExportReadingList(context.Background(), io.Discard, "read"); err == nil {
+		t.Fatal("expected an error for an unknown filter")
+	}
+}
+
+func TestService_ExportJSONL_ReturnsRepositoryError(t *testing.T) {
+	svc := NewService(&fakeClient{}, &fakeRepo{listErr: errors.New("boom")})
+	if _, err := svc.ExportJSONL(context.Background(), io.Discard); err == nil {
+		t.Fatal("expected an error from the cache")
+	}
+}
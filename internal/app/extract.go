@@ -0,0 +1,34 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+// ErrNoExtractedContent is returned for entries Feedbin gave no extraction
+// link for.
+var ErrNoExtractedContent = errors.New("no extracted full text for this entry")
+
+// ExtractedContent fetches the full text Feedbin's extraction service pulls
+// from the entry's article page. The result is not cached.
+func (s *Service) ExtractedContent(ctx context.Context, entry feedbin.Entry) (string, error) {
+	extractURL := strings.TrimSpace(entry.ExtractedContentURL)
+	if extractURL == "" {
+		return "", ErrNoExtractedContent
+	}
+	if s.Offline() {
+		return "", ErrOffline
+	}
+	content, err := s.client.ExtractContent(ctx, extractURL)
+	if err != nil {
+		return "", fmt.Errorf("extract entry %d: %w", entry.ID, err)
+	}
+	if strings.TrimSpace(content) == "" {
+		return "", ErrNoExtractedContent
+	}
+	return content, nil
+}
@@ -0,0 +1,32 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+func TestService_ExtractedContent(t *testing.T) {
+	client := &fakeClient{extracted: map[string]string{"https://extract.example.com/1": "<p>Full text</p>"}}
+	svc := NewService(client, &fakeRepo{})
+	ctx := context.Background()
+
+	content, err := svc.ExtractedContent(ctx, feedbin.Entry{ID: 1, ExtractedContentURL: "https://extract.example.com/1"})
+	if err != nil {
+		t.Fatalf("ExtractedContent returned error: %v", err)
+	}
+	if content != "<p>Full text</p>" {
+		t.Fatalf("unexpected content: %q", content)
+	}
+
+	if _, err := svc.ExtractedContent(ctx, feedbin.Entry{ID: 2}); !errors.Is(err, ErrNoExtractedContent) {
+		t.Fatalf("expected ErrNoExtractedContent without a link, got %v", err)
+	}
+
+	svc.SetOffline(true)
+	if _, err := svc.ExtractedContent(ctx, feedbin.Entry{ID: 1, ExtractedContentURL: "https://extract.example.com/1"}); !errors.Is(err, ErrOffline) {
+		t.Fatalf("expected ErrOffline while offline, got %v", err)
+	}
+}
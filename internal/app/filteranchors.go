@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const filterAnchorsKey = "filter_anchors"
+
+// FilterAnchors returns the entry last selected in each list filter.
+func (s *Service) FilterAnchors(ctx context.Context) (map[string]int64, error) {
+	raw, err := s.repo.GetAppState(ctx, filterAnchorsKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return map[string]int64{}, nil
+		}
+		return nil, fmt.Errorf("load filter anchors: %w", err)
+	}
+	anchors := map[string]int64{}
+	if raw == "" {
+		return anchors, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &anchors); err != nil {
+		return nil, fmt.Errorf("decode filter anchors: %w", err)
+	}
+	return anchors, nil
+}
+
+// SaveFilterAnchors stores the entry last selected in each list filter.
+func (s *Service) SaveFilterAnchors(ctx context.Context, anchors map[string]int64) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	data, err := json.Marshal(anchors)
+	if err != nil {
+		return fmt.Errorf("encode filter anchors: %w", err)
+	}
+	if err := s.repo.SetAppState(ctx, filterAnchorsKey, string(data)); err != nil {
+		return fmt.Errorf("save filter anchors: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"context"
+	"testing"
+)
+
+func TestService_FilterAnchors_RoundTrip(t *testing.T) {
+	svc := NewService(&fakeClient{}, &fakeRepo{})
+	ctx := context.Background()
+
+	anchors, err := svc.FilterAnchors(ctx)
+	if err != nil {
+		t.Fatalf("FilterAnchors returned error: %v", err)
+	}
+	if len(anchors) != 0 {
+		t.Fatalf("expected no anchors before saving, got %v", anchors)
+	}
+
+	if err := svc.SaveFilterAnchors(ctx, map[string]int64{"all": 7, "unread": 9}); err != nil {
+		t.Fatalf("SaveFilterAnchors returned error: %v", err)
+	}
+	anchors, err = svc.FilterAnchors(ctx)
+	if err != nil {
+		t.Fatalf("FilterAnchors returned error: %v", err)
+	}
+	if anchors["all"] != 7 || anchors["unread"] != 9 || len(anchors) != 2 {
+		t.Fatalf("unexpected anchors: %v", anchors)
+	}
+}
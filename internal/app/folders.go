@@ -0,0 +1,63 @@
+package app
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Strategies for picking the folder of a feed with several tags.
+const (
+	FolderByName     = "alphabetical"
+	FolderFirstSeen  = "first"
+	FolderByPriority = "priority"
+)
+
+// FolderResolution picks which tag of a multi-tag feed becomes its folder:
+// the alphabetically first (the default), the first in Feedbin's taggings,
+// or the first of Priority the feed has, falling back to alphabetical.
+type FolderResolution struct {
+	Strategy string
+	Priority []string
+}
+
+// SetFolderResolution sets how the next subscription sync resolves a feed's
+// tags to a folder.
+func (s *Service) SetFolderResolution(resolution FolderResolution) {
+	s.folderResolution = resolution
+}
+
+// order returns folders, given in tagging order, with the resolved folder
+// first and the others sorted case-insensitively.
+func (r FolderResolution) order(folders []string) []string {
+	if len(folders) == 0 {
+		return folders
+	}
+	chosen := ""
+	switch r.Strategy {
+	case FolderFirstSeen:
+		chosen = folders[0]
+	case FolderByPriority:
+		for _, want := range r.Priority {
+			if i := slices.IndexFunc(folders, func(f string) bool { return strings.EqualFold(f, strings.TrimSpace(want)) }); i >= 0 {
+				chosen = folders[i]
+				break
+			}
+		}
+	}
+	sorted := slices.Clone(folders)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return strings.ToLower(sorted[i]) < strings.ToLower(sorted[j])
+	})
+	if chosen == "" || chosen == sorted[0] {
+		return sorted
+	}
+	out := make([]string, 0, len(sorted))
+	out = append(out, chosen)
+	for _, folder := range sorted {
+		if folder != chosen {
+			out = append(out, folder)
+		}
+	}
+	return out
+}
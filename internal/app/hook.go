@@ -0,0 +1,119 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+// PostSyncTimeout bounds how long the post-sync command may run.
+const PostSyncTimeout = 30 * time.Second
+
+// postSyncWaitDelay is how long a timed-out post-sync command may keep its
+// output open, e.g. through a background child, before it is abandoned.
+const postSyncWaitDelay = 2 * time.Second
+
+// SyncSummary describes a finished sync for the post-sync command.
+type SyncSummary struct {
+	// Source is "refresh" for a refresh from the UI and "sync" for a cache
+	// warm-up (--sync or the first run).
+	Source string
+	// Fetched is how many entries Feedbin returned.
+	Fetched int
+	// New is how many synced entries have a higher ID than the newest entry
+	// cached before the sync. Feedbin IDs grow with arrival, so backdated
+	// publish dates do not hide new entries.
+	New      int
+	Duration time.Duration
+}
+
+// Environ returns the variables passed to the post-sync command.
+func (s SyncSummary) Environ() []string {
+	return []string{
+		"FEEDBIN_SYNC_SOURCE=" + s.Source,
+		"FEEDBIN_FETCHED_COUNT=" + strconv.Itoa(s.Fetched),
+		"FEEDBIN_NEW_COUNT=" + strconv.Itoa(s.New),
+		"FEEDBIN_SYNC_DURATION_MS=" + strconv.FormatInt(s.Duration.Milliseconds(), 10),
+	}
+}
+
+// SetPostSyncCommand sets a shell command run in the background after every
+// successful refresh or cache warm-up. Empty disables it.
+func (s *Service) SetPostSyncCommand(command string) {
+	s.postSyncCmd = strings.TrimSpace(command)
+}
+
+// WaitPostSync blocks until post-sync commands started so far have finished.
+func (s *Service) WaitPostSync() {
+	s.postSyncWG.Wait()
+}
+
+// newestCachedID is the highest cached entry ID, zero when the cache is
+// empty or no post-sync command is set.
+func (s *Service) newestCachedID(ctx context.Context) int64 {
+	if s.postSyncCmd == "" {
+		return 0
+	}
+	id, err := s.repo.NewestEntryID(ctx)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+func countNewerThan(entries []feedbin.Entry, newestID int64) int {
+	n := 0
+	for _, entry := range entries {
+		if entry.ID > newestID {
+			n++
+		}
+	}
+	return n
+}
+
+// runPostSync starts the post-sync command without waiting for it. Failures
+// are logged, never returned: a broken hook must not fail the sync.
+func (s *Service) runPostSync(summary SyncSummary) {
+	if s.postSyncCmd == "" {
+		return
+	}
+	run := s.runCommand
+	if run == nil {
+		run = runShellCommand
+	}
+	command := s.postSyncCmd
+	s.postSyncWG.Add(1)
+	go func() {
+		defer s.postSyncWG.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), PostSyncTimeout)
+		defer cancel()
+		if err := run(ctx, command, summary.Environ()); err != nil {
+			log.Printf("warning: post-sync command failed: %v", err)
+		}
+	}()
+}
+
+// runShellCommand runs command with sh and returns once it exits or ctx
+// ends. On timeout the whole process group is killed where supported, and
+// WaitDelay keeps a leftover child holding the output open from blocking.
+func runShellCommand(ctx context.Context, command string, env []string) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.WaitDelay = postSyncWaitDelay
+	killProcessGroupOnCancel(cmd)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if out := strings.TrimSpace(string(output)); out != "" {
+			return fmt.Errorf("%w: %s", err, out)
+		}
+		return err
+	}
+	return nil
+}
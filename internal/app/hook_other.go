@@ -0,0 +1,9 @@
+//go:build !unix
+
+package app
+
+import "os/exec"
+
+// killProcessGroupOnCancel keeps the default of killing only the shell;
+// WaitDelay still bounds the wait for its children.
+func killProcessGroupOnCancel(*exec.Cmd) {}
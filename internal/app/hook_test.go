@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+type recordedCommand struct {
+	command string
+	env     []string
+}
+
+func recordCommands(svc *Service, err error) func() []recordedCommand {
+	var (
+		mu   sync.Mutex
+		runs []recordedCommand
+	)
+	svc.runCommand = func(_ context.Context, command string, env []string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		runs = append(runs, recordedCommand{command: command, env: env})
+		return err
+	}
+	return func() []recordedCommand {
+		svc.WaitPostSync()
+		mu.Lock()
+		defer mu.Unlock()
+		return runs
+	}
+}
+
+func TestService_WarmCache_RunsPostSyncCommandWithCounts(t *testing.T) {
+	base := time.Date(2026, 2, 10, 8, 0, 0, 0, time.UTC)
+	client := &fakeClient{entries: []feedbin.Entry{
+		{ID: 3, PublishedAt: base.Add(2 * time.Hour)},
+		{ID: 2, PublishedAt: base.Add(time.Hour)},
+		{ID: 1, PublishedAt: base},
+	}}
+	repo := &fakeRepo{cached: []feedbin.Entry{{ID: 1, PublishedAt: base}}}
+	svc := NewService(client, repo)
+	svc.SetPostSyncCommand("notify-send synced")
+	runs := recordCommands(svc, nil)
+
+	if _, err := svc.WarmCache(context.Background(), 1); err != nil {
+		t.Fatalf("WarmCache returned error: %v", err)
+	}
+	got := runs()
+	if len(got) != 1 || got[0].command != "notify-send synced" {
+		t.Fatalf("expected one post-sync run, got %+v", got)
+	}
+	for _, want := range []string{"FEEDBIN_SYNC_SOURCE=sync", "FEEDBIN_FETCHED_COUNT=3", "FEEDBIN_NEW_COUNT=2"} {
+		if !slices.Contains(got[0].env, want) {
+			t.Fatalf("expected %s in %v", want, got[0].env)
+		}
+	}
+}
+
+func TestService_Refresh_PostSyncFailureDoesNotFailRefresh(t *testing.T) {
+	client := &fakeClient{entries: []feedbin.Entry{{ID: 1, PublishedAt: time.Now().UTC()}}}
+	svc := NewService(client, &fakeRepo{})
+	svc.SetPostSyncCommand("false")
+	runs := recordCommands(svc, errors.New("exit status 1"))
+
+	if _, err := svc.Refresh(context.Background(), 1, 20); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	got := runs()
+	if len(got) != 1 || !slices.Contains(got[0].env, "FEEDBIN_SYNC_SOURCE=refresh") || !slices.Contains(got[0].env, "FEEDBIN_FETCHED_COUNT=1") {
+		t.Fatalf("expected refresh post-sync run, got %+v", got)
+	}
+}
+
+func TestService_Refresh_NoPostSyncCommandByDefault(t *testing.T) {
+	svc := NewService(&fakeClient{entries: []feedbin.Entry{{ID: 1}}}, &fakeRepo{})
+	runs := recordCommands(svc, nil)
+
+	if _, err := svc.Refresh(context.Background(), 1, 20); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	if got := runs(); len(got) != 0 {
+		t.Fatalf("expected no post-sync run, got %+v", got)
+	}
+}
+
+func TestService_WarmCache_PostSyncCountsNewEntriesByID(t *testing.T) {
+	base := time.Date(2026, 2, 10, 8, 0, 0, 0, time.UTC)
+	client := &fakeClient{entries: []feedbin.Entry{
+		{ID: 5, PublishedAt: base.Add(-48 * time.Hour)},
+		{ID: 4, PublishedAt: base.Add(time.Hour)},
+	}}
+	repo := &fakeRepo{cached: []feedbin.Entry{{ID: 3, PublishedAt: base}}}
+	svc := NewService(client, repo)
+	svc.SetPostSyncCommand("notify-send synced")
+	runs := recordCommands(svc, nil)
+
+	if _, err := svc.WarmCache(context.Background(), 1); err != nil {
+		t.Fatalf("WarmCache returned error: %v", err)
+	}
+	if got := runs(); len(got) != 1 || !slices.Contains(got[0].env, "FEEDBIN_NEW_COUNT=2") {
+		t.Fatalf("expected the backdated entry counted as new, got %+v", got)
+	}
+}
+
+func TestRunShellCommand_TimeoutStopsBackgroundChildren(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := runShellCommand(ctx, "sleep 5; echo hi", nil); err == nil {
+		t.Fatal("expected the timed-out command to fail")
+	}
+	if elapsed := time.Since(start); elapsed > postSyncWaitDelay+time.Second {
+		t.Fatalf("expected the timeout to be enforced, took %v", elapsed)
+	}
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"context"
+	"fmt"
+)
+
+// MarkFeedReadLocally marks a feed's entries read in the cache without
+// telling Feedbin, so they keep counting as unread there and on other
+// devices. The mark covers entryIDs and every older entry of the feed, and
+// later syncs do not bring them back as unread; newer entries arrive unread
+// as usual.
+func (s *Service) MarkFeedReadLocally(ctx context.Context, feedID int64, entryIDs []int64) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	if len(entryIDs) == 0 {
+		return nil
+	}
+	through := entryIDs[0]
+	for _, id := range entryIDs[1:] {
+		through = max(through, id)
+	}
+	if err := s.repo.MarkFeedReadLocally(ctx, feedID, through); err != nil {
+		return fmt.Errorf("mark feed %d read locally: %w", feedID, err)
+	}
+	return nil
+}
+
+// ClearFeedReadLocally drops the local read mark set by MarkFeedReadLocally
+// and reports whether the feed had one. The next sync restores Feedbin's
+// unread state for the entries it covered.
+func (s *Service) ClearFeedReadLocally(ctx context.Context, feedID int64) (bool, error) {
+	if s.readOnly {
+		return false, ErrReadOnly
+	}
+	cleared, err := s.repo.ClearFeedLocalRead(ctx, feedID)
+	if err != nil {
+		return false, fmt.Errorf("clear local read mark for feed %d: %w", feedID, err)
+	}
+	return cleared, nil
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"testing"
+)
+
+func TestService_MarkFeedReadLocally_SkipsFeedbin(t *testing.T) {
+	client := &fakeClient{}
+	repo := &fakeRepo{}
+	svc := NewService(client, repo)
+
+	if err := svc.MarkFeedReadLocally(context.Background(), 7, []int64{12, 30, 18}); err != nil {
+		t.Fatalf("MarkFeedReadLocally returned error: %v", err)
+	}
+	if repo.localReads[7] != 30 {
+		t.Fatalf("expected feed 7 read locally through 30, got %+v", repo.localReads)
+	}
+	if len(client.markReadIDs) != 0 {
+		t.Fatalf("expected nothing sent to Feedbin, got %v", client.markReadIDs)
+	}
+}
+
+func TestService_SetEntriesUnread_SyncsFeedMarkRead(t *testing.T) {
+	client := &fakeClient{}
+	repo := &fakeRepo{}
+	svc := NewService(client, repo)
+
+	succeeded, failed, err := svc.SetEntriesUnread(context.Background(), []int64{12, 30}, false)
+	if err != nil || len(succeeded) != 2 || len(failed) != 0 {
+		t.Fatalf("unexpected result succeeded=%v failed=%v err=%v", succeeded, failed, err)
+	}
+	if len(client.markReadIDs) != 2 {
+		t.Fatalf("expected both entries sent to Feedbin, got %v", client.markReadIDs)
+	}
+	if len(repo.localReads) != 0 {
+		t.Fatalf("expected no local-only marks, got %+v", repo.localReads)
+	}
+}
+
+func TestService_ClearFeedReadLocally(t *testing.T) {
+	repo := &fakeRepo{}
+	svc := NewService(&fakeClient{}, repo)
+	if err := svc.MarkFeedReadLocally(context.Background(), 7, []int64{12}); err != nil {
+		t.Fatalf("MarkFeedReadLocally returned error: %v", err)
+	}
+
+	cleared, err := svc.ClearFeedReadLocally(context.Background(), 7)
+	if err != nil || !cleared {
+		t.Fatalf("expected feed 7's mark cleared, got %v, %v", cleared, err)
+	}
+	if _, ok := repo.localReads[7]; ok {
+		t.Fatalf("expected no local mark left, got %+v", repo.localReads)
+	}
+	if cleared, err = svc.ClearFeedReadLocally(context.Background(), 7); err != nil || cleared {
+		t.Fatalf("expected nothing to clear, got %v, %v", cleared, err)
+	}
+}
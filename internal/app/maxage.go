@@ -0,0 +1,25 @@
+package app
+
+import "time"
+
+// SetMaxAge limits the all and unread listings to entries published within
+// maxAge. Search, starred and feed views still reach older entries; zero
+// turns the cutoff off.
+func (s *Service) SetMaxAge(maxAge time.Duration) {
+	s.maxAge = maxAge
+}
+
+// SetShowAllAges suspends the age cutoff until it is called again with
+// false. It is safe to call while listings run.
+func (s *Service) SetShowAllAges(show bool) {
+	s.showAllAges.Store(show)
+}
+
+// ageCutoff returns the oldest publish time listed under filter, if the
+// cutoff applies to it.
+func (s *Service) ageCutoff(filter string) (time.Time, bool) {
+	if s.maxAge <= 0 || s.showAllAges.Load() || (filter != "all" && filter != "unread") {
+		return time.Time{}, false
+	}
+	return time.Now().Add(-s.maxAge), true
+}
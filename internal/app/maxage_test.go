@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+func TestService_MaxAgeLimitsAllAndUnreadOnly(t *testing.T) {
+	now := time.Now().UTC()
+	repo := &fakeRepo{cached: []feedbin.Entry{
+		{ID: 1, IsUnread: true, PublishedAt: now.Add(-time.Hour)},
+		{ID: 2, IsUnread: true, IsStarred: true, PublishedAt: now.Add(-10 * 24 * time.Hour)},
+	}}
+	svc := NewService(&fakeClient{}, repo)
+	svc.SetMaxAge(7 * 24 * time.Hour)
+
+	ids := func(filter string) []int64 {
+		entries, err := svc.ListCachedByFilter(context.Background(), 10, filter)
+		if err != nil {
+			t.Fatalf("ListCachedByFilter(%q) returned error: %v", filter, err)
+		}
+		out := make([]int64, 0, len(entries))
+		for _, entry := range entries {
+			out = append(out, entry.ID)
+		}
+		return out
+	}
+
+	for _, filter := range []string{"all", "unread"} {
+		if got := ids(filter); len(got) != 1 || got[0] != 1 {
+			t.Fatalf("expected only the recent entry under %s, got %v", filter, got)
+		}
+	}
+	if got := ids("starred"); len(got) != 1 || got[0] != 2 {
+		t.Fatalf("expected starred to reach old entries, got %v", got)
+	}
+
+	svc.SetShowAllAges(true)
+	if got := ids("all"); len(got) != 2 {
+		t.Fatalf("expected every age while the cutoff is suspended, got %v", got)
+	}
+}
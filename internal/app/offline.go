@@ -0,0 +1,191 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+// ErrOffline is returned by operations that need the Feedbin API while the
+// service is in offline mode.
+var ErrOffline = errors.New("offline mode, no network access")
+
+const queuedChangesKey = "offline_queued_changes"
+
+const (
+	queuedFieldUnread  = "unread"
+	queuedFieldStarred = "starred"
+)
+
+// queuedChange is a read/star change made offline and not yet sent to Feedbin.
+type queuedChange struct {
+	EntryID int64  `json:"entry_id"`
+	Field   string `json:"field"`
+	Value   bool   `json:"value"`
+}
+
+// SetOffline switches offline mode. While offline, refreshes and feed
+// management fail fast with ErrOffline, and read/star changes are written to
+// the cache and queued until the next online refresh sends them.
+func (s *Service) SetOffline(offline bool) {
+	s.offline = offline
+}
+
+// Offline reports whether the service works from the cache only: offline or
+// read-only mode was set, or the client's request budget is used up.
+func (s *Service) Offline() bool {
+	return s.offline || s.readOnly || s.requestBudgetSpent()
+}
+
+// offlineErr is what a sync returns while Offline. A spent budget reports
+// feedbin.ErrRequestBudget so the UI can say why it went cache-only.
+func (s *Service) offlineErr() error {
+	if !s.offline && !s.readOnly && s.requestBudgetSpent() {
+		return feedbin.ErrRequestBudget
+	}
+	return ErrOffline
+}
+
+// requestBudget is implemented by clients that cap API requests per session,
+// like feedbin.Client.
+type requestBudget interface {
+	RequestCount() int
+	MaxRequests() int
+	RequestBudgetSpent() bool
+}
+
+func (s *Service) requestBudgetSpent() bool {
+	budget, ok := s.client.(requestBudget)
+	return ok && budget.RequestBudgetSpent()
+}
+
+// QueuedChangeCount returns how many read/star changes await sending.
+func (s *Service) QueuedChangeCount(ctx context.Context) (int, error) {
+	changes, err := s.loadQueuedChanges(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(changes), nil
+}
+
+func (s *Service) loadQueuedChanges(ctx context.Context) ([]queuedChange, error) {
+	raw, err := s.repo.GetAppState(ctx, queuedChangesKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("load queued changes: %w", err)
+	}
+	if raw == "" {
+		return nil, nil
+	}
+	var changes []queuedChange
+	if err := json.Unmarshal([]byte(raw), &changes); err != nil {
+		return nil, fmt.Errorf("decode queued changes: %w", err)
+	}
+	return changes, nil
+}
+
+func (s *Service) saveQueuedChanges(ctx context.Context, changes []queuedChange) error {
+	raw := ""
+	if len(changes) > 0 {
+		data, err := json.Marshal(changes)
+		if err != nil {
+			return fmt.Errorf("encode queued changes: %w", err)
+		}
+		raw = string(data)
+	}
+	if err := s.repo.SetAppState(ctx, queuedChangesKey, raw); err != nil {
+		return fmt.Errorf("save queued changes: %w", err)
+	}
+	return nil
+}
+
+// queueChanges records field=value for each entry. A later change to the same
+// entry and field replaces the earlier one.
+func (s *Service) queueChanges(ctx context.Context, field string, entryIDs []int64, value bool) error {
+	changes, err := s.loadQueuedChanges(ctx)
+	if err != nil {
+		return err
+	}
+	index := make(map[int64]int, len(changes))
+	for i, change := range changes {
+		if change.Field == field {
+			index[change.EntryID] = i
+		}
+	}
+	for _, id := range entryIDs {
+		if i, ok := index[id]; ok {
+			changes[i].Value = value
+			continue
+		}
+		index[id] = len(changes)
+		changes = append(changes, queuedChange{EntryID: id, Field: field, Value: value})
+	}
+	return s.saveQueuedChanges(ctx, changes)
+}
+
+// FlushQueuedChanges sends changes queued while offline to Feedbin. Changes
+// Feedbin did not accept stay queued for the next refresh. It returns how many
+// changes were sent.
+func (s *Service) FlushQueuedChanges(ctx context.Context) (int, error) {
+	if s.Offline() {
+		return 0, ErrOffline
+	}
+	changes, err := s.loadQueuedChanges(ctx)
+	if err != nil || len(changes) == 0 {
+		return 0, err
+	}
+	type group struct {
+		field string
+		value bool
+	}
+	grouped := make(map[group][]int64)
+	for _, change := range changes {
+		key := group{field: change.Field, value: change.Value}
+		grouped[key] = append(grouped[key], change.EntryID)
+	}
+	sent := make(map[group]map[int64]bool, len(grouped))
+	var firstErr error
+	for key, ids := range grouped {
+		var (
+			succeeded []int64
+			sendErr   error
+		)
+		switch {
+		case key.field == queuedFieldUnread && key.value:
+			succeeded, _, sendErr = s.client.MarkEntriesUnreadBatch(ctx, ids)
+		case key.field == queuedFieldUnread:
+			succeeded, _, sendErr = s.client.MarkEntriesReadBatch(ctx, ids)
+		case key.value:
+			succeeded, _, sendErr = s.client.StarEntriesBatch(ctx, ids)
+		default:
+			succeeded, _, sendErr = s.client.UnstarEntriesBatch(ctx, ids)
+		}
+		if sendErr != nil && firstErr == nil {
+			firstErr = sendErr
+		}
+		sent[key] = make(map[int64]bool, len(succeeded))
+		for _, id := range succeeded {
+			sent[key][id] = true
+		}
+	}
+	remaining := make([]queuedChange, 0, len(changes))
+	for _, change := range changes {
+		if !sent[group{field: change.Field, value: change.Value}][change.EntryID] {
+			remaining = append(remaining, change)
+		}
+	}
+	if err := s.saveQueuedChanges(ctx, remaining); err != nil {
+		return 0, err
+	}
+	sentCount := len(changes) - len(remaining)
+	if firstErr != nil {
+		return sentCount, fmt.Errorf("send queued changes to feedbin: %w", firstErr)
+	}
+	return sentCount, nil
+}
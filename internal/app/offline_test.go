@@ -0,0 +1,144 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+func TestService_OfflineTogglesUpdateCacheAndQueue(t *testing.T) {
+	client := &fakeClient{}
+	repo := &fakeRepo{}
+	svc := NewService(client, repo)
+	svc.SetOffline(true)
+	ctx := context.Background()
+
+	if next, err := svc.ToggleUnread(ctx, 1, true); err != nil || next {
+		t.Fatalf("ToggleUnread offline = %v, %v", next, err)
+	}
+	if next, err := svc.ToggleStarred(ctx, 2, false); err != nil || !next {
+		t.Fatalf("ToggleStarred offline = %v, %v", next, err)
+	}
+	if _, _, err := svc.SetEntriesUnread(ctx, []int64{1, 3}, true); err != nil {
+		t.Fatalf("SetEntriesUnread offline returned error: %v", err)
+	}
+	if len(client.markReadIDs) != 0 || len(client.markUnreadIDs) != 0 || len(client.starIDs) != 0 {
+		t.Fatalf("expected no Feedbin calls offline, got read=%v unread=%v star=%v", client.markReadIDs, client.markUnreadIDs, client.starIDs)
+	}
+	if !repo.setUnread[1] || !repo.setUnread[3] || !repo.setStarred[2] {
+		t.Fatalf("expected cache updated offline, got unread=%v starred=%v", repo.setUnread, repo.setStarred)
+	}
+	count, err := svc.QueuedChangeCount(ctx)
+	if err != nil {
+		t.Fatalf("QueuedChangeCount returned error: %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("expected entry 1's later change to replace the earlier one (3 queued), got %d", count)
+	}
+
+	if _, err := svc.Refresh(ctx, 1, 20); !errors.Is(err, ErrOffline) {
+		t.Fatalf("expected Refresh to fail fast offline, got %v", err)
+	}
+	if _, err := svc.RefreshFeed(ctx, 5); !errors.Is(err, ErrOffline) {
+		t.Fatalf("expected RefreshFeed to fail fast offline, got %v", err)
+	}
+}
+
+func TestService_RefreshSendsQueuedChangesFirst(t *testing.T) {
+	client := &fakeClient{failIDs: map[int64]bool{3: true}}
+	repo := &fakeRepo{}
+	svc := NewService(client, repo)
+	ctx := context.Background()
+
+	svc.SetOffline(true)
+	if _, _, err := svc.SetEntriesUnread(ctx, []int64{1, 3}, false); err != nil {
+		t.Fatalf("SetEntriesUnread offline returned error: %v", err)
+	}
+	if _, err := svc.ToggleStarred(ctx, 2, false); err != nil {
+		t.Fatalf("ToggleStarred offline returned error: %v", err)
+	}
+
+	svc.SetOffline(false)
+	sent, err := svc.FlushQueuedChanges(ctx)
+	if err == nil {
+		t.Fatal("expected FlushQueuedChanges to report the rejected change")
+	}
+	if sent != 2 {
+		t.Fatalf("expected 2 changes sent, got %d", sent)
+	}
+	if len(client.markReadIDs) != 1 || client.markReadIDs[0] != 1 || len(client.starIDs) != 1 || client.starIDs[0] != 2 {
+		t.Fatalf("unexpected Feedbin calls: read=%v star=%v", client.markReadIDs, client.starIDs)
+	}
+	if count, _ := svc.QueuedChangeCount(ctx); count != 1 {
+		t.Fatalf("expected the rejected change to stay queued, got %d", count)
+	}
+
+	delete(client.failIDs, 3)
+	if _, err := svc.Refresh(ctx, 1, 20); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	if count, _ := svc.QueuedChangeCount(ctx); count != 0 {
+		t.Fatalf("expected refresh to send the remaining change, got %d queued", count)
+	}
+}
+
+func TestService_RefreshContinuesWhenQueuedChangesFail(t *testing.T) {
+	client := &fakeClient{failIDs: map[int64]bool{3: true}}
+	repo := &fakeRepo{}
+	svc := NewService(client, repo)
+	ctx := context.Background()
+
+	svc.SetOffline(true)
+	if _, _, err := svc.SetEntriesUnread(ctx, []int64{3}, false); err != nil {
+		t.Fatalf("SetEntriesUnread offline returned error: %v", err)
+	}
+	svc.SetOffline(false)
+
+	if _, err := svc.Refresh(ctx, 1, 20); err != nil {
+		t.Fatalf("expected Refresh to go on past the rejected change, got %v", err)
+	}
+	if warning := svc.SyncWarning(); !strings.HasPrefix(warning, "queued changes not sent: ") {
+		t.Fatalf("expected queued changes warning, got %q", warning)
+	}
+	if count, _ := svc.QueuedChangeCount(ctx); count != 1 {
+		t.Fatalf("expected the rejected change to stay queued, got %d", count)
+	}
+}
+
+// budgetClient is a fakeClient whose request budget is spent.
+type budgetClient struct {
+	fakeClient
+}
+
+func (*budgetClient) RequestCount() int        { return 3 }
+func (*budgetClient) MaxRequests() int         { return 3 }
+func (*budgetClient) RequestBudgetSpent() bool { return true }
+
+func TestService_SpentRequestBudgetServesCacheOnly(t *testing.T) {
+	client := &budgetClient{}
+	repo := &fakeRepo{}
+	svc := NewService(client, repo)
+	ctx := context.Background()
+
+	if !svc.Offline() {
+		t.Fatal("expected a spent budget to put the service offline")
+	}
+	if _, err := svc.Refresh(ctx, 1, 20); !errors.Is(err, feedbin.ErrRequestBudget) {
+		t.Fatalf("expected Refresh to fail fast with the budget error, got %v", err)
+	}
+	if next, err := svc.ToggleUnread(ctx, 1, true); err != nil || next {
+		t.Fatalf("ToggleUnread = %v, %v", next, err)
+	}
+	if _, cached := repo.setUnread[1]; len(client.markReadIDs) != 0 || !cached {
+		t.Fatalf("expected the change cached and queued, not sent, got read=%v", client.markReadIDs)
+	}
+	if count, err := svc.QueuedChangeCount(ctx); err != nil || count != 1 {
+		t.Fatalf("expected 1 queued change, got %d (err %v)", count, err)
+	}
+	if got := svc.Diagnostics(ctx, nil); !strings.Contains(got, "offline: true") || !strings.Contains(got, "api requests: 3 of 3") {
+		t.Fatalf("expected the budget in diagnostics:\n%s", got)
+	}
+}
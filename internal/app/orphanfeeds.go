@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// orphanFeedFailuresKey stores the orphaned feeds whose lookup failed, so
+// later syncs skip them instead of spending requests on them every time.
+const orphanFeedFailuresKey = "orphan_feed_failures"
+
+const (
+	// orphanFeedRetryBase is how long a feed is skipped after its first
+	// failed lookup; each further failure doubles it up to orphanFeedRetryMax.
+	orphanFeedRetryBase = time.Hour
+	orphanFeedRetryMax  = 7 * 24 * time.Hour
+)
+
+// orphanFeedFailure records the failed lookups of one orphaned feed.
+type orphanFeedFailure struct {
+	FailedAt time.Time `json:"failed_at"`
+	Attempts int       `json:"attempts"`
+}
+
+// retryAt is when the feed may be looked up again.
+func (f orphanFeedFailure) retryAt() time.Time {
+	delay := orphanFeedRetryBase
+	for i := 1; i < f.Attempts && delay < orphanFeedRetryMax; i++ {
+		delay *= 2
+	}
+	return f.FailedAt.Add(min(delay, orphanFeedRetryMax))
+}
+
+// orphanFeedFailures loads the recorded lookup failures by feed ID. A value
+// that no longer decodes starts over with none.
+func (s *Service) orphanFeedFailures(ctx context.Context) (map[int64]orphanFeedFailure, error) {
+	failures := make(map[int64]orphanFeedFailure)
+	raw, err := s.repo.GetAppState(ctx, orphanFeedFailuresKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return failures, nil
+		}
+		return nil, fmt.Errorf("load orphaned feed lookups: %w", err)
+	}
+	if raw == "" {
+		return failures, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &failures); err != nil {
+		return make(map[int64]orphanFeedFailure), nil
+	}
+	return failures, nil
+}
+
+func (s *Service) saveOrphanFeedFailures(ctx context.Context, failures map[int64]orphanFeedFailure) error {
+	data, err := json.Marshal(failures)
+	if err != nil {
+		return fmt.Errorf("encode orphaned feed lookups: %w", err)
+	}
+	if err := s.repo.SetAppState(ctx, orphanFeedFailuresKey, string(data)); err != nil {
+		return fmt.Errorf("save orphaned feed lookups: %w", err)
+	}
+	return nil
+}
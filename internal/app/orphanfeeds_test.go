@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+type feedLookupClient struct {
+	*fakeClient
+	lookups []int64
+}
+
+func (c *feedLookupClient) GetFeed(ctx context.Context, feedID int64) (feedbin.Subscription, error) {
+	c.lookups = append(c.lookups, feedID)
+	return c.fakeClient.GetFeed(ctx, feedID)
+}
+
+func TestService_ReconcileOrphanedFeeds_BacksOffFailedLookups(t *testing.T) {
+	client := &feedLookupClient{fakeClient: &fakeClient{feeds: map[int64]feedbin.Subscription{20: {ID: 20, Title: "Old Feed"}}}}
+	repo := &fakeRepo{saved: []feedbin.Entry{{ID: 1, FeedID: 20}, {ID: 2, FeedID: 30}}}
+	svc := NewService(client, repo)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	if err := svc.reconcileOrphanedFeeds(ctx, now); err != nil {
+		t.Fatalf("reconcileOrphanedFeeds returned error: %v", err)
+	}
+	if !slices.Equal(client.lookups, []int64{20, 30}) {
+		t.Fatalf("expected both orphaned feeds looked up, got %v", client.lookups)
+	}
+
+	client.lookups = nil
+	if err := svc.reconcileOrphanedFeeds(ctx, now.Add(30*time.Minute)); err != nil {
+		t.Fatalf("reconcileOrphanedFeeds returned error: %v", err)
+	}
+	if len(client.lookups) != 0 {
+		t.Fatalf("expected the failed feed skipped during its backoff, got %v", client.lookups)
+	}
+
+	if err := svc.reconcileOrphanedFeeds(ctx, now.Add(time.Hour)); err != nil {
+		t.Fatalf("reconcileOrphanedFeeds returned error: %v", err)
+	}
+	if !slices.Equal(client.lookups, []int64{30}) {
+		t.Fatalf("expected a retry once the backoff passed, got %v", client.lookups)
+	}
+
+	client.lookups = nil
+	if err := svc.reconcileOrphanedFeeds(ctx, now.Add(2*time.Hour+30*time.Minute)); err != nil {
+		t.Fatalf("reconcileOrphanedFeeds returned error: %v", err)
+	}
+	if len(client.lookups) != 0 {
+		t.Fatalf("expected the backoff to double after a second failure, got %v", client.lookups)
+	}
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const pinnedFeedsKey = "pinned_feeds"
+
+// PinnedFeeds returns the IDs of the feeds pinned to the top of the tree.
+func (s *Service) PinnedFeeds(ctx context.Context) ([]int64, error) {
+	raw, err := s.repo.GetAppState(ctx, pinnedFeedsKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("load pinned feeds: %w", err)
+	}
+	if raw == "" {
+		return nil, nil
+	}
+	var feedIDs []int64
+	if err := json.Unmarshal([]byte(raw), &feedIDs); err != nil {
+		return nil, fmt.Errorf("decode pinned feeds: %w", err)
+	}
+	return feedIDs, nil
+}
+
+// SavePinnedFeeds stores the IDs of the feeds pinned to the top of the tree.
+func (s *Service) SavePinnedFeeds(ctx context.Context, feedIDs []int64) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	data, err := json.Marshal(feedIDs)
+	if err != nil {
+		return fmt.Errorf("encode pinned feeds: %w", err)
+	}
+	if err := s.repo.SetAppState(ctx, pinnedFeedsKey, string(data)); err != nil {
+		return fmt.Errorf("save pinned feeds: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestService_PinnedFeeds_RoundTrip(t *testing.T) {
+	svc := NewService(&fakeClient{}, &fakeRepo{})
+	ctx := context.Background()
+
+	feedIDs, err := svc.PinnedFeeds(ctx)
+	if err != nil {
+		t.Fatalf("PinnedFeeds returned error: %v", err)
+	}
+	if len(feedIDs) != 0 {
+		t.Fatalf("expected no pinned feeds before saving, got %v", feedIDs)
+	}
+
+	if err := svc.SavePinnedFeeds(ctx, []int64{12, 5}); err != nil {
+		t.Fatalf("SavePinnedFeeds returned error: %v", err)
+	}
+	feedIDs, err = svc.PinnedFeeds(ctx)
+	if err != nil {
+		t.Fatalf("PinnedFeeds returned error: %v", err)
+	}
+	if !slices.Equal(feedIDs, []int64{12, 5}) {
+		t.Fatalf("unexpected pinned feeds: %v", feedIDs)
+	}
+}
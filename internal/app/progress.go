@@ -0,0 +1,31 @@
+package app
+
+import (
+	"context"
+	"fmt"
+)
+
+// SaveReadProgress remembers how far an entry was scrolled as a fraction of
+// its scrollable length. Zero (not started) and one or more (finished) clear
+// the entry's progress.
+func (s *Service) SaveReadProgress(ctx context.Context, entryID int64, fraction float64) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	if fraction >= 1 {
+		fraction = 0
+	}
+	if err := s.repo.SetReadProgress(ctx, entryID, max(fraction, 0)); err != nil {
+		return fmt.Errorf("save read progress in cache: %w", err)
+	}
+	return nil
+}
+
+// ReadProgress returns the stored read progress by entry ID.
+func (s *Service) ReadProgress(ctx context.Context) (map[int64]float64, error) {
+	progress, err := s.repo.ListReadProgress(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load read progress from cache: %w", err)
+	}
+	return progress, nil
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"context"
+	"testing"
+)
+
+func TestService_SaveReadProgress_ClearsFinishedEntries(t *testing.T) {
+	repo := &fakeRepo{}
+	svc := NewService(&fakeClient{}, repo)
+	ctx := context.Background()
+
+	if err := svc.SaveReadProgress(ctx, 1, 0.4); err != nil {
+		t.Fatalf("SaveReadProgress returned error: %v", err)
+	}
+	if err := svc.SaveReadProgress(ctx, 2, 0.7); err != nil {
+		t.Fatalf("SaveReadProgress returned error: %v", err)
+	}
+	if err := svc.SaveReadProgress(ctx, 2, 1); err != nil {
+		t.Fatalf("SaveReadProgress returned error: %v", err)
+	}
+
+	progress, err := svc.ReadProgress(ctx)
+	if err != nil {
+		t.Fatalf("ReadProgress returned error: %v", err)
+	}
+	if len(progress) != 1 || progress[1] != 0.4 {
+		t.Fatalf("expected only entry 1 in progress, got %v", progress)
+	}
+}
@@ -0,0 +1,20 @@
+package app
+
+import "errors"
+
+// ErrReadOnly is returned by operations that would write to the cache while
+// the service is in read-only mode.
+var ErrReadOnly = errors.New("read-only mode")
+
+// SetReadOnly switches read-only mode for browsing a cache the process may
+// not write, such as a shared or immutable file. While read-only, the
+// service works offline and refuses read/star changes, feed management and
+// preference saving with ErrReadOnly instead of queueing or caching them.
+func (s *Service) SetReadOnly(readOnly bool) {
+	s.readOnly = readOnly
+}
+
+// ReadOnly reports whether read-only mode is set.
+func (s *Service) ReadOnly() bool {
+	return s.readOnly
+}
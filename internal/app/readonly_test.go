@@ -0,0 +1,52 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestService_ReadOnlyRefusesMutations(t *testing.T) {
+	client := &fakeClient{}
+	repo := &fakeRepo{}
+	svc := NewService(client, repo)
+	svc.SetReadOnly(true)
+	ctx := context.Background()
+
+	if !svc.Offline() {
+		t.Fatal("expected read-only mode to work from the cache only")
+	}
+	if next, err := svc.ToggleUnread(ctx, 1, true); !errors.Is(err, ErrReadOnly) || !next {
+		t.Fatalf("ToggleUnread read-only = %v, %v", next, err)
+	}
+	if _, failed, err := svc.SetEntriesStarred(ctx, []int64{1, 2}, true); !errors.Is(err, ErrReadOnly) || len(failed) != 2 {
+		t.Fatalf("SetEntriesStarred read-only failed=%v err=%v", failed, err)
+	}
+	if err := svc.SaveUIPreferences(ctx, UIPreferences{Compact: true}); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected SaveUIPreferences to be refused, got %v", err)
+	}
+	if err := svc.SnoozeEntry(ctx, 1, time.Now().Add(time.Hour)); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected SnoozeEntry to be refused, got %v", err)
+	}
+	if err := svc.SaveReadProgress(ctx, 1, 0.5); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected SaveReadProgress to be refused, got %v", err)
+	}
+	var state bytes.Buffer
+	if err := svc.ExportState(ctx, &state, ViewState{LastEntryID: 1}); !errors.Is(err, ErrReadOnly) || state.Len() != 0 {
+		t.Fatalf("expected ExportState to be refused, got %v with %d bytes", err, state.Len())
+	}
+	if _, err := svc.Refresh(ctx, 1, 20); !errors.Is(err, ErrOffline) {
+		t.Fatalf("expected Refresh to stay offline, got %v", err)
+	}
+	if len(client.markReadIDs) != 0 || len(client.markUnreadIDs) != 0 || len(client.starIDs) != 0 {
+		t.Fatalf("expected no Feedbin calls, got read=%v unread=%v star=%v", client.markReadIDs, client.markUnreadIDs, client.starIDs)
+	}
+	if len(repo.setUnread) != 0 || len(repo.setStarred) != 0 || len(repo.appState) != 0 || len(repo.snoozed) != 0 || len(repo.progress) != 0 {
+		t.Fatalf("expected no cache writes, got unread=%v starred=%v state=%v", repo.setUnread, repo.setStarred, repo.appState)
+	}
+	if count, err := svc.QueuedChangeCount(ctx); err != nil || count != 0 {
+		t.Fatalf("expected nothing queued, got %d, %v", count, err)
+	}
+}
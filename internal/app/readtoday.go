@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// readTodayKey holds "YYYY-MM-DD N": how many entries were marked read on
+// that local day. A new day starts the count over.
+const readTodayKey = "read_today"
+
+// ReadToday returns how many entries were marked read on now's local day.
+func (s *Service) ReadToday(ctx context.Context, now time.Time) (int, error) {
+	s.readTodayMu.Lock()
+	defer s.readTodayMu.Unlock()
+	return s.loadReadToday(ctx, now)
+}
+
+// addReadToday adds n reads to now's local day. Counting is best effort: a
+// failure to update the counter never undoes the read itself.
+func (s *Service) addReadToday(ctx context.Context, now time.Time, n int) {
+	if s.readOnly {
+		return
+	}
+	if n <= 0 {
+		return
+	}
+	s.readTodayMu.Lock()
+	defer s.readTodayMu.Unlock()
+	count, err := s.loadReadToday(ctx, now)
+	if err != nil {
+		return
+	}
+	_ = s.repo.SetAppState(ctx, readTodayKey, readTodayDay(now)+" "+strconv.Itoa(count+n))
+}
+
+func (s *Service) loadReadToday(ctx context.Context, now time.Time) (int, error) {
+	raw, err := s.repo.GetAppState(ctx, readTodayKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("load read-today count: %w", err)
+	}
+	day, count, ok := strings.Cut(strings.TrimSpace(raw), " ")
+	if !ok || day != readTodayDay(now) {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(count)
+	if err != nil || n < 0 {
+		return 0, nil
+	}
+	return n, nil
+}
+
+func readTodayDay(now time.Time) string {
+	return now.Local().Format("2006-01-02")
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestService_ReadToday_CountsReadsPerDayAndRollsOver(t *testing.T) {
+	repo := &fakeRepo{}
+	svc := NewService(&fakeClient{}, repo)
+	ctx := context.Background()
+	now := time.Now()
+
+	if _, err := svc.ToggleUnread(ctx, 1, true); err != nil {
+		t.Fatalf("ToggleUnread returned error: %v", err)
+	}
+	if _, _, err := svc.SetEntriesUnread(ctx, []int64{2, 3}, false); err != nil {
+		t.Fatalf("SetEntriesUnread returned error: %v", err)
+	}
+	// Marking entries unread again does not take reads back.
+	if _, err := svc.ToggleUnread(ctx, 1, false); err != nil {
+		t.Fatalf("ToggleUnread returned error: %v", err)
+	}
+	if _, _, err := svc.SetEntriesUnread(ctx, []int64{2}, true); err != nil {
+		t.Fatalf("SetEntriesUnread returned error: %v", err)
+	}
+
+	count, err := svc.ReadToday(ctx, now)
+	if err != nil {
+		t.Fatalf("ReadToday returned error: %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("expected 3 reads today, got %d", count)
+	}
+
+	tomorrow := now.AddDate(0, 0, 1)
+	if count, _ := svc.ReadToday(ctx, tomorrow); count != 0 {
+		t.Fatalf("expected the count to start over the next day, got %d", count)
+	}
+	svc.addReadToday(ctx, tomorrow, 2)
+	if count, _ := svc.ReadToday(ctx, tomorrow); count != 2 {
+		t.Fatalf("expected 2 reads on the new day, got %d", count)
+	}
+	if got := repo.appState[readTodayKey]; got != tomorrow.Format("2006-01-02")+" 2" {
+		t.Fatalf("unexpected stored counter %q", got)
+	}
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+const searchModeKey = "search_mode"
+
+// SearchMode reports the cache search backend in use, "fts" or "like".
+func (s *Service) SearchMode() string {
+	return s.repo.SearchMode()
+}
+
+// SetSearchMode switches the cache search backend and remembers the choice
+// for the next start. When the FTS index cannot be built, search stays on
+// LIKE and the choice is not saved.
+func (s *Service) SetSearchMode(ctx context.Context, mode string) (string, error) {
+	if s.readOnly {
+		return s.repo.SearchMode(), ErrReadOnly
+	}
+	if err := s.repo.SetSearchMode(ctx, mode); err != nil {
+		return s.repo.SearchMode(), fmt.Errorf("switch search mode: %w", err)
+	}
+	active := s.repo.SearchMode()
+	if err := s.repo.SetAppState(ctx, searchModeKey, active); err != nil {
+		return active, fmt.Errorf("save search mode: %w", err)
+	}
+	return active, nil
+}
+
+// RestoreSearchMode applies the search backend saved by SetSearchMode, if
+// any, over the one the cache was opened with.
+func (s *Service) RestoreSearchMode(ctx context.Context) error {
+	if s.readOnly {
+		return nil
+	}
+	mode, err := s.repo.GetAppState(ctx, searchModeKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("load search mode: %w", err)
+	}
+	if mode == s.repo.SearchMode() {
+		return nil
+	}
+	if err := s.repo.SetSearchMode(ctx, mode); err != nil {
+		return fmt.Errorf("restore search mode: %w", err)
+	}
+	return nil
+}
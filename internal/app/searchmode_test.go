@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestService_SetSearchMode_PersistsAndRestores(t *testing.T) {
+	repo := &fakeRepo{}
+	svc := NewService(&fakeClient{}, repo)
+
+	mode, err := svc.SetSearchMode(context.Background(), "fts")
+	if err != nil {
+		t.Fatalf("SetSearchMode returned error: %v", err)
+	}
+	if mode != "fts" || svc.SearchMode() != "fts" {
+		t.Fatalf("expected fts active, got %q/%q", mode, svc.SearchMode())
+	}
+	if repo.appState[searchModeKey] != "fts" {
+		t.Fatalf("expected fts saved, got %q", repo.appState[searchModeKey])
+	}
+
+	repo.searchMode = "like"
+	if err := svc.RestoreSearchMode(context.Background()); err != nil {
+		t.Fatalf("RestoreSearchMode returned error: %v", err)
+	}
+	if svc.SearchMode() != "fts" {
+		t.Fatalf("expected saved fts restored, got %q", svc.SearchMode())
+	}
+}
+
+func TestService_SetSearchMode_FTSFailureStaysOnLike(t *testing.T) {
+	repo := &fakeRepo{ftsErr: errors.New("no fts5")}
+	svc := NewService(&fakeClient{}, repo)
+
+	mode, err := svc.SetSearchMode(context.Background(), "fts")
+	if err == nil {
+		t.Fatal("expected error when the FTS index cannot be built")
+	}
+	if mode != "like" {
+		t.Fatalf("expected like to stay active, got %q", mode)
+	}
+	if _, saved := repo.appState[searchModeKey]; saved {
+		t.Fatal("expected the failed choice not to be saved")
+	}
+}
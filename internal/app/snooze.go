@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SnoozeEntry marks an entry read and hides it from the all and unread views
+// until wakeAt, after which the next refresh marks it unread again.
+func (s *Service) SnoozeEntry(ctx context.Context, entryID int64, wakeAt time.Time) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	if s.Offline() {
+		if err := s.queueChanges(ctx, queuedFieldUnread, []int64{entryID}, false); err != nil {
+			return err
+		}
+	} else if err := s.client.MarkEntriesRead(ctx, []int64{entryID}); err != nil {
+		return fmt.Errorf("mark read in feedbin: %w", err)
+	}
+	if err := s.repo.SetEntryUnread(ctx, entryID, false); err != nil {
+		return fmt.Errorf("save unread state in cache: %w", err)
+	}
+	if err := s.repo.SnoozeEntry(ctx, entryID, wakeAt); err != nil {
+		return fmt.Errorf("save snooze in cache: %w", err)
+	}
+	return nil
+}
+
+// WakeSnoozedEntries marks entries whose snooze expired at or before now as
+// unread and makes them visible again. Entries Feedbin rejected stay snoozed
+// so the next refresh retries them. It returns how many entries woke.
+func (s *Service) WakeSnoozedEntries(ctx context.Context, now time.Time) (int, error) {
+	if s.readOnly {
+		return 0, nil
+	}
+	due, err := s.repo.ListDueSnoozedEntryIDs(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("load snoozed entries: %w", err)
+	}
+	if len(due) == 0 {
+		return 0, nil
+	}
+	woken, _, markErr := s.SetEntriesUnread(ctx, due, true)
+	if err := s.repo.UnsnoozeEntries(ctx, woken); err != nil {
+		return 0, fmt.Errorf("clear snoozed entries: %w", err)
+	}
+	if markErr != nil {
+		return len(woken), fmt.Errorf("wake snoozed entries: %w", markErr)
+	}
+	return len(woken), nil
+}
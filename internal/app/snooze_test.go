@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestService_SnoozeEntry_MarksReadAndStoresWakeTime(t *testing.T) {
+	client := &fakeClient{}
+	repo := &fakeRepo{}
+	svc := NewService(client, repo)
+	wakeAt := time.Date(2026, 2, 10, 8, 0, 0, 0, time.UTC)
+
+	if err := svc.SnoozeEntry(context.Background(), 7, wakeAt); err != nil {
+		t.Fatalf("SnoozeEntry returned error: %v", err)
+	}
+	if len(client.markReadIDs) != 1 || client.markReadIDs[0] != 7 {
+		t.Fatalf("expected entry marked read in feedbin, got %v", client.markReadIDs)
+	}
+	if unread, ok := repo.setUnread[7]; !ok || unread {
+		t.Fatalf("expected entry marked read in cache, got %v", repo.setUnread)
+	}
+	if !repo.snoozed[7].Equal(wakeAt) {
+		t.Fatalf("expected wake time stored, got %v", repo.snoozed)
+	}
+}
+
+func TestService_WakeSnoozedEntries_MarksDueUnreadAndRetriesFailures(t *testing.T) {
+	now := time.Date(2026, 2, 10, 8, 0, 0, 0, time.UTC)
+	client := &fakeClient{failIDs: map[int64]bool{3: true}}
+	repo := &fakeRepo{snoozed: map[int64]time.Time{
+		1: now.Add(-time.Minute),
+		2: now.Add(time.Hour),
+		3: now.Add(-time.Hour),
+	}}
+	svc := NewService(client, repo)
+
+	woken, err := svc.WakeSnoozedEntries(context.Background(), now)
+	if err == nil {
+		t.Fatal("expected error for the rejected entry")
+	}
+	if woken != 1 || !repo.setUnread[1] {
+		t.Fatalf("expected entry 1 woken and unread, got woken=%d unread=%v", woken, repo.setUnread)
+	}
+	if _, ok := repo.snoozed[1]; ok {
+		t.Fatal("expected woken entry unsnoozed")
+	}
+	if _, ok := repo.snoozed[2]; !ok {
+		t.Fatal("expected future snooze kept")
+	}
+	if _, ok := repo.snoozed[3]; !ok {
+		t.Fatal("expected rejected entry to stay snoozed for retry")
+	}
+}
+
+func TestService_RefreshContinuesWhenSnoozedEntriesFailToWake(t *testing.T) {
+	client := &fakeClient{failIDs: map[int64]bool{3: true}}
+	repo := &fakeRepo{snoozed: map[int64]time.Time{3: time.Now().Add(-time.Hour)}}
+	svc := NewService(client, repo)
+
+	if _, err := svc.Refresh(context.Background(), 1, 20); err != nil {
+		t.Fatalf("expected Refresh to go on past the failed wake, got %v", err)
+	}
+	if warning := svc.SyncWarning(); !strings.HasPrefix(warning, "snoozed entries not woken: ") {
+		t.Fatalf("expected wake warning, got %q", warning)
+	}
+	if _, ok := repo.snoozed[3]; !ok {
+		t.Fatal("expected rejected entry to stay snoozed for the next refresh")
+	}
+}
@@ -0,0 +1,219 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+// portableStateVersion is bumped when the state file layout changes
+// incompatibly.
+const portableStateVersion = 1
+
+// ViewState is the reading position and tree layout carried in a portable
+// state file.
+type ViewState struct {
+	LastEntryID       int64    `json:"last_entry_id,omitempty"`
+	Filter            string   `json:"filter,omitempty"`
+	CollapsedFolders  []string `json:"collapsed_folders,omitempty"`
+	CollapsedFeeds    []string `json:"collapsed_feeds,omitempty"`
+	CollapsedSections []string `json:"collapsed_sections,omitempty"`
+}
+
+type portableState struct {
+	Version     int                 `json:"version"`
+	View        ViewState           `json:"view"`
+	Preferences portablePreferences `json:"preferences"`
+	Marks       []feedbin.EntryMark `json:"marks,omitempty"`
+}
+
+type portablePreferences struct {
+	Compact              bool   `json:"compact"`
+	MarkReadOnOpen       bool   `json:"mark_read_on_open"`
+	ConfirmOpenRead      bool   `json:"confirm_open_read"`
+	TimeFormat           string `json:"time_format,omitempty"`
+	ShowNumbers          bool   `json:"show_numbers"`
+	AutoPreview          bool   `json:"auto_preview"`
+	ConfirmBulkActions   bool   `json:"confirm_bulk_actions"`
+	DateColumn           string `json:"date_column,omitempty"`
+	IncrementalSearch    bool   `json:"incremental_search"`
+	CompactCounts        bool   `json:"compact_counts"`
+	MergePages           bool   `json:"merge_pages"`
+	CompactTree          bool   `json:"compact_tree"`
+	TagsAsFolders        bool   `json:"tags_as_folders"`
+	HideRead             bool   `json:"hide_read"`
+	CompactUnreadFirst   bool   `json:"compact_unread_first"`
+	PreviewLines         int    `json:"preview_lines"`
+	PreviewSource        string `json:"preview_source,omitempty"`
+	PreferSummary        bool   `json:"prefer_summary"`
+	ShowReadToday        bool   `json:"show_read_today"`
+	MarkReadOnDetailOpen bool   `json:"mark_read_on_detail_open"`
+	StripFeedPrefix      bool   `json:"strip_feed_prefix"`
+}
+
+// UnmarshalJSON also reads relative_time, which files written before
+// time_format existed carry instead: true maps to "relative" and false to
+// "absolute". A time_format in the same file wins. Keys missing from the
+// file keep the values p already holds.
+func (p *portablePreferences) UnmarshalJSON(data []byte) error {
+	type plain portablePreferences
+	decoded := struct {
+		plain
+		TimeFormat   *string `json:"time_format"`
+		RelativeTime *bool   `json:"relative_time"`
+	}{plain: plain(*p)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*p = portablePreferences(decoded.plain)
+	switch {
+	case decoded.TimeFormat != nil:
+		p.TimeFormat = *decoded.TimeFormat
+	case decoded.RelativeTime != nil:
+		p.TimeFormat = "absolute"
+		if *decoded.RelativeTime {
+			p.TimeFormat = "relative"
+		}
+	}
+	return nil
+}
+
+// ExportState writes the reading position, UI preferences and cached
+// read/star marks as JSON so another device can pick up where this one left
+// off via a user-synced file. A read-only session refuses with ErrReadOnly,
+// since it could not import the file and would overwrite it with stale state.
+func (s *Service) ExportState(ctx context.Context, w io.Writer, view ViewState) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	prefs, err := s.LoadUIPreferences(ctx)
+	if err != nil {
+		return err
+	}
+	marks, err := s.repo.ListEntryMarks(ctx)
+	if err != nil {
+		return fmt.Errorf("load entry marks from cache: %w", err)
+	}
+
+	state := portableState{
+		Version:     portableStateVersion,
+		View:        view,
+		Preferences: portablePreferences(prefs),
+		Marks:       marks,
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(state); err != nil {
+		return fmt.Errorf("encode state: %w", err)
+	}
+	return nil
+}
+
+// ImportState reads a file written by ExportState, stores its preferences and
+// applies its marks to entries already in the cache. Preferences the file
+// leaves out keep their current values, and nothing is stored when a value is
+// out of range. The returned ViewState is for the UI to restore; the next
+// full sync with Feedbin still wins for read/star state.
+func (s *Service) ImportState(ctx context.Context, r io.Reader) (ViewState, error) {
+	if s.readOnly {
+		return ViewState{}, ErrReadOnly
+	}
+	current, err := s.LoadUIPreferences(ctx)
+	if err != nil {
+		return ViewState{}, err
+	}
+	state := portableState{Preferences: portablePreferences(current)}
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return ViewState{}, fmt.Errorf("decode state: %w", err)
+	}
+	if state.Version != portableStateVersion {
+		return ViewState{}, fmt.Errorf("unsupported state version %d", state.Version)
+	}
+
+	prefs := UIPreferences(state.Preferences)
+	if err := validateUIPreferences(prefs); err != nil {
+		return ViewState{}, err
+	}
+	if err := s.SaveUIPreferences(ctx, prefs); err != nil {
+		return ViewState{}, err
+	}
+	if len(state.Marks) > 0 {
+		if err := s.repo.SaveEntryMarks(ctx, state.Marks); err != nil {
+			return ViewState{}, fmt.Errorf("save entry marks to cache: %w", err)
+		}
+	}
+	return state.View, nil
+}
+
+// portablePreferencesVersion is bumped when the preferences file layout
+// changes incompatibly.
+const portablePreferencesVersion = 1
+
+type portablePreferencesFile struct {
+	Version     int                 `json:"version"`
+	Preferences portablePreferences `json:"preferences"`
+}
+
+// ExportPreferences writes the UI preferences alone as JSON, for keeping a
+// setup under version control or copying it to another machine.
+func (s *Service) ExportPreferences(ctx context.Context, w io.Writer) error {
+	prefs, err := s.LoadUIPreferences(ctx)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(portablePreferencesFile{Version: portablePreferencesVersion, Preferences: portablePreferences(prefs)}); err != nil {
+		return fmt.Errorf("encode preferences: %w", err)
+	}
+	return nil
+}
+
+// ImportPreferences reads a file written by ExportPreferences and stores its
+// preferences, replacing the current ones. Nothing is stored when a value is
+// out of range.
+func (s *Service) ImportPreferences(ctx context.Context, r io.Reader) (UIPreferences, error) {
+	if s.readOnly {
+		return UIPreferences{}, ErrReadOnly
+	}
+	var file portablePreferencesFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return UIPreferences{}, fmt.Errorf("decode preferences: %w", err)
+	}
+	if file.Version != portablePreferencesVersion {
+		return UIPreferences{}, fmt.Errorf("unsupported preferences version %d", file.Version)
+	}
+	prefs := UIPreferences(file.Preferences)
+	if err := validateUIPreferences(prefs); err != nil {
+		return UIPreferences{}, err
+	}
+	if err := s.SaveUIPreferences(ctx, prefs); err != nil {
+		return UIPreferences{}, err
+	}
+	return prefs, nil
+}
+
+func validateUIPreferences(prefs UIPreferences) error {
+	switch prefs.TimeFormat {
+	case "", "relative", "absolute", "both":
+	default:
+		return fmt.Errorf("invalid time_format %q (expected relative, absolute or both)", prefs.TimeFormat)
+	}
+	switch prefs.DateColumn {
+	case "", "full", "short", "hidden":
+	default:
+		return fmt.Errorf("invalid date_column %q (expected full, short or hidden)", prefs.DateColumn)
+	}
+	switch prefs.PreviewSource {
+	case "", "summary", "content":
+	default:
+		return fmt.Errorf("invalid preview_source %q (expected summary or content)", prefs.PreviewSource)
+	}
+	if prefs.PreviewLines < 0 || prefs.PreviewLines > 3 {
+		return fmt.Errorf("invalid preview_lines %d (expected 0 to 3)", prefs.PreviewLines)
+	}
+	return nil
+}
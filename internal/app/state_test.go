@@ -0,0 +1,156 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+func TestService_ExportImportState_RoundTrip(t *testing.T) {
+	source := &fakeRepo{marks: []feedbin.EntryMark{{ID: 1, Unread: true}, {ID: 2, Starred: true}}}
+	sourceSvc := NewService(&fakeClient{}, source)
+	if err := sourceSvc.SaveUIPreferences(context.Background(), UIPreferences{Compact: true, TimeFormat: "both", ConfirmBulkActions: true}); err != nil {
+		t.Fatalf("SaveUIPreferences returned error: %v", err)
+	}
+
+	view := ViewState{LastEntryID: 2, Filter: "unread", CollapsedFolders: []string{"Tech"}, CollapsedSections: []string{"Feeds"}}
+	var buf bytes.Buffer
+	if err := sourceSvc.ExportState(context.Background(), &buf, view); err != nil {
+		t.Fatalf("ExportState returned error: %v", err)
+	}
+
+	target := &fakeRepo{}
+	targetSvc := NewService(&fakeClient{}, target)
+	got, err := targetSvc.ImportState(context.Background(), &buf)
+	if err != nil {
+		t.Fatalf("ImportState returned error: %v", err)
+	}
+	if got.LastEntryID != 2 || got.Filter != "unread" || len(got.CollapsedFolders) != 1 || got.CollapsedSections[0] != "Feeds" {
+		t.Fatalf("unexpected view state: %+v", got)
+	}
+	if len(target.marks) != 2 || !target.marks[0].Unread || !target.marks[1].Starred {
+		t.Fatalf("expected marks imported, got %+v", target.marks)
+	}
+	prefs, err := targetSvc.LoadUIPreferences(context.Background())
+	if err != nil {
+		t.Fatalf("LoadUIPreferences returned error: %v", err)
+	}
+	if !prefs.Compact || prefs.TimeFormat != "both" || prefs.ShowNumbers {
+		t.Fatalf("unexpected imported preferences: %+v", prefs)
+	}
+}
+
+func TestService_ImportState_RejectsUnknownVersion(t *testing.T) {
+	svc := NewService(&fakeClient{}, &fakeRepo{})
+	if _, err := svc.ImportState(context.Background(), strings.NewReader(`{"version":99}`)); err == nil {
+		t.Fatal("expected error for unsupported version")
+	}
+}
+
+func TestService_ImportState_ValidatesAndKeepsMissingPreferences(t *testing.T) {
+	repo := &fakeRepo{}
+	svc := NewService(&fakeClient{}, repo)
+	if _, err := svc.ImportState(context.Background(), strings.NewReader(`{"version":1,"view":{},"preferences":{"preview_lines":7}}`)); err == nil {
+		t.Fatal("expected error for an out-of-range preference")
+	}
+	if len(repo.appState) != 0 {
+		t.Fatalf("expected nothing stored, got %v", repo.appState)
+	}
+
+	if _, err := svc.ImportState(context.Background(), strings.NewReader(`{"version":1,"view":{},"preferences":{"compact":true}}`)); err != nil {
+		t.Fatalf("ImportState returned error: %v", err)
+	}
+	prefs, err := svc.LoadUIPreferences(context.Background())
+	if err != nil {
+		t.Fatalf("LoadUIPreferences returned error: %v", err)
+	}
+	if !prefs.Compact || !prefs.ConfirmBulkActions {
+		t.Fatalf("expected compact imported and bulk confirmation kept on, got %+v", prefs)
+	}
+}
+
+func TestService_ExportImportPreferences_RoundTrip(t *testing.T) {
+	want := UIPreferences{
+		Compact:              true,
+		TimeFormat:           "relative",
+		ConfirmBulkActions:   true,
+		DateColumn:           "short",
+		CompactTree:          true,
+		HideRead:             true,
+		PreviewLines:         2,
+		PreviewSource:        "content",
+		ShowReadToday:        true,
+		MarkReadOnDetailOpen: true,
+		StripFeedPrefix:      true,
+	}
+	sourceSvc := NewService(&fakeClient{}, &fakeRepo{})
+	if err := sourceSvc.SaveUIPreferences(context.Background(), want); err != nil {
+		t.Fatalf("SaveUIPreferences returned error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := sourceSvc.ExportPreferences(context.Background(), &buf); err != nil {
+		t.Fatalf("ExportPreferences returned error: %v", err)
+	}
+
+	targetSvc := NewService(&fakeClient{}, &fakeRepo{})
+	imported, err := targetSvc.ImportPreferences(context.Background(), &buf)
+	if err != nil {
+		t.Fatalf("ImportPreferences returned error: %v", err)
+	}
+	got, err := targetSvc.LoadUIPreferences(context.Background())
+	if err != nil {
+		t.Fatalf("LoadUIPreferences returned error: %v", err)
+	}
+	if imported != want || got != want {
+		t.Fatalf("expected preferences to round-trip:\nwant %+v\nimported %+v\nstored %+v", want, imported, got)
+	}
+}
+
+func TestService_ImportPreferences_RejectsInvalidValues(t *testing.T) {
+	for _, input := range []string{
+		`{"version":99,"preferences":{}}`,
+		`{"version":1,"preferences":{"date_column":"sideways"}}`,
+		`{"version":1,"preferences":{"preview_source":"title"}}`,
+		`{"version":1,"preferences":{"preview_lines":7}}`,
+		`{"version":1,"preferences":{"time_format":"sundial"}}`,
+	} {
+		repo := &fakeRepo{}
+		svc := NewService(&fakeClient{}, repo)
+		if _, err := svc.ImportPreferences(context.Background(), strings.NewReader(input)); err == nil {
+			t.Fatalf("expected error for %s", input)
+		}
+		if len(repo.appState) != 0 {
+			t.Fatalf("expected nothing stored for %s, got %v", input, repo.appState)
+		}
+	}
+}
+
+func TestService_ImportPreferences_AcceptsLegacyRelativeTime(t *testing.T) {
+	for input, want := range map[string]string{
+		`{"version":1,"preferences":{"relative_time":true}}`:                       "relative",
+		`{"version":1,"preferences":{"relative_time":false}}`:                      "absolute",
+		`{"version":1,"preferences":{"relative_time":false,"time_format":"both"}}`: "both",
+		`{"version":1,"preferences":{"compact":true}}`:                             "",
+	} {
+		svc := NewService(&fakeClient{}, &fakeRepo{})
+		imported, err := svc.ImportPreferences(context.Background(), strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("ImportPreferences(%s) returned error: %v", input, err)
+		}
+		if imported.TimeFormat != want {
+			t.Fatalf("ImportPreferences(%s) time format = %q, want %q", input, imported.TimeFormat, want)
+		}
+	}
+
+	svc := NewService(&fakeClient{}, &fakeRepo{})
+	legacy := `{"version":1,"view":{},"preferences":{"relative_time":false}}`
+	if _, err := svc.ImportState(context.Background(), strings.NewReader(legacy)); err != nil {
+		t.Fatalf("ImportState returned error: %v", err)
+	}
+	if prefs, err := svc.LoadUIPreferences(context.Background()); err != nil || prefs.TimeFormat != "absolute" {
+		t.Fatalf("expected legacy state file to import absolute time, got %q (err %v)", prefs.TimeFormat, err)
+	}
+}
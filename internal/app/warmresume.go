@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+// warmProgressKey records the last page an interrupted WarmCache saved, so
+// the next one resumes after it; "0" or no value starts at page 1.
+const warmProgressKey = "warm_cache_last_page"
+
+// ResetWarmProgress forgets where an interrupted WarmCache stopped, so the
+// next one starts again at page 1.
+func (s *Service) ResetWarmProgress(ctx context.Context) error {
+	if err := s.repo.SetAppState(ctx, warmProgressKey, "0"); err != nil {
+		return fmt.Errorf("reset sync progress: %w", err)
+	}
+	return nil
+}
+
+// warmResumePage returns the page WarmCache starts at: the one after the last
+// page an interrupted run saved, or 1.
+func (s *Service) warmResumePage(ctx context.Context) (int, error) {
+	raw, err := s.repo.GetAppState(ctx, warmProgressKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 1, nil
+		}
+		return 0, fmt.Errorf("load sync progress: %w", err)
+	}
+	last, err := strconv.Atoi(raw)
+	if err != nil || last < 0 {
+		return 1, nil
+	}
+	return last + 1, nil
+}
+
+// saveWarmPrefix saves the pages an interrupted WarmCache fetched without a
+// gap after firstPage, and records the last of them as the resume point.
+// It writes with ctx's values but not its cancellation, since the run being
+// saved is usually the one that was canceled.
+func (s *Service) saveWarmPrefix(ctx context.Context, firstPage int, results [][]feedbin.Entry, done []bool) error {
+	ctx = context.WithoutCancel(ctx)
+	seen := make(map[int64]struct{})
+	var merged []feedbin.Entry
+	saved := 0
+	for i := range results {
+		if !done[i] {
+			break
+		}
+		saved++
+		for _, entry := range results[i] {
+			if _, ok := seen[entry.ID]; ok {
+				continue
+			}
+			seen[entry.ID] = struct{}{}
+			merged = append(merged, entry)
+		}
+	}
+	if saved == 0 {
+		return nil
+	}
+	if len(merged) > 0 {
+		if err := s.repo.SaveEntries(ctx, merged); err != nil {
+			return fmt.Errorf("save entries to cache: %w", err)
+		}
+	}
+	if err := s.repo.SetAppState(ctx, warmProgressKey, strconv.Itoa(firstPage+saved-1)); err != nil {
+		return fmt.Errorf("save sync progress: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+// ListCachedWindow lists up to limit cached entries of filter, newest first,
+// after skipping the offset newest ones, so a large cache can be held in
+// memory one window at a time.
+func (s *Service) ListCachedWindow(ctx context.Context, filter string, offset, limit int) ([]feedbin.Entry, error) {
+	since, _ := s.ageCutoff(filter)
+	entries, err := s.repo.ListEntriesByFilterOffset(ctx, limit, offset, filter, since)
+	if err != nil {
+		return nil, fmt.Errorf("load entry window from cache: %w", err)
+	}
+	return entries, nil
+}
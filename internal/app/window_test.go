@@ -0,0 +1,24 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+func TestService_ListCachedWindow_SkipsOffset(t *testing.T) {
+	repo := &fakeRepo{cached: []feedbin.Entry{{ID: 5}, {ID: 4}, {ID: 3}, {ID: 2}, {ID: 1}}}
+	svc := NewService(&fakeClient{}, repo)
+
+	window, err := svc.ListCachedWindow(context.Background(), "all", 1, 3)
+	if err != nil {
+		t.Fatalf("ListCachedWindow returned error: %v", err)
+	}
+	if len(window) != 3 || window[0].ID != 4 || window[2].ID != 2 {
+		t.Fatalf("expected entries 4..2, got %+v", window)
+	}
+	if repo.listLimit != 3 {
+		t.Fatalf("expected the window size passed as the limit, got %d", repo.listLimit)
+	}
+}
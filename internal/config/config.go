@@ -6,9 +6,17 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
-const defaultAPIBaseURL = "https://api.feedbin.com/v2"
+const (
+	defaultAPIBaseURL      = "https://api.feedbin.com/v2"
+	defaultArticleMaxLines = 5000
+	defaultSyncPages       = 10
+	defaultSyncConcurrency = 4
+	maxSyncConcurrency     = 8
+	minRefreshInterval     = time.Minute
+)
 
 // Config holds runtime settings for the CLI app.
 type Config struct {
@@ -18,12 +26,181 @@ type Config struct {
 	DBPath     string
 	SearchMode string
 
-	ArticleStyleLinks   bool
-	ArticlePostprocess  bool
-	ArticleImageModeRaw string
+	ArticleStyleLinks       bool
+	ArticlePostprocess      bool
+	ArticleImageModeRaw     string
+	ArticleMaxLines         int
+	ArticleASCIIPunctuation bool
+	// ArticleFooter appends a source attribution line to articles and
+	// Markdown exports.
+	ArticleFooter bool
+	// ArticleLineBreaks is "auto", "words" or "characters".
+	ArticleLineBreaks string
+	// ArticleBlankLines is "collapse", "pre" or "all": which runs of blank
+	// lines in an article are kept.
+	ArticleBlankLines string
+	// ArticleRTL right-aligns and marks articles written mostly in a
+	// right-to-left script.
+	ArticleRTL  bool
+	Hyperlinks  bool
+	ShortenURLs bool
+	// CommentCounts shows comment counts parsed from discussion feeds.
+	CommentCounts bool
+
+	DefaultFolder string
+	// AutoCollapse and AutoExpand name folders and feeds that start
+	// collapsed or expanded in the list.
+	AutoCollapse []string
+	AutoExpand   []string
+	// FolderResolution picks which tag of a multi-tag feed is its folder:
+	// "alphabetical", "first" (Feedbin's tagging order) or "priority".
+	FolderResolution string
+	// FolderPriority lists tag names, most preferred first, for the
+	// "priority" FolderResolution.
+	FolderPriority []string
+	// TreeFeedLimit shows at most this many feeds per folder and in the
+	// Feeds section; zero shows every feed.
+	TreeFeedLimit int
+	// TreeFeedOrder is "name" or "unread": which feeds TreeFeedLimit keeps.
+	TreeFeedOrder string
+	// ArticleIndent indents the article view this many columns further from
+	// the left edge.
+	ArticleIndent int
+	// StackedListWidth is the terminal width below which list entries put
+	// the feed and date on a second line under the title; zero disables it.
+	StackedListWidth int
+	// InitRefreshRetries is how many times a failed startup refresh is
+	// retried, with a doubling delay, before working offline from the cache.
+	InitRefreshRetries int
+	// MaxEntriesInMemory caps the entries the TUI holds at once, reading the
+	// cache in windows around the cursor; zero holds every loaded entry.
+	MaxEntriesInMemory int
+	// MaxRequests caps the Feedbin API requests of one session; once spent
+	// the app works from the cache only. Zero is unlimited.
+	MaxRequests int
+	// MuteRules are the lines of the FEEDBIN_MUTE_KEYWORDS file: substrings
+	// or /regex/ patterns hiding matching entries from the list.
+	MuteRules []string
+
+	SyncPages       int
+	SyncConcurrency int
+	WarmOnFirstRun  bool
+
+	StateFile string
+	// LogFile receives warnings logged while the UI is running.
+	LogFile string
+	// PostSyncCmd is a shell command run after each successful sync.
+	PostSyncCmd string
+
+	AutoOpenFirstUnread bool
+	// AutoNextFeed continues into the next unread feed at the end of a feed
+	// in the detail view without asking.
+	AutoNextFeed bool
+	// InfiniteScroll loads the next page when the list cursor nears the
+	// last entry.
+	InfiniteScroll bool
+	// EscClearsSearch lets esc in the list clear an active search, as
+	// ctrl+l does.
+	EscClearsSearch bool
+	// StickySearch keeps a committed search focused, so letter keys continue
+	// the query instead of running list actions until esc.
+	StickySearch bool
+	// SearchExpand opens the collapsed sections, folders and feeds holding
+	// search matches, restoring their collapse state when the search clears.
+	SearchExpand bool
+	// PreferCanonicalURL opens and copies an entry's canonical URL instead
+	// of an AMP or feed-proxy URL.
+	PreferCanonicalURL bool
+
+	// Offline reads only the local cache and queues read/star changes.
+	Offline bool
+
+	Timezone string
+
+	// OpenURLMode is "auto", "browser" or "copy".
+	OpenURLMode string
+
+	// RepeatRefresh is "ignore" or "queue": what r does while a refresh runs.
+	RepeatRefresh string
+	// ReadAdvance is "next" or "previous": which unread article the list
+	// cursor moves to when the one marked read leaves the unread view.
+	ReadAdvance string
+	// DetailBoundary is "stop", "wrap" or "exit": what [ and ] do in the
+	// detail view on the first and last entry.
+	DetailBoundary string
+	// KeepScrollPosition keeps the selected entry on the same screen row
+	// across a refresh instead of recentering the list.
+	KeepScrollPosition bool
+	// MaxAgeDays limits the all and unread views to entries published in
+	// the last N days; zero shows every age.
+	MaxAgeDays int
+	// RefreshInterval refreshes in the background while the UI runs; zero
+	// turns auto-refresh off.
+	RefreshInterval time.Duration
+	// Bell rings the terminal bell, or runs BellCmd when set, when an
+	// auto-refresh brings in new entries.
+	Bell    bool
+	BellCmd string
+	// QuietHours is a daily "HH:MM-HH:MM" window, which may cross
+	// midnight, without auto-refreshes or bells.
+	QuietHours string
+
+	// ReadStyle is "dim", "normal" or "hidden": how read entries are shown.
+	ReadStyle string
+	// UnreadStyle is "bold", "color", "both" or "plain": how unread titles
+	// stand out.
+	UnreadStyle string
 }
 
 func LoadFromEnv() (Config, error) {
+	articleMaxLines, err := parseEnvIntWithDefault("FEEDBIN_ARTICLE_MAX_LINES", defaultArticleMaxLines)
+	if err != nil {
+		return Config{}, err
+	}
+	syncPages, err := parseEnvIntWithDefault("FEEDBIN_SYNC_PAGES", defaultSyncPages)
+	if err != nil {
+		return Config{}, err
+	}
+	syncConcurrency, err := parseEnvIntWithDefault("FEEDBIN_SYNC_CONCURRENCY", defaultSyncConcurrency)
+	if err != nil {
+		return Config{}, err
+	}
+	maxAgeDays, err := parseEnvIntWithDefault("FEEDBIN_MAX_AGE_DAYS", 0)
+	if err != nil {
+		return Config{}, err
+	}
+	treeFeedLimit, err := parseEnvIntWithDefault("FEEDBIN_TREE_FEED_LIMIT", 0)
+	if err != nil {
+		return Config{}, err
+	}
+	initRefreshRetries, err := parseEnvIntWithDefault("FEEDBIN_INIT_REFRESH_RETRIES", 3)
+	if err != nil {
+		return Config{}, err
+	}
+	maxEntriesInMemory, err := parseEnvIntWithDefault("FEEDBIN_MAX_ENTRIES_IN_MEMORY", 0)
+	if err != nil {
+		return Config{}, err
+	}
+	maxRequests, err := parseEnvIntWithDefault("FEEDBIN_MAX_REQUESTS", 0)
+	if err != nil {
+		return Config{}, err
+	}
+	articleIndent, err := parseEnvIntWithDefault("FEEDBIN_ARTICLE_INDENT", 0)
+	if err != nil {
+		return Config{}, err
+	}
+	stackedListWidth, err := parseEnvIntWithDefault("FEEDBIN_STACKED_LIST_WIDTH", 40)
+	if err != nil {
+		return Config{}, err
+	}
+	refreshInterval, err := parseEnvDurationWithDefault("FEEDBIN_REFRESH_INTERVAL", 0)
+	if err != nil {
+		return Config{}, err
+	}
+	muteRules, err := readRuleFile("FEEDBIN_MUTE_KEYWORDS")
+	if err != nil {
+		return Config{}, err
+	}
 	cfg := Config{
 		Email:              os.Getenv("FEEDBIN_EMAIL"),
 		Password:           os.Getenv("FEEDBIN_PASSWORD"),
@@ -35,6 +212,55 @@ func LoadFromEnv() (Config, error) {
 		ArticleImageModeRaw: strings.ToLower(strings.TrimSpace(
 			os.Getenv("FEEDBIN_ARTICLE_IMAGE_MODE"),
 		)),
+		ArticleMaxLines:         articleMaxLines,
+		ArticleASCIIPunctuation: parseEnvBoolWithDefault("FEEDBIN_ARTICLE_ASCII_PUNCTUATION", false),
+		ArticleFooter:           parseEnvBoolWithDefault("FEEDBIN_ARTICLE_FOOTER", false),
+		ArticleLineBreaks:       strings.ToLower(strings.TrimSpace(os.Getenv("FEEDBIN_ARTICLE_LINE_BREAKS"))),
+		ArticleRTL:              parseEnvBoolWithDefault("FEEDBIN_ARTICLE_RTL", true),
+		ArticleBlankLines:       strings.ToLower(strings.TrimSpace(os.Getenv("FEEDBIN_ARTICLE_BLANK_LINES"))),
+		Hyperlinks:              parseEnvBoolWithDefault("FEEDBIN_HYPERLINKS", false),
+		ShortenURLs:             parseEnvBoolWithDefault("FEEDBIN_SHORTEN_URLS", true),
+		CommentCounts:           parseEnvBoolWithDefault("FEEDBIN_COMMENT_COUNTS", false),
+		DefaultFolder:           strings.TrimSpace(os.Getenv("FEEDBIN_DEFAULT_FOLDER")),
+		AutoCollapse:            parseEnvList("FEEDBIN_AUTO_COLLAPSE"),
+		AutoExpand:              parseEnvList("FEEDBIN_AUTO_EXPAND"),
+		FolderResolution:        strings.ToLower(strings.TrimSpace(os.Getenv("FEEDBIN_FOLDER_RESOLUTION"))),
+		FolderPriority:          parseEnvList("FEEDBIN_FOLDER_PRIORITY"),
+		TreeFeedLimit:           treeFeedLimit,
+		TreeFeedOrder:           strings.ToLower(strings.TrimSpace(os.Getenv("FEEDBIN_TREE_FEED_ORDER"))),
+		ArticleIndent:           articleIndent,
+		StackedListWidth:        stackedListWidth,
+		InitRefreshRetries:      initRefreshRetries,
+		MaxEntriesInMemory:      maxEntriesInMemory,
+		MaxRequests:             maxRequests,
+		MuteRules:               muteRules,
+		SyncPages:               syncPages,
+		SyncConcurrency:         syncConcurrency,
+		WarmOnFirstRun:          parseEnvBoolWithDefault("FEEDBIN_WARM_ON_FIRST_RUN", false),
+		StateFile:               strings.TrimSpace(os.Getenv("FEEDBIN_STATE_FILE")),
+		LogFile:                 strings.TrimSpace(os.Getenv("FEEDBIN_LOG_FILE")),
+		PostSyncCmd:             strings.TrimSpace(os.Getenv("FEEDBIN_POST_SYNC_CMD")),
+		AutoOpenFirstUnread:     parseEnvBoolWithDefault("FEEDBIN_AUTO_OPEN_FIRST_UNREAD", false),
+		AutoNextFeed:            parseEnvBoolWithDefault("FEEDBIN_AUTO_NEXT_FEED", false),
+		InfiniteScroll:          parseEnvBoolWithDefault("FEEDBIN_INFINITE_SCROLL", false),
+		EscClearsSearch:         parseEnvBoolWithDefault("FEEDBIN_ESC_CLEARS_SEARCH", false),
+		StickySearch:            parseEnvBoolWithDefault("FEEDBIN_STICKY_SEARCH", false),
+		SearchExpand:            parseEnvBoolWithDefault("FEEDBIN_SEARCH_EXPAND", false),
+		PreferCanonicalURL:      parseEnvBoolWithDefault("FEEDBIN_PREFER_CANONICAL_URL", false),
+		Offline:                 parseEnvBoolWithDefault("FEEDBIN_OFFLINE", false),
+		Timezone:                strings.TrimSpace(os.Getenv("FEEDBIN_TIMEZONE")),
+		OpenURLMode:             strings.ToLower(strings.TrimSpace(os.Getenv("FEEDBIN_OPEN_URL_MODE"))),
+		RepeatRefresh:           strings.ToLower(strings.TrimSpace(os.Getenv("FEEDBIN_REPEAT_REFRESH"))),
+		ReadAdvance:             strings.ToLower(strings.TrimSpace(os.Getenv("FEEDBIN_READ_ADVANCE"))),
+		DetailBoundary:          strings.ToLower(strings.TrimSpace(os.Getenv("FEEDBIN_DETAIL_BOUNDARY"))),
+		RefreshInterval:         refreshInterval,
+		KeepScrollPosition:      parseEnvBoolWithDefault("FEEDBIN_KEEP_SCROLL_POSITION", false),
+		MaxAgeDays:              maxAgeDays,
+		Bell:                    parseEnvBoolWithDefault("FEEDBIN_BELL", false),
+		BellCmd:                 strings.TrimSpace(os.Getenv("FEEDBIN_BELL_CMD")),
+		QuietHours:              strings.TrimSpace(os.Getenv("FEEDBIN_QUIET_HOURS")),
+		ReadStyle:               strings.ToLower(strings.TrimSpace(os.Getenv("FEEDBIN_READ_STYLE"))),
+		UnreadStyle:             strings.ToLower(strings.TrimSpace(os.Getenv("FEEDBIN_UNREAD_STYLE"))),
 	}
 
 	if cfg.APIBaseURL == "" {
@@ -49,6 +275,39 @@ func LoadFromEnv() (Config, error) {
 	if cfg.ArticleImageModeRaw == "" {
 		cfg.ArticleImageModeRaw = "label"
 	}
+	if cfg.OpenURLMode == "" {
+		cfg.OpenURLMode = "auto"
+	}
+	if cfg.RepeatRefresh == "" {
+		cfg.RepeatRefresh = "ignore"
+	}
+	if cfg.ReadAdvance == "" {
+		cfg.ReadAdvance = "next"
+	}
+	if cfg.DetailBoundary == "" {
+		cfg.DetailBoundary = "stop"
+	}
+	if cfg.ReadStyle == "" {
+		cfg.ReadStyle = "dim"
+	}
+	if cfg.UnreadStyle == "" {
+		cfg.UnreadStyle = "bold"
+	}
+	if cfg.TreeFeedOrder == "" {
+		cfg.TreeFeedOrder = "name"
+	}
+	if cfg.ArticleLineBreaks == "" {
+		cfg.ArticleLineBreaks = "auto"
+	}
+	if cfg.ArticleBlankLines == "" {
+		cfg.ArticleBlankLines = "collapse"
+	}
+	if cfg.FolderResolution == "" {
+		cfg.FolderResolution = "alphabetical"
+		if len(cfg.FolderPriority) > 0 {
+			cfg.FolderResolution = "priority"
+		}
+	}
 
 	if err := cfg.Validate(); err != nil {
 		return Config{}, err
@@ -76,12 +335,100 @@ func (c Config) Validate() error {
 	if c.ArticleImageModeRaw != "label" && c.ArticleImageModeRaw != "none" {
 		return fmt.Errorf("FEEDBIN_ARTICLE_IMAGE_MODE must be label or none: %s", c.ArticleImageModeRaw)
 	}
+	if c.ArticleMaxLines < 0 {
+		return fmt.Errorf("FEEDBIN_ARTICLE_MAX_LINES must be >= 0: %d", c.ArticleMaxLines)
+	}
+	if c.MaxAgeDays < 0 {
+		return fmt.Errorf("FEEDBIN_MAX_AGE_DAYS must be >= 0: %d", c.MaxAgeDays)
+	}
+	if c.TreeFeedLimit < 0 {
+		return fmt.Errorf("FEEDBIN_TREE_FEED_LIMIT must be >= 0: %d", c.TreeFeedLimit)
+	}
+	if c.StackedListWidth < 0 {
+		return fmt.Errorf("FEEDBIN_STACKED_LIST_WIDTH must be >= 0: %d", c.StackedListWidth)
+	}
+	if c.ArticleIndent < 0 || c.ArticleIndent > 20 {
+		return fmt.Errorf("FEEDBIN_ARTICLE_INDENT must be between 0 and 20: %d", c.ArticleIndent)
+	}
+	if c.InitRefreshRetries < 0 {
+		return fmt.Errorf("FEEDBIN_INIT_REFRESH_RETRIES must be >= 0: %d", c.InitRefreshRetries)
+	}
+	if c.MaxRequests < 0 {
+		return fmt.Errorf("FEEDBIN_MAX_REQUESTS must be >= 0: %d", c.MaxRequests)
+	}
+	if c.MaxEntriesInMemory != 0 && c.MaxEntriesInMemory < 100 {
+		return fmt.Errorf("FEEDBIN_MAX_ENTRIES_IN_MEMORY must be 0 or at least 100: %d", c.MaxEntriesInMemory)
+	}
+	switch c.FolderResolution {
+	case "", "alphabetical", "first":
+	case "priority":
+		if len(c.FolderPriority) == 0 {
+			return errors.New("FEEDBIN_FOLDER_RESOLUTION=priority requires FEEDBIN_FOLDER_PRIORITY")
+		}
+	default:
+		return fmt.Errorf("FEEDBIN_FOLDER_RESOLUTION must be alphabetical, first or priority: %s", c.FolderResolution)
+	}
+	if c.TreeFeedOrder != "" && c.TreeFeedOrder != "name" && c.TreeFeedOrder != "unread" {
+		return fmt.Errorf("FEEDBIN_TREE_FEED_ORDER must be name or unread: %s", c.TreeFeedOrder)
+	}
+	if c.SyncPages < 1 {
+		return fmt.Errorf("FEEDBIN_SYNC_PAGES must be >= 1: %d", c.SyncPages)
+	}
+	if c.SyncConcurrency < 1 || c.SyncConcurrency > maxSyncConcurrency {
+		return fmt.Errorf("FEEDBIN_SYNC_CONCURRENCY must be between 1 and %d: %d", maxSyncConcurrency, c.SyncConcurrency)
+	}
+	if c.OpenURLMode != "" && c.OpenURLMode != "auto" && c.OpenURLMode != "browser" && c.OpenURLMode != "copy" {
+		return fmt.Errorf("FEEDBIN_OPEN_URL_MODE must be auto, browser or copy: %s", c.OpenURLMode)
+	}
+	if c.RepeatRefresh != "" && c.RepeatRefresh != "ignore" && c.RepeatRefresh != "queue" {
+		return fmt.Errorf("FEEDBIN_REPEAT_REFRESH must be ignore or queue: %s", c.RepeatRefresh)
+	}
+	if c.ReadAdvance != "" && c.ReadAdvance != "next" && c.ReadAdvance != "previous" {
+		return fmt.Errorf("FEEDBIN_READ_ADVANCE must be next or previous: %s", c.ReadAdvance)
+	}
+	if c.DetailBoundary != "" && c.DetailBoundary != "stop" && c.DetailBoundary != "wrap" && c.DetailBoundary != "exit" {
+		return fmt.Errorf("FEEDBIN_DETAIL_BOUNDARY must be stop, wrap or exit: %s", c.DetailBoundary)
+	}
+	if c.RefreshInterval != 0 && c.RefreshInterval < minRefreshInterval {
+		return fmt.Errorf("FEEDBIN_REFRESH_INTERVAL must be 0 or at least %s: %s", minRefreshInterval, c.RefreshInterval)
+	}
+	if c.ReadStyle != "" && c.ReadStyle != "dim" && c.ReadStyle != "normal" && c.ReadStyle != "hidden" {
+		return fmt.Errorf("FEEDBIN_READ_STYLE must be dim, normal or hidden: %s", c.ReadStyle)
+	}
+	if c.UnreadStyle != "" && c.UnreadStyle != "bold" && c.UnreadStyle != "color" && c.UnreadStyle != "both" && c.UnreadStyle != "plain" {
+		return fmt.Errorf("FEEDBIN_UNREAD_STYLE must be bold, color, both or plain: %s", c.UnreadStyle)
+	}
+	if c.ArticleLineBreaks != "" && c.ArticleLineBreaks != "auto" && c.ArticleLineBreaks != "words" && c.ArticleLineBreaks != "characters" {
+		return fmt.Errorf("FEEDBIN_ARTICLE_LINE_BREAKS must be auto, words or characters: %s", c.ArticleLineBreaks)
+	}
+	if c.ArticleBlankLines != "" && c.ArticleBlankLines != "collapse" && c.ArticleBlankLines != "pre" && c.ArticleBlankLines != "all" {
+		return fmt.Errorf("FEEDBIN_ARTICLE_BLANK_LINES must be collapse, pre or all: %s", c.ArticleBlankLines)
+	}
+	if c.Timezone != "" {
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			return fmt.Errorf("FEEDBIN_TIMEZONE must be an IANA timezone name like Europe/Madrid: %s", c.Timezone)
+		}
+	}
 	if c.APIBaseURL[len(c.APIBaseURL)-1] == '/' {
 		return fmt.Errorf("APIBaseURL must not end with '/': %s", c.APIBaseURL)
 	}
 	return nil
 }
 
+// Location returns the zone dates are displayed in: the configured
+// Timezone, else the system local zone, else UTC.
+func (c Config) Location() *time.Location {
+	if c.Timezone != "" {
+		if loc, err := time.LoadLocation(c.Timezone); err == nil {
+			return loc
+		}
+	}
+	if time.Local != nil {
+		return time.Local
+	}
+	return time.UTC
+}
+
 func parseEnvBoolWithDefault(name string, fallback bool) bool {
 	v := strings.TrimSpace(os.Getenv(name))
 	if v == "" {
@@ -93,3 +440,62 @@ func parseEnvBoolWithDefault(name string, fallback bool) bool {
 	}
 	return ok
 }
+
+// parseEnvList splits a comma-separated variable, dropping empty items.
+func parseEnvList(name string) []string {
+	var out []string
+	for _, item := range strings.Split(os.Getenv(name), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// readRuleFile returns the non-blank lines of the file the variable names,
+// skipping "#" comments; an unset variable yields no rules.
+func readRuleFile(name string) ([]string, error) {
+	path := strings.TrimSpace(os.Getenv(name))
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	var rules []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rules = append(rules, line)
+	}
+	return rules, nil
+}
+
+// parseEnvDurationWithDefault reads a Go duration such as "5m"; a bare "0"
+// is accepted too.
+func parseEnvDurationWithDefault(name string, fallback time.Duration) (time.Duration, error) {
+	v := strings.TrimSpace(os.Getenv(name))
+	if v == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a duration like 5m: %s", name, v)
+	}
+	return d, nil
+}
+
+func parseEnvIntWithDefault(name string, fallback int) (int, error) {
+	v := strings.TrimSpace(os.Getenv(name))
+	if v == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer: %s", name, v)
+	}
+	return n, nil
+}
@@ -2,7 +2,11 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadFromEnv_UsesDefaults(t *testing.T) {
@@ -34,6 +38,471 @@ func TestLoadFromEnv_UsesDefaults(t *testing.T) {
 	if cfg.ArticleImageModeRaw != "label" {
 		t.Fatalf("unexpected article image mode: %s", cfg.ArticleImageModeRaw)
 	}
+	if cfg.ArticleASCIIPunctuation {
+		t.Fatal("expected ASCII punctuation disabled by default")
+	}
+	if cfg.ArticleFooter {
+		t.Fatal("expected article footer disabled by default")
+	}
+	if cfg.ArticleMaxLines != defaultArticleMaxLines {
+		t.Fatalf("unexpected article max lines: %d", cfg.ArticleMaxLines)
+	}
+	if cfg.DefaultFolder != "" {
+		t.Fatalf("expected no default folder, got %q", cfg.DefaultFolder)
+	}
+	if cfg.SyncPages != defaultSyncPages || cfg.SyncConcurrency != defaultSyncConcurrency || cfg.WarmOnFirstRun {
+		t.Fatalf("unexpected sync defaults: pages=%d concurrency=%d warm=%v", cfg.SyncPages, cfg.SyncConcurrency, cfg.WarmOnFirstRun)
+	}
+	if cfg.StateFile != "" {
+		t.Fatalf("expected no state file, got %q", cfg.StateFile)
+	}
+	if cfg.AutoOpenFirstUnread {
+		t.Fatal("expected auto-open of first unread disabled by default")
+	}
+	if cfg.Hyperlinks {
+		t.Fatal("expected hyperlinks disabled by default")
+	}
+	if cfg.RepeatRefresh != "ignore" {
+		t.Fatalf("expected repeated refreshes ignored by default, got %q", cfg.RepeatRefresh)
+	}
+	if cfg.KeepScrollPosition {
+		t.Fatal("expected refreshes to recenter the list by default")
+	}
+	if cfg.InfiniteScroll {
+		t.Fatal("expected infinite scroll disabled by default")
+	}
+	if cfg.EscClearsSearch {
+		t.Fatal("expected esc to leave searches alone by default")
+	}
+	if cfg.StickySearch {
+		t.Fatal("expected search focus to end when a search is committed by default")
+	}
+	if cfg.SearchExpand {
+		t.Fatal("expected searches to keep the collapse state by default")
+	}
+	if cfg.PreferCanonicalURL {
+		t.Fatal("expected entry URLs opened as-is by default")
+	}
+	if cfg.ReadStyle != "dim" || cfg.UnreadStyle != "bold" {
+		t.Fatalf("expected dim read and bold unread titles by default, got %q/%q", cfg.ReadStyle, cfg.UnreadStyle)
+	}
+	if !cfg.ShortenURLs {
+		t.Fatal("expected URL shortening enabled by default")
+	}
+	if cfg.CommentCounts {
+		t.Fatal("expected comment counts hidden by default")
+	}
+	if cfg.OpenURLMode != "auto" {
+		t.Fatalf("expected auto open-URL mode by default, got %q", cfg.OpenURLMode)
+	}
+	if cfg.Offline {
+		t.Fatal("expected offline mode disabled by default")
+	}
+	if cfg.ArticleLineBreaks != "auto" {
+		t.Fatalf("expected auto article line breaks by default, got %q", cfg.ArticleLineBreaks)
+	}
+	if cfg.ReadAdvance != "next" {
+		t.Fatalf("expected the cursor to advance to the next unread entry by default, got %q", cfg.ReadAdvance)
+	}
+	if cfg.DetailBoundary != "stop" {
+		t.Fatalf("expected [ and ] to stop at the ends by default, got %q", cfg.DetailBoundary)
+	}
+	if cfg.ArticleBlankLines != "collapse" {
+		t.Fatalf("expected blank lines collapsed by default, got %q", cfg.ArticleBlankLines)
+	}
+	if !cfg.ArticleRTL {
+		t.Fatal("expected right-to-left articles aligned by default")
+	}
+	if cfg.Timezone != "" || cfg.Location() != time.Local {
+		t.Fatalf("expected local timezone by default, got %q", cfg.Timezone)
+	}
+}
+
+func TestLoadFromEnv_AutoCollapseLists(t *testing.T) {
+	t.Setenv("FEEDBIN_EMAIL", "user@example.com")
+	t.Setenv("FEEDBIN_PASSWORD", "secret")
+	t.Setenv("FEEDBIN_AUTO_COLLAPSE", " News, ,Formula 1 ")
+	t.Setenv("FEEDBIN_AUTO_EXPAND", "Go Blog")
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv returned error: %v", err)
+	}
+	if len(cfg.AutoCollapse) != 2 || cfg.AutoCollapse[0] != "News" || cfg.AutoCollapse[1] != "Formula 1" {
+		t.Fatalf("unexpected auto-collapse list: %q", cfg.AutoCollapse)
+	}
+	if len(cfg.AutoExpand) != 1 || cfg.AutoExpand[0] != "Go Blog" {
+		t.Fatalf("unexpected auto-expand list: %q", cfg.AutoExpand)
+	}
+}
+
+func TestValidate_OpenURLMode(t *testing.T) {
+	cfg := Config{Email: "user@example.com", Password: "secret", APIBaseURL: "https://api.feedbin.com/v2", DBPath: "feedbin.db", SearchMode: "like", ArticleImageModeRaw: "label", SyncPages: 1, SyncConcurrency: 1}
+	for _, mode := range []string{"auto", "browser", "copy"} {
+		cfg.OpenURLMode = mode
+		if err := cfg.Validate(); err != nil {
+			t.Fatalf("expected %q to be valid, got %v", mode, err)
+		}
+	}
+	cfg.OpenURLMode = "lynx"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "FEEDBIN_OPEN_URL_MODE") {
+		t.Fatalf("expected open-URL mode error, got %v", err)
+	}
+}
+
+func TestLoadFromEnv_Timezone(t *testing.T) {
+	t.Setenv("FEEDBIN_EMAIL", "user@example.com")
+	t.Setenv("FEEDBIN_PASSWORD", "secret")
+	t.Setenv("FEEDBIN_TIMEZONE", "America/Argentina/Buenos_Aires")
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv returned error: %v", err)
+	}
+	if got := cfg.Location().String(); got != "America/Argentina/Buenos_Aires" {
+		t.Fatalf("unexpected location: %s", got)
+	}
+
+	t.Setenv("FEEDBIN_TIMEZONE", "Mars/Olympus_Mons")
+	_, err = LoadFromEnv()
+	if err == nil || !strings.Contains(err.Error(), "FEEDBIN_TIMEZONE") {
+		t.Fatalf("expected FEEDBIN_TIMEZONE error for invalid name, got %v", err)
+	}
+}
+
+func TestLoadFromEnv_SyncConcurrency(t *testing.T) {
+	t.Setenv("FEEDBIN_EMAIL", "user@example.com")
+	t.Setenv("FEEDBIN_PASSWORD", "secret")
+	t.Setenv("FEEDBIN_SYNC_CONCURRENCY", "6")
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv returned error: %v", err)
+	}
+	if cfg.SyncConcurrency != 6 {
+		t.Fatalf("unexpected sync concurrency: %d", cfg.SyncConcurrency)
+	}
+
+	t.Setenv("FEEDBIN_SYNC_CONCURRENCY", "32")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Fatal("expected error for sync concurrency above the cap")
+	}
+}
+
+func TestLoadFromEnv_RefreshIntervalAndBell(t *testing.T) {
+	t.Setenv("FEEDBIN_EMAIL", "user@example.com")
+	t.Setenv("FEEDBIN_PASSWORD", "secret")
+	t.Setenv("FEEDBIN_REFRESH_INTERVAL", "5m")
+	t.Setenv("FEEDBIN_BELL", "1")
+	t.Setenv("FEEDBIN_BELL_CMD", " notify-send reeder ")
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv returned error: %v", err)
+	}
+	if cfg.RefreshInterval != 5*time.Minute || !cfg.Bell || cfg.BellCmd != "notify-send reeder" {
+		t.Fatalf("unexpected refresh/bell config: interval=%s bell=%v cmd=%q", cfg.RefreshInterval, cfg.Bell, cfg.BellCmd)
+	}
+
+	for _, value := range []string{"soon", "10s"} {
+		t.Setenv("FEEDBIN_REFRESH_INTERVAL", value)
+		if _, err := LoadFromEnv(); err == nil || !strings.Contains(err.Error(), "FEEDBIN_REFRESH_INTERVAL") {
+			t.Fatalf("expected FEEDBIN_REFRESH_INTERVAL error for %q, got %v", value, err)
+		}
+	}
+}
+
+func TestLoadFromEnv_ArticleMaxLines(t *testing.T) {
+	t.Setenv("FEEDBIN_EMAIL", "user@example.com")
+	t.Setenv("FEEDBIN_PASSWORD", "secret")
+	t.Setenv("FEEDBIN_ARTICLE_MAX_LINES", "250")
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv returned error: %v", err)
+	}
+	if cfg.ArticleMaxLines != 250 {
+		t.Fatalf("unexpected article max lines: %d", cfg.ArticleMaxLines)
+	}
+
+	t.Setenv("FEEDBIN_ARTICLE_MAX_LINES", "lots")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Fatal("expected error for non-integer article max lines")
+	}
+
+	t.Setenv("FEEDBIN_ARTICLE_MAX_LINES", "-1")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Fatal("expected error for negative article max lines")
+	}
+}
+
+func TestLoadFromEnv_MaxAgeDays(t *testing.T) {
+	t.Setenv("FEEDBIN_EMAIL", "user@example.com")
+	t.Setenv("FEEDBIN_PASSWORD", "secret")
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv returned error: %v", err)
+	}
+	if cfg.MaxAgeDays != 0 {
+		t.Fatalf("expected no age cutoff by default, got %d", cfg.MaxAgeDays)
+	}
+
+	t.Setenv("FEEDBIN_MAX_AGE_DAYS", "14")
+	if cfg, err = LoadFromEnv(); err != nil || cfg.MaxAgeDays != 14 {
+		t.Fatalf("expected 14 days, got %d (err %v)", cfg.MaxAgeDays, err)
+	}
+
+	t.Setenv("FEEDBIN_MAX_AGE_DAYS", "-3")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Fatal("expected error for a negative age cutoff")
+	}
+}
+
+func TestLoadFromEnv_TreeFeedLimit(t *testing.T) {
+	t.Setenv("FEEDBIN_EMAIL", "user@example.com")
+	t.Setenv("FEEDBIN_PASSWORD", "secret")
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv returned error: %v", err)
+	}
+	if cfg.TreeFeedLimit != 0 || cfg.TreeFeedOrder != "name" {
+		t.Fatalf("expected every feed by name by default, got %d %q", cfg.TreeFeedLimit, cfg.TreeFeedOrder)
+	}
+
+	t.Setenv("FEEDBIN_TREE_FEED_LIMIT", "25")
+	t.Setenv("FEEDBIN_TREE_FEED_ORDER", "Unread")
+	if cfg, err = LoadFromEnv(); err != nil || cfg.TreeFeedLimit != 25 || cfg.TreeFeedOrder != "unread" {
+		t.Fatalf("expected 25 feeds by unread, got %d %q (err %v)", cfg.TreeFeedLimit, cfg.TreeFeedOrder, err)
+	}
+
+	t.Setenv("FEEDBIN_TREE_FEED_ORDER", "newest")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Fatal("expected error for an unknown feed order")
+	}
+	t.Setenv("FEEDBIN_TREE_FEED_ORDER", "")
+	t.Setenv("FEEDBIN_TREE_FEED_LIMIT", "-1")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Fatal("expected error for a negative feed limit")
+	}
+}
+
+func TestLoadFromEnv_ArticleBlankLines(t *testing.T) {
+	t.Setenv("FEEDBIN_EMAIL", "user@example.com")
+	t.Setenv("FEEDBIN_PASSWORD", "secret")
+
+	t.Setenv("FEEDBIN_ARTICLE_BLANK_LINES", "Pre")
+	if cfg, err := LoadFromEnv(); err != nil || cfg.ArticleBlankLines != "pre" {
+		t.Fatalf("expected pre blank lines, got %q (err %v)", cfg.ArticleBlankLines, err)
+	}
+	t.Setenv("FEEDBIN_ARTICLE_BLANK_LINES", "keep")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Fatal("expected error for an unknown blank-line mode")
+	}
+}
+
+func TestLoadFromEnv_ReadAdvance(t *testing.T) {
+	t.Setenv("FEEDBIN_EMAIL", "user@example.com")
+	t.Setenv("FEEDBIN_PASSWORD", "secret")
+
+	t.Setenv("FEEDBIN_READ_ADVANCE", "Previous")
+	if cfg, err := LoadFromEnv(); err != nil || cfg.ReadAdvance != "previous" {
+		t.Fatalf("expected previous, got %q (err %v)", cfg.ReadAdvance, err)
+	}
+	t.Setenv("FEEDBIN_READ_ADVANCE", "stay")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Fatal("expected error for an unknown read advance")
+	}
+}
+
+func TestLoadFromEnv_DetailBoundary(t *testing.T) {
+	t.Setenv("FEEDBIN_EMAIL", "user@example.com")
+	t.Setenv("FEEDBIN_PASSWORD", "secret")
+
+	t.Setenv("FEEDBIN_DETAIL_BOUNDARY", "Wrap")
+	if cfg, err := LoadFromEnv(); err != nil || cfg.DetailBoundary != "wrap" {
+		t.Fatalf("expected wrap, got %q (err %v)", cfg.DetailBoundary, err)
+	}
+	t.Setenv("FEEDBIN_DETAIL_BOUNDARY", "bounce")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Fatal("expected error for an unknown detail boundary")
+	}
+}
+
+func TestLoadFromEnv_StackedListWidth(t *testing.T) {
+	t.Setenv("FEEDBIN_EMAIL", "user@example.com")
+	t.Setenv("FEEDBIN_PASSWORD", "secret")
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv returned error: %v", err)
+	}
+	if cfg.StackedListWidth != 40 {
+		t.Fatalf("expected stacking below 40 columns by default, got %d", cfg.StackedListWidth)
+	}
+
+	t.Setenv("FEEDBIN_STACKED_LIST_WIDTH", "0")
+	if cfg, err = LoadFromEnv(); err != nil || cfg.StackedListWidth != 0 {
+		t.Fatalf("expected stacking to be disabled, got %d (err %v)", cfg.StackedListWidth, err)
+	}
+	t.Setenv("FEEDBIN_STACKED_LIST_WIDTH", "-1")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Fatal("expected error for a negative width")
+	}
+}
+
+func TestLoadFromEnv_ArticleIndent(t *testing.T) {
+	t.Setenv("FEEDBIN_EMAIL", "user@example.com")
+	t.Setenv("FEEDBIN_PASSWORD", "secret")
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv returned error: %v", err)
+	}
+	if cfg.ArticleIndent != 0 {
+		t.Fatalf("expected no indent by default, got %d", cfg.ArticleIndent)
+	}
+
+	t.Setenv("FEEDBIN_ARTICLE_INDENT", "4")
+	if cfg, err = LoadFromEnv(); err != nil || cfg.ArticleIndent != 4 {
+		t.Fatalf("expected an indent of 4, got %d (err %v)", cfg.ArticleIndent, err)
+	}
+	for _, value := range []string{"-1", "21"} {
+		t.Setenv("FEEDBIN_ARTICLE_INDENT", value)
+		if _, err := LoadFromEnv(); err == nil {
+			t.Fatalf("expected error for indent %s", value)
+		}
+	}
+}
+
+func TestLoadFromEnv_FolderResolution(t *testing.T) {
+	t.Setenv("FEEDBIN_EMAIL", "user@example.com")
+	t.Setenv("FEEDBIN_PASSWORD", "secret")
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv returned error: %v", err)
+	}
+	if cfg.FolderResolution != "alphabetical" {
+		t.Fatalf("expected alphabetical by default, got %q", cfg.FolderResolution)
+	}
+
+	t.Setenv("FEEDBIN_FOLDER_PRIORITY", "Formula 1, Sports")
+	if cfg, err = LoadFromEnv(); err != nil || cfg.FolderResolution != "priority" || !slices.Equal(cfg.FolderPriority, []string{"Formula 1", "Sports"}) {
+		t.Fatalf("expected a priority list to imply priority, got %q %v (err %v)", cfg.FolderResolution, cfg.FolderPriority, err)
+	}
+	t.Setenv("FEEDBIN_FOLDER_RESOLUTION", "First")
+	if cfg, err = LoadFromEnv(); err != nil || cfg.FolderResolution != "first" {
+		t.Fatalf("expected first, got %q (err %v)", cfg.FolderResolution, err)
+	}
+
+	t.Setenv("FEEDBIN_FOLDER_PRIORITY", "")
+	t.Setenv("FEEDBIN_FOLDER_RESOLUTION", "priority")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Fatal("expected error for priority without a list")
+	}
+	t.Setenv("FEEDBIN_FOLDER_RESOLUTION", "random")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Fatal("expected error for an unknown strategy")
+	}
+}
+
+func TestLoadFromEnv_MaxEntriesInMemory(t *testing.T) {
+	t.Setenv("FEEDBIN_EMAIL", "user@example.com")
+	t.Setenv("FEEDBIN_PASSWORD", "secret")
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv returned error: %v", err)
+	}
+	if cfg.MaxEntriesInMemory != 0 {
+		t.Fatalf("expected no cap by default, got %d", cfg.MaxEntriesInMemory)
+	}
+
+	t.Setenv("FEEDBIN_MAX_ENTRIES_IN_MEMORY", "2000")
+	if cfg, err = LoadFromEnv(); err != nil || cfg.MaxEntriesInMemory != 2000 {
+		t.Fatalf("expected a cap of 2000, got %d (err %v)", cfg.MaxEntriesInMemory, err)
+	}
+	t.Setenv("FEEDBIN_MAX_ENTRIES_IN_MEMORY", "10")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Fatal("expected error for a window too small to scroll through")
+	}
+}
+
+func TestLoadFromEnv_MaxRequests(t *testing.T) {
+	t.Setenv("FEEDBIN_EMAIL", "user@example.com")
+	t.Setenv("FEEDBIN_PASSWORD", "secret")
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv returned error: %v", err)
+	}
+	if cfg.MaxRequests != 0 {
+		t.Fatalf("expected no request budget by default, got %d", cfg.MaxRequests)
+	}
+
+	t.Setenv("FEEDBIN_MAX_REQUESTS", "50")
+	if cfg, err = LoadFromEnv(); err != nil || cfg.MaxRequests != 50 {
+		t.Fatalf("expected a budget of 50, got %d (err %v)", cfg.MaxRequests, err)
+	}
+	t.Setenv("FEEDBIN_MAX_REQUESTS", "-1")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Fatal("expected error for a negative budget")
+	}
+}
+
+func TestLoadFromEnv_InitRefreshRetries(t *testing.T) {
+	t.Setenv("FEEDBIN_EMAIL", "user@example.com")
+	t.Setenv("FEEDBIN_PASSWORD", "secret")
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv returned error: %v", err)
+	}
+	if cfg.InitRefreshRetries != 3 {
+		t.Fatalf("expected 3 retries by default, got %d", cfg.InitRefreshRetries)
+	}
+
+	t.Setenv("FEEDBIN_INIT_REFRESH_RETRIES", "0")
+	if cfg, err = LoadFromEnv(); err != nil || cfg.InitRefreshRetries != 0 {
+		t.Fatalf("expected retries off, got %d (err %v)", cfg.InitRefreshRetries, err)
+	}
+	t.Setenv("FEEDBIN_INIT_REFRESH_RETRIES", "-2")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Fatal("expected error for negative retries")
+	}
+}
+
+func TestLoadFromEnv_MuteRulesFile(t *testing.T) {
+	t.Setenv("FEEDBIN_EMAIL", "user@example.com")
+	t.Setenv("FEEDBIN_PASSWORD", "secret")
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv returned error: %v", err)
+	}
+	if len(cfg.MuteRules) != 0 {
+		t.Fatalf("expected no mute rules by default, got %v", cfg.MuteRules)
+	}
+
+	path := filepath.Join(t.TempDir(), "mute.txt")
+	if err := os.WriteFile(path, []byte("# spoilers\n  season finale \n\n/\\bscore[sd]?\\b/\n"), 0o600); err != nil {
+		t.Fatalf("write rules: %v", err)
+	}
+	t.Setenv("FEEDBIN_MUTE_KEYWORDS", path)
+	cfg, err = LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv returned error: %v", err)
+	}
+	if len(cfg.MuteRules) != 2 || cfg.MuteRules[0] != "season finale" || cfg.MuteRules[1] != `/\bscore[sd]?\b/` {
+		t.Fatalf("unexpected mute rules: %q", cfg.MuteRules)
+	}
+
+	t.Setenv("FEEDBIN_MUTE_KEYWORDS", filepath.Join(t.TempDir(), "missing.txt"))
+	if _, err := LoadFromEnv(); err == nil {
+		t.Fatal("expected error for a missing rules file")
+	}
 }
 
 func TestLoadFromEnv_MissingEmail(t *testing.T) {
@@ -0,0 +1,44 @@
+package feedbin
+
+import "errors"
+
+// ErrRequestBudget is returned instead of sending a request once the
+// session's request budget, set by SetMaxRequests, is spent.
+var ErrRequestBudget = errors.New("request budget reached — cache only")
+
+// SetMaxRequests caps how many API requests the client sends in this
+// session; zero lifts the cap.
+func (c *Client) SetMaxRequests(n int) {
+	c.maxRequests = int64(max(n, 0))
+}
+
+// MaxRequests is the cap set by SetMaxRequests; zero means none.
+func (c *Client) MaxRequests() int {
+	return int(c.maxRequests)
+}
+
+// RequestCount is how many API requests the client has sent.
+func (c *Client) RequestCount() int {
+	return int(c.requests.Load())
+}
+
+// RequestBudgetSpent reports whether the budget is used up, so the next
+// request would be refused. Callers check it before sending to fall back to
+// the cache instead of failing.
+func (c *Client) RequestBudgetSpent() bool {
+	return c.maxRequests > 0 && c.requests.Load() >= c.maxRequests
+}
+
+// spendRequest counts one request against the budget, or refuses it with
+// ErrRequestBudget. Concurrent page fetches never overshoot the cap.
+func (c *Client) spendRequest() error {
+	for {
+		n := c.requests.Load()
+		if c.maxRequests > 0 && n >= c.maxRequests {
+			return ErrRequestBudget
+		}
+		if c.requests.CompareAndSwap(n, n+1) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package feedbin
+
+import (
+	"net/url"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// feedProxyHosts redirect to the article instead of hosting it; the first
+// link of the content usually is the real article.
+var feedProxyHosts = map[string]bool{
+	"feedproxy.google.com":  true,
+	"feeds.feedburner.com":  true,
+	"feedburner.google.com": true,
+}
+
+// ResolveCanonicalURL returns the article's own URL: the stored canonical
+// URL, else one resolved from the content and URL.
+func (e Entry) ResolveCanonicalURL() string {
+	if e.CanonicalURL != "" {
+		return e.CanonicalURL
+	}
+	return canonicalURL(e.Content, e.URL)
+}
+
+// canonicalURL looks past AMP and feed-proxy URLs: a <link rel="canonical">
+// in the content wins, then the de-AMPed fallback, then for proxies the first
+// link leaving the proxy host. Anything else returns fallback unchanged.
+func canonicalURL(content, fallback string) string {
+	base, err := url.Parse(strings.TrimSpace(fallback))
+	if err != nil {
+		return fallback
+	}
+	canonical, anchors := contentLinks(content)
+	for _, href := range canonical {
+		if resolved, ok := absoluteHref(base, href); ok {
+			return resolved
+		}
+	}
+	if resolved, ok := deAMP(base); ok {
+		return resolved
+	}
+	if feedProxyHosts[strings.ToLower(base.Hostname())] {
+		for _, href := range anchors {
+			resolved, ok := absoluteHref(base, href)
+			if !ok {
+				continue
+			}
+			if parsed, err := url.Parse(resolved); err == nil && !feedProxyHosts[strings.ToLower(parsed.Hostname())] {
+				return resolved
+			}
+		}
+	}
+	return fallback
+}
+
+// contentLinks returns the href of every <link rel="canonical"> and every
+// <a> in content, in document order.
+func contentLinks(content string) (canonical, anchors []string) {
+	z := nethtml.NewTokenizer(strings.NewReader(content))
+	for {
+		switch z.Next() {
+		case nethtml.ErrorToken:
+			return canonical, anchors
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			token := z.Token()
+			switch token.DataAtom {
+			case atom.Link:
+				if relCanonical(attrValue(token, "rel")) {
+					canonical = append(canonical, attrValue(token, "href"))
+				}
+			case atom.A:
+				anchors = append(anchors, attrValue(token, "href"))
+			}
+		}
+	}
+}
+
+// relCanonical reports whether a space-separated rel value holds the
+// canonical link type.
+func relCanonical(rel string) bool {
+	for _, linkType := range strings.Fields(rel) {
+		if strings.EqualFold(linkType, "canonical") {
+			return true
+		}
+	}
+	return false
+}
+
+// absoluteHref returns href resolved against base, when it is an http(s)
+// URL with a host.
+func absoluteHref(base *url.URL, href string) (string, bool) {
+	ref, err := url.Parse(href)
+	if err != nil || href == "" {
+		return "", false
+	}
+	resolved := base.ResolveReference(ref)
+	if (resolved.Scheme != "http" && resolved.Scheme != "https") || resolved.Host == "" {
+		return "", false
+	}
+	return resolved.String(), true
+}
+
+// deAMP maps an AMP URL to the regular page: Google's and the AMP cache's
+// /amp/s/ wrappers, an amp. host, an /amp path segment or an amp query flag.
+func deAMP(u *url.URL) (string, bool) {
+	host := strings.ToLower(u.Hostname())
+	if host == "google.com" || host == "www.google.com" {
+		return unwrapAMPPath(u.Path, "/amp/")
+	}
+	if strings.HasSuffix(host, ".cdn.ampproject.org") {
+		for _, prefix := range []string{"/c/", "/v/"} {
+			if resolved, ok := unwrapAMPPath(u.Path, prefix); ok {
+				return resolved, true
+			}
+		}
+		return "", false
+	}
+
+	out := *u
+	changed := false
+	if rest, ok := strings.CutPrefix(out.Host, "amp."); ok {
+		out.Host = rest
+		changed = true
+	}
+	segments := strings.Split(out.Path, "/")
+	kept := segments[:0]
+	for _, segment := range segments {
+		if strings.EqualFold(segment, "amp") {
+			changed = true
+			continue
+		}
+		kept = append(kept, segment)
+	}
+	out.Path = strings.Join(kept, "/")
+	out.RawPath = ""
+	query := out.Query()
+	for _, key := range []string{"amp", "outputType"} {
+		value, ok := query[key]
+		if !ok || (key == "outputType" && (len(value) == 0 || !strings.EqualFold(value[0], "amp"))) {
+			continue
+		}
+		query.Del(key)
+		changed = true
+	}
+	if !changed {
+		return "", false
+	}
+	out.RawQuery = query.Encode()
+	if out.Path == "" {
+		out.Path = "/"
+	}
+	return out.String(), true
+}
+
+// unwrapAMPPath turns "<prefix>s/host/path" into https://host/path and
+// "<prefix>host/path" into http://host/path.
+func unwrapAMPPath(path, prefix string) (string, bool) {
+	rest, ok := strings.CutPrefix(path, prefix)
+	if !ok || rest == "" {
+		return "", false
+	}
+	scheme := "http://"
+	if inner, ok := strings.CutPrefix(rest, "s/"); ok {
+		scheme, rest = "https://", inner
+	}
+	if strings.HasPrefix(rest, "/") || !strings.Contains(strings.SplitN(rest, "/", 2)[0], ".") {
+		return "", false
+	}
+	return scheme + rest, true
+}
@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -23,20 +25,66 @@ type Entry struct {
 	Content     string    `json:"content"`
 	FeedID      int64     `json:"feed_id"`
 	PublishedAt time.Time `json:"published"`
+	// ExtractedContentURL is Feedbin's signed link to the full text its
+	// extraction service pulls from the article page.
+	ExtractedContentURL string `json:"extracted_content_url"`
+	// CanonicalURL is the article's own URL behind an AMP or feed-proxy URL,
+	// resolved when the entry is cached.
+	CanonicalURL string `json:"-"`
 
 	FeedTitle  string `json:"-"`
 	FeedFolder string `json:"-"`
-	IsUnread   bool   `json:"-"`
-	IsStarred  bool   `json:"-"`
+	// FeedFolders lists every tag of the feed; FeedFolder is the first.
+	FeedFolders []string `json:"-"`
+	FeedURL     string   `json:"-"`
+	IsUnread    bool     `json:"-"`
+	IsStarred   bool     `json:"-"`
 }
 
 // Subscription describes the subset of feed metadata used by the app.
 type Subscription struct {
-	ID      int64  `json:"feed_id"`
-	Title   string `json:"title"`
-	FeedURL string `json:"feed_url"`
-	SiteURL string `json:"site_url"`
-	Folder  string `json:"-"`
+	ID             int64  `json:"feed_id"`
+	SubscriptionID int64  `json:"id"`
+	Title          string `json:"title"`
+	FeedURL        string `json:"feed_url"`
+	SiteURL        string `json:"site_url"`
+	Folder         string `json:"-"`
+	// Folders lists every tag of the feed: Folder first, then the others
+	// sorted case-insensitively.
+	Folders []string `json:"-"`
+}
+
+// FeedSummary is a cached subscription plus local stats, as listed by the
+// subscription manager.
+type FeedSummary struct {
+	Subscription
+	UnreadCount int
+	LastUpdated time.Time
+	Muted       bool
+}
+
+// FolderAssignment is a feed's folders after it was tagged with Folder.
+type FolderAssignment struct {
+	Folder  string
+	Folders []string
+	// Created is true when no cached feed was filed under Folder before.
+	Created bool
+}
+
+// CacheStats counts what the local cache holds, for diagnostics.
+type CacheStats struct {
+	Entries int
+	Unread  int
+	Starred int
+	Feeds   int
+}
+
+// EntryMark is the cached read/star state of one entry, as exported to a
+// portable state file.
+type EntryMark struct {
+	ID      int64 `json:"id"`
+	Unread  bool  `json:"unread"`
+	Starred bool  `json:"starred"`
 }
 
 type Tagging struct {
@@ -45,11 +93,21 @@ type Tagging struct {
 	Name   string `json:"name"`
 }
 
+const (
+	// updateChunkSize is Feedbin's limit of entry IDs per update request.
+	updateChunkSize    = 1000
+	updateMaxAttempts  = 3
+	updateRetryBackoff = 500 * time.Millisecond
+)
+
 type Client struct {
-	baseURL  string
-	email    string
-	password string
-	http     *http.Client
+	baseURL      string
+	email        string
+	password     string
+	http         *http.Client
+	retryBackoff time.Duration
+	maxRequests  int64
+	requests     atomic.Int64
 }
 
 func NewClient(baseURL, email, password string, httpClient *http.Client) *Client {
@@ -57,10 +115,11 @@ func NewClient(baseURL, email, password string, httpClient *http.Client) *Client
 		httpClient = &http.Client{Timeout: 10 * time.Second}
 	}
 	return &Client{
-		baseURL:  strings.TrimRight(baseURL, "/"),
-		email:    email,
-		password: password,
-		http:     httpClient,
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		email:        email,
+		password:     password,
+		http:         httpClient,
+		retryBackoff: updateRetryBackoff,
 	}
 }
 
@@ -121,6 +180,43 @@ func (c *Client) ListEntries(ctx context.Context, page, perPage int) ([]Entry, e
 	return entries, nil
 }
 
+// ListFeedEntries lists entries of a single feed, newest first.
+func (c *Client) ListFeedEntries(ctx context.Context, feedID int64, page, perPage int) ([]Entry, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 20
+	}
+
+	q := make(url.Values)
+	q.Set("page", strconv.Itoa(page))
+	q.Set("per_page", strconv.Itoa(perPage))
+
+	path := fmt.Sprintf("/feeds/%d/entries.json?%s", feedID, q.Encode())
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list feed entries request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("list feed entries failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var entries []Entry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("decode feed entries response: %w", err)
+	}
+	return entries, nil
+}
+
 func (c *Client) ListEntriesByIDs(ctx context.Context, ids []int64) ([]Entry, error) {
 	if len(ids) == 0 {
 		return nil, nil
@@ -182,6 +278,70 @@ func (c *Client) ListSubscriptions(ctx context.Context) ([]Subscription, error)
 	return subscriptions, nil
 }
 
+// GetFeed fetches feed metadata by feed ID. It is used to resolve feeds that
+// entries reference but that are no longer part of the subscription list.
+func (c *Client) GetFeed(ctx context.Context, feedID int64) (Subscription, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/feeds/%d.json", feedID), nil)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return Subscription{}, fmt.Errorf("get feed request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return Subscription{}, fmt.Errorf("get feed %d failed with status %d: %s", feedID, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var feed struct {
+		ID      int64  `json:"id"`
+		Title   string `json:"title"`
+		FeedURL string `json:"feed_url"`
+		SiteURL string `json:"site_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return Subscription{}, fmt.Errorf("decode feed response: %w", err)
+	}
+	return Subscription{ID: feed.ID, Title: feed.Title, FeedURL: feed.FeedURL, SiteURL: feed.SiteURL}, nil
+}
+
+// ExtractContent fetches the full article text from an entry's
+// ExtractedContentURL. The link is signed, so it is requested without the
+// account credentials.
+func (c *Client) ExtractContent(ctx context.Context, extractURL string) (string, error) {
+	if err := c.spendRequest(); err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, extractURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("extract content request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return "", fmt.Errorf("extract content failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var extracted struct {
+		Content string `json:"content"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&extracted); err != nil {
+		return "", fmt.Errorf("decode extract response: %w", err)
+	}
+	return extracted.Content, nil
+}
+
 func (c *Client) ListUnreadEntryIDs(ctx context.Context) ([]int64, error) {
 	return c.listEntryIDs(ctx, "/unread_entries.json", "unread entries")
 }
@@ -224,19 +384,125 @@ func (c *Client) ListUpdatedEntryIDsSince(ctx context.Context, since time.Time)
 }
 
 func (c *Client) MarkEntriesUnread(ctx context.Context, entryIDs []int64) error {
-	return c.updateEntryIDs(ctx, http.MethodPost, "/unread_entries.json", "unread_entries", entryIDs, "mark entries unread")
+	_, _, err := c.MarkEntriesUnreadBatch(ctx, entryIDs)
+	return err
 }
 
 func (c *Client) MarkEntriesRead(ctx context.Context, entryIDs []int64) error {
-	return c.updateEntryIDs(ctx, http.MethodDelete, "/unread_entries.json", "unread_entries", entryIDs, "mark entries read")
+	_, _, err := c.MarkEntriesReadBatch(ctx, entryIDs)
+	return err
 }
 
 func (c *Client) StarEntries(ctx context.Context, entryIDs []int64) error {
-	return c.updateEntryIDs(ctx, http.MethodPost, "/starred_entries.json", "starred_entries", entryIDs, "star entries")
+	_, _, err := c.StarEntriesBatch(ctx, entryIDs)
+	return err
 }
 
 func (c *Client) UnstarEntries(ctx context.Context, entryIDs []int64) error {
-	return c.updateEntryIDs(ctx, http.MethodDelete, "/starred_entries.json", "starred_entries", entryIDs, "unstar entries")
+	_, _, err := c.UnstarEntriesBatch(ctx, entryIDs)
+	return err
+}
+
+// MarkEntriesUnreadBatch marks entries unread in chunks, retrying transient
+// failures per chunk. It reports which IDs were applied and which failed.
+func (c *Client) MarkEntriesUnreadBatch(ctx context.Context, entryIDs []int64) (succeeded []int64, failed []int64, err error) {
+	return c.updateEntryIDsBatch(ctx, http.MethodPost, "/unread_entries.json", "unread_entries", entryIDs, "mark entries unread")
+}
+
+// MarkEntriesReadBatch is the read counterpart of MarkEntriesUnreadBatch.
+func (c *Client) MarkEntriesReadBatch(ctx context.Context, entryIDs []int64) (succeeded []int64, failed []int64, err error) {
+	return c.updateEntryIDsBatch(ctx, http.MethodDelete, "/unread_entries.json", "unread_entries", entryIDs, "mark entries read")
+}
+
+// StarEntriesBatch stars entries in chunks with per-chunk retry.
+func (c *Client) StarEntriesBatch(ctx context.Context, entryIDs []int64) (succeeded []int64, failed []int64, err error) {
+	return c.updateEntryIDsBatch(ctx, http.MethodPost, "/starred_entries.json", "starred_entries", entryIDs, "star entries")
+}
+
+// UnstarEntriesBatch unstars entries in chunks with per-chunk retry.
+func (c *Client) UnstarEntriesBatch(ctx context.Context, entryIDs []int64) (succeeded []int64, failed []int64, err error) {
+	return c.updateEntryIDsBatch(ctx, http.MethodDelete, "/starred_entries.json", "starred_entries", entryIDs, "unstar entries")
+}
+
+// RenameSubscription sets a custom title for a subscription.
+func (c *Client) RenameSubscription(ctx context.Context, subscriptionID int64, title string) error {
+	body, err := json.Marshal(map[string]string{"title": title})
+	if err != nil {
+		return fmt.Errorf("rename subscription: marshal payload: %w", err)
+	}
+
+	path := fmt.Sprintf("/subscriptions/%d.json", subscriptionID)
+	req, err := c.newRequest(ctx, http.MethodPatch, path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("rename subscription request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("rename subscription failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+	return nil
+}
+
+// CreateTagging tags a feed, which files it under that folder. Feedbin creates
+// the tag on first use, so a new folder needs no separate request.
+func (c *Client) CreateTagging(ctx context.Context, feedID int64, name string) (Tagging, error) {
+	body, err := json.Marshal(map[string]any{"feed_id": feedID, "name": name})
+	if err != nil {
+		return Tagging{}, fmt.Errorf("create tagging: marshal payload: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/taggings.json", bytes.NewReader(body))
+	if err != nil {
+		return Tagging{}, err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return Tagging{}, fmt.Errorf("create tagging request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Feedbin answers 201 for a new tagging; an existing one is a 302 that the
+	// HTTP client follows to the tagging itself.
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return Tagging{}, fmt.Errorf("create tagging failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+
+	var tagging Tagging
+	if err := json.NewDecoder(resp.Body).Decode(&tagging); err != nil {
+		return Tagging{}, fmt.Errorf("decode tagging response: %w", err)
+	}
+	return tagging, nil
+}
+
+func (c *Client) Unsubscribe(ctx context.Context, subscriptionID int64) error {
+	path := fmt.Sprintf("/subscriptions/%d.json", subscriptionID)
+	req, err := c.newRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("unsubscribe request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("unsubscribe failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+	return nil
 }
 
 func (c *Client) listEntryIDs(ctx context.Context, path, resource string) ([]int64, error) {
@@ -263,6 +529,78 @@ func (c *Client) listEntryIDs(ctx context.Context, path, resource string) ([]int
 	return ids, nil
 }
 
+// updateEntryIDsBatch splits entryIDs into Feedbin-sized chunks and applies
+// each one independently, so a failing chunk does not discard the others.
+func (c *Client) updateEntryIDsBatch(ctx context.Context, method, path, key string, entryIDs []int64, action string) ([]int64, []int64, error) {
+	if len(entryIDs) == 0 {
+		return nil, nil, nil
+	}
+
+	succeeded := make([]int64, 0, len(entryIDs))
+	var failed []int64
+	var firstErr error
+	for _, chunk := range chunkInt64(entryIDs, updateChunkSize) {
+		if err := c.updateEntryIDsWithRetry(ctx, method, path, key, chunk, action); err != nil {
+			failed = append(failed, chunk...)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		succeeded = append(succeeded, chunk...)
+	}
+
+	if firstErr == nil {
+		return succeeded, nil, nil
+	}
+	if len(succeeded) == 0 {
+		return succeeded, failed, firstErr
+	}
+	return succeeded, failed, fmt.Errorf("%s: %d of %d entries failed: %w", action, len(failed), len(entryIDs), firstErr)
+}
+
+func (c *Client) updateEntryIDsWithRetry(ctx context.Context, method, path, key string, entryIDs []int64, action string) error {
+	backoff := c.retryBackoff
+	for attempt := 1; ; attempt++ {
+		err := c.updateEntryIDs(ctx, method, path, key, entryIDs, action)
+		if err == nil || attempt >= updateMaxAttempts || !isRetryableUpdateError(ctx, err) {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// isRetryableUpdateError reports whether a failed update is worth retrying:
+// transport errors, rate limiting and server errors are; client errors are not.
+func isRetryableUpdateError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, ErrRequestBudget) {
+		return false
+	}
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.status == http.StatusTooManyRequests || statusErr.status >= http.StatusInternalServerError
+	}
+	return true
+}
+
+type statusError struct {
+	action string
+	status int
+	body   string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s failed with status %d: %s", e.action, e.status, e.body)
+}
+
 func (c *Client) updateEntryIDs(ctx context.Context, method, path, key string, entryIDs []int64, action string) error {
 	if len(entryIDs) == 0 {
 		return nil
@@ -288,13 +626,16 @@ func (c *Client) updateEntryIDs(ctx context.Context, method, path, key string, e
 
 	if resp.StatusCode != http.StatusOK {
 		responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
-		return fmt.Errorf("%s failed with status %d: %s", action, resp.StatusCode, strings.TrimSpace(string(responseBody)))
+		return &statusError{action: action, status: resp.StatusCode, body: strings.TrimSpace(string(responseBody))}
 	}
 
 	return nil
 }
 
 func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	if err := c.spendRequest(); err != nil {
+		return nil, err
+	}
 	fullURL := c.baseURL + path
 	req, err := http.NewRequestWithContext(ctx, method, fullURL, body)
 	if err != nil {
@@ -3,10 +3,13 @@ package feedbin
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -108,6 +111,49 @@ func TestListSubscriptions_ParsesResponse(t *testing.T) {
 	}
 }
 
+func TestGetFeed_ParsesResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/feeds/10.json" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":10,"title":"Old Feed","feed_url":"https://example.com/feed.xml","site_url":"https://example.com"}`))
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "u@example.com", "secret", ts.Client())
+	feed, err := c.GetFeed(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("GetFeed returned error: %v", err)
+	}
+	if feed.ID != 10 || feed.Title != "Old Feed" || feed.FeedURL != "https://example.com/feed.xml" {
+		t.Fatalf("unexpected feed: %+v", feed)
+	}
+}
+
+func TestExtractContent_FetchesSignedURLWithoutCredentials(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); ok {
+			t.Fatal("expected no credentials on the signed extract URL")
+		}
+		if r.URL.Path != "/parser/feedbin/abc" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"title":"Post","content":"<p>Full text</p>"}`))
+	}))
+	defer ts.Close()
+
+	c := NewClient("https://api.feedbin.com/v2", "u@example.com", "secret", ts.Client())
+	content, err := c.ExtractContent(context.Background(), ts.URL+"/parser/feedbin/abc?base64_url=aHR0cHM6")
+	if err != nil {
+		t.Fatalf("ExtractContent returned error: %v", err)
+	}
+	if content != "<p>Full text</p>" {
+		t.Fatalf("unexpected content: %q", content)
+	}
+}
+
 func TestListUnreadEntryIDs_ParsesResponse(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/unread_entries.json" {
@@ -313,3 +359,237 @@ func TestStarAndUnstarEntries(t *testing.T) {
 		t.Fatalf("unexpected second request: %s", requests[1])
 	}
 }
+
+func TestMarkEntriesReadBatch_ReportsFailedChunks(t *testing.T) {
+	var mu sync.Mutex
+	attempts := map[int64]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string][]int64
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		ids := payload["unread_entries"]
+		mu.Lock()
+		attempts[ids[0]]++
+		attempt := attempts[ids[0]]
+		mu.Unlock()
+
+		switch ids[0] {
+		case 1001:
+			// Transient failure on the second chunk: recovers on retry.
+			if attempt == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		case 2001:
+			// Permanent failure on the third chunk: must not be retried.
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	ids := make([]int64, 0, 2500)
+	for i := int64(1); i <= 2500; i++ {
+		ids = append(ids, i)
+	}
+
+	c := NewClient(ts.URL, "u@example.com", "secret", ts.Client())
+	c.retryBackoff = time.Millisecond
+	succeeded, failed, err := c.MarkEntriesReadBatch(context.Background(), ids)
+	if err == nil {
+		t.Fatal("expected partial failure error")
+	}
+	if !strings.Contains(err.Error(), "500 of 2500 entries failed") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(succeeded) != 2000 || len(failed) != 500 {
+		t.Fatalf("expected 2000 succeeded and 500 failed, got %d and %d", len(succeeded), len(failed))
+	}
+	if failed[0] != 2001 || failed[len(failed)-1] != 2500 {
+		t.Fatalf("unexpected failed range: %d..%d", failed[0], failed[len(failed)-1])
+	}
+	if attempts[1] != 1 || attempts[1001] != 2 || attempts[2001] != 1 {
+		t.Fatalf("unexpected attempts: %+v", attempts)
+	}
+}
+
+func TestStarEntriesBatch_GivesUpAfterMaxAttempts(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "u@example.com", "secret", ts.Client())
+	c.retryBackoff = time.Millisecond
+	succeeded, failed, err := c.StarEntriesBatch(context.Background(), []int64{7, 8})
+	if err == nil || !strings.Contains(err.Error(), "star entries failed with status 502") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(succeeded) != 0 || len(failed) != 2 {
+		t.Fatalf("expected all entries failed, got succeeded=%v failed=%v", succeeded, failed)
+	}
+	if calls != updateMaxAttempts {
+		t.Fatalf("expected %d attempts, got %d", updateMaxAttempts, calls)
+	}
+}
+
+func TestSubscriptionManagementRequests(t *testing.T) {
+	requests := make([]string, 0, 3)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.RequestURI()+" "+string(body))
+		switch r.Method {
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`[{"id":9,"feed_id":42,"title":"Fresh"}]`))
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "u@example.com", "secret", ts.Client())
+	ctx := context.Background()
+	if err := c.RenameSubscription(ctx, 7, "Alias"); err != nil {
+		t.Fatalf("RenameSubscription returned error: %v", err)
+	}
+	if err := c.Unsubscribe(ctx, 7); err != nil {
+		t.Fatalf("Unsubscribe returned error: %v", err)
+	}
+	entries, err := c.ListFeedEntries(ctx, 42, 1, 50)
+	if err != nil {
+		t.Fatalf("ListFeedEntries returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].FeedID != 42 {
+		t.Fatalf("unexpected feed entries: %+v", entries)
+	}
+
+	if requests[0] != `PATCH /subscriptions/7.json {"title":"Alias"}` {
+		t.Fatalf("unexpected rename request: %s", requests[0])
+	}
+	if requests[1] != "DELETE /subscriptions/7.json " {
+		t.Fatalf("unexpected unsubscribe request: %s", requests[1])
+	}
+	if requests[2] != "GET /feeds/42/entries.json?page=1&per_page=50 " {
+		t.Fatalf("unexpected feed entries request: %s", requests[2])
+	}
+}
+
+func TestCreateTagging_PostsFeedAndName(t *testing.T) {
+	var got string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = r.Method + " " + r.URL.Path + " " + string(body)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":4,"feed_id":42,"name":"Tech"}`))
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "u@example.com", "secret", ts.Client())
+	tagging, err := c.CreateTagging(context.Background(), 42, "Tech")
+	if err != nil {
+		t.Fatalf("CreateTagging returned error: %v", err)
+	}
+	if got != `POST /taggings.json {"feed_id":42,"name":"Tech"}` {
+		t.Fatalf("unexpected request: %s", got)
+	}
+	if tagging != (Tagging{ID: 4, FeedID: 42, Name: "Tech"}) {
+		t.Fatalf("unexpected tagging: %+v", tagging)
+	}
+}
+
+func TestCanonicalURL_ResolvesAMPAndCanonicalLinks(t *testing.T) {
+	cases := []struct {
+		name     string
+		content  string
+		fallback string
+		want     string
+	}{
+		{"canonical link", `<link rel="canonical" href="https://example.com/story">`, "https://amp.example.com/story", "https://example.com/story"},
+		{"relative canonical with attributes swapped", `<link href="/story?id=1&amp;x=2" rel='canonical' />`, "https://example.com/amp/story", "https://example.com/story?id=1&x=2"},
+		{"google amp wrapper", "", "https://www.google.com/amp/s/example.com/2026/story.html", "https://example.com/2026/story.html"},
+		{"amp cache", "", "https://example-com.cdn.ampproject.org/c/s/example.com/story", "https://example.com/story"},
+		{"amp host", "", "https://amp.example.com/story", "https://example.com/story"},
+		{"amp path suffix", "", "https://example.com/2026/story/amp/", "https://example.com/2026/story/"},
+		{"amp query", "", "https://example.com/story?amp=1&ref=rss", "https://example.com/story?ref=rss"},
+		{"feed proxy", `<p>via <a href="https://feedproxy.google.com/x">proxy</a> <a href="https://example.com/real">Read</a></p>`, "https://feedproxy.google.com/~r/example/~3/abc/", "https://example.com/real"},
+		{"plain url", `<p><a href="https://other.example.com/">elsewhere</a></p>`, "https://example.com/post", "https://example.com/post"},
+		{"data-href before href", `<link data-href="https://tracker.example.com/" rel="canonical" href="https://example.com/story">`, "https://amp.example.com/story", "https://example.com/story"},
+		{"canonical among rel values", `<link rel="alternate CANONICAL" href="https://example.com/story">`, "https://example.com/story?amp=1", "https://example.com/story"},
+		{"canonical-amp rel", `<link rel="canonical-amp" href="https://amp.example.com/other">`, "https://example.com/post", "https://example.com/post"},
+		{"anchor data-href", `<a data-href="https://example.com/tracked" href="https://example.com/real">Read</a>`, "https://feeds.feedburner.com/~r/example/~3/abc/", "https://example.com/real"},
+		{"non-http canonical", `<link rel="canonical" href="javascript:alert(1)">`, "https://example.com/post", "https://example.com/post"},
+	}
+	for _, tc := range cases {
+		if got := canonicalURL(tc.content, tc.fallback); got != tc.want {
+			t.Fatalf("%s: expected %q, got %q", tc.name, tc.want, got)
+		}
+	}
+	if got := (Entry{URL: "https://amp.example.com/x", CanonicalURL: "https://example.com/stored"}).ResolveCanonicalURL(); got != "https://example.com/stored" {
+		t.Fatalf("expected the stored canonical URL, got %q", got)
+	}
+}
+
+func TestEntryCommentCount_ParsesKnownPatterns(t *testing.T) {
+	cases := []struct {
+		name  string
+		entry Entry
+		want  int
+		ok    bool
+	}{
+		{"hacker news", Entry{Content: `<p>Comments URL: <a href="https://news.ycombinator.com/item?id=1">link</a></p><p>Points: 120</p><p># Comments: 42</p>`}, 42, true},
+		{"reddit", Entry{Content: `submitted by <a href="/u/x">/u/x</a> <a href="https://www.reddit.com/r/golang/comments/abc/post/">[1,204 comments]</a>`}, 1204, true},
+		{"split by a link", Entry{Summary: `<a href="https://lobste.rs/s/1#comments">7</a> replies`}, 7, true},
+		{"plain text field", Entry{Content: "Points: 10\nComments: 3"}, 3, true},
+		{"count in prose", Entry{Content: "<p>We received 300 comments on the proposal.</p>"}, 0, false},
+		{"field in prose", Entry{Content: "<p>Reader comments: 12 of them were rude.</p>"}, 0, false},
+		{"link to the article", Entry{Content: `<a href="https://example.com/story">42 comments</a>`}, 0, false},
+		{"single comment", Entry{Content: "1 comment so far"}, 0, false},
+		{"no count", Entry{Content: "<p>Comments are closed.</p>"}, 0, false},
+		{"empty", Entry{}, 0, false},
+	}
+	for _, tc := range cases {
+		got, ok := tc.entry.CommentCount()
+		if got != tc.want || ok != tc.ok {
+			t.Fatalf("%s: expected %d/%v, got %d/%v", tc.name, tc.want, tc.ok, got, ok)
+		}
+	}
+}
+
+func TestClient_MaxRequestsRefusesCallsBeyondBudget(t *testing.T) {
+	var hits int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "u@example.com", "secret", ts.Client())
+	c.SetMaxRequests(2)
+	for i := 0; i < 2; i++ {
+		if err := c.Authenticate(context.Background()); err != nil {
+			t.Fatalf("request %d within budget returned error: %v", i+1, err)
+		}
+	}
+	if !c.RequestBudgetSpent() {
+		t.Fatal("expected the budget reported spent once the last request is sent")
+	}
+
+	if err := c.Authenticate(context.Background()); !errors.Is(err, ErrRequestBudget) {
+		t.Fatalf("expected ErrRequestBudget beyond the budget, got %v", err)
+	}
+	if err := c.MarkEntriesRead(context.Background(), []int64{1}); !errors.Is(err, ErrRequestBudget) {
+		t.Fatalf("expected updates refused without retrying, got %v", err)
+	}
+	if _, err := c.ExtractContent(context.Background(), ts.URL+"/extract"); !errors.Is(err, ErrRequestBudget) {
+		t.Fatalf("expected extraction refused, got %v", err)
+	}
+	if hits != 2 || c.RequestCount() != 2 || c.MaxRequests() != 2 || !c.RequestBudgetSpent() {
+		t.Fatalf("expected 2 requests sent and the budget spent, got hits=%d count=%d spent=%v", hits, c.RequestCount(), c.RequestBudgetSpent())
+	}
+}
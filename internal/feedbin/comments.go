@@ -0,0 +1,147 @@
+package feedbin
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	// "# Comments: 42" (Hacker News) or "Comments: 42", alone in its block.
+	reCommentField = regexp.MustCompile(`(?i)^(?:#\s*)?comments:\s*(\d[\d,]*)$`)
+	// "42 comments" or "[3 replies]" as the whole text of a link, or a bare
+	// "7" link followed by "replies".
+	reCommentLinkText = regexp.MustCompile(`(?i)^\[?(\d[\d,]*)(\s+(?:comments?|replies|reply))?\]?$`)
+	reCommentNoun     = regexp.MustCompile(`(?i)^\s*(?:comments?|replies|reply)\b`)
+)
+
+// commentPageHints mark an href as a discussion page.
+var commentPageHints = []string{"comment", "discuss", "item?id=", "thread", "#repl"}
+
+// CommentCount returns the comment or reply count a discussion feed wrote
+// into the entry, from the content or else the summary. Only the shapes such
+// feeds use count: a "Comments: N" field on its own, or a link to the
+// comments page whose text is the count. Counts in article prose are ignored.
+func (e Entry) CommentCount() (int, bool) {
+	for _, text := range []string{e.Content, e.Summary} {
+		if text == "" {
+			continue
+		}
+		if count, ok := commentCountIn(text); ok {
+			return count, true
+		}
+	}
+	return 0, false
+}
+
+func commentCountIn(content string) (int, bool) {
+	z := nethtml.NewTokenizer(strings.NewReader(content))
+	var (
+		block    strings.Builder
+		linkText strings.Builder
+		href     string
+		inLink   bool
+		// pending is a bare-number link to a comments page, confirmed when
+		// the text after it names comments or replies.
+		pending    string
+		hasPending bool
+	)
+	endBlock := func() (int, bool) {
+		text := strings.TrimSpace(block.String())
+		block.Reset()
+		if match := reCommentField.FindStringSubmatch(text); match != nil {
+			return parseCommentCount(match[1])
+		}
+		return 0, false
+	}
+	for {
+		switch z.Next() {
+		case nethtml.ErrorToken:
+			return endBlock()
+		case nethtml.TextToken:
+			text := string(z.Text())
+			if hasPending {
+				hasPending = false
+				if reCommentNoun.MatchString(text) {
+					return parseCommentCount(pending)
+				}
+			}
+			for i, line := range strings.Split(text, "\n") {
+				if i > 0 {
+					if count, ok := endBlock(); ok {
+						return count, true
+					}
+				}
+				block.WriteString(line)
+			}
+			if inLink {
+				linkText.WriteString(text)
+			}
+		case nethtml.StartTagToken, nethtml.EndTagToken, nethtml.SelfClosingTagToken:
+			hasPending = false
+			token := z.Token()
+			if token.DataAtom != atom.A {
+				if inlineTags[token.DataAtom] {
+					continue
+				}
+				if count, ok := endBlock(); ok {
+					return count, true
+				}
+				continue
+			}
+			if token.Type == nethtml.StartTagToken {
+				inLink = true
+				href = attrValue(token, "href")
+				linkText.Reset()
+				continue
+			}
+			if !inLink {
+				continue
+			}
+			inLink = false
+			match := reCommentLinkText.FindStringSubmatch(strings.TrimSpace(linkText.String()))
+			if match == nil || !commentPage(href) {
+				continue
+			}
+			if match[2] != "" {
+				return parseCommentCount(match[1])
+			}
+			pending, hasPending = match[1], true
+		}
+	}
+}
+
+// inlineTags do not break the text of a block.
+var inlineTags = map[atom.Atom]bool{
+	atom.Span: true, atom.B: true, atom.Strong: true, atom.Em: true, atom.I: true, atom.Code: true, atom.Small: true,
+}
+
+func commentPage(href string) bool {
+	lower := strings.ToLower(href)
+	for _, hint := range commentPageHints {
+		if strings.Contains(lower, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+func attrValue(token nethtml.Token, name string) string {
+	for _, attr := range token.Attr {
+		if attr.Namespace == "" && strings.EqualFold(attr.Key, name) {
+			return strings.TrimSpace(attr.Val)
+		}
+	}
+	return ""
+}
+
+func parseCommentCount(raw string) (int, bool) {
+	count, err := strconv.Atoi(strings.ReplaceAll(raw, ",", ""))
+	if err != nil {
+		return 0, false
+	}
+	return count, true
+}
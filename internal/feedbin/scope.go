@@ -0,0 +1,26 @@
+package feedbin
+
+import (
+	"strconv"
+	"strings"
+)
+
+const feedScopePrefix = "feed:"
+
+// FeedScopeFilter returns the listing filter that limits entries to one feed.
+func FeedScopeFilter(feedID int64) string {
+	return feedScopePrefix + strconv.FormatInt(feedID, 10)
+}
+
+// ParseFeedScope returns the feed ID of a "feed:<id>" filter.
+func ParseFeedScope(filter string) (int64, bool) {
+	raw, ok := strings.CutPrefix(filter, feedScopePrefix)
+	if !ok {
+		return 0, false
+	}
+	feedID, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || feedID <= 0 {
+		return 0, false
+	}
+	return feedID, true
+}
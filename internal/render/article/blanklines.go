@@ -0,0 +1,74 @@
+package article
+
+import "strings"
+
+// BlankLines selects which blank lines of an article survive rendering.
+type BlankLines int
+
+const (
+	// BlankLinesCollapse folds every run of blank lines into one.
+	BlankLinesCollapse BlankLines = iota
+	// BlankLinesPre keeps the blank lines inside <pre> blocks.
+	BlankLinesPre
+	// BlankLinesAll also keeps the blank lines made by consecutive <br>
+	// tags, such as the stanza breaks of a poem.
+	BlankLinesAll
+)
+
+// ParseBlankLines maps "collapse", "pre" or "all" to a BlankLines.
+func ParseBlankLines(raw string) (BlankLines, bool) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", "collapse":
+		return BlankLinesCollapse, true
+	case "pre":
+		return BlankLinesPre, true
+	case "all":
+		return BlankLinesAll, true
+	default:
+		return BlankLinesCollapse, false
+	}
+}
+
+const (
+	// keptBlank stands in for a blank line trimBlankLines must not fold;
+	// restoreBlankLines empties it once rendering is done.
+	keptBlank = "\x00"
+	// lineBreakMark follows the newline of a <br> under BlankLinesAll, so
+	// normalizeInlineText can tell it from a newline in the HTML source.
+	lineBreakMark = "\x1f"
+)
+
+// keepBlankLines drops the blank lines around lines and protects the ones
+// between them from collapsing.
+func keepBlankLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			line = keptBlank
+		}
+		out[i] = line
+	}
+	return out
+}
+
+// restoreBlankLines empties the kept blank lines, including ones a list or
+// quote prefixed or styled.
+func restoreBlankLines(lines []string) []string {
+	for i, line := range lines {
+		if !strings.Contains(line, keptBlank) {
+			continue
+		}
+		line = strings.ReplaceAll(line, keptBlank, "")
+		if strings.TrimSpace(stripANSI(line)) == "" {
+			line = ""
+		}
+		lines[i] = line
+	}
+	return lines
+}
@@ -0,0 +1,188 @@
+package article
+
+import (
+	"fmt"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+)
+
+// footnotes holds the notes of an article pulled out of its body so they can
+// be rendered as one section after the text.
+type footnotes struct {
+	// numbers maps a footnote id to the number shown for its references.
+	numbers map[string]int
+	// items are the footnote list items in number order.
+	items []*nethtml.Node
+}
+
+// extractFootnotes finds the footnote references of body, such as
+// <sup><a href="#fn1">1</a></sup>, and the list items they point to. The
+// items are detached from body along with their list and any container
+// marked as the footnotes section, and numbered in reference order.
+func extractFootnotes(body *nethtml.Node) footnotes {
+	var targets []string
+	refIDs := map[string]bool{}
+	seen := map[string]bool{}
+	walkElements(body, func(node *nethtml.Node) {
+		if !strings.EqualFold(node.Data, "a") || !isFootnoteRef(node) {
+			return
+		}
+		id := strings.TrimPrefix(nodeAttr(node, "href"), "#")
+		if id := nodeAttr(node, "id"); id != "" {
+			refIDs[id] = true
+		}
+		if sup := node.Parent; sup != nil && strings.EqualFold(sup.Data, "sup") && nodeAttr(sup, "id") != "" {
+			refIDs[nodeAttr(sup, "id")] = true
+		}
+		if !seen[id] {
+			seen[id] = true
+			targets = append(targets, id)
+		}
+	})
+	if len(targets) == 0 {
+		return footnotes{}
+	}
+
+	itemsByID := map[string]*nethtml.Node{}
+	walkElements(body, func(node *nethtml.Node) {
+		if id := nodeAttr(node, "id"); strings.EqualFold(node.Data, "li") && seen[id] {
+			itemsByID[id] = node
+		}
+	})
+	notes := footnotes{numbers: map[string]int{}}
+	for _, id := range targets {
+		item, ok := itemsByID[id]
+		if !ok {
+			continue
+		}
+		notes.items = append(notes.items, item)
+		notes.numbers[id] = len(notes.items)
+	}
+	for _, item := range notes.items {
+		removeBacklinks(item, refIDs)
+	}
+	for _, item := range notes.items {
+		detachFootnote(item, notes.numbers)
+	}
+	return notes
+}
+
+// isFootnoteRef reports whether a is a link to a note in the same document,
+// either wrapped in <sup> or marked as a note reference.
+func isFootnoteRef(a *nethtml.Node) bool {
+	href := nodeAttr(a, "href")
+	if len(href) < 2 || href[0] != '#' {
+		return false
+	}
+	if a.Parent != nil && a.Parent.Type == nethtml.ElementNode && strings.EqualFold(a.Parent.Data, "sup") {
+		return true
+	}
+	return strings.EqualFold(nodeAttr(a, "role"), "doc-noteref") || hasClassContaining(a, "footnote-ref")
+}
+
+// removeBacklinks drops the "↩" links a footnote carries back to its
+// reference; they lead nowhere once the notes are collected.
+func removeBacklinks(item *nethtml.Node, refIDs map[string]bool) {
+	var links []*nethtml.Node
+	walkElements(item, func(node *nethtml.Node) {
+		if !strings.EqualFold(node.Data, "a") {
+			return
+		}
+		href := nodeAttr(node, "href")
+		if strings.HasPrefix(href, "#") && refIDs[href[1:]] ||
+			strings.EqualFold(nodeAttr(node, "role"), "doc-backlink") ||
+			hasClassContaining(node, "footnote-back") {
+			links = append(links, node)
+		}
+	})
+	for _, link := range links {
+		if link.Parent != nil {
+			link.Parent.RemoveChild(link)
+		}
+	}
+}
+
+// detachFootnote removes item from the document. A list holding nothing but
+// footnotes goes with it, as does a container marked as the footnotes
+// section, so its heading or rule does not linger in the body.
+func detachFootnote(item *nethtml.Node, numbers map[string]int) {
+	list := item.Parent
+	if list == nil {
+		return
+	}
+	if !onlyFootnotes(list, numbers) {
+		list.RemoveChild(item)
+		return
+	}
+	target := list
+	for parent := list.Parent; parent != nil && parent.Type == nethtml.ElementNode && !strings.EqualFold(parent.Data, "body"); parent = parent.Parent {
+		if strings.EqualFold(nodeAttr(parent, "role"), "doc-endnotes") || hasClassContaining(parent, "footnotes") {
+			target = parent
+			break
+		}
+	}
+	if target.Parent != nil {
+		target.Parent.RemoveChild(target)
+	}
+}
+
+func onlyFootnotes(list *nethtml.Node, numbers map[string]int) bool {
+	for child := list.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != nethtml.ElementNode {
+			continue
+		}
+		if _, ok := numbers[nodeAttr(child, "id")]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func hasClassContaining(node *nethtml.Node, needle string) bool {
+	for _, class := range strings.Fields(nodeAttr(node, "class")) {
+		if strings.Contains(strings.ToLower(class), needle) {
+			return true
+		}
+	}
+	return false
+}
+
+func walkElements(node *nethtml.Node, fn func(*nethtml.Node)) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != nethtml.ElementNode {
+			continue
+		}
+		fn(child)
+		walkElements(child, fn)
+	}
+}
+
+// footnoteMarker returns the "[n]" marker for a reference link to a
+// collected footnote.
+func (r htmlArticleRenderer) footnoteMarker(a *nethtml.Node) (string, bool) {
+	if a == nil || a.Type != nethtml.ElementNode || !strings.EqualFold(a.Data, "a") || len(r.footnotes.numbers) == 0 {
+		return "", false
+	}
+	href := nodeAttr(a, "href")
+	if !strings.HasPrefix(href, "#") {
+		return "", false
+	}
+	n, ok := r.footnotes.numbers[href[1:]]
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("[%d]", n), true
+}
+
+// renderFootnotes renders the collected notes as a "Footnotes:" section.
+func (r htmlArticleRenderer) renderFootnotes() []string {
+	if len(r.footnotes.items) == 0 || r.budget.truncated {
+		return nil
+	}
+	lines := []string{"", "Footnotes:"}
+	for i, item := range r.footnotes.items {
+		lines = append(lines, r.renderListItem(item, 1, fmt.Sprintf("[%d] ", i+1))...)
+	}
+	return lines
+}
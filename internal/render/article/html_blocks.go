@@ -17,7 +17,7 @@ func (r htmlArticleRenderer) renderNodes(nodes []*nethtml.Node, listDepth int) [
 		if text == "" {
 			return
 		}
-		block := wrapText(text, r.width)
+		block := r.wrap(text, r.width)
 		if len(block) == 0 {
 			return
 		}
@@ -28,6 +28,9 @@ func (r htmlArticleRenderer) renderNodes(nodes []*nethtml.Node, listDepth int) [
 	}
 
 	for _, node := range nodes {
+		if r.budget.stop(len(lines)) {
+			break
+		}
 		switch node.Type {
 		case nethtml.TextNode:
 			inlineParts = append(inlineParts, node.Data)
@@ -61,7 +64,7 @@ func (r htmlArticleRenderer) renderBlock(node *nethtml.Node, listDepth int) []st
 		prefix := headingPrefix(level)
 		text := normalizeInlineText(r.renderInlineChildren(node))
 		return styleNonBlankLines(
-			wrapPrefixedText(text, r.width, prefix, strings.Repeat(" ", visibleLen(prefix))),
+			wrapPrefixedText(r.script, text, r.width, prefix, strings.Repeat(" ", visibleLen(prefix))),
 			detailHeadingStyle,
 		)
 	case "p", "div", "section", "article", "main", "header", "footer", "aside", "nav":
@@ -70,7 +73,7 @@ func (r htmlArticleRenderer) renderBlock(node *nethtml.Node, listDepth int) []st
 		}
 		text := normalizeInlineText(r.renderInlineChildren(node))
 		if text != "" {
-			return wrapText(text, r.width)
+			return r.wrap(text, r.width)
 		}
 		return r.renderNodes(elementChildren(node), listDepth)
 	case "blockquote":
@@ -80,7 +83,7 @@ func (r htmlArticleRenderer) renderBlock(node *nethtml.Node, listDepth int) []st
 			if text == "" {
 				return nil
 			}
-			inner = wrapText(text, r.width-2)
+			inner = r.wrap(text, r.width-2)
 		}
 		out := make([]string, 0, len(inner))
 		for _, line := range inner {
@@ -100,16 +103,20 @@ func (r htmlArticleRenderer) renderBlock(node *nethtml.Node, listDepth int) []st
 	case "figcaption", "caption":
 		text := normalizeInlineText(r.renderInlineChildren(node))
 		return styleNonBlankLines(
-			wrapPrefixedText(text, r.width, "— ", "  "),
+			wrapPrefixedText(r.script, text, r.width, "— ", "  "),
 			detailCitation,
 		)
 	case "figure":
 		return r.renderNodes(elementChildren(node), listDepth)
 	case "img":
-		if r.opts.ImageMode == ImageModeNone {
-			return nil
+		var out []string
+		if r.opts.ImageMode != ImageModeNone {
+			out = renderImageLabel(node, r.width, r.script)
+		}
+		if image, ok := r.images[node]; ok && r.opts.ImageAnchors {
+			out = append(out, imageAnchorLine(image))
 		}
-		return renderImageLabel(node, r.width)
+		return out
 	case "pre":
 		text := strings.ReplaceAll(collectRawText(node), "\r\n", "\n")
 		rawLines := strings.Split(text, "\n")
@@ -122,6 +129,9 @@ func (r htmlArticleRenderer) renderBlock(node *nethtml.Node, listDepth int) []st
 			}
 			out = append(out, "    "+line)
 		}
+		if r.opts.BlankLines != BlankLinesCollapse {
+			return keepBlankLines(out)
+		}
 		return trimBlankLines(out)
 	case "hr":
 		return []string{strings.Repeat("-", min(max(r.width, 3), 24))}
@@ -132,7 +142,7 @@ func (r htmlArticleRenderer) renderBlock(node *nethtml.Node, listDepth int) []st
 	default:
 		text := normalizeInlineText(r.renderInlineChildren(node))
 		if text != "" {
-			return wrapText(text, r.width)
+			return r.wrap(text, r.width)
 		}
 		return r.renderNodes(elementChildren(node), listDepth)
 	}
@@ -151,13 +161,13 @@ func (r htmlArticleRenderer) renderDefinitionList(node *nethtml.Node, listDepth
 			if text == "" {
 				continue
 			}
-			lines = append(lines, wrapPrefixedText(text, r.width, indent+"• ", indent+"  ")...)
+			lines = append(lines, wrapPrefixedText(r.script, text, r.width, indent+"• ", indent+"  ")...)
 		case "dd":
 			text := normalizeInlineText(r.renderInlineChildren(child))
 			if text == "" {
 				continue
 			}
-			lines = append(lines, wrapPrefixedText(text, r.width, indent+"  ", indent+"  ")...)
+			lines = append(lines, wrapPrefixedText(r.script, text, r.width, indent+"  ", indent+"  ")...)
 		}
 	}
 	return trimBlankLines(lines)
@@ -170,6 +180,9 @@ func (r htmlArticleRenderer) renderList(node *nethtml.Node, ordered bool, listDe
 		if child.Type != nethtml.ElementNode || strings.ToLower(child.Data) != "li" {
 			continue
 		}
+		if r.budget.stop(len(lines)) {
+			break
+		}
 		itemIndex++
 		marker := "- "
 		if ordered {
@@ -207,7 +220,7 @@ func (r htmlArticleRenderer) renderListItem(node *nethtml.Node, listDepth int, m
 	}
 	text := normalizeInlineText(strings.Join(textParts, " "))
 	if text != "" {
-		lines = append(lines, wrapPrefixedText(text, r.width, firstPrefix, restPrefix)...)
+		lines = append(lines, wrapPrefixedText(r.script, text, r.width, firstPrefix, restPrefix)...)
 	}
 
 	for child := node.FirstChild; child != nil; child = child.NextSibling {
@@ -233,7 +246,7 @@ func (r htmlArticleRenderer) renderListItem(node *nethtml.Node, listDepth int, m
 	return trimBlankLines(lines)
 }
 
-func wrapPrefixedText(text string, width int, firstPrefix, restPrefix string) []string {
+func wrapPrefixedText(sc script, text string, width int, firstPrefix, restPrefix string) []string {
 	text = normalizeInlineText(text)
 	if text == "" {
 		return nil
@@ -258,7 +271,7 @@ func wrapPrefixedText(text string, width int, firstPrefix, restPrefix string) []
 		if firstLine {
 			lineWidth = firstWidth
 		}
-		wrapped := wrapText(p, lineWidth)
+		wrapped := wrapTextFor(sc, p, lineWidth)
 		for i, line := range wrapped {
 			if firstLine && i == 0 {
 				out = append(out, firstPrefix+line)
@@ -0,0 +1,64 @@
+package article
+
+import (
+	"regexp"
+	"strings"
+)
+
+// osc8Pattern matches an OSC 8 hyperlink open or close sequence, terminated
+// by either ST (ESC \) or BEL.
+const osc8Pattern = `\x1b\]8;[^\x1b\a]*(?:\x1b\\|\a)`
+
+// osc8Close ends an OSC 8 hyperlink.
+const osc8Close = "\x1b]8;;\x1b\\"
+
+var reOSC8 = regexp.MustCompile(osc8Pattern)
+
+// Hyperlink wraps each word of text in an OSC 8 hyperlink to target, so the
+// line wrapper can break between words without leaving a link open. Targets
+// that are not http(s) or mailto, or that contain whitespace or control
+// characters, leave text unchanged.
+func Hyperlink(text, target string) string {
+	if !linkableTarget(target) {
+		return text
+	}
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return text
+	}
+	open := "\x1b]8;;" + target + "\x1b\\"
+	for i, word := range words {
+		words[i] = open + word + osc8Close
+	}
+	return strings.Join(words, " ")
+}
+
+func linkableTarget(target string) bool {
+	lower := strings.ToLower(target)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") && !strings.HasPrefix(lower, "mailto:") {
+		return false
+	}
+	for _, r := range target {
+		if r <= ' ' || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// replaceOutsideHyperlinks applies fn to the parts of s that are not OSC 8
+// sequences, so URL styling never rewrites a link target.
+func replaceOutsideHyperlinks(s string, re *regexp.Regexp, fn func(string) string) string {
+	if !strings.Contains(s, "\x1b]8;") {
+		return re.ReplaceAllStringFunc(s, fn)
+	}
+	var b strings.Builder
+	last := 0
+	for _, loc := range reOSC8.FindAllStringIndex(s, -1) {
+		b.WriteString(re.ReplaceAllStringFunc(s[last:loc[0]], fn))
+		b.WriteString(s[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(re.ReplaceAllStringFunc(s[last:], fn))
+	return b.String()
+}
@@ -3,18 +3,37 @@ package article
 import (
 	"html"
 	"strings"
+	"unicode"
 
+	"github.com/charmbracelet/lipgloss"
 	nethtml "golang.org/x/net/html"
 )
 
 func (r htmlArticleRenderer) renderInlineChildren(node *nethtml.Node) string {
 	parts := make([]string, 0, 4)
 	for child := node.FirstChild; child != nil; child = child.NextSibling {
-		parts = append(parts, r.renderInlineNode(child))
+		part := r.renderInlineNode(child)
+		// Superscripts and subscripts hug the text around them unless the
+		// source spaces them apart: x², H₂O.
+		hugs := isScriptNode(child) ||
+			(child.PrevSibling != nil && isScriptNode(child.PrevSibling) && strings.TrimLeftFunc(part, unicode.IsSpace) == part)
+		if len(parts) > 0 && hugs {
+			parts[len(parts)-1] += part
+			continue
+		}
+		parts = append(parts, part)
 	}
 	return strings.Join(parts, " ")
 }
 
+func isScriptNode(node *nethtml.Node) bool {
+	if node.Type != nethtml.ElementNode {
+		return false
+	}
+	tag := strings.ToLower(node.Data)
+	return tag == "sup" || tag == "sub"
+}
+
 func (r htmlArticleRenderer) renderInlineNode(node *nethtml.Node) string {
 	if node == nil {
 		return ""
@@ -28,13 +47,23 @@ func (r htmlArticleRenderer) renderInlineNode(node *nethtml.Node) string {
 		case "script", "style", "noscript", "img":
 			return ""
 		case "br":
+			if r.opts.BlankLines == BlankLinesAll {
+				return "\n" + lineBreakMark
+			}
 			return "\n"
 		case "a":
+			if marker, ok := r.footnoteMarker(node); ok {
+				return marker
+			}
 			text := normalizeInlineText(r.renderInlineChildren(node))
 			href := strings.TrimSpace(nodeAttr(node, "href"))
 			switch {
 			case href == "":
 				return text
+			case r.opts.Hyperlinks && text != "":
+				return Hyperlink(text, href)
+			case r.opts.Hyperlinks:
+				return Hyperlink(href, href)
 			case text == "":
 				return href
 			case strings.EqualFold(text, href):
@@ -54,6 +83,21 @@ func (r htmlArticleRenderer) renderInlineNode(node *nethtml.Node) string {
 				return ""
 			}
 			return detailCodeStyle.Render("`" + text + "`")
+		case "mark":
+			return styleInline(normalizeInlineText(r.renderInlineChildren(node)), detailMarkStyle)
+		case "del", "s", "strike":
+			return styleInline(normalizeInlineText(r.renderInlineChildren(node)), detailDelStyle)
+		case "ins":
+			return styleInline(normalizeInlineText(r.renderInlineChildren(node)), detailInsStyle)
+		case "sup":
+			if children := elementChildren(node); len(children) == 1 {
+				if marker, ok := r.footnoteMarker(children[0]); ok {
+					return marker
+				}
+			}
+			return scriptText(normalizeInlineText(r.renderInlineChildren(node)), superscripts, "^")
+		case "sub":
+			return scriptText(normalizeInlineText(r.renderInlineChildren(node)), subscripts, "_")
 		default:
 			return r.renderInlineChildren(node)
 		}
@@ -62,17 +106,131 @@ func (r htmlArticleRenderer) renderInlineNode(node *nethtml.Node) string {
 	}
 }
 
+// styleInline styles each word of text separately so wrapping between words
+// never splits an escape sequence across lines.
+func styleInline(text string, style lipgloss.Style) string {
+	if text == "" {
+		return ""
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		words := strings.Fields(line)
+		for j, word := range words {
+			words[j] = style.Render(word)
+		}
+		lines[i] = strings.Join(words, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+var (
+	superscripts = map[rune]rune{
+		'0': '⁰', '1': '¹', '2': '²', '3': '³', '4': '⁴', '5': '⁵', '6': '⁶', '7': '⁷', '8': '⁸', '9': '⁹',
+		'+': '⁺', '-': '⁻', '=': '⁼', '(': '⁽', ')': '⁾', 'n': 'ⁿ', 'i': 'ⁱ',
+	}
+	subscripts = map[rune]rune{
+		'0': '₀', '1': '₁', '2': '₂', '3': '₃', '4': '₄', '5': '₅', '6': '₆', '7': '₇', '8': '₈', '9': '₉',
+		'+': '₊', '-': '₋', '=': '₌', '(': '₍', ')': '₎', 'a': 'ₐ', 'e': 'ₑ', 'o': 'ₒ', 'x': 'ₓ',
+	}
+)
+
+// scriptText spells text with the Unicode superscript or subscript forms in
+// table when every character has one, and as marker+text otherwise: "^a",
+// "_(ij)".
+func scriptText(text string, table map[rune]rune, marker string) string {
+	if text == "" {
+		return ""
+	}
+	var b strings.Builder
+	for _, r := range text {
+		mapped, ok := table[r]
+		if !ok {
+			if len([]rune(text)) > 1 {
+				return marker + "(" + text + ")"
+			}
+			return marker + text
+		}
+		b.WriteRune(mapped)
+	}
+	return b.String()
+}
+
+// spaceNormalizer maps non-breaking spaces to plain spaces, so word splitting
+// and width math treat them like any other gap, and drops zero-width
+// characters that would otherwise count toward line width. The zero-width
+// joiner is kept because emoji sequences depend on it.
+var spaceNormalizer = strings.NewReplacer(
+	"\u00a0", " ",
+	"\u2007", " ",
+	"\u202f", " ",
+	"\u200b", "",
+	"\u200c", "",
+	"\u2060", "",
+	"\ufeff", "",
+	"\u00ad", "",
+)
+
+// asciiPunctuation replaces typographic quotes, dashes and ellipses with their
+// plain ASCII spelling for terminals or fonts that render them poorly.
+var asciiPunctuation = strings.NewReplacer(
+	"\u2018", "'",
+	"\u2019", "'",
+	"\u201a", "'",
+	"\u201b", "'",
+	"\u201c", `"`,
+	"\u201d", `"`,
+	"\u201e", `"`,
+	"\u201f", `"`,
+	"\u2032", "'",
+	"\u2033", `"`,
+	"\u2013", "-",
+	"\u2014", "--",
+	"\u2015", "--",
+	"\u2212", "-",
+	"\u2026", "...",
+)
+
+// normalizeTypography applies spaceNormalizer and, when ascii is set,
+// asciiPunctuation to already-unescaped text.
+func normalizeTypography(s string, ascii bool) string {
+	s = spaceNormalizer.Replace(s)
+	if ascii {
+		s = asciiPunctuation.Replace(s)
+	}
+	return s
+}
+
+// normalizeTextNodes rewrites every text node under node in place so the
+// typography pass runs once, before any block is wrapped.
+func normalizeTextNodes(node *nethtml.Node, ascii bool) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == nethtml.TextNode {
+			child.Data = normalizeTypography(child.Data, ascii)
+			continue
+		}
+		normalizeTextNodes(child, ascii)
+	}
+}
+
 func normalizeInlineText(s string) string {
-	s = html.UnescapeString(s)
+	s = normalizeTypography(html.UnescapeString(s), false)
 	parts := strings.Split(s, "\n")
 	out := make([]string, 0, len(parts))
 	for _, part := range parts {
-		part = strings.Join(strings.Fields(part), " ")
+		// A line holding only a <br> is a blank line BlankLinesAll keeps.
+		fromBreak := strings.Contains(part, lineBreakMark)
+		part = strings.Join(strings.Fields(strings.ReplaceAll(part, lineBreakMark, "")), " ")
 		if part == "" {
+			if fromBreak && len(out) > 0 {
+				out = append(out, keptBlank)
+			}
 			continue
 		}
 		out = append(out, part)
 	}
+	for len(out) > 0 && out[len(out)-1] == keptBlank {
+		out = out[:len(out)-1]
+	}
 	normalized := strings.Join(out, "\n")
 	replacer := strings.NewReplacer(
 		" .", ".",
@@ -0,0 +1,317 @@
+package article
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+// markdownBreak marks a <br> while inline whitespace is collapsed.
+const markdownBreak = "\x00br\x00"
+
+// MarkdownFromEntry converts the entry's HTML content to Markdown for export,
+// falling back to the summary when there is no content. Relative links and
+// images are resolved against the entry URL.
+func MarkdownFromEntry(entry feedbin.Entry) string {
+	content := strings.TrimSpace(entry.Content)
+	if content == "" {
+		return strings.TrimSpace(entry.Summary)
+	}
+	doc, err := nethtml.Parse(strings.NewReader("<html><body>" + content + "</body></html>"))
+	if err != nil {
+		return strings.TrimSpace(html.UnescapeString(content))
+	}
+	body := findBodyNode(doc)
+	if body == nil {
+		return strings.TrimSpace(html.UnescapeString(content))
+	}
+	base, _ := url.Parse(strings.TrimSpace(entry.URL))
+	w := markdownWriter{base: base}
+	return strings.Join(w.blocks(elementChildren(body)), "\n\n")
+}
+
+// MarkdownFromEntryWithFooter is MarkdownFromEntry followed by the FooterText
+// attribution line as its own paragraph.
+func MarkdownFromEntryWithFooter(entry feedbin.Entry) string {
+	body := MarkdownFromEntry(entry)
+	footer := FooterText(entry)
+	switch {
+	case footer == "":
+		return body
+	case body == "":
+		return footer
+	}
+	return body + "\n\n" + footer
+}
+
+type markdownWriter struct {
+	base *url.URL
+}
+
+// blocks converts sibling nodes to Markdown blocks, gathering runs of inline
+// nodes into paragraphs.
+func (w markdownWriter) blocks(nodes []*nethtml.Node) []string {
+	var out []string
+	var inline strings.Builder
+	flush := func() {
+		if text := finishInline(inline.String()); text != "" {
+			out = append(out, text)
+		}
+		inline.Reset()
+	}
+	for _, node := range nodes {
+		if node.Type != nethtml.ElementNode || !isBlockElement(strings.ToLower(node.Data)) {
+			inline.WriteString(w.inline(node))
+			continue
+		}
+		flush()
+		out = append(out, w.block(node)...)
+	}
+	flush()
+	return out
+}
+
+func (w markdownWriter) block(node *nethtml.Node) []string {
+	tag := strings.ToLower(node.Data)
+	switch tag {
+	case "script", "style", "noscript":
+		return nil
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		text := finishInline(w.inlineChildren(node))
+		if text == "" {
+			return nil
+		}
+		return []string{strings.Repeat("#", int(tag[1]-'0')) + " " + strings.ReplaceAll(text, "\n", " ")}
+	case "ul", "ol":
+		if list := w.list(node, tag == "ol", 0); list != "" {
+			return []string{list}
+		}
+		return nil
+	case "blockquote":
+		inner := w.blocks(elementChildren(node))
+		if len(inner) == 0 {
+			return nil
+		}
+		return []string{prefixLines(strings.Join(inner, "\n\n"), "> ")}
+	case "pre":
+		text := strings.Trim(html.UnescapeString(collectRawText(node)), "\n")
+		if strings.TrimSpace(text) == "" {
+			return nil
+		}
+		return []string{"```\n" + text + "\n```"}
+	case "hr":
+		return []string{"---"}
+	case "img":
+		if image := w.inline(node); image != "" {
+			return []string{image}
+		}
+		return nil
+	case "table":
+		if table := w.table(node); table != "" {
+			return []string{table}
+		}
+		return nil
+	}
+	if hasBlockChild(node) {
+		return w.blocks(elementChildren(node))
+	}
+	if text := finishInline(w.inlineChildren(node)); text != "" {
+		return []string{text}
+	}
+	return nil
+}
+
+func (w markdownWriter) list(node *nethtml.Node, ordered bool, depth int) string {
+	indent := strings.Repeat("  ", depth)
+	var lines []string
+	n := 0
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != nethtml.ElementNode || !strings.EqualFold(child.Data, "li") {
+			continue
+		}
+		n++
+		marker := "- "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", n)
+		}
+		var text strings.Builder
+		var nested []string
+		for part := child.FirstChild; part != nil; part = part.NextSibling {
+			if part.Type == nethtml.ElementNode && (strings.EqualFold(part.Data, "ul") || strings.EqualFold(part.Data, "ol")) {
+				if sub := w.list(part, strings.EqualFold(part.Data, "ol"), depth+1); sub != "" {
+					nested = append(nested, sub)
+				}
+				continue
+			}
+			text.WriteString(" " + w.inline(part) + " ")
+		}
+		item := strings.ReplaceAll(finishInline(text.String()), "\n", "\n"+indent+"  ")
+		lines = append(lines, indent+marker+item)
+		lines = append(lines, nested...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (w markdownWriter) table(node *nethtml.Node) string {
+	var rows [][]string
+	var walk func(*nethtml.Node)
+	walk = func(n *nethtml.Node) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != nethtml.ElementNode {
+				continue
+			}
+			if !strings.EqualFold(child.Data, "tr") {
+				walk(child)
+				continue
+			}
+			var cells []string
+			for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == nethtml.ElementNode && (strings.EqualFold(cell.Data, "td") || strings.EqualFold(cell.Data, "th")) {
+					text := strings.ReplaceAll(finishInline(w.inlineChildren(cell)), "\n", " ")
+					cells = append(cells, strings.ReplaceAll(text, "|", `\|`))
+				}
+			}
+			if len(cells) > 0 {
+				rows = append(rows, cells)
+			}
+		}
+	}
+	walk(node)
+	if len(rows) == 0 {
+		return ""
+	}
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	lines := make([]string, 0, len(rows)+1)
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (w markdownWriter) inlineChildren(node *nethtml.Node) string {
+	var b strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(w.inline(child))
+	}
+	return b.String()
+}
+
+func (w markdownWriter) inline(node *nethtml.Node) string {
+	switch node.Type {
+	case nethtml.TextNode:
+		return node.Data
+	case nethtml.ElementNode:
+	default:
+		return ""
+	}
+	switch strings.ToLower(node.Data) {
+	case "script", "style", "noscript":
+		return ""
+	case "br":
+		return markdownBreak
+	case "img":
+		src := w.resolve(nodeAttr(node, "src"))
+		if src == "" {
+			return ""
+		}
+		return "![" + nodeAttr(node, "alt") + "](" + src + ")"
+	case "a":
+		text := collapseSpaces(w.inlineChildren(node))
+		href := w.resolve(nodeAttr(node, "href"))
+		switch {
+		case href == "":
+			return text
+		case strings.TrimSpace(text) == "":
+			return "<" + href + ">"
+		default:
+			return "[" + strings.TrimSpace(text) + "](" + href + ")"
+		}
+	case "strong", "b":
+		return wrapInline(w.inlineChildren(node), "**")
+	case "em", "i", "cite":
+		return wrapInline(w.inlineChildren(node), "*")
+	case "del", "s", "strike":
+		return wrapInline(w.inlineChildren(node), "~~")
+	case "code", "kbd", "samp":
+		return wrapInline(w.inlineChildren(node), "`")
+	default:
+		return w.inlineChildren(node)
+	}
+}
+
+// resolve makes ref absolute against the entry URL.
+func (w markdownWriter) resolve(ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || w.base == nil {
+		return ref
+	}
+	parsed, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return w.base.ResolveReference(parsed).String()
+}
+
+// wrapInline surrounds text with marker, keeping surrounding whitespace
+// outside so the emphasis stays valid Markdown.
+func wrapInline(text, marker string) string {
+	trimmed := strings.TrimSpace(collapseSpaces(text))
+	if trimmed == "" {
+		return text
+	}
+	lead := text[:len(text)-len(strings.TrimLeft(text, " \t\n"))]
+	trail := text[len(strings.TrimRight(text, " \t\n")):]
+	return collapseSpaces(lead) + marker + trimmed + marker + collapseSpaces(trail)
+}
+
+func collapseSpaces(s string) string {
+	s = normalizeTypography(html.UnescapeString(s), false)
+	fields := strings.Fields(s)
+	out := strings.Join(fields, " ")
+	if len(fields) > 0 {
+		if strings.TrimLeft(s, " \t\n\r") != s {
+			out = " " + out
+		}
+		if strings.TrimRight(s, " \t\n\r") != s {
+			out += " "
+		}
+	} else if s != "" {
+		out = " "
+	}
+	return out
+}
+
+// finishInline collapses whitespace in a paragraph and turns <br> markers
+// into Markdown hard line breaks.
+func finishInline(s string) string {
+	parts := strings.Split(s, markdownBreak)
+	lines := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if line := strings.TrimSpace(collapseSpaces(part)); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "  \n")
+}
+
+func prefixLines(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(prefix+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,52 @@
+package article
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+func TestMarkdownFromEntry_ConvertsCommonBlocks(t *testing.T) {
+	entry := feedbin.Entry{
+		URL: "https://example.com/posts/1",
+		Content: `<h2>Release  notes</h2>
+<p>Read <a href="/docs">the <em>docs</em></a> and <strong>upgrade</strong>.<br>Thanks!</p>
+<ul><li>First</li><li>Second<ol><li>Nested</li></ol></li></ul>
+<blockquote><p>Quoted</p></blockquote>
+<pre><code>go test ./...</code></pre>
+<img src="img/shot.png" alt="Shot">`,
+	}
+	want := "## Release notes\n\n" +
+		"Read [the *docs*](https://example.com/docs) and **upgrade**.  \nThanks!\n\n" +
+		"- First\n- Second\n  1. Nested\n\n" +
+		"> Quoted\n\n" +
+		"```\ngo test ./...\n```\n\n" +
+		"![Shot](https://example.com/posts/img/shot.png)"
+	if got := MarkdownFromEntry(entry); got != want {
+		t.Fatalf("unexpected markdown:\n%s\n--- want ---\n%s", got, want)
+	}
+}
+
+func TestMarkdownFromEntry_FallsBackToSummary(t *testing.T) {
+	if got := MarkdownFromEntry(feedbin.Entry{Summary: "  Short summary "}); got != "Short summary" {
+		t.Fatalf("expected summary fallback, got %q", got)
+	}
+}
+
+func TestMarkdownFromEntryWithFooter_AppendsAttribution(t *testing.T) {
+	entry := feedbin.Entry{
+		URL:         "https://example.com/post",
+		FeedTitle:   "Feed A",
+		Content:     "<p>Body</p>",
+		PublishedAt: time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC),
+	}
+	want := "Body\n\n— Read more at https://example.com/post • Feed A • 2026-02-01"
+	if got := MarkdownFromEntryWithFooter(entry); got != want {
+		t.Fatalf("unexpected markdown:\n%s\n--- want ---\n%s", got, want)
+	}
+	if got := MarkdownFromEntry(entry); strings.Contains(got, "Read more at") {
+		t.Fatalf("expected no footer without the option, got %q", got)
+	}
+}
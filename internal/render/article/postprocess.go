@@ -1,15 +1,39 @@
 package article
 
 import (
+	"log"
 	"net/url"
 	"strings"
+	"sync"
 )
 
+// blankedArticles holds the URLs whose postprocessing warning was already
+// logged, so re-rendering the same article does not repeat it.
+var blankedArticles sync.Map
+
 func applyReaderPostprocessing(lines []string, articleURL string) []string {
+	return applyReaderRules(lines, readerFilterRules(articleURL), articleURL)
+}
+
+// applyReaderRules filters lines with rules. When the rules would drop every
+// paragraph of an article that has text, the unprocessed lines are kept and a
+// warning is logged once per URL: a rule that blanks whole articles is
+// misconfigured.
+func applyReaderRules(lines []string, rules readerFilterRuleSet, articleURL string) []string {
 	if len(lines) == 0 {
 		return nil
 	}
-	rules := readerFilterRules(articleURL)
+	out := filterReaderParagraphs(append([]string(nil), lines...), rules)
+	if len(out) == 0 && len(paragraphsFromLines(lines)) > 0 {
+		if _, logged := blankedArticles.LoadOrStore(articleURL, struct{}{}); !logged {
+			log.Printf("warning: postprocessing removed all content of %q, showing it unprocessed", articleURL)
+		}
+		return lines
+	}
+	return out
+}
+
+func filterReaderParagraphs(lines []string, rules readerFilterRuleSet) []string {
 	if len(rules.replaceAll) > 0 {
 		for i := range lines {
 			for old, newVal := range rules.replaceAll {
@@ -138,7 +162,7 @@ func styleDetailLinks(lines []string) []string {
 	}
 	out := make([]string, len(lines))
 	for i, line := range lines {
-		out[i] = reHTTPURL.ReplaceAllStringFunc(line, func(m string) string {
+		out[i] = replaceOutsideHyperlinks(line, reHTTPURL, func(m string) string {
 			return detailLinkURL.Render(m)
 		})
 	}
@@ -1,7 +1,11 @@
 package article
 
 import (
+	"bytes"
+	"log"
+	"os"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -104,3 +108,40 @@ func TestNormalizeRuleText(t *testing.T) {
 		t.Fatalf("unexpected normalized rule text: %q", got)
 	}
 }
+
+func TestApplyReaderRules_KeepsContentWhenRulesMatchEverything(t *testing.T) {
+	lines := []string{"References", "", "First paragraph of the story.", "", "Second paragraph."}
+	rules := readerFilterRuleSet{
+		skipParagraphContains: []string{"paragraph"},
+		endBeforeEquals:       []string{"references"},
+	}
+
+	got := applyReaderRules(append([]string(nil), lines...), rules, "https://example.com/story")
+	if !reflect.DeepEqual(got, lines) {
+		t.Fatalf("expected original content to survive, got %q", got)
+	}
+
+	rules = readerFilterRuleSet{skipParagraphContains: []string{"second"}}
+	got = applyReaderRules(append([]string(nil), lines...), rules, "https://example.com/story")
+	want := []string{"References", "", "First paragraph of the story."}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected partial filtering unchanged, got %q", got)
+	}
+}
+
+func TestApplyReaderRules_WarnsOncePerURL(t *testing.T) {
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	lines := []string{"Only paragraph."}
+	rules := readerFilterRuleSet{skipParagraphContains: []string{"paragraph"}}
+	for range 3 {
+		applyReaderRules(append([]string(nil), lines...), rules, "https://example.com/warn-once")
+	}
+	applyReaderRules(append([]string(nil), lines...), rules, "https://example.com/other")
+
+	if got := strings.Count(logged.String(), "removed all content"); got != 2 {
+		t.Fatalf("expected one warning per URL, got %d:\n%s", got, logged.String())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"html"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 
@@ -12,7 +13,8 @@ import (
 	"github.com/glabrego/reeder-cli/internal/feedbin"
 )
 
-var reANSICodes = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+// ANSICodes matches zero-width escapes: SGR styling and OSC 8 hyperlinks.
+var ANSICodes = regexp.MustCompile(`\x1b\[[0-9;]*m|` + osc8Pattern)
 var reHTTPURL = regexp.MustCompile(`https?://[^\s)]+`)
 
 type readerFilterRuleSet struct {
@@ -30,16 +32,81 @@ const (
 	ImageModeNone
 )
 
+// DefaultMaxLines bounds how many lines a single article may render to.
+const DefaultMaxLines = 5000
+
+// ImagePreviewAnchor marks where an image sits in rendered content when
+// Options.ImageAnchors is set, so callers can splice a preview in place. The
+// anchor line ends with the image's position among the <img> elements of the
+// content; see ImageAnchor.
+const ImagePreviewAnchor = "__INLINE_IMAGE_PREVIEW_ANCHOR__"
+
+func imageAnchorLine(image int) string {
+	return ImagePreviewAnchor + strconv.Itoa(image)
+}
+
+// ImageAnchor reports whether line is an ImagePreviewAnchor line and which
+// image, as numbered by PreviewImage, it marks.
+func ImageAnchor(line string) (int, bool) {
+	rest, ok := strings.CutPrefix(line, ImagePreviewAnchor)
+	if !ok {
+		return 0, false
+	}
+	image, err := strconv.Atoi(rest)
+	if err != nil {
+		return 0, false
+	}
+	return image, true
+}
+
+const truncatedContentNotice = "… content truncated (press o to open in browser)"
+
 type Options struct {
 	StyleLinks          bool
 	ApplyPostprocessing bool
 	ImageMode           ImageMode
+	// MaxLines stops rendering once an article reaches this many lines.
+	// Zero disables the budget.
+	MaxLines int
+	// ASCIIPunctuation converts smart quotes, dashes and ellipses to ASCII.
+	ASCIIPunctuation bool
+	// ImageAnchors emits an ImagePreviewAnchor line after each rendered image.
+	ImageAnchors bool
+	// Hyperlinks renders link text as OSC 8 hyperlinks instead of appending
+	// the URL in parentheses.
+	Hyperlinks bool
+	// LineBreaking picks word or character wrapping; auto detects CJK text.
+	LineBreaking LineBreaking
+	// ShortenURLs elides the middle of long URLs shown in the detail header
+	// and list titles; copy and open still use the full URL.
+	ShortenURLs bool
+	// CommentCounts shows the comment count discussion feeds write into
+	// their entries in the list and the detail header.
+	CommentCounts bool
+	// SourceLabel names where the article body comes from in a Source: line
+	// of the detail header; empty shows no such line.
+	SourceLabel string
+	// DateAge follows the date in the detail header, such as "2 hours ago";
+	// empty shows the date alone.
+	DateAge string
+	// BlankLines picks which blank lines are kept instead of folded into
+	// one.
+	BlankLines BlankLines
+	// RightToLeft right-aligns articles written mostly in a right-to-left
+	// script and marks them in the detail header.
+	RightToLeft bool
+	// Footer appends a "Read more at" line with the URL, feed and date.
+	Footer bool
+	// PreferSummary renders the summary when there is one and only falls
+	// back to the content when it is empty.
+	PreferSummary bool
 }
 
 var DefaultOptions = Options{
 	StyleLinks:          true,
 	ApplyPostprocessing: true,
 	ImageMode:           ImageModeLabel,
+	MaxLines:            DefaultMaxLines,
 }
 
 func withDefaults(opts Options) Options {
@@ -47,12 +114,44 @@ func withDefaults(opts Options) Options {
 	if out.ImageMode != ImageModeLabel && out.ImageMode != ImageModeNone {
 		out.ImageMode = DefaultOptions.ImageMode
 	}
+	if out.MaxLines < 0 {
+		out.MaxLines = 0
+	}
 	return out
 }
 
 type htmlArticleRenderer struct {
-	width int
-	opts  Options
+	width     int
+	opts      Options
+	budget    *lineBudget
+	script    script
+	footnotes footnotes
+	// images numbers the <img> elements in document order for their anchors.
+	images map[*nethtml.Node]int
+}
+
+func (r htmlArticleRenderer) wrap(text string, width int) []string {
+	return wrapTextFor(r.script, text, width)
+}
+
+// lineBudget is shared across nested renderer calls so block loops can bail
+// out early instead of rendering the remainder of a huge document.
+type lineBudget struct {
+	max       int
+	truncated bool
+}
+
+// stop reports whether rendering should halt given the lines produced so far
+// at the current nesting level, marking the budget as truncated when it does.
+func (b *lineBudget) stop(rendered int) bool {
+	if b == nil || b.max <= 0 {
+		return false
+	}
+	if b.truncated || rendered >= b.max {
+		b.truncated = true
+		return true
+	}
+	return false
 }
 
 func ContentLines(entry feedbin.Entry, width int) []string {
@@ -60,15 +159,54 @@ func ContentLines(entry feedbin.Entry, width int) []string {
 }
 
 func ContentLinesWithOptions(entry feedbin.Entry, width int, opts Options) []string {
+	opts = withDefaults(opts)
+	lines := contentLines(entry, width, opts)
+	if opts.RightToLeft && LinesRTL(lines) {
+		lines = alignRight(lines, width)
+	}
+	if !opts.Footer {
+		return lines
+	}
+	footer := normalizeTypography(FooterText(entry), opts.ASCIIPunctuation)
+	if footer == "" {
+		return lines
+	}
+	if len(lines) > 0 {
+		lines = append(lines, "")
+	}
+	return append(lines, wrapTextFor(resolveScript(opts.LineBreaking, footer), footer, width)...)
+}
+
+// FooterText is the attribution line appended to articles when
+// Options.Footer is set, e.g. "— Read more at <url> • Feed A • 2026-02-01".
+// Missing parts are left out; it is empty when the entry has none of them.
+func FooterText(entry feedbin.Entry) string {
+	var parts []string
+	if u := strings.TrimSpace(entry.URL); u != "" {
+		parts = append(parts, "Read more at "+u)
+	}
+	if feed := strings.TrimSpace(entry.FeedTitle); feed != "" {
+		parts = append(parts, feed)
+	}
+	if !entry.PublishedAt.IsZero() {
+		parts = append(parts, entry.PublishedAt.Format("2006-01-02"))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "— " + strings.Join(parts, " • ")
+}
+
+func contentLines(entry feedbin.Entry, width int, opts Options) []string {
 	content := strings.TrimSpace(entry.Content)
-	if content == "" {
-		summary := strings.TrimSpace(entry.Summary)
+	summary := strings.TrimSpace(entry.Summary)
+	if content == "" || (opts.PreferSummary && summary != "") {
 		if summary == "" {
 			return nil
 		}
-		return wrapText(summary, width)
+		return applyLineBudget(wrapTextFor(resolveScript(opts.LineBreaking, summary), normalizeTypography(summary, opts.ASCIIPunctuation), width), opts.MaxLines, false)
 	}
-	lines := renderHTMLFragmentLines(content, width, entry.URL, withDefaults(opts))
+	lines := renderHTMLFragmentLines(content, width, entry.URL, opts)
 	if len(lines) > 0 {
 		return lines
 	}
@@ -76,7 +214,7 @@ func ContentLinesWithOptions(entry feedbin.Entry, width int, opts Options) []str
 	if text == "" {
 		return nil
 	}
-	return wrapText(text, width)
+	return applyLineBudget(wrapTextFor(resolveScript(opts.LineBreaking, text), normalizeTypography(text, opts.ASCIIPunctuation), width), opts.MaxLines, false)
 }
 
 func TextFromEntry(entry feedbin.Entry) string {
@@ -100,21 +238,47 @@ func renderHTMLFragmentLines(raw string, width int, articleURL string, opts Opti
 	}
 	doc, err := nethtml.Parse(strings.NewReader("<html><body>" + raw + "</body></html>"))
 	if err != nil {
-		return wrapText(strings.TrimSpace(html.UnescapeString(raw)), width)
+		text := html.UnescapeString(raw)
+		return wrapTextFor(resolveScript(opts.LineBreaking, text), normalizeTypography(strings.TrimSpace(text), opts.ASCIIPunctuation), width)
 	}
 	body := findBodyNode(doc)
 	if body == nil {
-		return wrapText(strings.TrimSpace(html.UnescapeString(raw)), width)
+		text := html.UnescapeString(raw)
+		return wrapTextFor(resolveScript(opts.LineBreaking, text), normalizeTypography(strings.TrimSpace(text), opts.ASCIIPunctuation), width)
+	}
+	normalizeTextNodes(body, opts.ASCIIPunctuation)
+	images := make(map[*nethtml.Node]int)
+	for i, img := range imageNodes(body) {
+		images[img] = i
 	}
-	renderer := htmlArticleRenderer{width: max(1, width), opts: opts}
-	lines := trimBlankLines(renderer.renderNodes(elementChildren(body), 0))
+	notes := extractFootnotes(body)
+	budget := &lineBudget{max: opts.MaxLines}
+	renderer := htmlArticleRenderer{width: max(1, width), opts: opts, budget: budget, script: resolveScript(opts.LineBreaking, collectRawText(body)), footnotes: notes, images: images}
+	lines := trimBlankLines(append(renderer.renderNodes(elementChildren(body), 0), renderer.renderFootnotes()...))
 	if opts.ApplyPostprocessing {
 		lines = applyReaderPostprocessing(lines, articleURL)
 	}
+	lines = restoreBlankLines(lines)
 	if opts.StyleLinks {
 		lines = styleDetailLinks(lines)
 	}
-	return lines
+	return applyLineBudget(lines, opts.MaxLines, budget.truncated)
+}
+
+// applyLineBudget caps lines at maxLines and appends the truncation notice when
+// the cap was hit here or rendering already stopped early.
+func applyLineBudget(lines []string, maxLines int, truncated bool) []string {
+	if maxLines <= 0 {
+		return lines
+	}
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		truncated = true
+	}
+	if !truncated {
+		return lines
+	}
+	return append(trimBlankLines(lines), "", detailCitation.Render(truncatedContentNotice))
 }
 
 func ImageURLsFromContent(content string) []string {
@@ -152,6 +316,85 @@ func ImageURLsFromContent(content string) []string {
 	return out
 }
 
+// ImageChoice picks which image of an article gets the inline preview.
+type ImageChoice int
+
+const (
+	ImageChoiceFirst ImageChoice = iota
+	ImageChoiceLast
+	// ImageChoiceLargest uses the declared width × height attributes;
+	// images without them count as zero and ties go to the earlier image.
+	ImageChoiceLargest
+)
+
+// ParseImageChoice maps "last" and "largest" to their choice and anything
+// else to ImageChoiceFirst.
+func ParseImageChoice(v string) ImageChoice {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "last":
+		return ImageChoiceLast
+	case "largest":
+		return ImageChoiceLargest
+	}
+	return ImageChoiceFirst
+}
+
+var reLeadingDigits = regexp.MustCompile(`^\d+`)
+
+// PreviewImage picks the image to preview from content. It returns the image
+// URL and its position among the <img> elements the renderer parses, which
+// ImageAnchor reads back from the image's anchor line; ("", -1) when no image
+// has an http(s) source. Images the renderer drops have no anchor.
+func PreviewImage(content string, choice ImageChoice) (string, int) {
+	doc, err := nethtml.Parse(strings.NewReader("<html><body>" + strings.TrimSpace(content) + "</body></html>"))
+	if err != nil {
+		return "", -1
+	}
+	body := findBodyNode(doc)
+	if body == nil {
+		return "", -1
+	}
+	pickedURL, picked, pickedArea := "", -1, -1
+	for i, img := range imageNodes(body) {
+		raw := nodeAttr(img, "src")
+		parsed, err := url.Parse(raw)
+		if raw == "" || err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			continue
+		}
+		switch choice {
+		case ImageChoiceLast:
+			pickedURL, picked = raw, i
+		case ImageChoiceLargest:
+			if area := declaredImageArea(img); area > pickedArea {
+				pickedURL, picked, pickedArea = raw, i, area
+			}
+		default:
+			return raw, i
+		}
+	}
+	return pickedURL, picked
+}
+
+func declaredImageArea(img *nethtml.Node) int {
+	size := func(name string) int {
+		n, _ := strconv.Atoi(reLeadingDigits.FindString(nodeAttr(img, name)))
+		return n
+	}
+	return size("width") * size("height")
+}
+
+// imageNodes lists the <img> elements under body in document order. Images
+// inside <noscript> or <script> are text to the parser and not listed.
+func imageNodes(body *nethtml.Node) []*nethtml.Node {
+	var images []*nethtml.Node
+	walkElements(body, func(node *nethtml.Node) {
+		if strings.EqualFold(node.Data, "img") {
+			images = append(images, node)
+		}
+	})
+	return images
+}
+
 func trimBlankLines(lines []string) []string {
 	if len(lines) == 0 {
 		return lines
@@ -199,20 +442,21 @@ func wrapText(text string, width int) []string {
 		}
 		line := ""
 		for _, word := range words {
-			for len(word) > width {
+			for visibleLen(word) > width {
 				if line != "" {
 					out = append(out, line)
 					line = ""
 				}
-				out = append(out, word[:width])
-				word = word[width:]
+				head, rest := splitVisible(word, width)
+				out = append(out, head)
+				word = rest
 			}
 
 			if line == "" {
 				line = word
 				continue
 			}
-			if len(line)+1+len(word) <= width {
+			if visibleLen(line)+1+visibleLen(word) <= width {
 				line += " " + word
 				continue
 			}
@@ -227,12 +471,59 @@ func wrapText(text string, width int) []string {
 	return out
 }
 
+// splitVisible cuts word after width visible runes. A hyperlink or style
+// still open at the cut is closed in the head and reopened in the rest, so
+// escapes are never split and each piece is a complete link on its own.
+func splitVisible(word string, width int) (string, string) {
+	if !strings.Contains(word, "\x1b") {
+		runes := []rune(word)
+		return string(runes[:width]), string(runes[width:])
+	}
+	var styles []string
+	link := ""
+	n := 0
+	for i := 0; i < len(word); {
+		if word[i] == '\x1b' {
+			if loc := ANSICodes.FindStringIndex(word[i:]); loc != nil && loc[0] == 0 {
+				seq := word[i : i+loc[1]]
+				switch {
+				case strings.HasPrefix(seq, "\x1b]8;"):
+					link = ""
+					if !isClosingEscape(seq) {
+						link = seq
+					}
+				case isClosingEscape(seq):
+					styles = nil
+				default:
+					styles = append(styles, seq)
+				}
+				i += loc[1]
+				continue
+			}
+		}
+		if n == width {
+			head := word[:i]
+			if link != "" {
+				head += osc8Close
+			}
+			if len(styles) > 0 {
+				head += "\x1b[0m"
+			}
+			return head, strings.Join(styles, "") + link + word[i:]
+		}
+		_, size := utf8.DecodeRuneInString(word[i:])
+		n++
+		i += size
+	}
+	return word, ""
+}
+
 func visibleLen(s string) int {
 	return utf8.RuneCountInString(stripANSI(s))
 }
 
 func stripANSI(s string) string {
-	return reANSICodes.ReplaceAllString(s, "")
+	return ANSICodes.ReplaceAllString(s, "")
 }
 
 func findBodyNode(node *nethtml.Node) *nethtml.Node {
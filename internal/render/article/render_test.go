@@ -2,8 +2,10 @@ package article
 
 import (
 	"regexp"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/glabrego/reeder-cli/internal/feedbin"
 )
@@ -32,6 +34,39 @@ func TestImageURLsFromContent_OnlyHTTPAndDeduplicated(t *testing.T) {
 	}
 }
 
+func TestPreviewImage_FirstLastLargest(t *testing.T) {
+	content := `<p><img src="data:image/gif;base64,R0lGOD" width="1" height="1">` +
+		`<img src="https://example.com/logo.png" width="40" height="40">` +
+		`<img alt="hero" src="https://example.com/hero.jpg" width="800" height="450">` +
+		`<img src="https://example.com/footer.png"></p>`
+	cases := []struct {
+		choice     ImageChoice
+		wantURL    string
+		wantAnchor int
+	}{
+		{ImageChoiceFirst, "https://example.com/logo.png", 1},
+		{ImageChoiceLast, "https://example.com/footer.png", 3},
+		{ImageChoiceLargest, "https://example.com/hero.jpg", 2},
+	}
+	for _, tc := range cases {
+		url, anchor := PreviewImage(content, tc.choice)
+		if url != tc.wantURL || anchor != tc.wantAnchor {
+			t.Fatalf("choice %d: got (%q, %d), want (%q, %d)", tc.choice, url, anchor, tc.wantURL, tc.wantAnchor)
+		}
+	}
+
+	noSizes := `<img src="https://example.com/a.png"><img src="https://example.com/b.png">`
+	if url, anchor := PreviewImage(noSizes, ImageChoiceLargest); url != "https://example.com/a.png" || anchor != 0 {
+		t.Fatalf("expected ties to go to the first image, got (%q, %d)", url, anchor)
+	}
+	if url, anchor := PreviewImage(`<p>No images</p>`, ImageChoiceLast); url != "" || anchor != -1 {
+		t.Fatalf("expected no image, got (%q, %d)", url, anchor)
+	}
+	if ParseImageChoice(" Largest ") != ImageChoiceLargest || ParseImageChoice("last") != ImageChoiceLast || ParseImageChoice("bogus") != ImageChoiceFirst {
+		t.Fatal("unexpected ParseImageChoice mapping")
+	}
+}
+
 func TestContentLines_ImagesFollowContentOrder(t *testing.T) {
 	entry := feedbin.Entry{
 		Content: `<p>First paragraph.</p><p><img src="https://example.com/one.jpg" alt="Figure one"></p><p>Second paragraph.</p><p><img src="https://example.com/two.jpg" alt="Figure two"></p>`,
@@ -128,3 +163,422 @@ func TestContentLines_PostProcessingWiredRemovesPromoTail(t *testing.T) {
 		t.Fatalf("expected promo tail removed, got %q", got)
 	}
 }
+
+func TestContentLines_TruncatesAtLineBudget(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("<ul>")
+	for i := 0; i < 20000; i++ {
+		b.WriteString("<li>Item with some repeated words to wrap</li>")
+	}
+	b.WriteString("</ul><table>")
+	for i := 0; i < 5000; i++ {
+		b.WriteString("<tr><td>a</td><td>b</td></tr>")
+	}
+	b.WriteString("</table>")
+
+	opts := DefaultOptions
+	opts.MaxLines = 200
+	lines := ContentLinesWithOptions(feedbin.Entry{Content: b.String()}, 40, opts)
+	if len(lines) > opts.MaxLines+2 {
+		t.Fatalf("expected at most %d lines, got %d", opts.MaxLines+2, len(lines))
+	}
+	last := stripANSIForTest.ReplaceAllString(lines[len(lines)-1], "")
+	if last != truncatedContentNotice {
+		t.Fatalf("expected truncation notice as last line, got %q", last)
+	}
+}
+
+func TestContentLines_NoTruncationNoticeWithinBudget(t *testing.T) {
+	opts := DefaultOptions
+	opts.MaxLines = 10
+	lines := ContentLinesWithOptions(feedbin.Entry{Content: "<p>One.</p><p>Two.</p>"}, 40, opts)
+	got := stripANSIForTest.ReplaceAllString(strings.Join(lines, "\n"), "")
+	if strings.Contains(got, "content truncated") {
+		t.Fatalf("did not expect truncation notice, got %q", got)
+	}
+
+	opts.MaxLines = 0
+	var b strings.Builder
+	for i := 0; i < 50; i++ {
+		b.WriteString("<p>Paragraph.</p>")
+	}
+	lines = ContentLinesWithOptions(feedbin.Entry{Content: b.String()}, 40, opts)
+	if len(lines) != 99 {
+		t.Fatalf("expected unlimited rendering with zero budget, got %d lines", len(lines))
+	}
+}
+
+func TestContentLines_NormalizesNonBreakingAndZeroWidthSpaces(t *testing.T) {
+	entry := feedbin.Entry{Content: "<p>one&nbsp;two&nbsp;three\u200b&nbsp;four\u202ffive</p>"}
+	opts := Options{ImageMode: ImageModeNone}
+
+	got := ContentLinesWithOptions(entry, 10, opts)
+	want := []string{"one two", "three four", "five"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected wrapping: %q", got)
+	}
+}
+
+func TestContentLines_SmartPunctuationWidthAndASCIIOption(t *testing.T) {
+	entry := feedbin.Entry{Content: "<p>&ldquo;Quoted&rdquo; text&mdash;with a pause&hellip; and “more” quotes</p>"}
+	opts := Options{ImageMode: ImageModeNone}
+
+	lines := ContentLinesWithOptions(entry, 16, opts)
+	for _, line := range lines {
+		if n := visibleLen(line); n > 16 {
+			t.Fatalf("line %q is %d cells wide, want <= 16", line, n)
+		}
+	}
+	if joined := strings.Join(lines, " "); !strings.Contains(joined, "“Quoted”") || !strings.Contains(joined, "—") {
+		t.Fatalf("expected typographic punctuation kept by default, got %q", joined)
+	}
+
+	opts.ASCIIPunctuation = true
+	joined := strings.Join(ContentLinesWithOptions(entry, 80, opts), "\n")
+	if joined != `"Quoted" text--with a pause... and "more" quotes` {
+		t.Fatalf("unexpected ASCII rendering: %q", joined)
+	}
+
+	summary := ContentLinesWithOptions(feedbin.Entry{Summary: "It’s here"}, 80, opts)
+	if len(summary) != 1 || summary[0] != "It's here" {
+		t.Fatalf("expected summary fallback normalized, got %q", summary)
+	}
+}
+
+func TestWrapText_SplitsLongWordsOnRuneBoundaries(t *testing.T) {
+	got := wrapText("ééééé", 2)
+	want := []string{"éé", "éé", "é"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected rune wrapping: %q", got)
+	}
+}
+
+func TestContentLines_HyperlinksKeepVisibleWidth(t *testing.T) {
+	entry := feedbin.Entry{Content: `<p>Read the <a href="https://example.com/launch-notes">full launch notes</a> before upgrading your cluster today.</p>`}
+	opts := Options{Hyperlinks: true, StyleLinks: true}
+
+	linked := ContentLinesWithOptions(entry, 24, opts)
+	plain := ContentLinesWithOptions(feedbin.Entry{Content: `<p>Read the full launch notes before upgrading your cluster today.</p>`}, 24, opts)
+	if len(linked) != len(plain) {
+		t.Fatalf("expected the same wrapping with and without hyperlinks, got %q vs %q", linked, plain)
+	}
+	for i := range linked {
+		if stripANSI(linked[i]) != plain[i] || visibleLen(linked[i]) != visibleLen(plain[i]) {
+			t.Fatalf("line %d: expected visible text %q, got %q", i, plain[i], stripANSI(linked[i]))
+		}
+	}
+	joined := strings.Join(linked, "\n")
+	if !strings.Contains(joined, "\x1b]8;;https://example.com/launch-notes\x1b\\launch\x1b]8;;\x1b\\") {
+		t.Fatalf("expected OSC 8 wrapped link words, got %q", joined)
+	}
+	if strings.Count(joined, "\x1b]8;;https://") != 3 {
+		t.Fatalf("expected one hyperlink per link word, got %q", joined)
+	}
+}
+
+func TestContentLines_LongHyperlinkWrapsToWidth(t *testing.T) {
+	const href = "https://example.com/a/very/long/path/to/the/launch-notes"
+	entry := feedbin.Entry{Content: `<p>See <a href="` + href + `">` + href + `</a> now.</p>`}
+
+	lines := ContentLinesWithOptions(entry, 30, Options{Hyperlinks: true})
+	plain := ContentLinesWithOptions(entry, 30, Options{})
+	if len(lines) != len(plain) {
+		t.Fatalf("expected the same wrapping with and without hyperlinks, got %q vs %q", lines, plain)
+	}
+	for i, line := range lines {
+		if visibleLen(line) > 30 {
+			t.Fatalf("line %d is %d cells wide: %q", i, visibleLen(line), stripANSI(line))
+		}
+		if stripANSI(line) != plain[i] {
+			t.Fatalf("line %d: expected visible text %q, got %q", i, plain[i], stripANSI(line))
+		}
+		if strings.Count(line, "\x1b]8;;https://") != strings.Count(line, "\x1b]8;;\x1b\\") {
+			t.Fatalf("line %d leaves a hyperlink open: %q", i, line)
+		}
+	}
+}
+
+func TestHyperlink_RejectsUnsafeTargets(t *testing.T) {
+	for _, target := range []string{"javascript:alert(1)", "https://example.com/a b", "https://example.com/\x1b]"} {
+		if got := Hyperlink("text", target); got != "text" {
+			t.Fatalf("expected %q left unlinked, got %q", target, got)
+		}
+	}
+}
+
+func TestStyleDetailLinks_SkipsHyperlinkTargets(t *testing.T) {
+	line := Hyperlink("https://example.com/x", "https://example.com/x")
+	styled := styleDetailLinks([]string{line})[0]
+	if !strings.HasPrefix(styled, "\x1b]8;;https://example.com/x\x1b\\") || stripANSI(styled) != "https://example.com/x" {
+		t.Fatalf("expected link target untouched by URL styling, got %q", styled)
+	}
+}
+
+func TestDetectScript(t *testing.T) {
+	cases := []struct {
+		text string
+		want script
+	}{
+		{"吾輩は猫である。名前はまだ無い。", scriptCJK},
+		{"我们今天发布了新版本，欢迎试用。", scriptCJK},
+		{"Go 1.24 のリリースノートを読みました", scriptCJK},
+		{"A plain English paragraph with one 漢字 in it.", scriptOther},
+		{"", scriptOther},
+	}
+	for _, tc := range cases {
+		if got := detectScript(tc.text); got != tc.want {
+			t.Fatalf("detectScript(%q) = %v, want %v", tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestIsRTL(t *testing.T) {
+	cases := []struct {
+		text string
+		want bool
+	}{
+		{"مرحبا بالعالم، هذا مقال تجريبي.", true},
+		{"שלום עולם, זהו מאמר לדוגמה.", true},
+		{"Go 1.24 מביא שיפורים רבים לשפה", true},
+		{"An English paragraph quoting one word: سلام.", false},
+		{"", false},
+	}
+	for _, tc := range cases {
+		if got := isRTL(tc.text); got != tc.want {
+			t.Fatalf("isRTL(%q) = %v, want %v", tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestContentLines_RightToLeftAlignsToRightEdge(t *testing.T) {
+	entry := feedbin.Entry{Content: "<p>مرحبا بالعالم، هذا مقال تجريبي قصير.</p><p>فقرة ثانية.</p>"}
+	lines := ContentLinesWithOptions(entry, 50, Options{RightToLeft: true})
+	for _, line := range lines {
+		if line != "" && visibleCells(line) != 50 {
+			t.Fatalf("expected RTL lines to end at the right edge, got %q (%d)", line, visibleCells(line))
+		}
+	}
+	if !strings.HasPrefix(lines[0], " ") {
+		t.Fatalf("expected the short paragraph padded on the left, got %q", lines[0])
+	}
+
+	if plain := ContentLinesWithOptions(entry, 50, Options{}); strings.HasPrefix(plain[0], " ") {
+		t.Fatalf("expected no alignment without the preference, got %q", plain[0])
+	}
+	english := ContentLinesWithOptions(feedbin.Entry{Content: "<p>Left to right text.</p>"}, 50, Options{RightToLeft: true})
+	if strings.HasPrefix(english[0], " ") {
+		t.Fatalf("expected left-to-right articles untouched, got %q", english[0])
+	}
+}
+
+func TestContentLines_CJKWrapsBetweenCharacters(t *testing.T) {
+	entry := feedbin.Entry{Content: "<p>吾輩は猫である。名前はまだ無い。どこで生れたかとんと見当がつかぬ。</p><p>我们今天发布了新版本，欢迎大家试用并反馈意见。</p>"}
+	lines := ContentLinesWithOptions(entry, 20, Options{})
+	if len(lines) < 4 {
+		t.Fatalf("expected CJK paragraphs wrapped over several lines, got %q", lines)
+	}
+	for _, line := range lines {
+		if visibleCells(line) > 20 {
+			t.Fatalf("expected lines within 20 cells, got %q (%d)", line, visibleCells(line))
+		}
+		if line != "" && strings.ContainsAny(string([]rune(line)[:1]), "、。，") {
+			t.Fatalf("expected no line to start with closing punctuation, got %q", line)
+		}
+	}
+	if lines[0] != "吾輩は猫である。名前" {
+		t.Fatalf("expected the first line filled to 20 cells, got %q", lines[0])
+	}
+	joined := strings.Join(lines, "")
+	if !strings.Contains(joined, "吾輩は猫である。名前はまだ無い。どこで生れたかとんと見当がつかぬ。") {
+		t.Fatalf("expected wrapping to add no spaces, got %q", lines)
+	}
+
+	words := ContentLinesWithOptions(entry, 20, Options{LineBreaking: LineBreakingWords})
+	if words[0] != "吾輩は猫である。名前はまだ無い。どこで生" {
+		t.Fatalf("expected word breaking to cut the unspaced sentence by runes, got %q", words[0])
+	}
+}
+
+func TestWrapCJK_KeepsSpacedWordsAndStylingIntact(t *testing.T) {
+	lines := wrapCJK("新しい \x1b[1mGo\x1b[0m リリース notes", 10)
+	for _, line := range lines {
+		if visibleCells(line) > 10 {
+			t.Fatalf("expected lines within 10 cells, got %q", line)
+		}
+	}
+	joined := strings.Join(lines, "\n")
+	if !strings.Contains(joined, "\x1b[1mGo\x1b[0m") {
+		t.Fatalf("expected styled word kept whole, got %q", lines)
+	}
+	if stripANSI(strings.Join(lines, " ")) != "新しい Go リリース notes" {
+		t.Fatalf("expected original spacing preserved, got %q", lines)
+	}
+}
+
+func TestContentLines_FooterAppearsOnceOnlyWhenEnabled(t *testing.T) {
+	entry := feedbin.Entry{
+		URL:         "https://example.com/post",
+		FeedTitle:   "Feed A",
+		Content:     "<p>Body text.</p>",
+		PublishedAt: time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC),
+	}
+	const footer = "— Read more at https://example.com/post • Feed A • 2026-02-01"
+
+	plain := strings.Join(ContentLinesWithOptions(entry, 120, DefaultOptions), "\n")
+	if strings.Contains(plain, "Read more at") {
+		t.Fatalf("expected no footer by default, got %q", plain)
+	}
+
+	opts := DefaultOptions
+	opts.Footer = true
+	lines := ContentLinesWithOptions(entry, 120, opts)
+	got := stripANSIForTest.ReplaceAllString(strings.Join(lines, "\n"), "")
+	if strings.Count(got, "Read more at") != 1 {
+		t.Fatalf("expected the footer exactly once, got %q", got)
+	}
+	if last := lines[len(lines)-1]; last != footer {
+		t.Fatalf("expected footer as the last line, got %q", last)
+	}
+	if lines[len(lines)-2] != "" {
+		t.Fatalf("expected a blank line before the footer, got %q", lines[len(lines)-2])
+	}
+
+	if got := FooterText(feedbin.Entry{FeedTitle: "Feed A"}); got != "— Feed A" {
+		t.Fatalf("expected missing parts left out, got %q", got)
+	}
+}
+
+func TestContentLines_PreferSummaryOrdering(t *testing.T) {
+	entry := feedbin.Entry{
+		Summary: "Short summary",
+		Content: "<p>Full content body</p>",
+	}
+
+	got := stripANSIForTest.ReplaceAllString(strings.Join(ContentLinesWithOptions(entry, 80, DefaultOptions), "\n"), "")
+	if !strings.Contains(got, "Full content body") || strings.Contains(got, "Short summary") {
+		t.Fatalf("expected content by default, got %q", got)
+	}
+
+	opts := DefaultOptions
+	opts.PreferSummary = true
+	got = stripANSIForTest.ReplaceAllString(strings.Join(ContentLinesWithOptions(entry, 80, opts), "\n"), "")
+	if got != "Short summary" {
+		t.Fatalf("expected summary when preferred, got %q", got)
+	}
+
+	entry.Summary = "  "
+	got = stripANSIForTest.ReplaceAllString(strings.Join(ContentLinesWithOptions(entry, 80, opts), "\n"), "")
+	if !strings.Contains(got, "Full content body") {
+		t.Fatalf("expected content fallback without a summary, got %q", got)
+	}
+}
+
+func TestContentLines_SemanticInlineTags(t *testing.T) {
+	entry := feedbin.Entry{
+		Content: `<p>E = mc<sup>2</sup>, H<sub>2</sub>O, x<sup>a</sup> and y<sub>ij</sub>; <mark>key idea</mark>, <del>old</del> <ins>new</ins>.</p>`,
+	}
+
+	raw := strings.Join(ContentLines(entry, 80), "\n")
+	got := stripANSIForTest.ReplaceAllString(raw, "")
+	want := "E = mc², H₂O, x^a and y_(ij); key idea, old new."
+	if got != want {
+		t.Fatalf("unexpected inline rendering:\n got %q\nwant %q", got, want)
+	}
+	for _, styled := range []string{
+		detailMarkStyle.Render("key"),
+		detailMarkStyle.Render("idea"),
+		detailDelStyle.Render("old"),
+		detailInsStyle.Render("new"),
+	} {
+		if !strings.Contains(raw, styled) {
+			t.Fatalf("expected %q in rendered output, got %q", styled, raw)
+		}
+	}
+}
+
+func TestScriptText_MapsOrFallsBack(t *testing.T) {
+	for _, tc := range []struct {
+		text, marker string
+		table        map[rune]rune
+		want         string
+	}{
+		{"10", "^", superscripts, "¹⁰"},
+		{"-1", "^", superscripts, "⁻¹"},
+		{"n", "^", superscripts, "ⁿ"},
+		{"x", "^", superscripts, "^x"},
+		{"2", "_", subscripts, "₂"},
+		{"i", "_", subscripts, "_i"},
+		{"ij", "_", subscripts, "_(ij)"},
+		{"", "^", superscripts, ""},
+	} {
+		if got := scriptText(tc.text, tc.table, tc.marker); got != tc.want {
+			t.Fatalf("scriptText(%q, %q) = %q, want %q", tc.text, tc.marker, got, tc.want)
+		}
+	}
+}
+
+func TestContentLines_BlankLinesInPreBlocks(t *testing.T) {
+	entry := feedbin.Entry{Content: "<p>Intro</p><pre>\nfunc main() {\n\n\n\tfmt.Println(\"hi\")\n}\n\n</pre><p>After</p>"}
+
+	kept := ContentLinesWithOptions(entry, 60, Options{BlankLines: BlankLinesPre})
+	want := []string{"Intro", "", "    func main() {", "", "", "    \tfmt.Println(\"hi\")", "    }", "", "After"}
+	if !slices.Equal(kept, want) {
+		t.Fatalf("expected the pre block's blank lines kept, got %q", kept)
+	}
+
+	collapsed := ContentLinesWithOptions(entry, 60, Options{})
+	if strings.Contains(strings.Join(collapsed, "\n"), "{\n\n\n") {
+		t.Fatalf("expected blank lines collapsed by default, got %q", collapsed)
+	}
+	for _, line := range kept {
+		if strings.Contains(line, keptBlank) {
+			t.Fatalf("expected no placeholder left in the output, got %q", kept)
+		}
+	}
+}
+
+func TestContentLines_BlankLinesAllKeepsStanzaBreaks(t *testing.T) {
+	entry := feedbin.Entry{Content: "<p>Line one<br>line two<br><br>\n<br>line three<br></p>"}
+
+	all := ContentLinesWithOptions(entry, 60, Options{BlankLines: BlankLinesAll})
+	if want := []string{"Line one", "line two", "", "", "line three"}; !slices.Equal(all, want) {
+		t.Fatalf("expected stanza breaks kept, got %q", all)
+	}
+	pre := ContentLinesWithOptions(entry, 60, Options{BlankLines: BlankLinesPre})
+	if want := []string{"Line one", "line two", "line three"}; !slices.Equal(pre, want) {
+		t.Fatalf("expected <br> runs folded outside pre blocks, got %q", pre)
+	}
+}
+
+func TestContentLines_CollectsFootnotes(t *testing.T) {
+	entry := feedbin.Entry{Content: `<p>Cats sleep a lot<sup id="fnref1"><a href="#fn1" class="footnote-ref">1</a></sup> and dogs less<sup id="fnref2"><a href="#fn2">2</a></sup>.</p>` +
+		`<section class="footnotes" role="doc-endnotes"><hr><ol>` +
+		`<li id="fn1"><p>Up to sixteen hours a day. <a href="#fnref1" class="footnote-back">↩</a></p></li>` +
+		`<li id="fn2"><p>About twelve. <a href="#fnref2">↩</a></p></li>` +
+		`</ol></section>`}
+	lines := ContentLinesWithOptions(entry, 80, Options{})
+	want := []string{
+		"Cats sleep a lot[1] and dogs less[2].",
+		"",
+		"Footnotes:",
+		"[1] Up to sixteen hours a day.",
+		"[2] About twelve.",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("lines = %q, want %q", lines, want)
+	}
+}
+
+func TestContentLines_FootnotesKeepOtherListsAndSuperscripts(t *testing.T) {
+	entry := feedbin.Entry{Content: `<p>E = mc<sup>2</sup><sup><a href="#note">a</a></sup></p>` +
+		`<ol><li>First step</li><li id="note">Einstein, 1905.</li></ol>`}
+	joined := strings.Join(ContentLinesWithOptions(entry, 80, Options{}), "\n")
+	for _, want := range []string{"E = mc²[1]", "1. First step", "Footnotes:\n[1] Einstein, 1905."} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("expected %q in:\n%s", want, joined)
+		}
+	}
+	if strings.Count(joined, "Einstein") != 1 {
+		t.Fatalf("footnote rendered twice:\n%s", joined)
+	}
+}
@@ -0,0 +1,274 @@
+package article
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// LineBreaking selects where wrapped article text may break.
+type LineBreaking int
+
+const (
+	// LineBreakingAuto breaks between characters when the article is mostly
+	// CJK text and at whitespace otherwise.
+	LineBreakingAuto LineBreaking = iota
+	LineBreakingWords
+	LineBreakingCharacters
+)
+
+// ParseLineBreaking maps "auto", "words" or "characters" to a LineBreaking.
+func ParseLineBreaking(raw string) (LineBreaking, bool) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", "auto":
+		return LineBreakingAuto, true
+	case "words":
+		return LineBreakingWords, true
+	case "characters":
+		return LineBreakingCharacters, true
+	default:
+		return LineBreakingAuto, false
+	}
+}
+
+type script int
+
+const (
+	scriptOther script = iota
+	scriptCJK
+)
+
+// detectScript reports the dominant script of text by counting letters. Text
+// is CJK when Han, kana or Hangul make up at least half of its letters.
+func detectScript(text string) script {
+	letters, cjk := 0, 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if isCJKRune(r) {
+			cjk++
+		}
+	}
+	if letters > 0 && cjk*2 >= letters {
+		return scriptCJK
+	}
+	return scriptOther
+}
+
+func resolveScript(mode LineBreaking, text string) script {
+	switch mode {
+	case LineBreakingWords:
+		return scriptOther
+	case LineBreakingCharacters:
+		return scriptCJK
+	default:
+		return detectScript(text)
+	}
+}
+
+// isRTL reports whether text is written mostly in a right-to-left script:
+// Arabic, Hebrew, Syriac, Thaana or N'Ko letters make up at least half of
+// its letters.
+func isRTL(text string) bool {
+	letters, rtl := 0, 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.In(r, unicode.Arabic, unicode.Hebrew, unicode.Syriac, unicode.Thaana, unicode.Nko) {
+			rtl++
+		}
+	}
+	return letters > 0 && rtl*2 >= letters
+}
+
+// LinesRTL reports whether rendered article lines are mostly right-to-left
+// text.
+func LinesRTL(lines []string) bool {
+	return isRTL(stripANSI(strings.Join(lines, "\n")))
+}
+
+// alignRight pads lines so they end at the right edge of width. Full bidi
+// reordering is left to the terminal; this only keeps the ragged edge on the
+// side right-to-left readers start from.
+func alignRight(lines []string, width int) []string {
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		cells := visibleCells(line)
+		if _, anchor := ImageAnchor(line); line == "" || anchor || cells >= width {
+			out[i] = line
+			continue
+		}
+		out[i] = strings.Repeat(" ", width-cells) + line
+	}
+	return out
+}
+
+func isCJKRune(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// runeCells is the terminal width of r: CJK letters, CJK punctuation and
+// fullwidth forms take two cells.
+func runeCells(r rune) int {
+	switch {
+	case isCJKRune(r),
+		r >= 0x3000 && r <= 0x303f,
+		r >= 0xff01 && r <= 0xff60,
+		r >= 0xffe0 && r <= 0xffe6:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func visibleCells(s string) int {
+	cells := 0
+	for _, r := range stripANSI(s) {
+		cells += runeCells(r)
+	}
+	return cells
+}
+
+// cjkNoLineStart and cjkNoLineEnd hold punctuation that must not begin or
+// end a line respectively.
+const (
+	cjkNoLineStart = "、。，．！？：；）」』】〉》〕…ー・々ぁぃぅぇぉっゃゅょァィゥェォッャュョ,.!?:;)]}"
+	cjkNoLineEnd   = "（「『【〈《〔([{"
+)
+
+// cjkUnit is a piece of text character-based wrapping will not break inside.
+// spaceBefore records whether whitespace preceded it in the source.
+type cjkUnit struct {
+	text        string
+	spaceBefore bool
+}
+
+// cjkUnits splits a paragraph into units: each CJK character on its own and
+// runs of other non-space characters as words. Escape sequences stay attached
+// to the glyph they style, and line-start and line-end punctuation is glued
+// to its neighbour.
+func cjkUnits(p string) []cjkUnit {
+	units := make([]cjkUnit, 0, len(p))
+	pending := ""
+	space := false
+	inWord := false
+	glueNext := false
+	appendGlyph := func(glyph string, standalone bool) {
+		switch {
+		case len(units) > 0 && !space && (glueNext || (inWord && !standalone) || strings.Contains(cjkNoLineStart, glyph)):
+			units[len(units)-1].text += pending + glyph
+		default:
+			units = append(units, cjkUnit{text: pending + glyph, spaceBefore: space && len(units) > 0})
+		}
+		pending = ""
+		space = false
+		inWord = !standalone
+		glueNext = strings.Contains(cjkNoLineEnd, glyph)
+	}
+	for i := 0; i < len(p); {
+		if p[i] == '\x1b' {
+			if loc := ANSICodes.FindStringIndex(p[i:]); loc != nil && loc[0] == 0 {
+				seq := p[i : i+loc[1]]
+				if isClosingEscape(seq) && pending == "" && len(units) > 0 && !space {
+					units[len(units)-1].text += seq
+				} else {
+					pending += seq
+				}
+				i += loc[1]
+				continue
+			}
+		}
+		r, size := utf8.DecodeRuneInString(p[i:])
+		i += size
+		if unicode.IsSpace(r) {
+			space = true
+			inWord = false
+			continue
+		}
+		appendGlyph(string(r), isCJKRune(r) || runeCells(r) == 2)
+	}
+	if pending != "" {
+		if len(units) > 0 {
+			units[len(units)-1].text += pending
+		} else {
+			units = append(units, cjkUnit{text: pending})
+		}
+	}
+	return units
+}
+
+// isClosingEscape reports whether seq ends styling or a hyperlink, in which
+// case it belongs to the glyph before it.
+func isClosingEscape(seq string) bool {
+	return seq == "\x1b[0m" || seq == "\x1b[m" || strings.HasPrefix(seq, "\x1b]8;;\x1b") || strings.HasPrefix(seq, "\x1b]8;;\a")
+}
+
+// splitCells cuts s after at most width cells, keeping at least one rune.
+func splitCells(s string, width int) (string, string) {
+	cells := 0
+	for i, r := range s {
+		cells += runeCells(r)
+		if cells > width && i > 0 {
+			return s[:i], s[i:]
+		}
+	}
+	return s, ""
+}
+
+// wrapTextFor wraps text for the given script.
+func wrapTextFor(sc script, text string, width int) []string {
+	if sc == scriptCJK && width >= 1 {
+		return wrapCJK(text, width)
+	}
+	return wrapText(text, width)
+}
+
+// wrapCJK wraps text by terminal cells, breaking between CJK characters as
+// well as at whitespace.
+func wrapCJK(text string, width int) []string {
+	paragraphs := strings.Split(text, "\n")
+	out := make([]string, 0, len(paragraphs))
+	for _, p := range paragraphs {
+		units := cjkUnits(p)
+		if len(units) == 0 {
+			out = append(out, "")
+			continue
+		}
+		line, cells := "", 0
+		for _, unit := range units {
+			unitCells := visibleCells(unit.text)
+			for unitCells > width && !strings.Contains(unit.text, "\x1b") {
+				if line != "" {
+					out = append(out, line)
+					line, cells = "", 0
+				}
+				head, rest := splitCells(unit.text, width)
+				out = append(out, head)
+				unit = cjkUnit{text: rest}
+				unitCells = visibleCells(rest)
+			}
+			if unit.text == "" {
+				continue
+			}
+			sep := 0
+			if unit.spaceBefore && line != "" {
+				sep = 1
+			}
+			if line != "" && cells+sep+unitCells > width {
+				out = append(out, line)
+				line, cells, sep = "", 0, 0
+			}
+			if sep == 1 {
+				line += " "
+			}
+			line += unit.text
+			cells += sep + unitCells
+		}
+		out = append(out, line)
+	}
+	return out
+}
@@ -30,6 +30,9 @@ var (
 	detailQuoteText   = lipgloss.NewStyle().Italic(true).Foreground(cpSubtext0)
 	detailCitation    = lipgloss.NewStyle().Italic(true).Foreground(cpOverlay0).Faint(true)
 	detailCodeStyle   = lipgloss.NewStyle().Foreground(cpPeach)
+	detailMarkStyle   = lipgloss.NewStyle().Background(cpYellow).Foreground(lipgloss.Color("#1e1e2e"))
+	detailDelStyle    = lipgloss.NewStyle().Strikethrough(true).Foreground(cpOverlay1)
+	detailInsStyle    = lipgloss.NewStyle().Underline(true)
 	detailTableBorder = lipgloss.NewStyle().Foreground(cpSurface2)
 	detailTableHeader = lipgloss.NewStyle().Bold(true).Foreground(cpYellow)
 	detailImageLabel  = lipgloss.NewStyle().Foreground(cpMauve).Faint(true).Italic(true)
@@ -13,6 +13,9 @@ func renderTableLines(tableNode *nethtml.Node, renderer htmlArticleRenderer) []s
 	}
 	lines := make([]string, 0, len(rows)+2)
 	for i, row := range rows {
+		if renderer.budget.stop(len(lines)) {
+			break
+		}
 		rowToRender := row
 		if i == 0 && rowHasHeader(tableNode) {
 			rowToRender = make([]string, len(row))
@@ -21,24 +24,27 @@ func renderTableLines(tableNode *nethtml.Node, renderer htmlArticleRenderer) []s
 			}
 		}
 		cellLine := detailTableBorder.Render("|") + " " + strings.Join(rowToRender, " "+detailTableBorder.Render("|")+" ") + " " + detailTableBorder.Render("|")
-		lines = append(lines, wrapText(cellLine, renderer.width)...)
+		lines = append(lines, renderer.wrap(cellLine, renderer.width)...)
 		if i == 0 && rowHasHeader(tableNode) {
 			sep := make([]string, len(row))
 			for j := range sep {
 				sep[j] = "---"
 			}
 			sepLine := detailTableBorder.Render("|") + " " + detailTableBorder.Render(strings.Join(sep, " | ")) + " " + detailTableBorder.Render("|")
-			lines = append(lines, wrapText(sepLine, renderer.width)...)
+			lines = append(lines, renderer.wrap(sepLine, renderer.width)...)
 		}
 	}
 	return trimBlankLines(lines)
 }
 
-func renderImageLabel(imgNode *nethtml.Node, width int) []string {
+// ImageLabelText prefixes the placeholder line rendered for each image.
+const ImageLabelText = "◌◌◌ Image"
+
+func renderImageLabel(imgNode *nethtml.Node, width int, sc script) []string {
 	if imgNode == nil {
 		return nil
 	}
-	label := detailImageLabel.Render("◌◌◌ Image")
+	label := detailImageLabel.Render(ImageLabelText)
 	alt := normalizeInlineText(nodeAttr(imgNode, "alt"))
 	title := normalizeInlineText(nodeAttr(imgNode, "title"))
 	text := alt
@@ -49,7 +55,7 @@ func renderImageLabel(imgNode *nethtml.Node, width int) []string {
 	if text != "" {
 		line += " " + detailImageText.Render(text)
 	}
-	return wrapText(line, max(1, width))
+	return wrapTextFor(sc, line, max(1, width))
 }
 
 func tableRows(tableNode *nethtml.Node) [][]string {
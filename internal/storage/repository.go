@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 
@@ -15,9 +17,16 @@ import (
 )
 
 type Repository struct {
-	db         *sql.DB
+	db *sql.DB
+
+	// mu guards searchMode and ftsReady, which the search switch changes
+	// while refreshes and searches read them.
+	mu         sync.RWMutex
 	searchMode string
 	ftsReady   bool
+	// ftsMu serializes rebuilding the FTS index with keeping it in sync, so
+	// entries saved during a rebuild are indexed once it finishes.
+	ftsMu sync.Mutex
 }
 
 func NewRepository(path string) (*Repository, error) {
@@ -35,6 +44,21 @@ func NewRepositoryWithSearch(path, searchMode string) (*Repository, error) {
 	}, nil
 }
 
+// NewReadOnlyRepository opens an existing cache database without write
+// access, for browsing a shared or immutable file. Init and CheckWritable must
+// not be called on it, and search stays on LIKE since building the FTS index
+// writes to the database.
+func NewReadOnlyRepository(path string) (*Repository, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite database: %w", err)
+	}
+	return &Repository{
+		db:         db,
+		searchMode: "like",
+	}, nil
+}
+
 func (r *Repository) Close() error {
 	if r == nil || r.db == nil {
 		return nil
@@ -65,6 +89,8 @@ CREATE TABLE IF NOT EXISTS entries (
   fetched_at TEXT NOT NULL,
   is_unread INTEGER NOT NULL DEFAULT 0,
   is_starred INTEGER NOT NULL DEFAULT 0,
+  extracted_content_url TEXT,
+  canonical_url TEXT,
   FOREIGN KEY(feed_id) REFERENCES feeds(id)
 );
 
@@ -73,6 +99,27 @@ CREATE TABLE IF NOT EXISTS app_state (
   value TEXT NOT NULL,
   updated_at TEXT NOT NULL
 );
+
+CREATE TABLE IF NOT EXISTS snoozed_entries (
+  entry_id INTEGER PRIMARY KEY,
+  wake_at INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS dismissed_entries (
+  entry_id INTEGER PRIMARY KEY,
+  dismissed_at INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS read_progress (
+  entry_id INTEGER PRIMARY KEY,
+  fraction REAL NOT NULL,
+  updated_at INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS local_read_feeds (
+  feed_id INTEGER PRIMARY KEY,
+  through_entry_id INTEGER NOT NULL
+);
 `
 	_, err := r.db.ExecContext(ctx, schema)
 	if err != nil {
@@ -88,16 +135,33 @@ CREATE TABLE IF NOT EXISTS app_state (
 	if err := r.addColumnIfMissing(ctx, "entries", "content", "TEXT"); err != nil {
 		return err
 	}
+	if err := r.addColumnIfMissing(ctx, "entries", "extracted_content_url", "TEXT"); err != nil {
+		return err
+	}
+	if err := r.addColumnIfMissing(ctx, "entries", "canonical_url", "TEXT"); err != nil {
+		return err
+	}
 	if err := r.addColumnIfMissing(ctx, "feeds", "folder_name", "TEXT"); err != nil {
 		return err
 	}
+	if err := r.addColumnIfMissing(ctx, "feeds", "folder_names", "TEXT"); err != nil {
+		return err
+	}
+	if err := r.addColumnIfMissing(ctx, "feeds", "subscription_id", "INTEGER"); err != nil {
+		return err
+	}
+	if err := r.addColumnIfMissing(ctx, "feeds", "muted", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
 	if err := r.ensureIndexes(ctx); err != nil {
 		return err
 	}
-	if r.searchMode == "fts" {
+	if r.wantsFTS() {
+		r.ftsMu.Lock()
+		defer r.ftsMu.Unlock()
 		if err := r.initFTS(ctx); err != nil {
 			// Keep app behavior stable by falling back to LIKE search if FTS setup fails.
-			r.ftsReady = false
+			r.setFTSReady(false)
 		}
 	}
 
@@ -130,6 +194,65 @@ func normalizeSearchMode(mode string) string {
 	}
 }
 
+// SearchMode reports the search backend in use: "fts" only while the FTS
+// index is built, "like" otherwise.
+func (r *Repository) SearchMode() string {
+	if r.ftsActive() {
+		return "fts"
+	}
+	return "like"
+}
+
+func (r *Repository) wantsFTS() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.searchMode == "fts"
+}
+
+// ftsActive reports whether searches and saves use the FTS index.
+func (r *Repository) ftsActive() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.searchMode == "fts" && r.ftsReady
+}
+
+func (r *Repository) ftsIndexReady() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.ftsReady
+}
+
+func (r *Repository) setFTSReady(ready bool) {
+	r.mu.Lock()
+	r.ftsReady = ready
+	r.mu.Unlock()
+}
+
+func (r *Repository) setSearchState(mode string, ready bool) {
+	r.mu.Lock()
+	r.searchMode, r.ftsReady = mode, ready
+	r.mu.Unlock()
+}
+
+// SetSearchMode switches search between "like" and "fts" at runtime.
+// Switching to fts rebuilds the index from the cached entries, since it is
+// not kept up to date under LIKE search; a failed build stays on LIKE.
+func (r *Repository) SetSearchMode(ctx context.Context, mode string) error {
+	mode = normalizeSearchMode(mode)
+	r.ftsMu.Lock()
+	defer r.ftsMu.Unlock()
+	if mode == "like" {
+		r.setSearchState("like", false)
+		return nil
+	}
+	if err := r.initFTS(ctx); err != nil {
+		r.setSearchState("like", false)
+		return err
+	}
+	r.setSearchState("fts", true)
+	return nil
+}
+
 func (r *Repository) initFTS(ctx context.Context) error {
 	if r == nil || r.db == nil {
 		return errors.New("repository not initialized")
@@ -155,7 +278,7 @@ FROM entries;
 `); err != nil {
 		return fmt.Errorf("seed fts table: %w", err)
 	}
-	r.ftsReady = true
+	r.setFTSReady(true)
 	return nil
 }
 
@@ -179,13 +302,15 @@ func (r *Repository) SaveSubscriptions(ctx context.Context, subscriptions []feed
 	defer func() { _ = tx.Rollback() }()
 
 	stmt, err := tx.PrepareContext(ctx, `
-INSERT INTO feeds (id, title, feed_url, site_url, folder_name, updated_at)
-VALUES (?, ?, ?, ?, ?, ?)
+INSERT INTO feeds (id, subscription_id, title, feed_url, site_url, folder_name, folder_names, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(id) DO UPDATE SET
+  subscription_id=excluded.subscription_id,
   title=excluded.title,
   feed_url=excluded.feed_url,
   site_url=excluded.site_url,
   folder_name=excluded.folder_name,
+  folder_names=excluded.folder_names,
   updated_at=excluded.updated_at
 `)
 	if err != nil {
@@ -195,7 +320,7 @@ ON CONFLICT(id) DO UPDATE SET
 
 	now := time.Now().UTC().Format(time.RFC3339Nano)
 	for _, sub := range subscriptions {
-		_, err := stmt.ExecContext(ctx, sub.ID, sub.Title, sub.FeedURL, sub.SiteURL, sub.Folder, now)
+		_, err := stmt.ExecContext(ctx, sub.ID, sub.SubscriptionID, sub.Title, sub.FeedURL, sub.SiteURL, sub.Folder, joinFolderNames(sub), now)
 		if err != nil {
 			return fmt.Errorf("save subscription %d: %w", sub.ID, err)
 		}
@@ -207,6 +332,36 @@ ON CONFLICT(id) DO UPDATE SET
 	return nil
 }
 
+// ListOrphanedFeedIDs returns feed IDs referenced by cached entries that have
+// no matching feeds row, e.g. entries hydrated before subscriptions synced or
+// starred entries from feeds that were unsubscribed.
+func (r *Repository) ListOrphanedFeedIDs(ctx context.Context) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `
+SELECT DISTINCT e.feed_id
+FROM entries e
+LEFT JOIN feeds f ON f.id = e.feed_id
+WHERE f.id IS NULL
+ORDER BY e.feed_id
+`)
+	if err != nil {
+		return nil, fmt.Errorf("query orphaned feeds: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0, 8)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan orphaned feed: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate orphaned feeds: %w", err)
+	}
+	return ids, nil
+}
+
 func (r *Repository) SaveEntries(ctx context.Context, entries []feedbin.Entry) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -215,8 +370,8 @@ func (r *Repository) SaveEntries(ctx context.Context, entries []feedbin.Entry) e
 	defer func() { _ = tx.Rollback() }()
 
 	stmt, err := tx.PrepareContext(ctx, `
-INSERT INTO entries (id, title, url, author, summary, content, feed_id, published_at, fetched_at, is_unread, is_starred)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO entries (id, title, url, author, summary, content, feed_id, published_at, fetched_at, is_unread, is_starred, extracted_content_url, canonical_url)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(id) DO UPDATE SET
   title=excluded.title,
   url=excluded.url,
@@ -227,7 +382,9 @@ ON CONFLICT(id) DO UPDATE SET
   published_at=excluded.published_at,
   fetched_at=excluded.fetched_at,
   is_unread=excluded.is_unread,
-  is_starred=excluded.is_starred
+  is_starred=excluded.is_starred,
+  extracted_content_url=excluded.extracted_content_url,
+  canonical_url=excluded.canonical_url
 `)
 	if err != nil {
 		return fmt.Errorf("prepare save statement: %w", err)
@@ -249,18 +406,25 @@ ON CONFLICT(id) DO UPDATE SET
 			now,
 			boolToInt(entry.IsUnread),
 			boolToInt(entry.IsStarred),
+			entry.ExtractedContentURL,
+			entry.ResolveCanonicalURL(),
 		)
 		if err != nil {
 			return fmt.Errorf("save entry %d: %w", entry.ID, err)
 		}
 	}
+	if err := applyLocalReads(ctx, tx); err != nil {
+		return err
+	}
 
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("commit tx: %w", err)
 	}
-	if r.searchMode == "fts" && r.ftsReady {
+	r.ftsMu.Lock()
+	defer r.ftsMu.Unlock()
+	if r.ftsActive() {
 		if err := r.syncEntriesToFTS(ctx, entries); err != nil {
-			r.ftsReady = false
+			r.setFTSReady(false)
 		}
 	}
 	return nil
@@ -324,6 +488,9 @@ func (r *Repository) SaveEntryStates(ctx context.Context, unreadIDs, starredIDs
 			return fmt.Errorf("mark entry starred %d: %w", id, err)
 		}
 	}
+	if err := applyLocalReads(ctx, tx); err != nil {
+		return err
+	}
 
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("commit tx: %w", err)
@@ -332,11 +499,30 @@ func (r *Repository) SaveEntryStates(ctx context.Context, unreadIDs, starredIDs
 	return nil
 }
 
+// SetEntryUnread stores an entry's unread state. Marking an entry unread
+// lowers its feed's local read mark below it, so the next sync does not read
+// it again.
 func (r *Repository) SetEntryUnread(ctx context.Context, entryID int64, unread bool) error {
-	_, err := r.db.ExecContext(ctx, `UPDATE entries SET is_unread = ? WHERE id = ?`, boolToInt(unread), entryID)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE entries SET is_unread = ? WHERE id = ?`, boolToInt(unread), entryID); err != nil {
 		return fmt.Errorf("set entry unread state for %d: %w", entryID, err)
 	}
+	if unread {
+		if _, err := tx.ExecContext(ctx, `
+UPDATE local_read_feeds SET through_entry_id = ? - 1
+WHERE feed_id = (SELECT feed_id FROM entries WHERE id = ?) AND through_entry_id >= ?
+`, entryID, entryID, entryID); err != nil {
+			return fmt.Errorf("lower local read mark for entry %d: %w", entryID, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
 	return nil
 }
 
@@ -348,6 +534,58 @@ func (r *Repository) SetEntryStarred(ctx context.Context, entryID int64, starred
 	return nil
 }
 
+// ListEntryMarks returns the read/star state of every cached entry.
+func (r *Repository) ListEntryMarks(ctx context.Context) ([]feedbin.EntryMark, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, is_unread, is_starred FROM entries ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("query entry marks: %w", err)
+	}
+	defer rows.Close()
+
+	marks := make([]feedbin.EntryMark, 0, 256)
+	for rows.Next() {
+		var mark feedbin.EntryMark
+		var isUnread, isStarred int
+		if err := rows.Scan(&mark.ID, &isUnread, &isStarred); err != nil {
+			return nil, fmt.Errorf("scan entry mark: %w", err)
+		}
+		mark.Unread = intToBool(isUnread)
+		mark.Starred = intToBool(isStarred)
+		marks = append(marks, mark)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate entry marks: %w", err)
+	}
+	return marks, nil
+}
+
+// SaveEntryMarks applies read/star states to cached entries. Unlike
+// SaveEntryStates it leaves entries missing from marks untouched.
+func (r *Repository) SaveEntryMarks(ctx context.Context, marks []feedbin.EntryMark) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.PrepareContext(ctx, `UPDATE entries SET is_unread = ?, is_starred = ? WHERE id = ?`)
+	if err != nil {
+		return fmt.Errorf("prepare save marks statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, mark := range marks {
+		if _, err := stmt.ExecContext(ctx, boolToInt(mark.Unread), boolToInt(mark.Starred), mark.ID); err != nil {
+			return fmt.Errorf("save entry mark %d: %w", mark.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
 func (r *Repository) CheckWritable(ctx context.Context) error {
 	_, err := r.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS healthcheck (id INTEGER PRIMARY KEY, touched_at TEXT NOT NULL)`)
 	if err != nil {
@@ -360,6 +598,38 @@ func (r *Repository) CheckWritable(ctx context.Context) error {
 	return nil
 }
 
+// IsCorrupt reports whether err is SQLite saying the database file is
+// damaged or not a database at all, as opposed to a permission or schema
+// problem.
+func IsCorrupt(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "database disk image is malformed") ||
+		strings.Contains(msg, "file is not a database") ||
+		strings.Contains(msg, "file is encrypted or is not a database") ||
+		strings.Contains(msg, "sqlite_corrupt") ||
+		strings.Contains(msg, "sqlite_notadb")
+}
+
+// BackupCorruptDatabase moves a corrupt database file, and any WAL or
+// shared-memory files next to it, out of the way as
+// <path>.corrupt-<timestamp> so a fresh one can be created at path. It returns
+// the backup path of the main file.
+func BackupCorruptDatabase(path string, now time.Time) (string, error) {
+	backup := fmt.Sprintf("%s.corrupt-%s", path, now.UTC().Format("20060102-150405"))
+	if err := os.Rename(path, backup); err != nil {
+		return "", fmt.Errorf("back up corrupt database: %w", err)
+	}
+	for _, suffix := range []string{"-wal", "-shm", "-journal"} {
+		if err := os.Rename(path+suffix, backup+suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return backup, fmt.Errorf("back up corrupt database %s file: %w", suffix, err)
+		}
+	}
+	return backup, nil
+}
+
 func (r *Repository) GetSyncCursor(ctx context.Context, key string) (time.Time, error) {
 	value, err := r.GetAppState(ctx, key)
 	if err != nil {
@@ -409,33 +679,439 @@ ON CONFLICT(key) DO UPDATE SET
 	return nil
 }
 
+// notMutedClause hides entries of feeds muted in the subscription manager.
+const notMutedClause = "COALESCE(f.muted, 0) = 0"
+
+// notSnoozedClause hides snoozed entries until they are woken.
+const notSnoozedClause = "e.id NOT IN (SELECT entry_id FROM snoozed_entries)"
+
+// notDismissedClause hides entries dismissed locally; only the dismissed
+// filter lists them.
+const notDismissedClause = "e.id NOT IN (SELECT entry_id FROM dismissed_entries)"
+
+// filterClauses returns the WHERE parts shared by listing and search for the
+// given filter. Snoozed entries stay visible under starred, and a feed scope
+// shows its feed even when it is muted.
+func filterClauses(filter string) []string {
+	if feedID, ok := feedbin.ParseFeedScope(filter); ok {
+		return []string{notSnoozedClause, notDismissedClause, fmt.Sprintf("e.feed_id = %d", feedID)}
+	}
+	switch filter {
+	case "unread":
+		return []string{notMutedClause, notSnoozedClause, notDismissedClause, "e.is_unread = 1"}
+	case "starred":
+		return []string{notMutedClause, notDismissedClause, "e.is_starred = 1"}
+	case "dismissed":
+		return []string{"e.id IN (SELECT entry_id FROM dismissed_entries)"}
+	default:
+		return []string{notMutedClause, notSnoozedClause, notDismissedClause}
+	}
+}
+
+// Dismiss hides an entry from every listing but the dismissed filter without
+// touching its read state. It never syncs to Feedbin.
+func (r *Repository) Dismiss(ctx context.Context, entryID int64) error {
+	_, err := r.db.ExecContext(ctx, `
+INSERT INTO dismissed_entries (entry_id, dismissed_at)
+VALUES (?, ?)
+ON CONFLICT(entry_id) DO NOTHING
+`, entryID, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("dismiss entry %d: %w", entryID, err)
+	}
+	return nil
+}
+
+// MarkFeedReadLocally marks a feed's entries up to throughEntryID read in
+// the cache only. The mark outlives later syncs: entries it covers stay read
+// even while Feedbin still lists them as unread.
+func (r *Repository) MarkFeedReadLocally(ctx context.Context, feedID, throughEntryID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `
+INSERT INTO local_read_feeds (feed_id, through_entry_id)
+VALUES (?, ?)
+ON CONFLICT(feed_id) DO UPDATE SET
+  through_entry_id=MAX(through_entry_id, excluded.through_entry_id)
+`, feedID, throughEntryID); err != nil {
+		return fmt.Errorf("save local read mark for feed %d: %w", feedID, err)
+	}
+	if err := applyLocalReads(ctx, tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// ClearFeedLocalRead drops a feed's local read mark and reports whether it
+// had one. Entries it covered stay read in the cache until the next sync
+// brings back Feedbin's unread state.
+func (r *Repository) ClearFeedLocalRead(ctx context.Context, feedID int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM local_read_feeds WHERE feed_id = ?`, feedID)
+	if err != nil {
+		return false, fmt.Errorf("clear local read mark for feed %d: %w", feedID, err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("clear local read mark for feed %d: %w", feedID, err)
+	}
+	return n > 0, nil
+}
+
+// applyLocalReads marks read again the entries covered by local-only feed
+// read marks, after a sync restored Feedbin's unread state.
+func applyLocalReads(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, `
+UPDATE entries SET is_unread = 0
+WHERE is_unread = 1 AND EXISTS (
+  SELECT 1 FROM local_read_feeds l
+  WHERE l.feed_id = entries.feed_id AND entries.id <= l.through_entry_id
+)
+`); err != nil {
+		return fmt.Errorf("apply local read marks: %w", err)
+	}
+	return nil
+}
+
+// Undismiss makes a dismissed entry visible again.
+func (r *Repository) Undismiss(ctx context.Context, entryID int64) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM dismissed_entries WHERE entry_id = ?`, entryID); err != nil {
+		return fmt.Errorf("undismiss entry %d: %w", entryID, err)
+	}
+	return nil
+}
+
+// ListDismissed returns the IDs of dismissed entries, most recently
+// dismissed first.
+func (r *Repository) ListDismissed(ctx context.Context) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT entry_id FROM dismissed_entries ORDER BY dismissed_at DESC, entry_id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("query dismissed entries: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0, 8)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan dismissed entry: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate dismissed entries: %w", err)
+	}
+	return ids, nil
+}
+
+// SnoozeEntry hides an entry from the all and unread listings until wakeAt.
+// Snoozing an already snoozed entry moves its wake time.
+func (r *Repository) SnoozeEntry(ctx context.Context, entryID int64, wakeAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+INSERT INTO snoozed_entries (entry_id, wake_at)
+VALUES (?, ?)
+ON CONFLICT(entry_id) DO UPDATE SET
+  wake_at=excluded.wake_at
+`, entryID, wakeAt.Unix())
+	if err != nil {
+		return fmt.Errorf("snooze entry %d: %w", entryID, err)
+	}
+	return nil
+}
+
+// ListDueSnoozedEntryIDs returns snoozed entries whose wake time is at or
+// before now.
+func (r *Repository) ListDueSnoozedEntryIDs(ctx context.Context, now time.Time) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT entry_id FROM snoozed_entries WHERE wake_at <= ? ORDER BY entry_id`, now.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("query due snoozed entries: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0, 8)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan snoozed entry: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate snoozed entries: %w", err)
+	}
+	return ids, nil
+}
+
+// UnsnoozeEntries makes the given entries visible again.
+func (r *Repository) UnsnoozeEntries(ctx context.Context, entryIDs []int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, id := range entryIDs {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM snoozed_entries WHERE entry_id = ?`, id); err != nil {
+			return fmt.Errorf("unsnooze entry %d: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// SetReadProgress stores how far an entry was scrolled, as a fraction of its
+// scrollable length. Zero clears the entry's progress.
+func (r *Repository) SetReadProgress(ctx context.Context, entryID int64, fraction float64) error {
+	if fraction <= 0 {
+		if _, err := r.db.ExecContext(ctx, `DELETE FROM read_progress WHERE entry_id = ?`, entryID); err != nil {
+			return fmt.Errorf("clear read progress for entry %d: %w", entryID, err)
+		}
+		return nil
+	}
+	_, err := r.db.ExecContext(ctx, `
+INSERT INTO read_progress (entry_id, fraction, updated_at)
+VALUES (?, ?, ?)
+ON CONFLICT(entry_id) DO UPDATE SET
+  fraction=excluded.fraction,
+  updated_at=excluded.updated_at
+`, entryID, fraction, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("save read progress for entry %d: %w", entryID, err)
+	}
+	return nil
+}
+
+// ListReadProgress returns the stored read progress by entry ID.
+func (r *Repository) ListReadProgress(ctx context.Context) (map[int64]float64, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT entry_id, fraction FROM read_progress`)
+	if err != nil {
+		return nil, fmt.Errorf("query read progress: %w", err)
+	}
+	defer rows.Close()
+
+	progress := make(map[int64]float64)
+	for rows.Next() {
+		var (
+			entryID  int64
+			fraction float64
+		)
+		if err := rows.Scan(&entryID, &fraction); err != nil {
+			return nil, fmt.Errorf("scan read progress: %w", err)
+		}
+		progress[entryID] = fraction
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate read progress: %w", err)
+	}
+	return progress, nil
+}
+
+// ListFeeds returns cached subscriptions with unread counts and the newest
+// entry timestamp, ordered by folder then title.
+func (r *Repository) ListFeeds(ctx context.Context) ([]feedbin.FeedSummary, error) {
+	rows, err := r.db.QueryContext(ctx, `
+SELECT f.id, COALESCE(f.subscription_id, 0), f.title, COALESCE(f.feed_url, ''), COALESCE(f.site_url, ''), COALESCE(f.folder_name, ''), COALESCE(f.folder_names, ''), COALESCE(f.muted, 0),
+  COALESCE(SUM(CASE WHEN e.is_unread = 1 THEN 1 ELSE 0 END), 0), COALESCE(MAX(e.published_at), '')
+FROM feeds f
+LEFT JOIN entries e ON e.feed_id = f.id
+GROUP BY f.id
+ORDER BY COALESCE(f.folder_name, '') = '', LOWER(COALESCE(f.folder_name, '')), LOWER(f.title)
+`)
+	if err != nil {
+		return nil, fmt.Errorf("query feeds: %w", err)
+	}
+	defer rows.Close()
+
+	feeds := make([]feedbin.FeedSummary, 0, 32)
+	for rows.Next() {
+		var feed feedbin.FeedSummary
+		var muted int
+		var lastUpdated, folderNames string
+		if err := rows.Scan(
+			&feed.ID,
+			&feed.SubscriptionID,
+			&feed.Title,
+			&feed.FeedURL,
+			&feed.SiteURL,
+			&feed.Folder,
+			&folderNames,
+			&muted,
+			&feed.UnreadCount,
+			&lastUpdated,
+		); err != nil {
+			return nil, fmt.Errorf("scan feed: %w", err)
+		}
+		feed.Muted = intToBool(muted)
+		feed.Folders = splitFolderNames(folderNames)
+		if lastUpdated != "" {
+			feed.LastUpdated, err = time.Parse(time.RFC3339Nano, lastUpdated)
+			if err != nil {
+				return nil, fmt.Errorf("parse feed last updated %q: %w", lastUpdated, err)
+			}
+		}
+		feeds = append(feeds, feed)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate feeds: %w", err)
+	}
+	return feeds, nil
+}
+
+func (r *Repository) SetFeedMuted(ctx context.Context, feedID int64, muted bool) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE feeds SET muted = ? WHERE id = ?`, boolToInt(muted), feedID)
+	if err != nil {
+		return fmt.Errorf("set feed muted state for %d: %w", feedID, err)
+	}
+	return nil
+}
+
+func (r *Repository) RenameFeed(ctx context.Context, feedID int64, title string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE feeds SET title = ? WHERE id = ?`, title, feedID)
+	if err != nil {
+		return fmt.Errorf("rename feed %d: %w", feedID, err)
+	}
+	return nil
+}
+
+// SetFeedFolders replaces a feed's cached folders; the first one is the
+// folder it sits under in the tree.
+func (r *Repository) SetFeedFolders(ctx context.Context, feedID int64, folders []string) error {
+	primary := ""
+	if len(folders) > 0 {
+		primary = folders[0]
+	}
+	_, err := r.db.ExecContext(ctx, `UPDATE feeds SET folder_name = ?, folder_names = ? WHERE id = ?`, primary, strings.Join(folders, "\n"), feedID)
+	if err != nil {
+		return fmt.Errorf("set folders for feed %d: %w", feedID, err)
+	}
+	return nil
+}
+
+// DeleteFeed removes a feed and its cached entries.
+func (r *Repository) DeleteFeed(ctx context.Context, feedID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if r.ftsIndexReady() {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM entries_fts WHERE rowid IN (SELECT id FROM entries WHERE feed_id = ?)`, feedID); err != nil {
+			return fmt.Errorf("delete feed %d search rows: %w", feedID, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM entries WHERE feed_id = ?`, feedID); err != nil {
+		return fmt.Errorf("delete feed %d entries: %w", feedID, err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM feeds WHERE id = ?`, feedID); err != nil {
+		return fmt.Errorf("delete feed %d: %w", feedID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// GetEntry returns one cached entry regardless of filters, or sql.ErrNoRows
+// when it is not cached.
+func (r *Repository) GetEntry(ctx context.Context, entryID int64) (feedbin.Entry, error) {
+	rows, err := r.db.QueryContext(ctx, `
+SELECT e.id, e.title, e.url, e.author, e.summary, COALESCE(e.content, ''), e.feed_id, e.published_at, e.is_unread, e.is_starred, COALESCE(f.title, ''), COALESCE(f.folder_name, ''), COALESCE(f.folder_names, ''), COALESCE(f.feed_url, ''), COALESCE(e.extracted_content_url, ''), COALESCE(e.canonical_url, '')
+FROM entries e
+LEFT JOIN feeds f ON f.id = e.feed_id
+WHERE e.id = ?
+`, entryID)
+	if err != nil {
+		return feedbin.Entry{}, fmt.Errorf("query entry %d: %w", entryID, err)
+	}
+	defer rows.Close()
+
+	entries, err := scanEntriesRows(rows, 1)
+	if err != nil {
+		return feedbin.Entry{}, err
+	}
+	if len(entries) == 0 {
+		return feedbin.Entry{}, sql.ErrNoRows
+	}
+	return entries[0], nil
+}
+
 func (r *Repository) ListEntries(ctx context.Context, limit int) ([]feedbin.Entry, error) {
 	return r.ListEntriesByFilter(ctx, limit, "all")
 }
 
 func (r *Repository) ListEntriesByFilter(ctx context.Context, limit int, filter string) ([]feedbin.Entry, error) {
+	return r.ListEntriesByFilterSince(ctx, limit, filter, time.Time{})
+}
+
+// ListEntriesByFilterSince is ListEntriesByFilter limited to entries
+// published at or after since; a zero since lists every age.
+func (r *Repository) ListEntriesByFilterSince(ctx context.Context, limit int, filter string, since time.Time) ([]feedbin.Entry, error) {
+	return r.ListEntriesByFilterOffset(ctx, limit, 0, filter, since)
+}
+
+// NewestEntryID is the highest cached entry ID, zero for an empty cache.
+func (r *Repository) NewestEntryID(ctx context.Context) (int64, error) {
+	var id int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(id), 0) FROM entries`).Scan(&id); err != nil {
+		return 0, fmt.Errorf("query newest entry id: %w", err)
+	}
+	return id, nil
+}
+
+// Stats counts the cached entries, their read and starred states and the
+// cached feeds.
+func (r *Repository) Stats(ctx context.Context) (feedbin.CacheStats, error) {
+	var stats feedbin.CacheStats
+	err := r.db.QueryRowContext(ctx, `
+SELECT
+	(SELECT COUNT(*) FROM entries),
+	(SELECT COUNT(*) FROM entries WHERE is_unread = 1),
+	(SELECT COUNT(*) FROM entries WHERE is_starred = 1),
+	(SELECT COUNT(*) FROM feeds)
+`).Scan(&stats.Entries, &stats.Unread, &stats.Starred, &stats.Feeds)
+	if err != nil {
+		return feedbin.CacheStats{}, fmt.Errorf("count cached entries: %w", err)
+	}
+	return stats, nil
+}
+
+// ListEntriesByFilterOffset is ListEntriesByFilterSince skipping the offset
+// newest matching entries, for reading the cache one window at a time.
+func (r *Repository) ListEntriesByFilterOffset(ctx context.Context, limit, offset int, filter string, since time.Time) ([]feedbin.Entry, error) {
 	if limit < 1 {
 		limit = 1000
 	}
+	offset = max(offset, 0)
 
-	whereClause := ""
-	switch filter {
-	case "unread":
-		whereClause = "WHERE e.is_unread = 1"
-	case "starred":
-		whereClause = "WHERE e.is_starred = 1"
+	whereParts := filterClauses(filter)
+	args := []any{}
+	if !since.IsZero() {
+		whereParts = append(whereParts, "e.published_at >= ?")
+		args = append(args, since.UTC().Format(time.RFC3339Nano))
 	}
+	args = append(args, limit, offset)
 
 	query := fmt.Sprintf(`
-SELECT e.id, e.title, e.url, e.author, e.summary, COALESCE(e.content, ''), e.feed_id, e.published_at, e.is_unread, e.is_starred, COALESCE(f.title, ''), COALESCE(f.folder_name, '')
+SELECT e.id, e.title, e.url, e.author, e.summary, COALESCE(e.content, ''), e.feed_id, e.published_at, e.is_unread, e.is_starred, COALESCE(f.title, ''), COALESCE(f.folder_name, ''), COALESCE(f.folder_names, ''), COALESCE(f.feed_url, ''), COALESCE(e.extracted_content_url, ''), COALESCE(e.canonical_url, '')
 FROM entries e
 LEFT JOIN feeds f ON f.id = e.feed_id
-%s
+WHERE %s
 ORDER BY e.published_at DESC
-LIMIT ?
-`, whereClause)
+LIMIT ? OFFSET ?
+`, strings.Join(whereParts, " AND "))
 
-	rows, err := r.db.QueryContext(ctx, query, limit)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("query entries: %w", err)
 	}
@@ -447,6 +1123,7 @@ LIMIT ?
 		var publishedAt string
 		var isUnread int
 		var isStarred int
+		var folderNames string
 		if err := rows.Scan(
 			&entry.ID,
 			&entry.Title,
@@ -460,6 +1137,10 @@ LIMIT ?
 			&isStarred,
 			&entry.FeedTitle,
 			&entry.FeedFolder,
+			&folderNames,
+			&entry.FeedURL,
+			&entry.ExtractedContentURL,
+			&entry.CanonicalURL,
 		); err != nil {
 			return nil, fmt.Errorf("scan entry: %w", err)
 		}
@@ -470,6 +1151,7 @@ LIMIT ?
 		}
 		entry.IsUnread = intToBool(isUnread)
 		entry.IsStarred = intToBool(isStarred)
+		entry.FeedFolders = splitFolderNames(folderNames)
 		entries = append(entries, entry)
 	}
 
@@ -480,6 +1162,36 @@ LIMIT ?
 	return entries, nil
 }
 
+// EachEntry calls fn for every cached entry, newest first, reading one row at
+// a time so the whole cache is never held in memory. It stops at the first
+// error fn returns and passes it back.
+func (r *Repository) EachEntry(ctx context.Context, fn func(feedbin.Entry) error) error {
+	rows, err := r.db.QueryContext(ctx, `
+SELECT e.id, e.title, e.url, e.author, e.summary, COALESCE(e.content, ''), e.feed_id, e.published_at, e.is_unread, e.is_starred, COALESCE(f.title, ''), COALESCE(f.folder_name, ''), COALESCE(f.folder_names, ''), COALESCE(f.feed_url, ''), COALESCE(e.extracted_content_url, ''), COALESCE(e.canonical_url, '')
+FROM entries e
+LEFT JOIN feeds f ON f.id = e.feed_id
+ORDER BY e.published_at DESC
+`)
+	if err != nil {
+		return fmt.Errorf("query entries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		entry, err := scanEntryRow(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows iteration: %w", err)
+	}
+	return nil
+}
+
 func (r *Repository) SearchEntriesByFilter(ctx context.Context, limit int, filter, query string) ([]feedbin.Entry, error) {
 	trimmedQuery := strings.TrimSpace(query)
 	if trimmedQuery == "" {
@@ -488,7 +1200,7 @@ func (r *Repository) SearchEntriesByFilter(ctx context.Context, limit int, filte
 	if limit < 1 {
 		limit = 1000
 	}
-	if r.searchMode == "fts" && r.ftsReady {
+	if r.ftsActive() {
 		entries, err := r.searchEntriesByFTS(ctx, limit, filter, trimmedQuery)
 		if err == nil {
 			return entries, nil
@@ -499,22 +1211,15 @@ func (r *Repository) SearchEntriesByFilter(ctx context.Context, limit int, filte
 
 func (r *Repository) searchEntriesByLike(ctx context.Context, limit int, filter, query string) ([]feedbin.Entry, error) {
 	pattern := "%" + strings.ToLower(query) + "%"
-	whereParts := make([]string, 0, 2)
+	whereParts := filterClauses(filter)
 	args := make([]any, 0, 8)
-
-	switch filter {
-	case "unread":
-		whereParts = append(whereParts, "e.is_unread = 1")
-	case "starred":
-		whereParts = append(whereParts, "e.is_starred = 1")
-	}
 	whereParts = append(whereParts, `(LOWER(e.title) LIKE ? OR LOWER(COALESCE(e.author, '')) LIKE ? OR LOWER(COALESCE(e.summary, '')) LIKE ? OR LOWER(COALESCE(e.content, '')) LIKE ? OR LOWER(e.url) LIKE ? OR LOWER(COALESCE(f.title, '')) LIKE ? OR LOWER(COALESCE(f.folder_name, '')) LIKE ?)`)
 	for i := 0; i < 7; i++ {
 		args = append(args, pattern)
 	}
 
 	querySQL := fmt.Sprintf(`
-SELECT e.id, e.title, e.url, e.author, e.summary, COALESCE(e.content, ''), e.feed_id, e.published_at, e.is_unread, e.is_starred, COALESCE(f.title, ''), COALESCE(f.folder_name, '')
+SELECT e.id, e.title, e.url, e.author, e.summary, COALESCE(e.content, ''), e.feed_id, e.published_at, e.is_unread, e.is_starred, COALESCE(f.title, ''), COALESCE(f.folder_name, ''), COALESCE(f.folder_names, ''), COALESCE(f.feed_url, ''), COALESCE(e.extracted_content_url, ''), COALESCE(e.canonical_url, '')
 FROM entries e
 LEFT JOIN feeds f ON f.id = e.feed_id
 WHERE %s
@@ -537,19 +1242,13 @@ func (r *Repository) searchEntriesByFTS(ctx context.Context, limit int, filter,
 		return r.searchEntriesByLike(ctx, limit, filter, query)
 	}
 	pattern := "%" + strings.ToLower(query) + "%"
-	whereParts := make([]string, 0, 2)
+	whereParts := filterClauses(filter)
 	args := make([]any, 0, 5)
-	switch filter {
-	case "unread":
-		whereParts = append(whereParts, "e.is_unread = 1")
-	case "starred":
-		whereParts = append(whereParts, "e.is_starred = 1")
-	}
 	whereParts = append(whereParts, `(e.id IN (SELECT rowid FROM entries_fts WHERE entries_fts MATCH ?) OR LOWER(COALESCE(f.title, '')) LIKE ? OR LOWER(COALESCE(f.folder_name, '')) LIKE ?)`)
 	args = append(args, ftsQuery, pattern, pattern)
 
 	querySQL := fmt.Sprintf(`
-SELECT e.id, e.title, e.url, e.author, e.summary, COALESCE(e.content, ''), e.feed_id, e.published_at, e.is_unread, e.is_starred, COALESCE(f.title, ''), COALESCE(f.folder_name, '')
+SELECT e.id, e.title, e.url, e.author, e.summary, COALESCE(e.content, ''), e.feed_id, e.published_at, e.is_unread, e.is_starred, COALESCE(f.title, ''), COALESCE(f.folder_name, ''), COALESCE(f.folder_names, ''), COALESCE(f.feed_url, ''), COALESCE(e.extracted_content_url, ''), COALESCE(e.canonical_url, '')
 FROM entries e
 LEFT JOIN feeds f ON f.id = e.feed_id
 WHERE %s
@@ -593,33 +1292,10 @@ func buildFTSQuery(query string) (string, bool) {
 func scanEntriesRows(rows *sql.Rows, limit int) ([]feedbin.Entry, error) {
 	entries := make([]feedbin.Entry, 0, limit)
 	for rows.Next() {
-		var entry feedbin.Entry
-		var publishedAt string
-		var isUnread int
-		var isStarred int
-		if err := rows.Scan(
-			&entry.ID,
-			&entry.Title,
-			&entry.URL,
-			&entry.Author,
-			&entry.Summary,
-			&entry.Content,
-			&entry.FeedID,
-			&publishedAt,
-			&isUnread,
-			&isStarred,
-			&entry.FeedTitle,
-			&entry.FeedFolder,
-		); err != nil {
-			return nil, fmt.Errorf("scan search entry: %w", err)
-		}
-		parsed, err := time.Parse(time.RFC3339Nano, publishedAt)
+		entry, err := scanEntryRow(rows)
 		if err != nil {
-			return nil, fmt.Errorf("parse entry published_at %q: %w", publishedAt, err)
+			return nil, err
 		}
-		entry.PublishedAt = parsed
-		entry.IsUnread = intToBool(isUnread)
-		entry.IsStarred = intToBool(isStarred)
 		entries = append(entries, entry)
 	}
 	if err := rows.Err(); err != nil {
@@ -628,6 +1304,44 @@ func scanEntriesRows(rows *sql.Rows, limit int) ([]feedbin.Entry, error) {
 	return entries, nil
 }
 
+// scanEntryRow reads the current row of an entries-with-feed query.
+func scanEntryRow(rows *sql.Rows) (feedbin.Entry, error) {
+	var entry feedbin.Entry
+	var publishedAt string
+	var isUnread int
+	var isStarred int
+	var folderNames string
+	if err := rows.Scan(
+		&entry.ID,
+		&entry.Title,
+		&entry.URL,
+		&entry.Author,
+		&entry.Summary,
+		&entry.Content,
+		&entry.FeedID,
+		&publishedAt,
+		&isUnread,
+		&isStarred,
+		&entry.FeedTitle,
+		&entry.FeedFolder,
+		&folderNames,
+		&entry.FeedURL,
+		&entry.ExtractedContentURL,
+		&entry.CanonicalURL,
+	); err != nil {
+		return feedbin.Entry{}, fmt.Errorf("scan search entry: %w", err)
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, publishedAt)
+	if err != nil {
+		return feedbin.Entry{}, fmt.Errorf("parse entry published_at %q: %w", publishedAt, err)
+	}
+	entry.PublishedAt = parsed
+	entry.IsUnread = intToBool(isUnread)
+	entry.IsStarred = intToBool(isStarred)
+	entry.FeedFolders = splitFolderNames(folderNames)
+	return entry, nil
+}
+
 func boolToInt(v bool) int {
 	if v {
 		return 1
@@ -638,3 +1352,19 @@ func boolToInt(v bool) int {
 func intToBool(v int) bool {
 	return v != 0
 }
+
+// joinFolderNames stores a subscription's tags one per line. Subscriptions
+// saved without Folders (e.g. orphaned feeds) keep their single folder.
+func joinFolderNames(sub feedbin.Subscription) string {
+	if len(sub.Folders) == 0 {
+		return sub.Folder
+	}
+	return strings.Join(sub.Folders, "\n")
+}
+
+func splitFolderNames(joined string) []string {
+	if joined == "" {
+		return nil
+	}
+	return strings.Split(joined, "\n")
+}
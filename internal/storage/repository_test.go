@@ -2,8 +2,12 @@ package storage
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
@@ -69,6 +73,9 @@ func TestRepository_SaveAndListEntries(t *testing.T) {
 	if listed[0].FeedFolder != "Formula 1" {
 		t.Fatalf("expected feed folder from subscription, got %q", listed[0].FeedFolder)
 	}
+	if listed[0].FeedURL != "https://example.com/feed.xml" {
+		t.Fatalf("expected feed URL from subscription, got %q", listed[0].FeedURL)
+	}
 	if !listed[0].IsStarred {
 		t.Fatal("expected starred state persisted")
 	}
@@ -252,6 +259,110 @@ func TestRepository_ListEntriesByFilter(t *testing.T) {
 	}
 }
 
+func TestRepository_ListEntriesByFilterOffset(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "feedbin.db")
+	repo, err := NewRepository(dbPath)
+	if err != nil {
+		t.Fatalf("NewRepository returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	entries := make([]feedbin.Entry, 0, 5)
+	for i := 1; i <= 5; i++ {
+		entries = append(entries, feedbin.Entry{ID: int64(i), Title: "Entry", URL: "https://example.com/", FeedID: 1, PublishedAt: time.Date(2026, 2, i, 0, 0, 0, 0, time.UTC)})
+	}
+	if err := repo.SaveEntries(ctx, entries); err != nil {
+		t.Fatalf("SaveEntries returned error: %v", err)
+	}
+
+	window, err := repo.ListEntriesByFilterOffset(ctx, 2, 2, "all", time.Time{})
+	if err != nil {
+		t.Fatalf("ListEntriesByFilterOffset returned error: %v", err)
+	}
+	if len(window) != 2 || window[0].ID != 3 || window[1].ID != 2 {
+		t.Fatalf("expected entries 3 and 2 after skipping the two newest, got %+v", window)
+	}
+
+	tail, err := repo.ListEntriesByFilterOffset(ctx, 2, 4, "all", time.Time{})
+	if err != nil {
+		t.Fatalf("ListEntriesByFilterOffset returned error: %v", err)
+	}
+	if len(tail) != 1 || tail[0].ID != 1 {
+		t.Fatalf("expected only the oldest entry past the end, got %+v", tail)
+	}
+}
+
+func TestRepository_Stats(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "feedbin.db")
+	repo, err := NewRepository(dbPath)
+	if err != nil {
+		t.Fatalf("NewRepository returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	if err := repo.SaveSubscriptions(ctx, []feedbin.Subscription{{ID: 1, SubscriptionID: 1, Title: "Feed"}}); err != nil {
+		t.Fatalf("SaveSubscriptions returned error: %v", err)
+	}
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Read", URL: "https://example.com/1", FeedID: 1, PublishedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Title: "Unread", URL: "https://example.com/2", FeedID: 1, PublishedAt: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC), IsUnread: true, IsStarred: true},
+	}
+	if err := repo.SaveEntries(ctx, entries); err != nil {
+		t.Fatalf("SaveEntries returned error: %v", err)
+	}
+
+	stats, err := repo.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats returned error: %v", err)
+	}
+	if stats != (feedbin.CacheStats{Entries: 2, Unread: 1, Starred: 1, Feeds: 1}) {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if id, err := repo.NewestEntryID(ctx); err != nil || id != 2 {
+		t.Fatalf("NewestEntryID = %d, %v; want 2", id, err)
+	}
+}
+
+func TestRepository_ListEntriesByFilter_FeedScope(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "feedbin.db")
+	repo, err := NewRepository(dbPath)
+	if err != nil {
+		t.Fatalf("NewRepository returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "One", URL: "https://example.com/1", FeedID: 1, PublishedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Title: "Two", URL: "https://example.com/2", FeedID: 2, PublishedAt: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC), IsUnread: true},
+		{ID: 3, Title: "Three", URL: "https://example.com/3", FeedID: 1, PublishedAt: time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)},
+	}
+	if err := repo.SaveEntries(ctx, entries); err != nil {
+		t.Fatalf("SaveEntries returned error: %v", err)
+	}
+
+	scoped, err := repo.ListEntriesByFilter(ctx, 20, feedbin.FeedScopeFilter(1))
+	if err != nil {
+		t.Fatalf("ListEntriesByFilter feed scope returned error: %v", err)
+	}
+	if len(scoped) != 2 || scoped[0].ID != 3 || scoped[1].ID != 1 {
+		t.Fatalf("unexpected feed-scoped entries: %+v", scoped)
+	}
+}
+
 func TestRepository_SearchEntriesByFilter(t *testing.T) {
 	dbPath := filepath.Join(t.TempDir(), "feedbin.db")
 	repo, err := NewRepository(dbPath)
@@ -322,6 +433,50 @@ func TestRepository_SearchEntriesByFilter_FTSMode(t *testing.T) {
 	}
 }
 
+func TestRepository_SetSearchMode_SwitchesBackend(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "feedbin.db")
+	repo, err := NewRepositoryWithSearch(dbPath, "like")
+	if err != nil {
+		t.Fatalf("NewRepositoryWithSearch returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	if err := repo.SaveEntries(ctx, []feedbin.Entry{
+		{ID: 1, Title: "Golang tips", URL: "https://example.com/golang", FeedID: 1, PublishedAt: time.Now().UTC()},
+		{ID: 2, Title: "Language wars", URL: "https://example.com/wars", FeedID: 1, PublishedAt: time.Now().UTC()},
+	}); err != nil {
+		t.Fatalf("SaveEntries returned error: %v", err)
+	}
+	search := func() []feedbin.Entry {
+		t.Helper()
+		found, err := repo.SearchEntriesByFilter(ctx, 20, "all", "lang")
+		if err != nil {
+			t.Fatalf("SearchEntriesByFilter returned error: %v", err)
+		}
+		return found
+	}
+
+	if repo.SearchMode() != "like" || len(search()) != 2 {
+		t.Fatalf("expected LIKE to match inside words, got mode %q", repo.SearchMode())
+	}
+	if err := repo.SetSearchMode(ctx, "fts"); err != nil {
+		t.Fatalf("SetSearchMode returned error: %v", err)
+	}
+	if found := search(); repo.SearchMode() != "fts" || len(found) != 1 || found[0].ID != 2 {
+		t.Fatalf("expected FTS to match word prefixes only, got mode %q results %+v", repo.SearchMode(), found)
+	}
+	if err := repo.SetSearchMode(ctx, "like"); err != nil {
+		t.Fatalf("SetSearchMode returned error: %v", err)
+	}
+	if repo.SearchMode() != "like" || len(search()) != 2 {
+		t.Fatalf("expected LIKE back, got mode %q", repo.SearchMode())
+	}
+}
+
 func TestRepository_CheckWritable(t *testing.T) {
 	dbPath := filepath.Join(t.TempDir(), "feedbin.db")
 	repo, err := NewRepository(dbPath)
@@ -472,3 +627,674 @@ func seedBenchmarkRepo(b *testing.B, mode string, count int) *Repository {
 	}
 	return repo
 }
+
+func TestRepository_ListFeedsAndManage(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "feedbin.db")
+	repo, err := NewRepository(dbPath)
+	if err != nil {
+		t.Fatalf("NewRepository returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	subs := []feedbin.Subscription{
+		{ID: 10, SubscriptionID: 100, Title: "Feed A", FeedURL: "https://a.example.com/feed.xml", Folder: "Tech"},
+		{ID: 20, SubscriptionID: 200, Title: "Feed B", FeedURL: "https://b.example.com/feed.xml"},
+	}
+	if err := repo.SaveSubscriptions(ctx, subs); err != nil {
+		t.Fatalf("SaveSubscriptions returned error: %v", err)
+	}
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "A1", URL: "https://a.example.com/1", FeedID: 10, PublishedAt: time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC), IsUnread: true},
+		{ID: 2, Title: "A2", URL: "https://a.example.com/2", FeedID: 10, PublishedAt: time.Date(2026, 2, 2, 10, 0, 0, 0, time.UTC)},
+		{ID: 3, Title: "B1", URL: "https://b.example.com/1", FeedID: 20, PublishedAt: time.Date(2026, 2, 3, 10, 0, 0, 0, time.UTC), IsUnread: true},
+	}
+	if err := repo.SaveEntries(ctx, entries); err != nil {
+		t.Fatalf("SaveEntries returned error: %v", err)
+	}
+
+	feeds, err := repo.ListFeeds(ctx)
+	if err != nil {
+		t.Fatalf("ListFeeds returned error: %v", err)
+	}
+	if len(feeds) != 2 || feeds[0].ID != 10 || feeds[1].ID != 20 {
+		t.Fatalf("expected foldered feed first, got %+v", feeds)
+	}
+	if feeds[0].SubscriptionID != 100 || feeds[0].UnreadCount != 1 {
+		t.Fatalf("unexpected feed summary: %+v", feeds[0])
+	}
+	if !feeds[0].LastUpdated.Equal(time.Date(2026, 2, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected last updated: %s", feeds[0].LastUpdated)
+	}
+
+	if err := repo.SetFeedMuted(ctx, 20, true); err != nil {
+		t.Fatalf("SetFeedMuted returned error: %v", err)
+	}
+	listed, err := repo.ListEntries(ctx, 10)
+	if err != nil {
+		t.Fatalf("ListEntries returned error: %v", err)
+	}
+	if len(listed) != 2 {
+		t.Fatalf("expected muted feed entries hidden, got %d entries", len(listed))
+	}
+
+	if err := repo.RenameFeed(ctx, 10, "Alias A"); err != nil {
+		t.Fatalf("RenameFeed returned error: %v", err)
+	}
+	if err := repo.SetFeedFolders(ctx, 10, []string{"Tech", "News"}); err != nil {
+		t.Fatalf("SetFeedFolders returned error: %v", err)
+	}
+	if err := repo.DeleteFeed(ctx, 20); err != nil {
+		t.Fatalf("DeleteFeed returned error: %v", err)
+	}
+	feeds, err = repo.ListFeeds(ctx)
+	if err != nil {
+		t.Fatalf("ListFeeds returned error: %v", err)
+	}
+	if len(feeds) != 1 || feeds[0].Title != "Alias A" {
+		t.Fatalf("unexpected feeds after rename/delete: %+v", feeds)
+	}
+	if feeds[0].Folder != "Tech" || !slices.Equal(feeds[0].Folders, []string{"Tech", "News"}) {
+		t.Fatalf("expected assigned folders cached, got %q / %v", feeds[0].Folder, feeds[0].Folders)
+	}
+}
+
+func TestRepository_OrphanedEntriesResolveAfterSubscriptionSync(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "feedbin.db")
+	repo, err := NewRepository(dbPath)
+	if err != nil {
+		t.Fatalf("NewRepository returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Early", URL: "https://a.example.com/1", FeedID: 10, PublishedAt: time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC)},
+		{ID: 2, Title: "Gone", URL: "https://gone.example.com/1", FeedID: 30, PublishedAt: time.Date(2026, 2, 2, 10, 0, 0, 0, time.UTC)},
+	}
+	if err := repo.SaveEntries(ctx, entries); err != nil {
+		t.Fatalf("SaveEntries returned error: %v", err)
+	}
+
+	orphans, err := repo.ListOrphanedFeedIDs(ctx)
+	if err != nil {
+		t.Fatalf("ListOrphanedFeedIDs returned error: %v", err)
+	}
+	if len(orphans) != 2 || orphans[0] != 10 || orphans[1] != 30 {
+		t.Fatalf("unexpected orphaned feeds: %v", orphans)
+	}
+
+	if err := repo.SaveSubscriptions(ctx, []feedbin.Subscription{{ID: 10, SubscriptionID: 100, Title: "Feed A", Folder: "Tech"}}); err != nil {
+		t.Fatalf("SaveSubscriptions returned error: %v", err)
+	}
+	orphans, err = repo.ListOrphanedFeedIDs(ctx)
+	if err != nil {
+		t.Fatalf("ListOrphanedFeedIDs returned error: %v", err)
+	}
+	if len(orphans) != 1 || orphans[0] != 30 {
+		t.Fatalf("expected only feed 30 orphaned, got %v", orphans)
+	}
+
+	got, err := repo.ListEntries(ctx, 10)
+	if err != nil {
+		t.Fatalf("ListEntries returned error: %v", err)
+	}
+	for _, entry := range got {
+		switch entry.ID {
+		case 1:
+			if entry.FeedTitle != "Feed A" || entry.FeedFolder != "Tech" {
+				t.Fatalf("expected back-filled feed metadata, got %+v", entry)
+			}
+		case 2:
+			if entry.FeedTitle != "" || entry.FeedFolder != "" {
+				t.Fatalf("expected unknown feed metadata, got %+v", entry)
+			}
+		}
+	}
+}
+
+func TestRepository_EntryMarksRoundTrip(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "feedbin.db")
+	repo, err := NewRepository(dbPath)
+	if err != nil {
+		t.Fatalf("NewRepository returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "One", URL: "https://example.com/1", FeedID: 10, PublishedAt: time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC), IsUnread: true},
+		{ID: 2, Title: "Two", URL: "https://example.com/2", FeedID: 10, PublishedAt: time.Date(2026, 2, 2, 10, 0, 0, 0, time.UTC), IsStarred: true},
+	}
+	if err := repo.SaveEntries(ctx, entries); err != nil {
+		t.Fatalf("SaveEntries returned error: %v", err)
+	}
+
+	if err := repo.SaveEntryMarks(ctx, []feedbin.EntryMark{{ID: 1, Unread: false, Starred: true}, {ID: 99, Unread: true}}); err != nil {
+		t.Fatalf("SaveEntryMarks returned error: %v", err)
+	}
+	marks, err := repo.ListEntryMarks(ctx)
+	if err != nil {
+		t.Fatalf("ListEntryMarks returned error: %v", err)
+	}
+	want := []feedbin.EntryMark{{ID: 1, Unread: false, Starred: true}, {ID: 2, Unread: false, Starred: true}}
+	if len(marks) != len(want) || marks[0] != want[0] || marks[1] != want[1] {
+		t.Fatalf("unexpected marks: got %+v want %+v", marks, want)
+	}
+}
+
+func TestRepository_SnoozedEntriesHiddenUntilWoken(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "feedbin.db")
+	repo, err := NewRepository(dbPath)
+	if err != nil {
+		t.Fatalf("NewRepository returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Snoozed", URL: "https://example.com/1", FeedID: 1, PublishedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), IsStarred: true},
+		{ID: 2, Title: "Visible", URL: "https://example.com/2", FeedID: 1, PublishedAt: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC), IsUnread: true},
+	}
+	if err := repo.SaveEntries(ctx, entries); err != nil {
+		t.Fatalf("SaveEntries returned error: %v", err)
+	}
+
+	now := time.Date(2026, 2, 3, 12, 0, 0, 0, time.UTC)
+	if err := repo.SnoozeEntry(ctx, 1, now.Add(time.Hour)); err != nil {
+		t.Fatalf("SnoozeEntry returned error: %v", err)
+	}
+	all, err := repo.ListEntriesByFilter(ctx, 20, "all")
+	if err != nil {
+		t.Fatalf("ListEntriesByFilter returned error: %v", err)
+	}
+	if len(all) != 1 || all[0].ID != 2 {
+		t.Fatalf("expected snoozed entry hidden from all, got %+v", all)
+	}
+	starred, err := repo.ListEntriesByFilter(ctx, 20, "starred")
+	if err != nil {
+		t.Fatalf("ListEntriesByFilter returned error: %v", err)
+	}
+	if len(starred) != 1 || starred[0].ID != 1 {
+		t.Fatalf("expected snoozed entry kept under starred, got %+v", starred)
+	}
+
+	due, err := repo.ListDueSnoozedEntryIDs(ctx, now)
+	if err != nil {
+		t.Fatalf("ListDueSnoozedEntryIDs returned error: %v", err)
+	}
+	if len(due) != 0 {
+		t.Fatalf("expected nothing due yet, got %v", due)
+	}
+	due, err = repo.ListDueSnoozedEntryIDs(ctx, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("ListDueSnoozedEntryIDs returned error: %v", err)
+	}
+	if len(due) != 1 || due[0] != 1 {
+		t.Fatalf("expected entry 1 due at wake time, got %v", due)
+	}
+
+	if err := repo.UnsnoozeEntries(ctx, due); err != nil {
+		t.Fatalf("UnsnoozeEntries returned error: %v", err)
+	}
+	all, err = repo.ListEntriesByFilter(ctx, 20, "all")
+	if err != nil {
+		t.Fatalf("ListEntriesByFilter returned error: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected woken entry visible again, got %+v", all)
+	}
+}
+
+func TestRepository_MarkFeedReadLocally_SurvivesSync(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "feedbin.db")
+	repo, err := NewRepository(dbPath)
+	if err != nil {
+		t.Fatalf("NewRepository returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	published := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	if err := repo.SaveEntries(ctx, []feedbin.Entry{
+		{ID: 1, Title: "Old", URL: "https://example.com/1", FeedID: 1, PublishedAt: published, IsUnread: true},
+		{ID: 2, Title: "Older", URL: "https://example.com/2", FeedID: 1, PublishedAt: published, IsUnread: true},
+		{ID: 3, Title: "Other feed", URL: "https://example.com/3", FeedID: 2, PublishedAt: published, IsUnread: true},
+	}); err != nil {
+		t.Fatalf("SaveEntries returned error: %v", err)
+	}
+	if err := repo.MarkFeedReadLocally(ctx, 1, 2); err != nil {
+		t.Fatalf("MarkFeedReadLocally returned error: %v", err)
+	}
+
+	// Feedbin still reports the feed's entries unread, and a new one arrives.
+	if err := repo.SaveEntries(ctx, []feedbin.Entry{
+		{ID: 4, Title: "New", URL: "https://example.com/4", FeedID: 1, PublishedAt: published.Add(time.Hour), IsUnread: true},
+	}); err != nil {
+		t.Fatalf("SaveEntries returned error: %v", err)
+	}
+	if err := repo.SaveEntryStates(ctx, []int64{1, 2, 3, 4}, nil); err != nil {
+		t.Fatalf("SaveEntryStates returned error: %v", err)
+	}
+
+	marks, err := repo.ListEntryMarks(ctx)
+	if err != nil {
+		t.Fatalf("ListEntryMarks returned error: %v", err)
+	}
+	unread := map[int64]bool{}
+	for _, mark := range marks {
+		unread[mark.ID] = mark.Unread
+	}
+	if unread[1] || unread[2] {
+		t.Fatalf("expected locally read entries to stay read, got %+v", marks)
+	}
+	if !unread[3] || !unread[4] {
+		t.Fatalf("expected other feeds and newer entries to stay unread, got %+v", marks)
+	}
+
+	// Marking a covered entry unread takes it out of the mark for good.
+	if err := repo.SetEntryUnread(ctx, 2, true); err != nil {
+		t.Fatalf("SetEntryUnread returned error: %v", err)
+	}
+	if err := repo.SaveEntryStates(ctx, []int64{1, 2, 3, 4}, nil); err != nil {
+		t.Fatalf("SaveEntryStates returned error: %v", err)
+	}
+	entry, err := repo.GetEntry(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetEntry returned error: %v", err)
+	}
+	if !entry.IsUnread {
+		t.Fatal("expected entry 2 to stay unread after the next sync")
+	}
+
+	cleared, err := repo.ClearFeedLocalRead(ctx, 1)
+	if err != nil || !cleared {
+		t.Fatalf("expected the mark cleared, got %v, %v", cleared, err)
+	}
+	if err := repo.SaveEntryStates(ctx, []int64{1, 2, 3, 4}, nil); err != nil {
+		t.Fatalf("SaveEntryStates returned error: %v", err)
+	}
+	if entry, err = repo.GetEntry(ctx, 1); err != nil || !entry.IsUnread {
+		t.Fatalf("expected Feedbin's unread state back once the mark is cleared, got %+v, %v", entry, err)
+	}
+	if cleared, err = repo.ClearFeedLocalRead(ctx, 1); err != nil || cleared {
+		t.Fatalf("expected no mark left to clear, got %v, %v", cleared, err)
+	}
+}
+
+func TestRepository_DismissedEntriesHiddenUntilUndismissed(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "feedbin.db")
+	repo, err := NewRepository(dbPath)
+	if err != nil {
+		t.Fatalf("NewRepository returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Dismissed", URL: "https://example.com/1", FeedID: 1, PublishedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), IsUnread: true, IsStarred: true},
+		{ID: 2, Title: "Visible", URL: "https://example.com/2", FeedID: 1, PublishedAt: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC), IsUnread: true},
+	}
+	if err := repo.SaveEntries(ctx, entries); err != nil {
+		t.Fatalf("SaveEntries returned error: %v", err)
+	}
+
+	if err := repo.Dismiss(ctx, 1); err != nil {
+		t.Fatalf("Dismiss returned error: %v", err)
+	}
+	for _, filter := range []string{"all", "unread", "starred", feedbin.FeedScopeFilter(1)} {
+		listed, err := repo.ListEntriesByFilter(ctx, 20, filter)
+		if err != nil {
+			t.Fatalf("ListEntriesByFilter(%q) returned error: %v", filter, err)
+		}
+		for _, entry := range listed {
+			if entry.ID == 1 {
+				t.Fatalf("expected dismissed entry hidden from %q, got %+v", filter, listed)
+			}
+		}
+	}
+	dismissed, err := repo.ListEntriesByFilter(ctx, 20, "dismissed")
+	if err != nil {
+		t.Fatalf("ListEntriesByFilter returned error: %v", err)
+	}
+	if len(dismissed) != 1 || dismissed[0].ID != 1 || !dismissed[0].IsUnread {
+		t.Fatalf("expected dismissed entry listed and still unread, got %+v", dismissed)
+	}
+	ids, err := repo.ListDismissed(ctx)
+	if err != nil {
+		t.Fatalf("ListDismissed returned error: %v", err)
+	}
+	if len(ids) != 1 || ids[0] != 1 {
+		t.Fatalf("unexpected dismissed IDs: %v", ids)
+	}
+
+	if err := repo.Undismiss(ctx, 1); err != nil {
+		t.Fatalf("Undismiss returned error: %v", err)
+	}
+	all, err := repo.ListEntriesByFilter(ctx, 20, "all")
+	if err != nil {
+		t.Fatalf("ListEntriesByFilter returned error: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected undismissed entry visible again, got %+v", all)
+	}
+}
+
+func TestRepository_GetEntry(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "feedbin.db")
+	repo, err := NewRepository(dbPath)
+	if err != nil {
+		t.Fatalf("NewRepository returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	if err := repo.SaveEntries(ctx, []feedbin.Entry{
+		{ID: 9, Title: "Kept", URL: "https://example.com/9", Content: "<p>Body</p>", FeedID: 1, PublishedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), IsStarred: true, ExtractedContentURL: "https://extract.example.com/9"},
+		{ID: 11, Title: "AMP", URL: "https://example.com/news/11/amp", FeedID: 1, PublishedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}); err != nil {
+		t.Fatalf("SaveEntries returned error: %v", err)
+	}
+
+	entry, err := repo.GetEntry(ctx, 9)
+	if err != nil {
+		t.Fatalf("GetEntry returned error: %v", err)
+	}
+	if entry.Title != "Kept" || entry.Content != "<p>Body</p>" || !entry.IsStarred || entry.ExtractedContentURL != "https://extract.example.com/9" {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+	if entry.CanonicalURL != "https://example.com/9" {
+		t.Fatalf("expected the plain URL stored as canonical, got %q", entry.CanonicalURL)
+	}
+	if amp, err := repo.GetEntry(ctx, 11); err != nil || amp.CanonicalURL != "https://example.com/news/11" {
+		t.Fatalf("expected the de-AMPed URL stored as canonical, got %q (%v)", amp.CanonicalURL, err)
+	}
+	if _, err := repo.GetEntry(ctx, 10); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows for missing entry, got %v", err)
+	}
+}
+
+func TestRepository_ReadProgressRoundTrip(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "feedbin.db")
+	repo, err := NewRepository(dbPath)
+	if err != nil {
+		t.Fatalf("NewRepository returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	if err := repo.SetReadProgress(ctx, 1, 0.25); err != nil {
+		t.Fatalf("SetReadProgress returned error: %v", err)
+	}
+	if err := repo.SetReadProgress(ctx, 1, 0.5); err != nil {
+		t.Fatalf("SetReadProgress returned error: %v", err)
+	}
+	if err := repo.SetReadProgress(ctx, 2, 0.75); err != nil {
+		t.Fatalf("SetReadProgress returned error: %v", err)
+	}
+	if err := repo.SetReadProgress(ctx, 2, 0); err != nil {
+		t.Fatalf("SetReadProgress returned error: %v", err)
+	}
+
+	progress, err := repo.ListReadProgress(ctx)
+	if err != nil {
+		t.Fatalf("ListReadProgress returned error: %v", err)
+	}
+	if len(progress) != 1 || progress[1] != 0.5 {
+		t.Fatalf("expected updated progress for entry 1 only, got %v", progress)
+	}
+}
+
+func TestRepository_ListEntries_CarriesEveryFeedTag(t *testing.T) {
+	repo, err := NewRepository(filepath.Join(t.TempDir(), "feedbin.db"))
+	if err != nil {
+		t.Fatalf("NewRepository returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	subs := []feedbin.Subscription{{ID: 1, Title: "Wire", Folder: "News", Folders: []string{"News", "Tech"}}}
+	if err := repo.SaveSubscriptions(ctx, subs); err != nil {
+		t.Fatalf("SaveSubscriptions returned error: %v", err)
+	}
+	if err := repo.SaveEntries(ctx, []feedbin.Entry{{ID: 1, Title: "Story", FeedID: 1, PublishedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)}}); err != nil {
+		t.Fatalf("SaveEntries returned error: %v", err)
+	}
+
+	listed, err := repo.ListEntries(ctx, 10)
+	if err != nil {
+		t.Fatalf("ListEntries returned error: %v", err)
+	}
+	if len(listed) != 1 || listed[0].FeedFolder != "News" || len(listed[0].FeedFolders) != 2 || listed[0].FeedFolders[1] != "Tech" {
+		t.Fatalf("expected both tags on the entry, got %+v", listed)
+	}
+}
+
+func TestRepository_CorruptDatabaseIsDetectedAndBackedUp(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "feedbin.db")
+	repo, err := NewRepository(dbPath)
+	if err != nil {
+		t.Fatalf("NewRepository returned error: %v", err)
+	}
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	if err := repo.SaveEntries(ctx, []feedbin.Entry{{ID: 1, Title: "Cached", URL: "https://example.com/1", PublishedAt: time.Now().UTC()}}); err != nil {
+		t.Fatalf("SaveEntries returned error: %v", err)
+	}
+	if err := repo.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	// Keep the SQLite header but cut the first page short, as a crash or a
+	// partial copy would.
+	if err := os.Truncate(dbPath, 512); err != nil {
+		t.Fatalf("Truncate returned error: %v", err)
+	}
+
+	corrupt, err := NewRepository(dbPath)
+	if err != nil {
+		t.Fatalf("NewRepository returned error: %v", err)
+	}
+	initErr := corrupt.Init(ctx)
+	_ = corrupt.Close()
+	if initErr == nil {
+		t.Fatal("expected Init to fail on a truncated database")
+	}
+	if !IsCorrupt(initErr) {
+		t.Fatalf("expected a corruption error, got %v", initErr)
+	}
+
+	backup, err := BackupCorruptDatabase(dbPath, time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("BackupCorruptDatabase returned error: %v", err)
+	}
+	if want := dbPath + ".corrupt-20260304-050607"; backup != want {
+		t.Fatalf("unexpected backup path %q, want %q", backup, want)
+	}
+	if _, err := os.Stat(backup); err != nil {
+		t.Fatalf("expected backup file: %v", err)
+	}
+
+	fresh, err := NewRepository(dbPath)
+	if err != nil {
+		t.Fatalf("NewRepository returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = fresh.Close() })
+	if err := fresh.Init(ctx); err != nil {
+		t.Fatalf("Init on a fresh database returned error: %v", err)
+	}
+	if err := fresh.CheckWritable(ctx); err != nil {
+		t.Fatalf("CheckWritable returned error: %v", err)
+	}
+	entries, err := fresh.ListEntries(ctx, 10)
+	if err != nil {
+		t.Fatalf("ListEntries returned error: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected an empty cache, got %d entries", len(entries))
+	}
+}
+
+func TestIsCorrupt(t *testing.T) {
+	for _, msg := range []string{
+		"database disk image is malformed (11)",
+		"file is not a database (26)",
+		"SQLITE_NOTADB",
+	} {
+		if !IsCorrupt(errors.New(msg)) {
+			t.Fatalf("expected %q to be a corruption error", msg)
+		}
+	}
+	if IsCorrupt(errors.New("unable to open database file: permission denied")) || IsCorrupt(nil) {
+		t.Fatal("expected permission errors and nil not to be corruption")
+	}
+}
+
+func TestRepository_ListEntriesByFilterSince(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "feedbin.db")
+	repo, err := NewRepository(dbPath)
+	if err != nil {
+		t.Fatalf("NewRepository returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	cutoff := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	if err := repo.SaveEntries(ctx, []feedbin.Entry{
+		{ID: 1, Title: "Old", URL: "https://example.com/1", PublishedAt: cutoff.Add(-time.Hour), IsUnread: true},
+		{ID: 2, Title: "New", URL: "https://example.com/2", PublishedAt: cutoff.Add(time.Hour), IsUnread: true},
+	}); err != nil {
+		t.Fatalf("SaveEntries returned error: %v", err)
+	}
+
+	for _, filter := range []string{"all", "unread"} {
+		entries, err := repo.ListEntriesByFilterSince(ctx, 10, filter, cutoff)
+		if err != nil {
+			t.Fatalf("ListEntriesByFilterSince returned error: %v", err)
+		}
+		if len(entries) != 1 || entries[0].ID != 2 {
+			t.Fatalf("expected only the entry after the cutoff under %s, got %+v", filter, entries)
+		}
+	}
+	entries, err := repo.ListEntriesByFilterSince(ctx, 10, "all", time.Time{})
+	if err != nil {
+		t.Fatalf("ListEntriesByFilterSince returned error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected every entry without a cutoff, got %d", len(entries))
+	}
+}
+
+func TestRepository_EachEntry_StreamsNewestFirstAndStopsOnError(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "feedbin.db")
+	repo, err := NewRepository(dbPath)
+	if err != nil {
+		t.Fatalf("NewRepository returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	base := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	if err := repo.SaveEntries(ctx, []feedbin.Entry{
+		{ID: 1, Title: "Old", URL: "https://example.com/1", PublishedAt: base},
+		{ID: 2, Title: "New", URL: "https://example.com/2", PublishedAt: base.Add(time.Hour), IsUnread: true},
+	}); err != nil {
+		t.Fatalf("SaveEntries returned error: %v", err)
+	}
+
+	var ids []int64
+	if err := repo.EachEntry(ctx, func(entry feedbin.Entry) error {
+		ids = append(ids, entry.ID)
+		return nil
+	}); err != nil {
+		t.Fatalf("EachEntry returned error: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != 2 || ids[1] != 1 {
+		t.Fatalf("expected entries newest first, got %v", ids)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = repo.EachEntry(ctx, func(feedbin.Entry) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Fatalf("expected the callback error after one call, got %v after %d", err, calls)
+	}
+}
+
+func TestNewReadOnlyRepository_ReadsButRefusesWrites(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "feedbin.db")
+	repo, err := NewRepository(dbPath)
+	if err != nil {
+		t.Fatalf("NewRepository returned error: %v", err)
+	}
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	if err := repo.SaveSubscriptions(ctx, []feedbin.Subscription{{ID: 10, Title: "Feed A"}}); err != nil {
+		t.Fatalf("SaveSubscriptions returned error: %v", err)
+	}
+	entry := feedbin.Entry{ID: 1, Title: "Cached", URL: "https://example.com/1", FeedID: 10, PublishedAt: time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC), IsUnread: true}
+	if err := repo.SaveEntries(ctx, []feedbin.Entry{entry}); err != nil {
+		t.Fatalf("SaveEntries returned error: %v", err)
+	}
+	_ = repo.Close()
+	if err := os.Chmod(dbPath, 0o444); err != nil {
+		t.Fatalf("chmod: %v", err)
+	}
+
+	readOnly, err := NewReadOnlyRepository(dbPath)
+	if err != nil {
+		t.Fatalf("NewReadOnlyRepository returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = readOnly.Close() })
+
+	listed, err := readOnly.ListEntries(ctx, 10)
+	if err != nil {
+		t.Fatalf("ListEntries returned error: %v", err)
+	}
+	if len(listed) != 1 || listed[0].Title != "Cached" {
+		t.Fatalf("expected the cached entry, got %+v", listed)
+	}
+	if err := readOnly.SetEntryUnread(ctx, 1, false); err == nil {
+		t.Fatal("expected writing a read-only database to fail")
+	}
+	if readOnly.SearchMode() != "like" {
+		t.Fatalf("expected LIKE search, got %q", readOnly.SearchMode())
+	}
+}
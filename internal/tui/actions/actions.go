@@ -2,8 +2,12 @@ package actions
 
 import (
 	"context"
+	"encoding/xml"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -17,12 +21,69 @@ type Service interface {
 	LoadMore(ctx context.Context, page, perPage int, filter string, limit int) ([]feedbin.Entry, int, error)
 	ToggleUnread(ctx context.Context, entryID int64, currentUnread bool) (bool, error)
 	ToggleStarred(ctx context.Context, entryID int64, currentStarred bool) (bool, error)
+	SetEntriesUnread(ctx context.Context, entryIDs []int64, unread bool) ([]int64, []int64, error)
+	SetEntriesStarred(ctx context.Context, entryIDs []int64, starred bool) ([]int64, []int64, error)
+	// SyncWarning reports a non-fatal problem from the latest refresh.
+	SyncWarning() string
+}
+
+// FeedManager backs the subscription manager overlay.
+type FeedManager interface {
+	ListFeeds(ctx context.Context) ([]feedbin.FeedSummary, error)
+	RenameFeed(ctx context.Context, feedID, subscriptionID int64, title string) error
+	SetFeedMuted(ctx context.Context, feedID int64, muted bool) error
+	Unsubscribe(ctx context.Context, feedID, subscriptionID int64) error
+	RefreshFeed(ctx context.Context, feedID int64) (int, error)
+}
+
+// FolderAssigner files a feed under a Feedbin folder.
+type FolderAssigner interface {
+	AssignFolder(ctx context.Context, feedID int64, folder string) (feedbin.FolderAssignment, error)
+}
+
+// Snoozer hides an entry until a wake time.
+type Snoozer interface {
+	SnoozeEntry(ctx context.Context, entryID int64, wakeAt time.Time) error
+}
+
+// Dismisser hides an entry locally without changing its read state.
+type Dismisser interface {
+	SetEntryDismissed(ctx context.Context, entryID int64, dismissed bool) error
+}
+
+// EntryWindowLoader reads one window of cached entries.
+type EntryWindowLoader interface {
+	ListCachedWindow(ctx context.Context, filter string, offset, limit int) ([]feedbin.Entry, error)
+}
+
+// ContentExtractor fetches an entry's extracted full text.
+type ContentExtractor interface {
+	ExtractedContent(ctx context.Context, entry feedbin.Entry) (string, error)
+}
+
+// DiagnosticsSource renders the diagnostic bundle for bug reports.
+type DiagnosticsSource interface {
+	Diagnostics(ctx context.Context, lastErr error) string
+}
+
+// LocalReader marks a feed's entries read in the cache only, and clears
+// that mark again.
+type LocalReader interface {
+	MarkFeedReadLocally(ctx context.Context, feedID int64, entryIDs []int64) error
+	ClearFeedReadLocally(ctx context.Context, feedID int64) (bool, error)
+}
+
+// SearchModeSwitcher switches the cache search backend between "like" and
+// "fts".
+type SearchModeSwitcher interface {
+	SetSearchMode(ctx context.Context, mode string) (string, error)
 }
 
 type RefreshSuccessMsg struct {
 	Entries  []feedbin.Entry
 	Duration time.Duration
 	Source   string
+	Warning  string
 }
 
 type RefreshErrorMsg struct {
@@ -34,12 +95,37 @@ type RefreshErrorMsg struct {
 type FilterLoadSuccessMsg struct {
 	Filter  string
 	Entries []feedbin.Entry
+	// Reload marks a re-read of the current filter after a refresh, which
+	// keeps the refresh's status line.
+	Reload bool
 }
 
 type FilterLoadErrorMsg struct {
 	Err error
 }
 
+// EntryWindowSuccessMsg carries the window of cached entries starting
+// Offset entries from the newest.
+type EntryWindowSuccessMsg struct {
+	Filter  string
+	Offset  int
+	Entries []feedbin.Entry
+}
+
+type EntryWindowErrorMsg struct {
+	Err error
+}
+
+type ExtractContentSuccessMsg struct {
+	EntryID int64
+	Content string
+}
+
+type ExtractContentErrorMsg struct {
+	EntryID int64
+	Err     error
+}
+
 type SearchLoadSuccessMsg struct {
 	Filter  string
 	Query   string
@@ -72,8 +158,84 @@ type ToggleStarredSuccessMsg struct {
 	Status      string
 }
 
+// SnoozeSuccessMsg reports that an entry was marked read and hidden until
+// WakeAt.
+type SnoozeSuccessMsg struct {
+	EntryID int64
+	WakeAt  time.Time
+	Status  string
+}
+
+// LocalReadResultMsg reports entries marked read locally. Err is set when a
+// feed's mark could not be saved; EntryIDs then holds the ones that were.
+type LocalReadResultMsg struct {
+	EntryIDs []int64
+	Status   string
+	Err      error
+}
+
+// SearchModeResultMsg reports the search backend active after a switch. Err
+// is set when the requested backend could not be enabled.
+type SearchModeResultMsg struct {
+	Mode string
+	Err  error
+}
+
+// DismissResultMsg reports a dismissed or restored entry. Err is set when the
+// change could not be saved.
+type DismissResultMsg struct {
+	EntryID   int64
+	Dismissed bool
+	Status    string
+	Err       error
+}
+
+// ToggleActionErrorMsg reports a failed read/star toggle. EntryID and Field
+// identify the rejected change so the model can roll back its local state.
 type ToggleActionErrorMsg struct {
-	Err error
+	EntryID int64
+	Field   string
+	Err     error
+}
+
+// UndoSuccessMsg reports that a read/star toggle was reverted; Value is the
+// restored state of Field.
+type UndoSuccessMsg struct {
+	EntryID int64
+	Field   string
+	Value   bool
+	Status  string
+}
+
+// BatchUpdateResultMsg reports a bulk read/star update. Failed holds the IDs
+// Feedbin rejected so the caller can offer a retry.
+type BatchUpdateResultMsg struct {
+	Field     string
+	Value     bool
+	Succeeded []int64
+	Failed    []int64
+	Err       error
+	Status    string
+}
+
+type FeedsLoadedMsg struct {
+	Feeds []feedbin.FeedSummary
+	Err   error
+}
+
+// FeedActionResultMsg reports a subscription manager action. On success the
+// caller reloads both the feed list and the entry list.
+type FeedActionResultMsg struct {
+	Status string
+	Err    error
+}
+
+// FolderAssignedMsg reports tagging a feed with a folder.
+type FolderAssignedMsg struct {
+	FeedID     int64
+	FeedTitle  string
+	Assignment feedbin.FolderAssignment
+	Err        error
 }
 
 type OpenURLSuccessMsg struct {
@@ -97,7 +259,7 @@ func RefreshCmd(service Service, perPage int, source string) tea.Cmd {
 		if err != nil {
 			return RefreshErrorMsg{Err: err, Duration: time.Since(start), Source: source}
 		}
-		return RefreshSuccessMsg{Entries: entries, Duration: time.Since(start), Source: source}
+		return RefreshSuccessMsg{Entries: entries, Duration: time.Since(start), Source: source, Warning: service.SyncWarning()}
 	}
 }
 
@@ -114,6 +276,49 @@ func LoadFilterCmd(service Service, filter string, limit int) tea.Cmd {
 	}
 }
 
+// LoadEntryWindowCmd reads limit cached entries of filter starting offset
+// entries from the newest.
+func LoadEntryWindowCmd(loader EntryWindowLoader, filter string, offset, limit int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		entries, err := loader.ListCachedWindow(ctx, filter, offset, limit)
+		if err != nil {
+			return EntryWindowErrorMsg{Err: err}
+		}
+		return EntryWindowSuccessMsg{Filter: filter, Offset: offset, Entries: entries}
+	}
+}
+
+// ExtractContentCmd fetches the extracted full text of entry.
+func ExtractContentCmd(extractor ContentExtractor, entry feedbin.Entry) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		content, err := extractor.ExtractedContent(ctx, entry)
+		if err != nil {
+			return ExtractContentErrorMsg{EntryID: entry.ID, Err: err}
+		}
+		return ExtractContentSuccessMsg{EntryID: entry.ID, Content: content}
+	}
+}
+
+// ReloadFilterCmd is LoadFilterCmd for re-reading the current filter after a
+// refresh.
+func ReloadFilterCmd(service Service, filter string, limit int) tea.Cmd {
+	load := LoadFilterCmd(service, filter, limit)
+	return func() tea.Msg {
+		msg := load()
+		if success, ok := msg.(FilterLoadSuccessMsg); ok {
+			success.Reload = true
+			return success
+		}
+		return msg
+	}
+}
+
 func LoadSearchCmd(service Service, filter, query string, limit int) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -147,7 +352,7 @@ func ToggleUnreadCmd(service Service, entryID int64, currentUnread bool) tea.Cmd
 
 		nextUnread, err := service.ToggleUnread(ctx, entryID, currentUnread)
 		if err != nil {
-			return ToggleActionErrorMsg{Err: err}
+			return ToggleActionErrorMsg{EntryID: entryID, Field: BatchFieldUnread, Err: err}
 		}
 
 		status := "Marked as read"
@@ -165,7 +370,7 @@ func ToggleStarredCmd(service Service, entryID int64, currentStarred bool) tea.C
 
 		nextStarred, err := service.ToggleStarred(ctx, entryID, currentStarred)
 		if err != nil {
-			return ToggleActionErrorMsg{Err: err}
+			return ToggleActionErrorMsg{EntryID: entryID, Field: BatchFieldStarred, Err: err}
 		}
 
 		status := "Unstarred entry"
@@ -176,6 +381,98 @@ func ToggleStarredCmd(service Service, entryID int64, currentStarred bool) tea.C
 	}
 }
 
+// UndoToggleCmd restores Field of the entry to prior, reverting the toggle
+// that flipped it away from that value.
+func UndoToggleCmd(service Service, entryID int64, field string, prior bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var (
+			restored bool
+			err      error
+			undone   string
+		)
+		if field == BatchFieldStarred {
+			restored, err = service.ToggleStarred(ctx, entryID, !prior)
+			undone = "starred"
+			if prior {
+				undone = "unstarred"
+			}
+		} else {
+			restored, err = service.ToggleUnread(ctx, entryID, !prior)
+			undone = "marked unread"
+			if prior {
+				undone = "marked read"
+			}
+		}
+		if err != nil {
+			return ToggleActionErrorMsg{EntryID: entryID, Field: field, Err: fmt.Errorf("undo %s: %w", undone, err)}
+		}
+		return UndoSuccessMsg{EntryID: entryID, Field: field, Value: restored, Status: "Undid: " + undone}
+	}
+}
+
+const (
+	BatchFieldUnread  = "unread"
+	BatchFieldStarred = "starred"
+)
+
+func BatchUpdateCmd(service Service, field string, entryIDs []int64, value bool) tea.Cmd {
+	return func() tea.Msg {
+		// Large batches are chunked and retried by the client, so allow more
+		// time than a single toggle.
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		var (
+			succeeded, failed []int64
+			err               error
+		)
+		if field == BatchFieldStarred {
+			succeeded, failed, err = service.SetEntriesStarred(ctx, entryIDs, value)
+		} else {
+			succeeded, failed, err = service.SetEntriesUnread(ctx, entryIDs, value)
+		}
+		return BatchUpdateResultMsg{
+			Field:     field,
+			Value:     value,
+			Succeeded: succeeded,
+			Failed:    failed,
+			Err:       err,
+			Status:    BatchStatus(field, value, len(succeeded), len(failed)),
+		}
+	}
+}
+
+// BatchRetryHint ends the status of a partially failed bulk update.
+const BatchRetryHint = " — press r to retry"
+
+// BatchStatus formats the outcome of a bulk update, e.g.
+// "Marked 900/1000 read, 100 failed — press r to retry".
+func BatchStatus(field string, value bool, succeeded, failed int) string {
+	var format string
+	switch {
+	case field == BatchFieldStarred && value:
+		format = "Starred %s"
+	case field == BatchFieldStarred:
+		format = "Unstarred %s"
+	case value:
+		format = "Marked %s unread"
+	default:
+		format = "Marked %s read"
+	}
+	if failed == 0 {
+		noun := "entries"
+		if succeeded == 1 {
+			noun = "entry"
+		}
+		return fmt.Sprintf(format, fmt.Sprintf("%d %s", succeeded, noun))
+	}
+	count := fmt.Sprintf("%d/%d", succeeded, succeeded+failed)
+	return fmt.Sprintf(format+", %d failed"+BatchRetryHint, count, failed)
+}
+
 func OpenURLCmd(entryID int64, unreadBefore bool, url string, openFn, copyFn func(string) error) tea.Cmd {
 	return func() tea.Msg {
 		if openFn != nil {
@@ -183,22 +480,286 @@ func OpenURLCmd(entryID int64, unreadBefore bool, url string, openFn, copyFn fun
 				return OpenURLSuccessMsg{Status: "Opened URL in browser", EntryID: entryID, UnreadBefore: unreadBefore, Opened: true}
 			}
 		}
-		if copyFn != nil {
-			if err := copyFn(url); err == nil {
-				return OpenURLSuccessMsg{Status: "Could not open browser, URL copied to clipboard", EntryID: entryID, UnreadBefore: unreadBefore, Opened: false}
-			}
+		status, err := copyWithStatus(url, "Could not open browser, copied URL", copyFn)
+		if err != nil {
+			return OpenURLErrorMsg{Err: fmt.Errorf("could not open URL or copy to clipboard: %w", err)}
 		}
-		return OpenURLErrorMsg{Err: fmt.Errorf("could not open URL or copy to clipboard")}
+		return OpenURLSuccessMsg{Status: status, EntryID: entryID, UnreadBefore: unreadBefore, Opened: false}
+	}
+}
+
+// CopyInsteadOfOpenCmd handles the open action when launching a browser would
+// not reach the user, such as over SSH. reason prefixes the status.
+func CopyInsteadOfOpenCmd(entryID int64, unreadBefore bool, url, reason string, copyFn func(string) error) tea.Cmd {
+	return func() tea.Msg {
+		status, err := copyWithStatus(url, reason+": copied URL instead of opening a browser", copyFn)
+		if err != nil {
+			return OpenURLErrorMsg{Err: fmt.Errorf("copy URL to clipboard: %w", err)}
+		}
+		return OpenURLSuccessMsg{Status: status, EntryID: entryID, UnreadBefore: unreadBefore, Opened: false}
 	}
 }
 
 func CopyURLCmd(url string, copyFn func(string) error) tea.Cmd {
+	return copyTextCmd(url, "Copied URL", "URL", copyFn)
+}
+
+func CopyFeedURLCmd(feedTitle, feedURL string, copyFn func(string) error) tea.Cmd {
+	return copyTextCmd(feedURL, "Copied feed URL for "+feedTitle, "feed URL", copyFn)
+}
+
+func CopyFeedOPMLCmd(feedTitle, feedURL string, copyFn func(string) error) tea.Cmd {
+	return copyTextCmd(FeedOPMLOutline(feedTitle, feedURL), "Copied OPML outline for "+feedTitle, "OPML outline", copyFn)
+}
+
+// CopyUnreadSummaryCmd copies UnreadSummaryText for the unread entries of a
+// feed or group; read entries are skipped.
+func CopyUnreadSummaryCmd(label string, entries []feedbin.Entry, copyFn func(string) error) tea.Cmd {
+	unread := make([]feedbin.Entry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsUnread {
+			unread = append(unread, entry)
+		}
+	}
+	noun := "titles"
+	if len(unread) == 1 {
+		noun = "title"
+	}
+	status := fmt.Sprintf("Copied %d unread %s from %s", len(unread), noun, label)
+	return copyTextCmd(UnreadSummaryText(label, unread), status, "unread list", copyFn)
+}
+
+// UnreadSummaryText renders entries as a plain-text bullet list under a
+// "<label> (N unread)" heading, each title followed by its URL.
+func UnreadSummaryText(label string, entries []feedbin.Entry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%d unread)\n", label, len(entries))
+	for _, entry := range entries {
+		title := strings.TrimSpace(entry.Title)
+		if title == "" {
+			title = "(untitled)"
+		}
+		b.WriteString("- " + title + "\n")
+		if u := strings.TrimSpace(entry.URL); u != "" {
+			b.WriteString("  " + u + "\n")
+		}
+	}
+	return b.String()
+}
+
+// copyTextCmd is the shared path for every copy action: label starts the
+// success status and what names the content in the error.
+// CopyDiagnosticsCmd copies the diagnostic bundle, built off the UI
+// goroutine since it reads the cache.
+func CopyDiagnosticsCmd(source DiagnosticsSource, lastErr error, copyFn func(string) error) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return copyTextCmd(source.Diagnostics(ctx, lastErr), "Copied diagnostic info", "diagnostic info", copyFn)()
+	}
+}
+
+func copyTextCmd(text, label, what string, copyFn func(string) error) tea.Cmd {
+	return func() tea.Msg {
+		status, err := copyWithStatus(text, label, copyFn)
+		if err != nil {
+			return OpenURLErrorMsg{Err: fmt.Errorf("copy %s to clipboard: %w", what, err)}
+		}
+		return OpenURLSuccessMsg{Status: status}
+	}
+}
+
+// copyWithStatus copies text and returns label followed by its size, such as
+// "Copied URL: 4.2 KB (820 chars)".
+func copyWithStatus(text, label string, copyFn func(string) error) (string, error) {
+	if copyFn == nil {
+		return "", errors.New("no clipboard available")
+	}
+	if err := copyFn(text); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s: %s (%d chars)", label, formatByteSize(len(text)), utf8.RuneCountInString(text)), nil
+}
+
+// formatByteSize renders n bytes as "512 B", "4.2 KB" or "1.3 MB".
+func formatByteSize(n int) string {
+	switch {
+	case n < 1024:
+		return fmt.Sprintf("%d B", n)
+	case n < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	}
+}
+
+// FeedOPMLOutline returns a single OPML <outline> element for a feed, ready to
+// paste into another reader's import file.
+func FeedOPMLOutline(feedTitle, feedURL string) string {
+	title := xmlAttr(feedTitle)
+	return fmt.Sprintf(`<outline type="rss" text="%s" title="%s" xmlUrl="%s"/>`, title, title, xmlAttr(feedURL))
+}
+
+func xmlAttr(value string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
+
+// SnoozeCmd snoozes an entry. Failures come back as ToggleActionErrorMsg so
+// the model rolls back the optimistic mark-read.
+func SnoozeCmd(snoozer Snoozer, entryID int64, wakeAt time.Time, label string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := snoozer.SnoozeEntry(ctx, entryID, wakeAt); err != nil {
+			return ToggleActionErrorMsg{EntryID: entryID, Field: BatchFieldUnread, Err: fmt.Errorf("snooze entry: %w", err)}
+		}
+		status := fmt.Sprintf("Snoozed %s (until %s)", label, wakeAt.Format("Mon Jan 2 15:04"))
+		return SnoozeSuccessMsg{EntryID: entryID, WakeAt: wakeAt, Status: status}
+	}
+}
+
+// DismissCmd dismisses an entry, or restores it when dismissed is false.
+func DismissCmd(dismisser Dismisser, entryID int64, dismissed bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := dismisser.SetEntryDismissed(ctx, entryID, dismissed); err != nil {
+			return DismissResultMsg{EntryID: entryID, Dismissed: dismissed, Err: fmt.Errorf("dismiss entry: %w", err)}
+		}
+		status := "Dismissed (X shows dismissed entries)"
+		if !dismissed {
+			status = "Restored dismissed entry"
+		}
+		return DismissResultMsg{EntryID: entryID, Dismissed: dismissed, Status: status}
+	}
+}
+
+// LocalReadCmd marks entries read locally, feed by feed, leaving Feedbin
+// untouched.
+func LocalReadCmd(reader LocalReader, label string, entries []feedbin.Entry) tea.Cmd {
 	return func() tea.Msg {
-		if copyFn != nil {
-			if err := copyFn(url); err == nil {
-				return OpenURLSuccessMsg{Status: "URL copied to clipboard"}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		byFeed := make(map[int64][]int64)
+		order := make([]int64, 0, 1)
+		for _, entry := range entries {
+			if _, ok := byFeed[entry.FeedID]; !ok {
+				order = append(order, entry.FeedID)
+			}
+			byFeed[entry.FeedID] = append(byFeed[entry.FeedID], entry.ID)
+		}
+		done := make([]int64, 0, len(entries))
+		for _, feedID := range order {
+			if err := reader.MarkFeedReadLocally(ctx, feedID, byFeed[feedID]); err != nil {
+				return LocalReadResultMsg{EntryIDs: done, Err: err}
 			}
+			done = append(done, byFeed[feedID]...)
+		}
+		return LocalReadResultMsg{EntryIDs: done, Status: fmt.Sprintf("Marked %d read locally in %s (Feedbin keeps them unread)", len(done), label)}
+	}
+}
+
+// ClearLocalReadCmd drops a feed's local read mark so the next refresh
+// brings back Feedbin's unread state for it.
+func ClearLocalReadCmd(reader LocalReader, label string, feedID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		cleared, err := reader.ClearFeedReadLocally(ctx, feedID)
+		if err != nil {
+			return LocalReadResultMsg{Err: err}
+		}
+		if !cleared {
+			return LocalReadResultMsg{Status: "No unread entries in " + label}
 		}
-		return OpenURLErrorMsg{Err: fmt.Errorf("could not copy URL to clipboard")}
+		return LocalReadResultMsg{Status: "Cleared the local read mark on " + label + "; the next refresh restores Feedbin's unread state"}
+	}
+}
+
+// SetSearchModeCmd switches the search backend; building the FTS index can
+// take a while on a large cache.
+func SetSearchModeCmd(switcher SearchModeSwitcher, mode string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		active, err := switcher.SetSearchMode(ctx, mode)
+		return SearchModeResultMsg{Mode: active, Err: err}
+	}
+}
+
+func LoadFeedsCmd(manager FeedManager) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		feeds, err := manager.ListFeeds(ctx)
+		return FeedsLoadedMsg{Feeds: feeds, Err: err}
+	}
+}
+
+func RenameFeedCmd(manager FeedManager, feed feedbin.FeedSummary, title string) tea.Cmd {
+	return feedActionCmd(func(ctx context.Context) (string, error) {
+		if err := manager.RenameFeed(ctx, feed.ID, feed.SubscriptionID, title); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Renamed %s to %s", feed.Title, strings.TrimSpace(title)), nil
+	})
+}
+
+func SetFeedMutedCmd(manager FeedManager, feed feedbin.FeedSummary, muted bool) tea.Cmd {
+	return feedActionCmd(func(ctx context.Context) (string, error) {
+		if err := manager.SetFeedMuted(ctx, feed.ID, muted); err != nil {
+			return "", err
+		}
+		if muted {
+			return "Muted " + feed.Title, nil
+		}
+		return "Unmuted " + feed.Title, nil
+	})
+}
+
+func UnsubscribeCmd(manager FeedManager, feed feedbin.FeedSummary) tea.Cmd {
+	return feedActionCmd(func(ctx context.Context) (string, error) {
+		if err := manager.Unsubscribe(ctx, feed.ID, feed.SubscriptionID); err != nil {
+			return "", err
+		}
+		return "Unsubscribed from " + feed.Title, nil
+	})
+}
+
+func RefreshFeedCmd(manager FeedManager, feed feedbin.FeedSummary) tea.Cmd {
+	return feedActionCmd(func(ctx context.Context) (string, error) {
+		count, err := manager.RefreshFeed(ctx, feed.ID)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Refreshed %s (%d entries)", feed.Title, count), nil
+	})
+}
+
+func AssignFolderCmd(assigner FolderAssigner, feedID int64, feedTitle, folder string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		assignment, err := assigner.AssignFolder(ctx, feedID, folder)
+		return FolderAssignedMsg{FeedID: feedID, FeedTitle: feedTitle, Assignment: assignment, Err: err}
+	}
+}
+
+func feedActionCmd(run func(ctx context.Context) (string, error)) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		status, err := run(ctx)
+		return FeedActionResultMsg{Status: status, Err: err}
 	}
 }
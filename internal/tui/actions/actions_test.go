@@ -3,6 +3,7 @@ package actions
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 type fakeService struct {
 	refreshEntries []feedbin.Entry
 	refreshErr     error
+	syncWarning    string
 
 	filterEntries []feedbin.Entry
 	filterErr     error
@@ -29,12 +31,17 @@ type fakeService struct {
 	toggleStarredNext bool
 	toggleStarredErr  error
 
+	batchSucceeded []int64
+	batchFailed    []int64
+	batchErr       error
+
 	lastRefreshDeadline     time.Time
 	lastFilterDeadline      time.Time
 	lastSearchDeadline      time.Time
 	lastLoadMoreDeadline    time.Time
 	lastToggleUnreadDL      time.Time
 	lastToggleStarredDL     time.Time
+	lastBatchDL             time.Time
 	lastFilter              string
 	lastSearchFilter        string
 	lastSearchQuery         string
@@ -52,6 +59,10 @@ func (f *fakeService) Refresh(ctx context.Context, page, perPage int) ([]feedbin
 	return f.refreshEntries, nil
 }
 
+func (f *fakeService) SyncWarning() string {
+	return f.syncWarning
+}
+
 func (f *fakeService) ListCachedByFilter(ctx context.Context, limit int, filter string) ([]feedbin.Entry, error) {
 	if dl, ok := ctx.Deadline(); ok {
 		f.lastFilterDeadline = dl
@@ -107,14 +118,28 @@ func (f *fakeService) ToggleStarred(ctx context.Context, entryID int64, currentS
 	return f.toggleStarredNext, nil
 }
 
+func (f *fakeService) SetEntriesUnread(ctx context.Context, entryIDs []int64, unread bool) ([]int64, []int64, error) {
+	if dl, ok := ctx.Deadline(); ok {
+		f.lastBatchDL = dl
+	}
+	return f.batchSucceeded, f.batchFailed, f.batchErr
+}
+
+func (f *fakeService) SetEntriesStarred(ctx context.Context, entryIDs []int64, starred bool) ([]int64, []int64, error) {
+	if dl, ok := ctx.Deadline(); ok {
+		f.lastBatchDL = dl
+	}
+	return f.batchSucceeded, f.batchFailed, f.batchErr
+}
+
 func TestRefreshCmd(t *testing.T) {
-	svc := &fakeService{refreshEntries: []feedbin.Entry{{ID: 1}}}
+	svc := &fakeService{refreshEntries: []feedbin.Entry{{ID: 1}}, syncWarning: "folders not updated"}
 	msg := RefreshCmd(svc, 20, "manual")()
 	success, ok := msg.(RefreshSuccessMsg)
 	if !ok {
 		t.Fatalf("expected RefreshSuccessMsg, got %T", msg)
 	}
-	if success.Source != "manual" || len(success.Entries) != 1 {
+	if success.Source != "manual" || len(success.Entries) != 1 || success.Warning != "folders not updated" {
 		t.Fatalf("unexpected success payload: %+v", success)
 	}
 	if svc.lastRefreshDeadline.IsZero() {
@@ -186,6 +211,58 @@ func TestLoadMoreAndToggleCmds(t *testing.T) {
 	}
 }
 
+func TestUndoToggleCmd(t *testing.T) {
+	svc := &fakeService{toggleUnreadNext: true, toggleStarredNext: false}
+
+	msg := UndoToggleCmd(svc, 7, BatchFieldUnread, true)()
+	undoMsg, ok := msg.(UndoSuccessMsg)
+	if !ok {
+		t.Fatalf("expected UndoSuccessMsg, got %T", msg)
+	}
+	if undoMsg.EntryID != 7 || !undoMsg.Value || undoMsg.Status != "Undid: marked read" {
+		t.Fatalf("unexpected undo unread payload: %+v", undoMsg)
+	}
+	if svc.lastToggleUnreadEntryID != 7 {
+		t.Fatalf("expected toggle unread on entry 7, got %d", svc.lastToggleUnreadEntryID)
+	}
+
+	msg = UndoToggleCmd(svc, 7, BatchFieldStarred, false)()
+	undoMsg, ok = msg.(UndoSuccessMsg)
+	if !ok || undoMsg.Field != BatchFieldStarred || undoMsg.Value || undoMsg.Status != "Undid: starred" {
+		t.Fatalf("unexpected undo starred payload: %+v", msg)
+	}
+}
+
+type fakeSnoozer struct {
+	entryID int64
+	wakeAt  time.Time
+	err     error
+}
+
+func (f *fakeSnoozer) SnoozeEntry(_ context.Context, entryID int64, wakeAt time.Time) error {
+	f.entryID = entryID
+	f.wakeAt = wakeAt
+	return f.err
+}
+
+func TestSnoozeCmd(t *testing.T) {
+	wakeAt := time.Date(2026, 2, 10, 8, 0, 0, 0, time.UTC)
+	snoozer := &fakeSnoozer{}
+	msg, ok := SnoozeCmd(snoozer, 7, wakeAt, "tomorrow")().(SnoozeSuccessMsg)
+	if !ok || msg.EntryID != 7 || !msg.WakeAt.Equal(wakeAt) || msg.Status != "Snoozed tomorrow (until Tue Feb 10 08:00)" {
+		t.Fatalf("unexpected snooze payload: %+v", msg)
+	}
+	if snoozer.entryID != 7 || !snoozer.wakeAt.Equal(wakeAt) {
+		t.Fatalf("expected snoozer called with entry 7, got %+v", snoozer)
+	}
+
+	snoozer.err = errors.New("offline")
+	errMsg, ok := SnoozeCmd(snoozer, 7, wakeAt, "tomorrow")().(ToggleActionErrorMsg)
+	if !ok || errMsg.EntryID != 7 || errMsg.Field != BatchFieldUnread {
+		t.Fatalf("expected rollback-able error, got %+v", errMsg)
+	}
+}
+
 func TestActionErrors(t *testing.T) {
 	svc := &fakeService{
 		refreshErr:       errors.New("refresh failed"),
@@ -208,11 +285,66 @@ func TestActionErrors(t *testing.T) {
 	if _, ok := LoadMoreCmd(svc, 2, 20, "all", 20)().(LoadMoreErrorMsg); !ok {
 		t.Fatal("expected LoadMoreErrorMsg")
 	}
-	if _, ok := ToggleUnreadCmd(svc, 1, false)().(ToggleActionErrorMsg); !ok {
-		t.Fatal("expected ToggleActionErrorMsg for unread")
+	if msg, ok := ToggleUnreadCmd(svc, 1, false)().(ToggleActionErrorMsg); !ok || msg.EntryID != 1 || msg.Field != BatchFieldUnread {
+		t.Fatalf("expected ToggleActionErrorMsg for unread, got %#v", msg)
 	}
-	if _, ok := ToggleStarredCmd(svc, 1, false)().(ToggleActionErrorMsg); !ok {
-		t.Fatal("expected ToggleActionErrorMsg for starred")
+	if msg, ok := ToggleStarredCmd(svc, 1, false)().(ToggleActionErrorMsg); !ok || msg.EntryID != 1 || msg.Field != BatchFieldStarred {
+		t.Fatalf("expected ToggleActionErrorMsg for starred, got %#v", msg)
+	}
+}
+
+func TestCopyInsteadOfOpenCmd(t *testing.T) {
+	msg := CopyInsteadOfOpenCmd(1, true, "https://example.com", "SSH session", func(string) error { return nil })()
+	success, ok := msg.(OpenURLSuccessMsg)
+	if !ok || success.Opened || success.EntryID != 1 || !strings.HasPrefix(success.Status, "SSH session: ") {
+		t.Fatalf("expected copy success explaining the reason, got %T %+v", msg, success)
+	}
+
+	msg = CopyInsteadOfOpenCmd(1, true, "https://example.com", "SSH session", func(string) error { return errors.New("copy failed") })()
+	if _, ok := msg.(OpenURLErrorMsg); !ok {
+		t.Fatalf("expected OpenURLErrorMsg, got %T", msg)
+	}
+}
+
+func TestCopyURLCmd_ReportsSizeAndNamesFailure(t *testing.T) {
+	text := strings.Repeat("é", 2100)
+	msg := CopyURLCmd(text, func(string) error { return nil })()
+	success, ok := msg.(OpenURLSuccessMsg)
+	if !ok || success.Status != "Copied URL: 4.1 KB (2100 chars)" {
+		t.Fatalf("expected size in status, got %T %+v", msg, success)
+	}
+
+	msg = CopyFeedOPMLCmd("Feed", "https://example.com/feed", func(string) error {
+		return errors.New("clipboard command xclip failed: exit status 1")
+	})()
+	failure, ok := msg.(OpenURLErrorMsg)
+	if !ok || failure.Err.Error() != "copy OPML outline to clipboard: clipboard command xclip failed: exit status 1" {
+		t.Fatalf("expected error naming content and command, got %T %+v", msg, failure)
+	}
+}
+
+func TestCopyUnreadSummaryCmd_SkipsReadEntries(t *testing.T) {
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Read", URL: "https://example.com/1"},
+		{ID: 2, Title: "  ", URL: "https://example.com/2", IsUnread: true},
+	}
+	var copied string
+	msg := CopyUnreadSummaryCmd("Feed A", entries, func(text string) error { copied = text; return nil })()
+	success, ok := msg.(OpenURLSuccessMsg)
+	if !ok || !strings.HasPrefix(success.Status, "Copied 1 unread title from Feed A: ") {
+		t.Fatalf("expected count in status, got %T %+v", msg, success)
+	}
+	if copied != "Feed A (1 unread)\n- (untitled)\n  https://example.com/2\n" {
+		t.Fatalf("unexpected copied text %q", copied)
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	cases := map[int]string{0: "0 B", 1023: "1023 B", 1024: "1.0 KB", 4300: "4.2 KB", 3 * 1024 * 1024: "3.0 MB"}
+	for n, want := range cases {
+		if got := formatByteSize(n); got != want {
+			t.Fatalf("formatByteSize(%d) = %q, want %q", n, got, want)
+		}
 	}
 }
 
@@ -254,3 +386,42 @@ func TestCopyURLCmd(t *testing.T) {
 		t.Fatalf("expected OpenURLErrorMsg, got %T", msg)
 	}
 }
+
+func TestBatchUpdateCmd_ReportsPartialFailure(t *testing.T) {
+	svc := &fakeService{
+		batchSucceeded: []int64{1, 2, 3},
+		batchFailed:    []int64{4},
+		batchErr:       errors.New("chunk failed"),
+	}
+	msg := BatchUpdateCmd(svc, BatchFieldUnread, []int64{1, 2, 3, 4}, false)()
+	result, ok := msg.(BatchUpdateResultMsg)
+	if !ok {
+		t.Fatalf("expected BatchUpdateResultMsg, got %T", msg)
+	}
+	if result.Status != "Marked 3/4 read, 1 failed — press r to retry" {
+		t.Fatalf("unexpected status: %q", result.Status)
+	}
+	if len(result.Failed) != 1 || result.Failed[0] != 4 || result.Err == nil {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if svc.lastBatchDL.IsZero() {
+		t.Fatal("expected batch context deadline to be set")
+	}
+}
+
+func TestBatchStatus(t *testing.T) {
+	if got := BatchStatus(BatchFieldStarred, true, 10, 0); got != "Starred 10 entries" {
+		t.Fatalf("unexpected status: %q", got)
+	}
+	if got := BatchStatus(BatchFieldUnread, true, 900, 100); got != "Marked 900/1000 unread, 100 failed — press r to retry" {
+		t.Fatalf("unexpected status: %q", got)
+	}
+}
+
+func TestFeedOPMLOutline_EscapesAttributes(t *testing.T) {
+	got := FeedOPMLOutline(`Tom & "Jerry"`, "https://example.com/feed?a=1&b=2")
+	want := `<outline type="rss" text="Tom &amp; &#34;Jerry&#34;" title="Tom &amp; &#34;Jerry&#34;" xmlUrl="https://example.com/feed?a=1&amp;b=2"/>`
+	if got != want {
+		t.Fatalf("unexpected outline:\n got %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"math"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+// openArticle is the article open in detail before a refresh replaced the
+// entries, with how far it was scrolled.
+type openArticle struct {
+	entry    feedbin.Entry
+	fraction float64
+	open     bool
+}
+
+func (m Model) snapshotOpenArticle() openArticle {
+	if !m.inDetail || len(m.entries) == 0 || m.cursor < 0 || m.cursor >= len(m.entries) {
+		return openArticle{}
+	}
+	fraction := 0.0
+	if maxTop := m.detailMaxTop(); maxTop > 0 {
+		fraction = float64(m.detailTop) / float64(maxTop)
+	}
+	return openArticle{entry: m.entries[m.cursor], fraction: fraction, open: true}
+}
+
+// reflowUpdatedArticle keeps reading at the same relative spot when the
+// refresh brought a corrected version of the open article, reporting
+// whether it changed.
+func (m *Model) reflowUpdatedArticle(before openArticle) bool {
+	if !before.open || !m.inDetail || len(m.entries) == 0 {
+		return false
+	}
+	current := m.entries[m.cursor]
+	if current.ID != before.entry.ID || !articleChanged(before.entry, current) {
+		return false
+	}
+	m.detailTop = int(math.Round(before.fraction * float64(m.detailMaxTop())))
+	return true
+}
+
+func articleChanged(before, after feedbin.Entry) bool {
+	return before.Title != after.Title || before.Content != after.Content || before.Summary != after.Summary
+}
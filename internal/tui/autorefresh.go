@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+	tuiactions "github.com/glabrego/reeder-cli/internal/tui/actions"
+	tuiplatform "github.com/glabrego/reeder-cli/internal/tui/platform"
+)
+
+// newEntryBellGap is the least time between two new-entry bells, so a run of
+// busy auto-refreshes rings once.
+const newEntryBellGap = time.Minute
+
+type autoRefreshMsg struct{}
+
+type bellErrorMsg struct {
+	err error
+}
+
+// SetAutoRefresh refreshes in the background every interval; zero turns it
+// off.
+func (m *Model) SetAutoRefresh(interval time.Duration) {
+	m.autoRefreshInterval = interval
+}
+
+// SetNewEntryBell rings the terminal bell, or runs command when it is not
+// empty, when an auto-refresh brings in new entries.
+func (m *Model) SetNewEntryBell(command string) {
+	m.bellFn = func(newEntries int) error {
+		return tuiplatform.RingBell(command, newEntries)
+	}
+}
+
+func autoRefreshTickCmd(interval time.Duration) tea.Cmd {
+	if interval <= 0 {
+		return nil
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return autoRefreshMsg{}
+	})
+}
+
+// startAutoRefresh runs a background refresh unless another one is in
+// flight or it is quiet hours, and schedules the next tick either way.
+func (m Model) startAutoRefresh() (tea.Model, tea.Cmd) {
+	next := autoRefreshTickCmd(m.autoRefreshInterval)
+	if m.refreshing || m.loading || m.service == nil || m.offline || m.inQuietHours() {
+		return m, next
+	}
+	m.refreshing = true
+	return m, tea.Batch(tuiactions.RefreshCmd(m.service, m.perPage, "auto"), next)
+}
+
+// noteNewEntries tracks the newest entry ID seen by refreshes and returns a
+// bell command when an auto-refresh brought in entries newer than that.
+// Feedbin IDs grow with arrival, so the count ignores publish dates that
+// feeds backdate.
+func (m *Model) noteNewEntries(entries []feedbin.Entry, source string) tea.Cmd {
+	newest := m.newestSeenID
+	count := 0
+	for _, entry := range entries {
+		if m.newestSeenID != 0 && entry.ID > m.newestSeenID {
+			count++
+		}
+		if entry.ID > newest {
+			newest = entry.ID
+		}
+	}
+	seenBefore := m.newestSeenID != 0
+	m.newestSeenID = newest
+	if source != "auto" || !seenBefore || count == 0 || m.bellFn == nil || m.inQuietHours() {
+		return nil
+	}
+	now := m.nowFn()
+	if !m.lastBellAt.IsZero() && now.Sub(m.lastBellAt) < newEntryBellGap {
+		return nil
+	}
+	m.lastBellAt = now
+	bell := m.bellFn
+	return func() tea.Msg {
+		if err := bell(count); err != nil {
+			return bellErrorMsg{err: err}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,70 @@
+package tui
+
+import "strings"
+
+// treeRules holds the folder and feed names that start collapsed or expanded.
+// Each tree node is seeded from the rules once, the first time it is seen, so
+// later toggles by the user stick.
+type treeRules struct {
+	collapse map[string]bool
+	expand   map[string]bool
+	seen     map[string]bool
+}
+
+func nameSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			set[name] = true
+		}
+	}
+	return set
+}
+
+// SetTreeRules makes the named folders and feeds start collapsed or expanded,
+// overriding any restored view state. Names match case-insensitively and a
+// name in both lists is expanded. Nodes are seeded as they first appear in
+// the list, whatever entries they hold.
+func (m *Model) SetTreeRules(collapse, expand []string) {
+	if len(collapse) == 0 && len(expand) == 0 {
+		m.treeRules = nil
+		return
+	}
+	m.treeRules = &treeRules{
+		collapse: nameSet(collapse),
+		expand:   nameSet(expand),
+		seen:     make(map[string]bool),
+	}
+	m.applyTreeRules()
+}
+
+// applyTreeRules seeds collapse state for folders and feeds that have not
+// been seen since the rules were set.
+func (m *Model) applyTreeRules() {
+	rules := m.treeRules
+	if rules == nil {
+		return
+	}
+	for _, entry := range m.entries {
+		folder := folderNameForEntry(entry, m.defaultFolder)
+		if folder != "" && !rules.seen["folder:"+folder] {
+			rules.seen["folder:"+folder] = true
+			rules.apply(m, m.collapsedFolders, folder, folder)
+		}
+		feedKey := treeFeedKey(folder, feedNameForEntry(entry))
+		if !rules.seen["feed:"+feedKey] {
+			rules.seen["feed:"+feedKey] = true
+			rules.apply(m, m.collapsedFeeds, feedKey, feedNameForEntry(entry))
+		}
+	}
+}
+
+func (r *treeRules) apply(m *Model, collapsed map[string]bool, key, name string) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch {
+	case r.expand[name]:
+		m.setCollapsed(collapsed, key, false)
+	case r.collapse[name]:
+		m.setCollapsed(collapsed, key, true)
+	}
+}
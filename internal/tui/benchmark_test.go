@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+// BenchmarkTreeRows compares a render-loop of treeRows calls with and without
+// the row cache. Uncached runs drop the cache so every call rebuilds the tree.
+func BenchmarkTreeRows(b *testing.B) {
+	for _, n := range []int{5000, 20000} {
+		for _, cached := range []bool{false, true} {
+			name := fmt.Sprintf("entries=%d/cached=%v", n, cached)
+			b.Run(name, func(b *testing.B) {
+				// NewModel trims the seed to one page, so load the full set directly.
+				m := NewModel(nil, nil)
+				m.entries = benchmarkEntries(n)
+				m.sortEntries()
+				if !cached {
+					m.treeCache = nil
+				}
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					_ = m.treeRows()
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkEntryWindow compares the per-frame tree work on a 50k-entry cache
+// held fully in memory against a bounded window of it.
+func BenchmarkEntryWindow(b *testing.B) {
+	const cacheSize = 50000
+	all := benchmarkEntries(cacheSize)
+	for _, window := range []int{0, 2000} {
+		name := "full"
+		if window > 0 {
+			name = fmt.Sprintf("window=%d", window)
+		}
+		b.Run(name, func(b *testing.B) {
+			m := NewModel(nil, nil)
+			m.entries = append([]feedbin.Entry(nil), all...)
+			if window > 0 {
+				m.entries = m.entries[:window]
+			}
+			m.sortEntries()
+			m.treeCache = nil
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = m.treeRows()
+				_, _ = m.unreadCountsByTreeNode()
+			}
+		})
+	}
+}
+
+func benchmarkEntries(n int) []feedbin.Entry {
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	entries := make([]feedbin.Entry, 0, n)
+	for i := 0; i < n; i++ {
+		entries = append(entries, feedbin.Entry{
+			ID:          int64(i + 1),
+			Title:       fmt.Sprintf("Entry %05d", i),
+			FeedTitle:   fmt.Sprintf("Feed %02d", i%40),
+			FeedFolder:  fmt.Sprintf("Folder %02d", i%8),
+			PublishedAt: now.Add(-time.Duration(i) * time.Minute),
+		})
+	}
+	return entries
+}
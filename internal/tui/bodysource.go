@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"context"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+	article "github.com/glabrego/reeder-cli/internal/render/article"
+	tuiactions "github.com/glabrego/reeder-cli/internal/tui/actions"
+)
+
+// ContentExtractor fetches the full text Feedbin extracts from an article
+// page, the third body source s cycles to in the detail view.
+type ContentExtractor interface {
+	ExtractedContent(ctx context.Context, entry feedbin.Entry) (string, error)
+}
+
+// SetContentExtractor lets s cycle the detail view through the feed
+// content, the feed summary and the extracted full text, labeling the
+// active source in the header.
+func (m *Model) SetContentExtractor(extractor ContentExtractor) {
+	m.extractor = extractor
+	m.extractedContent = make(map[int64]string)
+}
+
+// detailSource applies the body source chosen with s to entry and names it
+// for the detail header.
+func (m Model) detailSource(entry feedbin.Entry) (feedbin.Entry, article.Options) {
+	opts := m.renderOptions()
+	if m.extractor == nil {
+		return entry, opts
+	}
+	if content, ok := m.extractedContent[entry.ID]; ok && m.extractedEntryID == entry.ID {
+		entry.Content = content
+		opts.PreferSummary = false
+		opts.SourceLabel = "extracted full text"
+		return entry, opts
+	}
+	if strings.TrimSpace(entry.Content) == "" || (opts.PreferSummary && strings.TrimSpace(entry.Summary) != "") {
+		opts.SourceLabel = "feed summary"
+	} else {
+		opts.SourceLabel = "feed content"
+	}
+	return entry, opts
+}
+
+// cycleBodySource moves the open article from the feed content to the
+// summary to the extracted full text and back. Entries without an
+// extraction link skip the extracted text; extracted text is fetched once
+// per entry and kept for the session.
+func (m Model) cycleBodySource() (tea.Model, tea.Cmd) {
+	if m.extractor == nil || len(m.entries) == 0 {
+		return m.togglePreferSummary()
+	}
+	entry := m.entries[m.cursor]
+	if m.extractedEntryID == entry.ID {
+		m.extractedEntryID = 0
+		m.preferSummary = true
+		return m.togglePreferSummary()
+	}
+	if !m.preferSummary || strings.TrimSpace(entry.ExtractedContentURL) == "" {
+		return m.togglePreferSummary()
+	}
+	m.extractedEntryID = entry.ID
+	m.detailTop = 0
+	m.err = nil
+	if _, ok := m.extractedContent[entry.ID]; ok {
+		m.status = "Article text: extracted full text"
+		return m, nil
+	}
+	m.status = "Extracting full text…"
+	return m, tuiactions.ExtractContentCmd(m.extractor, entry)
+}
+
+func (m Model) finishExtractContent(msg tuiactions.ExtractContentSuccessMsg) (tea.Model, tea.Cmd) {
+	m.extractedContent[msg.EntryID] = msg.Content
+	if m.extractedEntryID == msg.EntryID {
+		m.status = "Article text: extracted full text"
+	}
+	return m, nil
+}
+
+func (m Model) failExtractContent(msg tuiactions.ExtractContentErrorMsg) (tea.Model, tea.Cmd) {
+	if m.extractedEntryID == msg.EntryID {
+		m.extractedEntryID = 0
+	}
+	m.status = ""
+	m.err = msg.Err
+	return m, nil
+}
@@ -0,0 +1,256 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+	tuiactions "github.com/glabrego/reeder-cli/internal/tui/actions"
+)
+
+// bulkAction is an operation touching many entries (or a whole feed) that
+// goes through the shared Shift+M confirmation when confirmBulkActions is on.
+type bulkAction struct {
+	// summary names the count and target, e.g. "Mark 12 entries read in Tech".
+	summary string
+	run     func(Model) (tea.Model, tea.Cmd)
+}
+
+func (m Model) requestBulkAction(action bulkAction) (tea.Model, tea.Cmd) {
+	if !m.confirmBulkActions {
+		return action.run(m)
+	}
+	m.pendingBulk = &action
+	m.err = nil
+	m.status = action.summary + "? Press Shift+M to confirm, any other key cancels"
+	return m, nil
+}
+
+// handlePendingBulkKey consumes the key pressed while a bulk action awaits
+// confirmation: Shift+M runs it, anything else cancels.
+func (m Model) handlePendingBulkKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action := m.pendingBulk
+	m.pendingBulk = nil
+	if msg.String() != "M" {
+		m.status = "Canceled: " + action.summary
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 3*time.Second)
+	}
+	return action.run(m)
+}
+
+func entryCountLabel(n int) string {
+	if n == 1 {
+		return "1 entry"
+	}
+	return fmt.Sprintf("%d entries", n)
+}
+
+// currentCollection returns the label and loaded entries under the section,
+// folder or feed row at the tree cursor.
+func (m Model) currentCollection() (string, []feedbin.Entry, bool) {
+	rows := m.treeRows()
+	if m.treeCursor < 0 || m.treeCursor >= len(rows) {
+		return "", nil, false
+	}
+	row := rows[m.treeCursor]
+	if row.Kind == treeRowFeed {
+		entries := make([]feedbin.Entry, 0, 16)
+		for _, entry := range m.entries {
+			if m.entryOnFeedRow(entry, row) {
+				entries = append(entries, entry)
+			}
+		}
+		return row.Feed, entries, true
+	}
+
+	var (
+		label   string
+		matches func(folder string) bool
+	)
+	switch row.Kind {
+	case treeRowSection:
+		label = row.Label
+		inFolders := row.Label == "Folders"
+		matches = func(folder string) bool { return row.Pinned || (folder != "") == inFolders }
+	case treeRowFolder:
+		label = row.Folder
+		matches = func(folder string) bool { return folder == row.Folder }
+	default:
+		return "", nil, false
+	}
+
+	entries := make([]feedbin.Entry, 0, 16)
+	for _, entry := range m.entries {
+		// Pinned feeds belong to the Pinned section only.
+		if m.entryPinned(entry) != row.Pinned {
+			continue
+		}
+		for _, folder := range m.entryFolders(entry) {
+			if matches(folder) {
+				entries = append(entries, entry)
+				break
+			}
+		}
+	}
+	return label, entries, true
+}
+
+// copyCollectionUnread copies the titles and URLs of the unread entries under
+// the feed, folder or section row at the cursor.
+func (m Model) copyCollectionUnread() (tea.Model, tea.Cmd) {
+	label, entries, ok := m.currentCollection()
+	if !ok {
+		m.err = nil
+		m.status = "Move to a feed row to copy its unread titles"
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 3*time.Second)
+	}
+	unread := 0
+	for _, entry := range entries {
+		if entry.IsUnread {
+			unread++
+		}
+	}
+	if unread == 0 {
+		m.err = nil
+		m.status = "No unread entries in " + label
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 3*time.Second)
+	}
+	return m, tuiactions.CopyUnreadSummaryCmd(label, entries, m.copyURLFn)
+}
+
+// toggleCollectionRead works like U on a single entry: it marks the unread
+// entries under the row read, or, when everything is already read, marks the
+// whole collection unread again.
+func (m Model) toggleCollectionRead() (tea.Model, tea.Cmd) {
+	if m.service == nil {
+		return m, nil
+	}
+	label, entries, ok := m.currentCollection()
+	if !ok || len(entries) == 0 {
+		return m, nil
+	}
+	unreadIDs := make([]int64, 0, len(entries))
+	allIDs := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		allIDs = append(allIDs, entry.ID)
+		if entry.IsUnread {
+			unreadIDs = append(unreadIDs, entry.ID)
+		}
+	}
+
+	if len(unreadIDs) == 0 {
+		return m.requestBulkAction(bulkAction{
+			summary: fmt.Sprintf("All read: mark %s unread in %s", entryCountLabel(len(allIDs)), label),
+			run: func(m Model) (tea.Model, tea.Cmd) {
+				return m.batchUpdate(tuiactions.BatchFieldUnread, allIDs, true)
+			},
+		})
+	}
+	return m.requestBulkAction(bulkAction{
+		summary: fmt.Sprintf("Mark %s read in %s", entryCountLabel(len(unreadIDs)), label),
+		run: func(m Model) (tea.Model, tea.Cmd) {
+			return m.batchUpdate(tuiactions.BatchFieldUnread, unreadIDs, false)
+		},
+	})
+}
+
+func (m Model) starCollection() (tea.Model, tea.Cmd) {
+	if m.service == nil {
+		return m, nil
+	}
+	label, entries, ok := m.currentCollection()
+	if !ok {
+		return m, nil
+	}
+	ids := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsStarred {
+			ids = append(ids, entry.ID)
+		}
+	}
+	if len(ids) == 0 {
+		m.status = "All entries in " + label + " are already starred"
+		return m, nil
+	}
+	return m.requestBulkAction(bulkAction{
+		summary: fmt.Sprintf("Star %s in %s", entryCountLabel(len(ids)), label),
+		run: func(m Model) (tea.Model, tea.Cmd) {
+			return m.batchUpdate(tuiactions.BatchFieldStarred, ids, true)
+		},
+	})
+}
+
+// SetLocalReader lets ctrl+u mark a feed read without syncing to Feedbin.
+func (m *Model) SetLocalReader(reader LocalReader) {
+	m.localReader = reader
+}
+
+// markFeedReadLocally marks the unread entries of the feed row at the
+// cursor read in the cache only, for feeds that should not change the
+// Feedbin unread count seen on other devices. On a feed with nothing unread
+// it clears the feed's local read mark instead.
+func (m Model) markFeedReadLocally() (tea.Model, tea.Cmd) {
+	if m.localReader == nil {
+		return m, nil
+	}
+	rows := m.treeRows()
+	if m.treeCursor < 0 || m.treeCursor >= len(rows) || rows[m.treeCursor].Kind != treeRowFeed {
+		m.err = nil
+		m.status = "Move to a feed row to mark it read locally"
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 3*time.Second)
+	}
+	label, entries, _ := m.currentCollection()
+	unread := make([]feedbin.Entry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsUnread {
+			unread = append(unread, entry)
+		}
+	}
+	reader := m.localReader
+	if len(unread) == 0 {
+		if len(entries) == 0 {
+			m.err = nil
+			m.status = "No unread entries in " + label
+			m.statusID++
+			return m, clearStatusCmd(m.statusID, 3*time.Second)
+		}
+		m.loading = true
+		m.status = ""
+		m.err = nil
+		return m, tuiactions.ClearLocalReadCmd(reader, label, entries[0].FeedID)
+	}
+	return m.requestBulkAction(bulkAction{
+		summary: fmt.Sprintf("Mark %s read locally in %s", entryCountLabel(len(unread)), label),
+		run: func(m Model) (tea.Model, tea.Cmd) {
+			m.loading = true
+			m.status = ""
+			m.err = nil
+			return m, tuiactions.LocalReadCmd(reader, label, unread)
+		},
+	})
+}
+
+func (m Model) finishLocalRead(msg tuiactions.LocalReadResultMsg) (tea.Model, tea.Cmd) {
+	m.loading = false
+	anchorID := m.anchorEntryID()
+	for _, id := range msg.EntryIDs {
+		m.setEntryUnread(id, false)
+	}
+	m.applyCurrentFilter()
+	m.restoreSelection(anchorID)
+	if msg.Err != nil {
+		m.status = ""
+		m.err = msg.Err
+		return m, nil
+	}
+	m.err = nil
+	m.status = msg.Status
+	m.statusID++
+	return m, clearStatusCmd(m.statusID, 4*time.Second)
+}
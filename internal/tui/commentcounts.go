@@ -0,0 +1,47 @@
+package tui
+
+import "github.com/glabrego/reeder-cli/internal/feedbin"
+
+// commentCountCache holds the comment counts parsed from the loaded entries,
+// so a frame does not reparse every row. It is shared by pointer across Model
+// copies, like treeRowsCache, and starts over whenever the entries slice is
+// replaced, so it never holds more than the entries in memory.
+type commentCountCache struct {
+	first  *feedbin.Entry
+	count  int
+	counts map[int64]commentCountMemo
+}
+
+// commentCountMemo is reused only while the entry's content and summary are
+// unchanged, e.g. until a refresh rewrites them.
+type commentCountMemo struct {
+	content string
+	summary string
+	count   int
+	ok      bool
+}
+
+// entryCommentCount is the comment count of entry when comment counts are on
+// and its feed reported one.
+func (m Model) entryCommentCount(entry feedbin.Entry) (int, bool) {
+	if !m.articleOptions.CommentCounts {
+		return 0, false
+	}
+	if m.commentCounts == nil {
+		return entry.CommentCount()
+	}
+	var first *feedbin.Entry
+	if len(m.entries) > 0 {
+		first = &m.entries[0]
+	}
+	cache := m.commentCounts
+	if cache.counts == nil || cache.first != first || cache.count != len(m.entries) {
+		*cache = commentCountCache{first: first, count: len(m.entries), counts: make(map[int64]commentCountMemo)}
+	}
+	if memo, found := cache.counts[entry.ID]; found && memo.content == entry.Content && memo.summary == entry.Summary {
+		return memo.count, memo.ok
+	}
+	count, ok := entry.CommentCount()
+	cache.counts[entry.ID] = commentCountMemo{content: entry.Content, summary: entry.Summary, count: count, ok: ok}
+	return count, ok
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	detailBoundaryWrap = "wrap"
+	detailBoundaryExit = "exit"
+)
+
+// SetDetailBoundary picks what [ on the first entry and ] on the last one do
+// in the detail view: "wrap" cycles to the other end, "exit" returns to the
+// list, and anything else stops there.
+func (m *Model) SetDetailBoundary(mode string) {
+	switch mode {
+	case detailBoundaryWrap, detailBoundaryExit:
+		m.detailBoundary = mode
+	default:
+		m.detailBoundary = ""
+	}
+}
+
+// showDetailEntry opens entry i in the detail view in place of the current
+// one, marking it read when V is on.
+func (m Model) showDetailEntry(i int) (tea.Model, tea.Cmd) {
+	saveCmd := m.recordReadProgress()
+	m.cursor = i
+	m.selectedID = m.entries[m.cursor].ID
+	m.resumeReadProgress()
+	markCmd := m.markReadOnDetailCmd()
+	return m, tea.Batch(saveCmd, markCmd, m.ensureInlineImagePreviewCmd())
+}
+
+// closeDetail leaves the detail view for the list.
+func (m Model) closeDetail() (tea.Model, tea.Cmd) {
+	saveCmd := m.recordReadProgress()
+	if m.imageQueue != nil {
+		m.imageQueue.dropExcept(0)
+	}
+	m.inDetail = false
+	m.detailTop = 0
+	return m, tea.Batch(saveCmd, tea.ClearScreen)
+}
+
+// crossDetailBoundary handles [ on the first entry (direction -1) and ] on
+// the last one (direction 1).
+func (m Model) crossDetailBoundary(direction int) (tea.Model, tea.Cmd) {
+	switch m.detailBoundary {
+	case detailBoundaryWrap:
+		target, status := 0, "Wrapped to first entry"
+		if direction < 0 {
+			target, status = len(m.entries)-1, "Wrapped to last entry"
+		}
+		next, cmd := m.showDetailEntry(target)
+		model := next.(Model)
+		model.status = status
+		model.statusID++
+		return model, tea.Batch(cmd, clearStatusCmd(model.statusID, 3*time.Second))
+	case detailBoundaryExit:
+		next, cmd := m.closeDetail()
+		model := next.(Model)
+		model.status = "No more entries"
+		model.statusID++
+		return model, tea.Batch(cmd, clearStatusCmd(model.statusID, 3*time.Second))
+	}
+	return m, nil
+}
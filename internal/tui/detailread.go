@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	tuiactions "github.com/glabrego/reeder-cli/internal/tui/actions"
+)
+
+// markReadOnDetailCmd marks the entry whose detail view just opened as read
+// when the preference is on, through the same debounce and confirm flow as
+// opening its URL.
+func (m *Model) markReadOnDetailCmd() tea.Cmd {
+	if !m.markReadOnDetailOpen || m.service == nil || len(m.entries) == 0 {
+		return nil
+	}
+	entry := m.entries[m.cursor]
+	if !entry.IsUnread {
+		return nil
+	}
+	return m.autoMarkRead(entry.ID)
+}
+
+// autoMarkRead marks entryID read after an open, unless the same entry was
+// just marked (debounce) or the confirm prompt is on, in which case Shift+M
+// finishes the job.
+func (m *Model) autoMarkRead(entryID int64) tea.Cmd {
+	if m.readOnly {
+		return nil
+	}
+	now := m.nowFn()
+	if m.lastOpenReadEntryID == entryID && now.Sub(m.lastOpenReadAt) < m.autoReadDebounce {
+		m.status = "Skipped mark-read (debounced)"
+		m.statusID++
+		return clearStatusCmd(m.statusID, 3*time.Second)
+	}
+	if m.confirmOpenRead {
+		m.pendingOpenReadEntryID = entryID
+		m.status = "Press Shift+M to confirm mark as read"
+		m.statusID++
+		return clearStatusCmd(m.statusID, 4*time.Second)
+	}
+	m.lastOpenReadEntryID = entryID
+	m.lastOpenReadAt = now
+	m.loading = true
+	m.beginToggle(entryID, tuiactions.BatchFieldUnread, false)
+	return tuiactions.ToggleUnreadCmd(m.service, entryID, true)
+}
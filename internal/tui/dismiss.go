@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	tuiactions "github.com/glabrego/reeder-cli/internal/tui/actions"
+)
+
+// dismissedFilter lists only the entries dismissed with x.
+const dismissedFilter = "dismissed"
+
+func (m *Model) SetDismisser(dismisser Dismisser) {
+	m.dismisser = dismisser
+}
+
+// dismissCurrent hides the current article from the views without marking
+// it read; in the dismissed view it restores the article instead.
+func (m Model) dismissCurrent() (tea.Model, tea.Cmd) {
+	if len(m.entries) == 0 || (!m.inDetail && !m.currentTreeRowIsArticle()) {
+		return m, nil
+	}
+	if m.dismisser == nil {
+		m.status = "Dismiss unavailable"
+		return m, nil
+	}
+	m.loading = true
+	m.status = ""
+	m.err = nil
+	return m, tuiactions.DismissCmd(m.dismisser, m.entries[m.cursor].ID, m.filter != dismissedFilter)
+}
+
+// toggleDismissedView switches between the dismissed entries and the all
+// view.
+func (m Model) toggleDismissedView() (tea.Model, tea.Cmd) {
+	if m.filter == dismissedFilter {
+		return m.switchFilter("all")
+	}
+	return m.switchFilter(dismissedFilter)
+}
+
+// finishDismiss drops a dismissed or restored entry from the current view;
+// either way it now belongs to the other one.
+func (m Model) finishDismiss(msg tuiactions.DismissResultMsg) (tea.Model, tea.Cmd) {
+	m.loading = false
+	if msg.Err != nil {
+		m.status = ""
+		m.err = msg.Err
+		return m, nil
+	}
+	anchorID := m.anchorEntryID()
+	kept := m.entries[:0]
+	for _, entry := range m.entries {
+		if entry.ID != msg.EntryID {
+			kept = append(kept, entry)
+		}
+	}
+	m.entries = kept
+	m.err = nil
+	m.status = msg.Status
+	m.restoreSelection(anchorID)
+	m.statusID++
+	return m, clearStatusCmd(m.statusID, 3*time.Second)
+}
@@ -0,0 +1,255 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	tuitree "github.com/glabrego/reeder-cli/internal/tui/tree"
+)
+
+// jumpToUnreadFeed moves to the first unread article of the next (direction
+// > 0) or previous feed that has unread entries, skipping fully read feeds
+// and expanding whatever hides the target. In the detail view the article is
+// opened. Passing the last unread feed wraps around with a status note.
+func (m Model) jumpToUnreadFeed(direction int) (tea.Model, tea.Cmd) {
+	rows := m.treeRows()
+	if len(rows) == 0 {
+		return m, nil
+	}
+	m.ensureTreeCursorValid()
+	full, feeds, at := m.unreadFeedRows()
+	if len(feeds) == 0 {
+		m.status = "No feeds with unread articles"
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 3*time.Second)
+	}
+
+	target, wrapped := -1, false
+	if direction > 0 {
+		for _, i := range feeds {
+			if i > at {
+				target = i
+				break
+			}
+		}
+		if target < 0 {
+			target, wrapped = feeds[0], true
+		}
+	} else {
+		for k := len(feeds) - 1; k >= 0; k-- {
+			if feeds[k] < at {
+				target = feeds[k]
+				break
+			}
+		}
+		if target < 0 {
+			target, wrapped = feeds[len(feeds)-1], true
+		}
+	}
+
+	var saveCmd tea.Cmd
+	if m.inDetail {
+		saveCmd = m.recordReadProgress()
+	}
+	feed := full[target]
+	m.revealFeed(feed)
+	for i, row := range m.treeRows() {
+		if row.Kind != treeRowArticle || row.Folder != feed.Folder || row.Feed != feed.Feed || !m.entries[row.EntryIndex].IsUnread {
+			continue
+		}
+		m.treeCursor = i
+		m.cursor = row.EntryIndex
+		break
+	}
+	m.ensureCursorVisible()
+
+	var cmd tea.Cmd
+	if m.inDetail {
+		m.selectedID = m.entries[m.cursor].ID
+		m.resumeReadProgress()
+		cmd = m.ensureInlineImagePreviewCmd()
+	}
+	if wrapped {
+		m.status = "Visited all unread feeds, back to " + feed.Feed
+	} else {
+		m.status = "Feed: " + feed.Feed
+	}
+	m.statusID++
+	statusCmd := clearStatusCmd(m.statusID, 3*time.Second)
+	var markCmd tea.Cmd
+	if m.inDetail {
+		// Runs last so a confirm prompt is not hidden by the feed name.
+		markCmd = m.markReadOnDetailCmd()
+	}
+	return m, tea.Batch(saveCmd, cmd, statusCmd, markCmd)
+}
+
+// unreadFeedRows returns the fully expanded tree, so collapsed feeds are
+// candidates too, the indices of its feed rows with unread articles, and the
+// index of the feed holding the cursor (or the open article).
+func (m Model) unreadFeedRows() ([]treeRow, []int, int) {
+	_, unreadByFeed := m.unreadCountsByTreeNode()
+	full := tuitree.BuildRows(m.entries, tuitree.BuildOptions{DefaultFolder: m.defaultFolder, PinnedFeeds: m.pinnedFeeds})
+	var current treeRow
+	if m.inDetail {
+		// "[" and "]" move the open article without moving the tree cursor.
+		entry := m.entries[m.cursor]
+		current = treeRow{Kind: treeRowArticle, Folder: folderNameForEntry(entry, m.defaultFolder), Feed: feedNameForEntry(entry)}
+	} else {
+		current = m.treeRows()[m.treeCursor]
+	}
+	at := fullTreeIndex(full, current)
+	var feeds []int
+	for i, row := range full {
+		if row.Kind == treeRowFeed && unreadByFeed[tuitree.FeedCountKey(row.Folder, row.Feed, row.Pinned)] > 0 {
+			feeds = append(feeds, i)
+		}
+	}
+	return full, feeds, at
+}
+
+// unreadFeedFollows reports whether a feed with unread articles comes after
+// the open article's feed, without wrapping around.
+func (m Model) unreadFeedFollows() bool {
+	_, feeds, at := m.unreadFeedRows()
+	return len(feeds) > 0 && feeds[len(feeds)-1] > at
+}
+
+// SetAutoNextFeed makes "]" on the last article of a feed continue straight
+// into the next feed with unread articles instead of asking first.
+func (m *Model) SetAutoNextFeed(enabled bool) {
+	m.autoNextFeed = enabled
+}
+
+// atFeedEnd reports whether the open article is the last one of its feed.
+func (m Model) atFeedEnd() bool {
+	if m.cursor >= len(m.entries)-1 {
+		return true
+	}
+	current, next := m.entries[m.cursor], m.entries[m.cursor+1]
+	return folderNameForEntry(current, m.defaultFolder) != folderNameForEntry(next, m.defaultFolder) ||
+		feedNameForEntry(current) != feedNameForEntry(next)
+}
+
+// showFeedEnd tells the reader the feed is done; pressing "]" again moves on
+// to the next feed with unread articles.
+func (m Model) showFeedEnd() (tea.Model, tea.Cmd) {
+	m.feedEndShown = true
+	m.status = "End of " + feedNameForEntry(m.entries[m.cursor]) + " — press ] for next feed"
+	m.statusID++
+	return m, clearStatusCmd(m.statusID, 3*time.Second)
+}
+
+// fullTreeIndex finds the position of row in the fully expanded tree. Article
+// rows resolve to their feed row so the jump starts from the current feed.
+func fullTreeIndex(full []treeRow, row treeRow) int {
+	for i, candidate := range full {
+		switch row.Kind {
+		case treeRowArticle, treeRowFeed:
+			if candidate.Kind == treeRowFeed && candidate.Folder == row.Folder && candidate.Feed == row.Feed {
+				return i
+			}
+		case treeRowFolder:
+			if candidate.Kind == treeRowFolder && candidate.Folder == row.Folder {
+				return i
+			}
+		default:
+			if candidate.Kind == row.Kind && candidate.Label == row.Label {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// revealFeed expands the section, folder and feed that contain a feed row.
+func (m *Model) revealFeed(row treeRow) {
+	folder, feed := row.Folder, row.Feed
+	section := "Feeds"
+	if row.Pinned {
+		section = tuitree.PinnedSection
+	} else if folder != "" {
+		section = "Folders"
+		if m.collapsedFolders[folder] {
+			m.setCollapsed(m.collapsedFolders, folder, false)
+		}
+	}
+	if m.collapsedSections[section] {
+		m.setCollapsed(m.collapsedSections, section, false)
+	}
+	if key := treeFeedKey(folder, feed); m.collapsedFeeds[key] {
+		m.setCollapsed(m.collapsedFeeds, key, false)
+	}
+}
+
+// jumpToFeedGroup moves the list cursor to the next (direction > 0) or
+// previous feed row, whatever its section or unread count, wrapping around at
+// either end with a status note. Trees without feed headers (compact modes)
+// jump between the first articles of each feed instead.
+func (m Model) jumpToFeedGroup(direction int) (tea.Model, tea.Cmd) {
+	rows := m.treeRows()
+	if len(rows) == 0 {
+		return m, nil
+	}
+	m.ensureTreeCursorValid()
+	var groups []int
+	for i, row := range rows {
+		if row.Kind == treeRowFeed {
+			groups = append(groups, i)
+		}
+	}
+	if len(groups) == 0 {
+		prev := -1
+		for i, row := range rows {
+			if row.Kind != treeRowArticle {
+				continue
+			}
+			if prev < 0 || rows[prev].Folder != row.Folder || rows[prev].Feed != row.Feed {
+				groups = append(groups, i)
+			}
+			prev = i
+		}
+	}
+	if len(groups) == 0 {
+		return m, nil
+	}
+
+	target, wrapped := -1, false
+	if direction > 0 {
+		for _, i := range groups {
+			if i > m.treeCursor {
+				target = i
+				break
+			}
+		}
+		if target < 0 {
+			target, wrapped = groups[0], true
+		}
+	} else {
+		for k := len(groups) - 1; k >= 0; k-- {
+			if groups[k] < m.treeCursor {
+				target = groups[k]
+				break
+			}
+		}
+		if target < 0 {
+			target, wrapped = groups[len(groups)-1], true
+		}
+	}
+
+	m.treeCursor = target
+	m.syncCursorFromTree()
+	m.ensureCursorVisible()
+	feed := rows[target].Feed
+	switch {
+	case wrapped && direction > 0:
+		m.status = "Wrapped to the first feed: " + feed
+	case wrapped:
+		m.status = "Wrapped to the last feed: " + feed
+	default:
+		m.status = "Feed: " + feed
+	}
+	m.statusID++
+	return m, clearStatusCmd(m.statusID, 3*time.Second)
+}
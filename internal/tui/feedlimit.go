@@ -0,0 +1,56 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	tuitree "github.com/glabrego/reeder-cli/internal/tui/tree"
+)
+
+const treeRowMore treeRowKind = tuitree.RowMore
+
+// SetFeedLimit shows at most limit feeds per folder and in the Feeds section,
+// picked by order (tuitree.FeedOrderUnread or alphabetical); zero shows every
+// feed.
+func (m *Model) SetFeedLimit(limit int, order string) {
+	m.feedLimit = max(limit, 0)
+	m.feedOrder = order
+	m.treeVersion++
+}
+
+// expandFeedList shows every feed of the folder, or the Feeds section, that
+// the row under the cursor belongs to.
+func (m Model) expandFeedList() (tea.Model, tea.Cmd) {
+	rows := m.treeRows()
+	if len(rows) == 0 {
+		return m, nil
+	}
+	m.ensureTreeCursorValid()
+	row := rows[m.treeCursor]
+	key := row.Folder
+	if row.Kind == treeRowSection {
+		if row.Label != "Feeds" {
+			return m, nil
+		}
+		key = ""
+	}
+	if !m.hasMoreRow(key) {
+		return m, nil
+	}
+	m.setCollapsed(m.expandedFeedLists, key, true)
+	if key == "" {
+		m.status = "Showing every feed"
+	} else {
+		m.status = "Showing every feed in " + key
+	}
+	m.ensureCursorVisible()
+	return m, nil
+}
+
+func (m Model) hasMoreRow(folder string) bool {
+	for _, row := range m.treeRows() {
+		if row.Kind == treeRowMore && row.Folder == folder {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package tui
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+	tuiactions "github.com/glabrego/reeder-cli/internal/tui/actions"
+	tuiview "github.com/glabrego/reeder-cli/internal/tui/view"
+)
+
+func (m Model) openFeedManager() (tea.Model, tea.Cmd) {
+	if m.feedManager == nil {
+		m.status = "Feed manager unavailable"
+		return m, nil
+	}
+	m.feedsOpen = true
+	m.feedsRenaming = false
+	m.feedsRenameInput = ""
+	m.err = nil
+	return m, tuiactions.LoadFeedsCmd(m.feedManager)
+}
+
+func (m Model) handleFeedsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.feedsRenaming {
+		return m.handleFeedRenameKeys(msg)
+	}
+
+	switch msg.String() {
+	case "esc", "F":
+		m.feedsOpen = false
+		return m, tea.ClearScreen
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "up", "k":
+		if m.feedsCursor > 0 {
+			m.feedsCursor--
+		}
+		return m, nil
+	case "down", "j":
+		if m.feedsCursor < len(m.feeds)-1 {
+			m.feedsCursor++
+		}
+		return m, nil
+	case "g":
+		m.feedsCursor = 0
+		return m, nil
+	case "G":
+		if len(m.feeds) > 0 {
+			m.feedsCursor = len(m.feeds) - 1
+		}
+		return m, nil
+	}
+
+	feed, ok := m.currentFeedSummary()
+	if !ok {
+		return m, nil
+	}
+	switch msg.String() {
+	case "e", "x":
+		// Feeds resolved for orphaned entries have no subscription to act on.
+		if feed.SubscriptionID == 0 {
+			m.status = "Not subscribed to " + feed.Title + " — rename and unsubscribe are unavailable"
+			return m, nil
+		}
+	}
+	switch msg.String() {
+	case "e":
+		m.feedsRenaming = true
+		m.feedsRenameInput = feed.Title
+		return m, nil
+	case "m":
+		m.loading = true
+		m.status = ""
+		return m, tuiactions.SetFeedMutedCmd(m.feedManager, feed, !feed.Muted)
+	case "x":
+		return m.requestBulkAction(bulkAction{
+			summary: "Unsubscribe from " + feed.Title,
+			run: func(m Model) (tea.Model, tea.Cmd) {
+				m.loading = true
+				m.status = ""
+				return m, tuiactions.UnsubscribeCmd(m.feedManager, feed)
+			},
+		})
+	case "r":
+		if m.offline {
+			return m.offlineNotice("Offline — feeds refresh when back online")
+		}
+		m.loading = true
+		m.status = ""
+		return m, tuiactions.RefreshFeedCmd(m.feedManager, feed)
+	default:
+		return m, nil
+	}
+}
+
+func (m Model) handleFeedRenameKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		feed, ok := m.currentFeedSummary()
+		m.feedsRenaming = false
+		if !ok {
+			return m, nil
+		}
+		m.loading = true
+		m.status = ""
+		return m, tuiactions.RenameFeedCmd(m.feedManager, feed, m.feedsRenameInput)
+	case "esc":
+		m.feedsRenaming = false
+		m.feedsRenameInput = ""
+		m.status = "Rename canceled"
+		return m, nil
+	case "ctrl+c":
+		return m, tea.Quit
+	case "backspace", "ctrl+h":
+		if len(m.feedsRenameInput) > 0 {
+			_, size := utf8.DecodeLastRuneInString(m.feedsRenameInput)
+			m.feedsRenameInput = m.feedsRenameInput[:len(m.feedsRenameInput)-size]
+		}
+		return m, nil
+	default:
+		if msg.Type == tea.KeyRunes && len(msg.Runes) > 0 {
+			m.feedsRenameInput += string(msg.Runes)
+		}
+		return m, nil
+	}
+}
+
+func (m Model) currentFeedSummary() (feedbin.FeedSummary, bool) {
+	if m.feedsCursor < 0 || m.feedsCursor >= len(m.feeds) {
+		return feedbin.FeedSummary{}, false
+	}
+	return m.feeds[m.feedsCursor], true
+}
+
+func (m Model) feedManagerView() string {
+	now := m.nowFn()
+	rows := make([]tuiview.FeedManagerRow, 0, len(m.feeds))
+	for _, feed := range m.feeds {
+		updated := ""
+		if !feed.LastUpdated.IsZero() {
+			updated = "updated " + relativeTimeLabel(now, feed.LastUpdated)
+		}
+		rows = append(rows, tuiview.FeedManagerRow{
+			Title:   feed.Title,
+			Folder:  feed.Folder,
+			Unread:  feed.UnreadCount,
+			Updated: updated,
+			Muted:   feed.Muted,
+		})
+	}
+
+	prompt := ""
+	if feed, ok := m.currentFeedSummary(); ok {
+		switch {
+		case m.feedsRenaming:
+			prompt = fmt.Sprintf("Rename %s> %s", feed.Title, m.feedsRenameInput)
+		case m.pendingBulk != nil:
+			prompt = m.pendingBulk.summary + "? Press Shift+M to confirm"
+		}
+	}
+
+	return tuiview.RenderFeedManager(tuiview.FeedManagerInput{
+		Rows:   rows,
+		Cursor: m.feedsCursor,
+		Height: m.listBodyHeight() - 2,
+		Width:  m.contentWidth(),
+		Prompt: prompt,
+	}, uiTheme)
+}
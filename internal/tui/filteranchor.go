@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// FilterAnchorStore persists the entry last selected in each list filter.
+type FilterAnchorStore interface {
+	SaveFilterAnchors(ctx context.Context, anchors map[string]int64) error
+}
+
+type filterAnchorSaveErrorMsg struct {
+	err error
+}
+
+// SetFilterAnchors wires the store used to persist the per-filter selection
+// and seeds the anchors loaded at startup.
+func (m *Model) SetFilterAnchors(store FilterAnchorStore, anchors map[string]int64) {
+	m.filterAnchorStore = store
+	m.filterAnchors = make(map[string]int64, len(anchors))
+	for filter, id := range anchors {
+		if rememberedFilter(filter) {
+			m.filterAnchors[filter] = id
+		}
+	}
+}
+
+// rememberedFilter reports whether filter keeps its own selection. Feed
+// scopes are transient and always start from the scope's own anchor.
+func rememberedFilter(filter string) bool {
+	return filter == "all" || filter == "unread" || filter == "starred"
+}
+
+// rememberFilterAnchor records the selected entry for the current filter
+// before switching away from it.
+func (m *Model) rememberFilterAnchor() tea.Cmd {
+	if !rememberedFilter(m.filter) || m.searchQuery != "" {
+		return nil
+	}
+	anchorID := m.anchorEntryID()
+	if anchorID == 0 {
+		return nil
+	}
+	if m.filterAnchors == nil {
+		m.filterAnchors = make(map[string]int64)
+	}
+	if m.filterAnchors[m.filter] == anchorID {
+		return nil
+	}
+	m.filterAnchors[m.filter] = anchorID
+	return saveFilterAnchorsCmd(m.filterAnchorStore, maps.Clone(m.filterAnchors))
+}
+
+// filterAnchor is the entry to select after loading filter: the one
+// remembered for it when switching in from another filter, else fallback.
+func (m Model) filterAnchor(filter string, fallback int64) int64 {
+	if filter == m.filter || !rememberedFilter(filter) {
+		return fallback
+	}
+	if id, ok := m.filterAnchors[filter]; ok && id != 0 {
+		return id
+	}
+	return fallback
+}
+
+func saveFilterAnchorsCmd(store FilterAnchorStore, anchors map[string]int64) tea.Cmd {
+	if store == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := store.SaveFilterAnchors(ctx, anchors); err != nil {
+			return filterAnchorSaveErrorMsg{err: err}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,179 @@
+package tui
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+	tuiactions "github.com/glabrego/reeder-cli/internal/tui/actions"
+)
+
+// FolderAssigner files a feed under a Feedbin folder (m on a feed row).
+type FolderAssigner interface {
+	AssignFolder(ctx context.Context, feedID int64, folder string) (feedbin.FolderAssignment, error)
+}
+
+// folderPrompt is the folder name being typed for a feed after m.
+type folderPrompt struct {
+	feedID    int64
+	feedTitle string
+	input     string
+	// prefix is what was typed before tab started cycling completions.
+	prefix string
+}
+
+func (m *Model) SetFolderAssigner(assigner FolderAssigner) {
+	m.folderAssigner = assigner
+}
+
+// startFolderAssign prompts for the folder to file the feed row at the
+// cursor under.
+func (m Model) startFolderAssign() (tea.Model, tea.Cmd) {
+	if m.folderAssigner == nil {
+		return m, nil
+	}
+	rows := m.treeRows()
+	m.ensureTreeCursorValid()
+	m.err = nil
+	feedID := int64(0)
+	if m.treeCursor >= 0 && m.treeCursor < len(rows) && rows[m.treeCursor].Kind == treeRowFeed {
+		feedID = m.feedIDForRow(rows[m.treeCursor])
+	}
+	if feedID == 0 {
+		m.status = "Move to a feed row to file it under a folder"
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 3*time.Second)
+	}
+	m.folderPrompt = &folderPrompt{feedID: feedID, feedTitle: rows[m.treeCursor].Feed}
+	m.statusID++
+	m.status = m.folderPromptStatus()
+	return m, nil
+}
+
+// handleFolderPromptKeys edits the folder name; tab cycles the known folders
+// starting with what was typed.
+func (m Model) handleFolderPromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	prompt := *m.folderPrompt
+	switch msg.String() {
+	case "enter":
+		m.folderPrompt = nil
+		name := strings.TrimSpace(prompt.input)
+		if name == "" {
+			m.status = "Canceled folder change"
+			m.statusID++
+			return m, clearStatusCmd(m.statusID, 3*time.Second)
+		}
+		m.loading = true
+		m.status = ""
+		return m, tuiactions.AssignFolderCmd(m.folderAssigner, prompt.feedID, prompt.feedTitle, name)
+	case "esc":
+		m.folderPrompt = nil
+		m.status = "Canceled folder change"
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 3*time.Second)
+	case "ctrl+c":
+		return m, tea.Quit
+	case "tab":
+		matches := make([]string, 0, 8)
+		for _, folder := range m.knownFolders() {
+			if strings.HasPrefix(strings.ToLower(folder), strings.ToLower(prompt.prefix)) {
+				matches = append(matches, folder)
+			}
+		}
+		if len(matches) > 0 {
+			next := slices.Index(matches, prompt.input) + 1
+			prompt.input = matches[next%len(matches)]
+		}
+	case "backspace", "ctrl+h":
+		if len(prompt.input) > 0 {
+			_, size := utf8.DecodeLastRuneInString(prompt.input)
+			prompt.input = prompt.input[:len(prompt.input)-size]
+		}
+		prompt.prefix = prompt.input
+	default:
+		if msg.Type == tea.KeyRunes && len(msg.Runes) > 0 {
+			prompt.input += string(msg.Runes)
+			prompt.prefix = prompt.input
+		}
+	}
+	m.folderPrompt = &prompt
+	m.status = m.folderPromptStatus()
+	return m, nil
+}
+
+func (m Model) folderPromptStatus() string {
+	prompt := m.folderPrompt
+	hint := "tab completes, enter files, esc cancels"
+	if name := strings.TrimSpace(prompt.input); name != "" && !slices.ContainsFunc(m.knownFolders(), func(folder string) bool {
+		return strings.EqualFold(folder, name)
+	}) {
+		hint = "new folder; " + hint
+	}
+	return "Folder for " + prompt.feedTitle + ": " + prompt.input + "_ (" + hint + ")"
+}
+
+// knownFolders lists the folders of the loaded entries, sorted
+// case-insensitively.
+func (m Model) knownFolders() []string {
+	seen := make(map[string]bool)
+	folders := make([]string, 0, 16)
+	for _, entry := range m.entries {
+		for _, folder := range append([]string{entry.FeedFolder}, entry.FeedFolders...) {
+			if folder == "" || seen[folder] {
+				continue
+			}
+			seen[folder] = true
+			folders = append(folders, folder)
+		}
+	}
+	slices.SortFunc(folders, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	return folders
+}
+
+// finishFolderAssign moves the feed's loaded entries to their new folders
+// without waiting for a reload, and keeps the cursor on the feed.
+func (m Model) finishFolderAssign(msg tuiactions.FolderAssignedMsg) (tea.Model, tea.Cmd) {
+	m.loading = false
+	if msg.Err != nil {
+		m.status = ""
+		m.err = msg.Err
+		return m, nil
+	}
+	folders := msg.Assignment.Folders
+	target := treeRow{Kind: treeRowFeed, Feed: msg.FeedTitle, Pinned: m.pinnedFeeds[msg.FeedID]}
+	for i := range m.entries {
+		if m.entries[i].FeedID != msg.FeedID {
+			continue
+		}
+		m.entries[i].FeedFolders = slices.Clone(folders)
+		m.entries[i].FeedFolder = ""
+		if len(folders) > 0 {
+			m.entries[i].FeedFolder = folders[0]
+		}
+		if treeFolders := m.entryFolders(m.entries[i]); slices.Contains(treeFolders, msg.Assignment.Folder) {
+			target.Folder = msg.Assignment.Folder
+		} else if len(treeFolders) > 0 {
+			target.Folder = treeFolders[0]
+		}
+	}
+	m.sortEntries()
+	m.revealFeed(target)
+	m.setTreeCursorToFeed(target.Folder, target.Feed)
+	m.ensureCursorVisible()
+
+	m.err = nil
+	if msg.Assignment.Created {
+		m.status = "Filed " + msg.FeedTitle + " under new folder " + msg.Assignment.Folder
+	} else {
+		m.status = "Filed " + msg.FeedTitle + " under " + msg.Assignment.Folder
+	}
+	m.statusID++
+	return m, clearStatusCmd(m.statusID, 3*time.Second)
+}
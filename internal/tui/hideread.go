@@ -0,0 +1,49 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	tuiactions "github.com/glabrego/reeder-cli/internal/tui/actions"
+	tuitheme "github.com/glabrego/reeder-cli/internal/tui/theme"
+)
+
+// SetTitleStyles restyles read and unread titles ("dim", "normal" or
+// "hidden"; "bold", "color", "both" or "plain"). Hidden read entries start
+// with read entries left out of the all view. The theme is shared by every
+// model, so this is meant to be called once at startup.
+func (m *Model) SetTitleStyles(read, unread string) {
+	uiTheme = tuitheme.Default().WithTitleStyles(tuitheme.ReadStyle(read), tuitheme.UnreadStyle(unread))
+	if tuitheme.ReadStyle(read) == tuitheme.ReadHidden && !m.hideRead {
+		m.hideRead = true
+		m.applyCurrentFilter()
+	}
+}
+
+// hidingRead reports whether read, unstarred entries are left out of the
+// list: only in the all view and outside of a search, which should still
+// find read entries.
+func (m Model) hidingRead() bool {
+	return m.hideRead && m.filter == "all" && m.searchQuery == ""
+}
+
+// toggleHideRead switches the all view between every entry and unread plus
+// starred ones. Hidden entries are dropped from the list, so showing them
+// again reloads the filter.
+func (m Model) toggleHideRead() (tea.Model, tea.Cmd) {
+	m.hideRead = !m.hideRead
+	m.err = nil
+	persist := persistPreferencesCmd(m.savePreferencesFn, m.preferences())
+	if m.hideRead {
+		m.status = "Read entries in all: hidden"
+		anchorID := m.anchorEntryID()
+		m.applyCurrentFilter()
+		m.restoreSelection(anchorID)
+		return m, persist
+	}
+	m.status = "Read entries in all: shown"
+	if m.service == nil || m.filter != "all" || m.searchQuery != "" {
+		return m, persist
+	}
+	m.loading = true
+	return m, tea.Batch(tuiactions.LoadFilterCmd(m.service, m.filter, m.currentLimit()), persist)
+}
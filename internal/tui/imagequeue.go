@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	defaultImageRenderConcurrency = 2
+	maxImageRenderConcurrency     = 8
+)
+
+type inlineImagePreviewCanceledMsg struct {
+	entryID int64
+}
+
+// imageRenderQueue bounds how many inline image previews render at once so
+// quick navigation through image-heavy feeds does not start a chafa process
+// per article. Renders still waiting for a slot are dropped once the reader
+// moves to another article. It is shared by pointer across Model copies.
+type imageRenderQueue struct {
+	slots   chan struct{}
+	mu      sync.Mutex
+	waiting map[int64]context.CancelFunc
+}
+
+func newImageRenderQueue(limit int) *imageRenderQueue {
+	return &imageRenderQueue{
+		slots:   make(chan struct{}, max(1, limit)),
+		waiting: make(map[int64]context.CancelFunc),
+	}
+}
+
+// imageRenderConcurrencyFromEnv reads FEEDBIN_INLINE_IMAGE_CONCURRENCY,
+// falling back to the default for unset or invalid values.
+func imageRenderConcurrencyFromEnv() int {
+	n, err := strconv.Atoi(strings.TrimSpace(os.Getenv("FEEDBIN_INLINE_IMAGE_CONCURRENCY")))
+	if err != nil || n < 1 {
+		return defaultImageRenderConcurrency
+	}
+	return min(n, maxImageRenderConcurrency)
+}
+
+// enqueue registers a render for entryID and returns the context that is
+// canceled if the render is dropped before it gets a slot.
+func (q *imageRenderQueue) enqueue(entryID int64) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	q.mu.Lock()
+	q.waiting[entryID] = cancel
+	q.mu.Unlock()
+	return ctx
+}
+
+// dropExcept cancels waiting renders for every entry but keep.
+func (q *imageRenderQueue) dropExcept(keep int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for id, cancel := range q.waiting {
+		if id != keep {
+			cancel()
+			delete(q.waiting, id)
+		}
+	}
+}
+
+// acquire waits for a render slot. Once it has one the render can no longer
+// be dropped.
+func (q *imageRenderQueue) acquire(ctx context.Context, entryID int64) bool {
+	select {
+	case q.slots <- struct{}{}:
+	case <-ctx.Done():
+		return false
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if ctx.Err() != nil {
+		<-q.slots
+		return false
+	}
+	if cancel, ok := q.waiting[entryID]; ok {
+		cancel()
+		delete(q.waiting, entryID)
+	}
+	return true
+}
+
+func (q *imageRenderQueue) release() {
+	<-q.slots
+}
+
+func queuedInlineImagePreviewCmd(queue *imageRenderQueue, entryID int64, imageURL string, width int, renderFn func(string, int) (string, error)) tea.Cmd {
+	render := inlineImagePreviewCmd(entryID, imageURL, width, renderFn)
+	if render == nil || queue == nil {
+		return render
+	}
+	ctx := queue.enqueue(entryID)
+	return func() tea.Msg {
+		if !queue.acquire(ctx, entryID) {
+			return inlineImagePreviewCanceledMsg{entryID: entryID}
+		}
+		defer queue.release()
+		return render()
+	}
+}
@@ -0,0 +1,37 @@
+package tui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// infiniteScrollEdgeRows is how close to the end of the tree the cursor gets
+// before the next page is loaded.
+const infiniteScrollEdgeRows = 3
+
+// SetInfiniteScroll loads the next page, as n does, when the list cursor
+// comes near the last entry.
+func (m *Model) SetInfiniteScroll(enabled bool) {
+	m.infiniteScroll = enabled
+	m.infiniteScrollArmed = true
+}
+
+// autoLoadMore loads the next page once each time the cursor crosses into
+// the last rows of the list; it re-arms when the cursor leaves them.
+func (m Model) autoLoadMore() (Model, tea.Cmd) {
+	if !m.infiniteScroll || m.inDetail {
+		return m, nil
+	}
+	rows := m.treeRows()
+	if m.treeCursor < len(rows)-infiniteScrollEdgeRows {
+		m.infiniteScrollArmed = true
+		return m, nil
+	}
+	if !m.infiniteScrollArmed || m.loading || m.offline || m.pagesExhausted || m.service == nil {
+		return m, nil
+	}
+	if m.windowed() && m.windowFilled && m.dateOrdered() {
+		// slideEntryWindow already reads the next window.
+		return m, nil
+	}
+	m.infiniteScrollArmed = false
+	next, cmd := m.loadMore()
+	return next.(Model), cmd
+}
@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	tuiactions "github.com/glabrego/reeder-cli/internal/tui/actions"
+)
+
+// initRetryBaseDelay is the wait before the first startup refresh retry;
+// each further retry waits twice as long.
+const initRetryBaseDelay = 2 * time.Second
+
+// initRefreshMsg starts the startup refresh, first from Init and then for
+// each retry.
+type initRefreshMsg struct{}
+
+// SetInitialRefreshRetries retries a failed startup refresh up to n times
+// before settling on the cached entries; zero gives up right away.
+func (m *Model) SetInitialRefreshRetries(n int) {
+	m.initRetryLimit = max(n, 0)
+}
+
+// SetLastSynced seeds when state last synced with Feedbin, shown once the
+// startup refresh gives up.
+func (m *Model) SetLastSynced(at time.Time) {
+	m.lastSyncedAt = at
+}
+
+func initRetryDelay(attempt int) time.Duration {
+	return initRetryBaseDelay << (attempt - 1)
+}
+
+// retryInitialRefresh schedules another startup refresh when attempts are
+// left, reporting whether it did.
+func (m *Model) retryInitialRefresh(err error) (tea.Cmd, bool) {
+	if m.initRetryAttempt >= m.initRetryLimit || m.service == nil {
+		return nil, false
+	}
+	m.initRetryAttempt++
+	delay := initRetryDelay(m.initRetryAttempt)
+	m.err = nil
+	m.status = fmt.Sprintf("Initial refresh failed (%v), retrying in %s (%d/%d)", err, delay, m.initRetryAttempt, m.initRetryLimit)
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return initRefreshMsg{}
+	}), true
+}
+
+// startInitialRefresh runs the startup refresh, or a retry of it, unless a
+// refresh the user started is already in flight.
+func (m Model) startInitialRefresh() (tea.Model, tea.Cmd) {
+	if m.service == nil || m.initialRefreshDone || m.refreshing && m.initRetryAttempt == 0 {
+		return m, nil
+	}
+	m.refreshing = true
+	m.loading = true
+	return m, tuiactions.RefreshCmd(m.service, m.perPage, "init")
+}
+
+// lastSyncedLabel names when state last synced, in the display zone.
+func (m Model) lastSyncedLabel() string {
+	if m.lastSyncedAt.IsZero() {
+		return "never synced"
+	}
+	at := m.lastSyncedAt
+	if m.location != nil {
+		at = at.In(m.location)
+	}
+	return "last synced " + at.Format("2006-01-02 15:04")
+}
+
+// freshnessLabel tells whether the list reflects a refresh made this session
+// ("LIVE") or only the cache, with the age of the last sync.
+func (m Model) freshnessLabel() string {
+	if m.refreshedThisSession {
+		return "LIVE"
+	}
+	if m.lastSyncedAt.IsZero() {
+		return "CACHED — never synced"
+	}
+	return "CACHED — last sync " + relativeTimeLabel(m.nowFn(), m.lastSyncedAt)
+}
+
+func (m Model) workingOfflineStatus() string {
+	return "Working offline, " + m.lastSyncedLabel()
+}
@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyScope is where a binding applies.
+type keyScope int
+
+const (
+	scopeList keyScope = 1 << iota
+	scopeDetail
+	scopeAll = scopeList | scopeDetail
+)
+
+// keyBinding names what a key does in the list and detail views.
+type keyBinding struct {
+	keys        []string
+	scope       keyScope
+	action      string
+	description string
+}
+
+// keyBindings is the table the describe-key mode (W) answers from. Keep it in
+// step with handleListKeys, handleDetailKeys and handleGlobalKeys.
+var keyBindings = []keyBinding{
+	{keys: []string{"?"}, scope: scopeAll, action: "help", description: "show or hide the in-app help"},
+	{keys: []string{"W"}, scope: scopeAll, action: "describe key", description: "show what the next key does without running it"},
+	{keys: []string{"M"}, scope: scopeAll, action: "confirm", description: "confirm a pending mark-as-read or bulk action"},
+	{keys: []string{"q", "ctrl+c"}, scope: scopeAll, action: "quit", description: "exit reeder"},
+	{keys: []string{"up", "k"}, scope: scopeList, action: "move up", description: "move the cursor to the previous row"},
+	{keys: []string{"down", "j"}, scope: scopeList, action: "move down", description: "move the cursor to the next row"},
+	{keys: []string{"up", "k"}, scope: scopeDetail, action: "scroll up", description: "scroll the article up one line"},
+	{keys: []string{"down", "j"}, scope: scopeDetail, action: "scroll down", description: "scroll the article down one line"},
+	{keys: []string{"g"}, scope: scopeList, action: "top", description: "jump to the first row"},
+	{keys: []string{"G"}, scope: scopeList, action: "bottom", description: "jump to the last row"},
+	{keys: []string{"pgup", "ctrl+b"}, scope: scopeList, action: "page up", description: "move up one page"},
+	{keys: []string{"pgdown", "ctrl+f"}, scope: scopeList, action: "page down", description: "move down one page"},
+	{keys: []string{"["}, scope: scopeList, action: "previous section", description: "jump to the previous section"},
+	{keys: []string{"]"}, scope: scopeList, action: "next section", description: "jump to the next section"},
+	{keys: []string{"["}, scope: scopeDetail, action: "previous article", description: "open the previous entry"},
+	{keys: []string{"]"}, scope: scopeDetail, action: "next article", description: "open the next entry; at the end of a feed, continue with the next unread feed when one follows"},
+	{keys: []string{"{"}, scope: scopeAll, action: "previous unread feed", description: "jump to the previous feed with unread articles"},
+	{keys: []string{"}"}, scope: scopeAll, action: "next unread feed", description: "jump to the next feed with unread articles"},
+	{keys: []string{"("}, scope: scopeList, action: "previous feed", description: "jump to the previous feed row in any section, wrapping at the top"},
+	{keys: []string{")"}, scope: scopeList, action: "next feed", description: "jump to the next feed row in any section, wrapping at the bottom"},
+	{keys: []string{"left", "h"}, scope: scopeList, action: "collapse", description: "collapse the current feed, then its folder"},
+	{keys: []string{"right", "l"}, scope: scopeList, action: "expand", description: "expand the current folder or feed"},
+	{keys: []string{"+"}, scope: scopeList, action: "show more feeds", description: "show every feed of the folder or section cut short by FEEDBIN_TREE_FEED_LIMIT"},
+	{keys: []string{"enter"}, scope: scopeList, action: "open", description: "open the article, or toggle a folder or feed"},
+	{keys: []string{"esc"}, scope: scopeList, action: "release search", description: "release the sticky search focus; with FEEDBIN_ESC_CLEARS_SEARCH, clear a committed search as ctrl+l does"},
+	{keys: []string{"esc", "backspace"}, scope: scopeDetail, action: "back", description: "return to the list"},
+	{keys: []string{"o"}, scope: scopeDetail, action: "open URL", description: "open the article in the browser"},
+	{keys: []string{"s"}, scope: scopeDetail, action: "body source", description: "cycle the feed content, the feed summary and Feedbin's extracted full text"},
+	{keys: []string{"y"}, scope: scopeAll, action: "copy URL", description: "copy the article URL (the feed URL on feed rows)"},
+	{keys: []string{"Y"}, scope: scopeList, action: "copy OPML", description: "copy an OPML outline for the current feed"},
+	{keys: []string{"C"}, scope: scopeList, action: "copy unread list", description: "copy the unread titles and URLs under a feed, folder or section row"},
+	{keys: []string{"f"}, scope: scopeDetail, action: "feed view", description: "list every cached entry of the article's feed"},
+	{keys: []string{"U"}, scope: scopeAll, action: "toggle unread", description: "mark read or unread (all loaded entries on group rows)"},
+	{keys: []string{"ctrl+u"}, scope: scopeList, action: "mark feed read locally", description: "on a feed row, mark its entries read in the cache only; Feedbin keeps them unread. On a fully read feed, clear that mark"},
+	{keys: []string{"i"}, scope: scopeList, action: "pin feed", description: "on a feed row, pin the feed to the Pinned section at the top of the tree, or unpin it"},
+	{keys: []string{"m"}, scope: scopeList, action: "file under folder", description: "on a feed row, tag the feed with a Feedbin folder, new or existing (tab completes)"},
+	{keys: []string{"S"}, scope: scopeAll, action: "toggle star", description: "star or unstar (all loaded entries on group rows)"},
+	{keys: []string{"ctrl+z"}, scope: scopeAll, action: "undo", description: "undo the last read/star toggle"},
+	{keys: []string{"z"}, scope: scopeAll, action: "snooze", description: "hide the article until a later time"},
+	{keys: []string{"x"}, scope: scopeAll, action: "dismiss", description: "hide the article without marking it read, or restore it in the dismissed view"},
+	{keys: []string{"r", "R", "ctrl+r"}, scope: scopeList, action: "refresh", description: "refresh entries from Feedbin"},
+	{keys: []string{"n"}, scope: scopeList, action: "next page", description: "load the next page of entries"},
+	{keys: []string{"/"}, scope: scopeList, action: "search", description: "search cached entries"},
+	{keys: []string{"b"}, scope: scopeList, action: "search backend", description: "switch cache search between LIKE and FTS (persisted)"},
+	{keys: []string{"ctrl+l"}, scope: scopeList, action: "clear search", description: "clear the search or leave the feed view"},
+	{keys: []string{"a"}, scope: scopeList, action: "filter all", description: "show all entries"},
+	{keys: []string{"u"}, scope: scopeList, action: "filter unread", description: "show unread entries"},
+	{keys: []string{"*"}, scope: scopeList, action: "filter starred", description: "show starred entries"},
+	{keys: []string{"e"}, scope: scopeList, action: "every age", description: "toggle showing entries older than FEEDBIN_MAX_AGE_DAYS in all and unread"},
+	{keys: []string{"X"}, scope: scopeList, action: "dismissed entries", description: "toggle listing the dismissed entries"},
+	{keys: []string{"F"}, scope: scopeList, action: "feed manager", description: "rename, mute, unsubscribe or refresh feeds"},
+	{keys: []string{"c"}, scope: scopeList, action: "compact mode", description: "toggle the flat, date-sorted list"},
+	{keys: []string{"O"}, scope: scopeList, action: "unread first", description: "toggle listing unread entries first in compact mode"},
+	{keys: []string{"H"}, scope: scopeList, action: "compact tree", description: "toggle hiding redundant tree headers"},
+	{keys: []string{"A"}, scope: scopeList, action: "hide read", description: "toggle leaving read, unstarred entries out of the all view"},
+	{keys: []string{"T"}, scope: scopeList, action: "feeds under every tag", description: "toggle listing feeds with several tags under each of their folders"},
+	{keys: []string{"N"}, scope: scopeList, action: "numbering", description: "toggle article numbers"},
+	{keys: []string{"d"}, scope: scopeList, action: "time format", description: "cycle dates: relative, absolute, both"},
+	{keys: []string{"D"}, scope: scopeList, action: "date column", description: "cycle the date column: full, short, hidden"},
+	{keys: []string{"I"}, scope: scopeList, action: "incremental search", description: "toggle filtering while typing a search"},
+	{keys: []string{"K"}, scope: scopeList, action: "compact counts", description: "toggle 1.2k-style counts"},
+	{keys: []string{"P"}, scope: scopeList, action: "page insert", description: "toggle full sort or merge when loading pages"},
+	{keys: []string{"t"}, scope: scopeList, action: "mark read on open", description: "toggle marking articles read when opening their URL"},
+	{keys: []string{"V"}, scope: scopeList, action: "mark read on detail open", description: "toggle marking an unread entry read when its detail view opens (persisted)"},
+	{keys: []string{"p"}, scope: scopeList, action: "confirm open", description: "toggle confirmation before mark-read on open"},
+	{keys: []string{"B"}, scope: scopeList, action: "confirm bulk", description: "toggle confirmation for bulk actions"},
+	{keys: []string{"v"}, scope: scopeList, action: "auto-preview", description: "toggle the preview under a resting cursor"},
+	{keys: []string{"L"}, scope: scopeList, action: "preview lines", description: "cycle the snippet under each entry: off, 1, 2, 3 lines"},
+	{keys: []string{"E"}, scope: scopeList, action: "preview source", description: "toggle taking snippets from the summary or the article content"},
+	{keys: []string{"#"}, scope: scopeList, action: "read-today counter", description: "show or hide how many entries were marked read today in the footer"},
+	{keys: []string{"~"}, scope: scopeList, action: "show muted", description: "show or hide entries matching the FEEDBIN_MUTE_KEYWORDS rules"},
+	{keys: []string{"Z"}, scope: scopeList, action: "strip feed prefix", description: "toggle hiding a leading \"Feed name:\" in titles, since the tree already shows the feed"},
+}
+
+// lookupKeyBinding finds what key does in scope.
+func lookupKeyBinding(key string, scope keyScope) (keyBinding, bool) {
+	for _, binding := range keyBindings {
+		if binding.scope&scope == 0 {
+			continue
+		}
+		for _, k := range binding.keys {
+			if k == key {
+				return binding, true
+			}
+		}
+	}
+	return keyBinding{}, false
+}
+
+// startDescribeKey waits for a key to describe instead of running it.
+func (m Model) startDescribeKey() (tea.Model, tea.Cmd) {
+	m.describeKeyPending = true
+	m.err = nil
+	m.status = "Press a key to see what it does"
+	return m, nil
+}
+
+// handleDescribeKey reports the binding of the key pressed after W and
+// returns to normal mode.
+func (m Model) handleDescribeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.describeKeyPending = false
+	scope, view := scopeList, "list"
+	if m.inDetail {
+		scope, view = scopeDetail, "detail view"
+	}
+	key := msg.String()
+	if binding, ok := lookupKeyBinding(key, scope); ok {
+		m.status = key + ": " + binding.action + " — " + binding.description
+		if len(binding.keys) > 1 {
+			m.status += " (also " + strings.Join(otherKeys(binding.keys, key), ", ") + ")"
+		}
+	} else {
+		m.status = key + " does nothing in the " + view
+	}
+	m.statusID++
+	return m, clearStatusCmd(m.statusID, 5*time.Second)
+}
+
+func otherKeys(keys []string, key string) []string {
+	out := make([]string, 0, len(keys)-1)
+	for _, k := range keys {
+		if k != key {
+			out = append(out, k)
+		}
+	}
+	return out
+}
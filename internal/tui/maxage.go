@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+	tuiactions "github.com/glabrego/reeder-cli/internal/tui/actions"
+)
+
+// AgeCutoffSwitch suspends the cached listings' age cutoff while every age
+// is shown.
+type AgeCutoffSwitch interface {
+	SetShowAllAges(show bool)
+}
+
+// SetMaxAge limits the all and unread views to entries published within
+// maxAge; sw is told when e shows every age. Zero turns the cutoff off.
+func (m *Model) SetMaxAge(maxAge time.Duration, sw AgeCutoffSwitch) {
+	m.maxAge = maxAge
+	m.ageSwitch = sw
+	m.applyCurrentFilter()
+}
+
+// ageCutoffActive reports whether old entries are left out of the list:
+// only in the all and unread views, and never while searching.
+func (m Model) ageCutoffActive() bool {
+	return m.maxAge > 0 && !m.showAllAges && (m.filter == "all" || m.filter == "unread") && m.searchQuery == ""
+}
+
+// tooOld reports whether entry falls before the age cutoff.
+func (m Model) tooOld(entry feedbin.Entry) bool {
+	return entry.PublishedAt.Before(m.nowFn().Add(-m.maxAge))
+}
+
+func (m Model) maxAgeLabel() string {
+	days := int(m.maxAge / (24 * time.Hour))
+	if days < 1 {
+		return fmt.Sprintf("last %s", m.maxAge)
+	}
+	return fmt.Sprintf("last %dd", days)
+}
+
+// toggleShowAllAges shows entries of every age until pressed again. Old
+// entries are not loaded yet, so showing them reloads the filter.
+func (m Model) toggleShowAllAges() (tea.Model, tea.Cmd) {
+	m.err = nil
+	if m.maxAge <= 0 {
+		m.status = "No age cutoff set (FEEDBIN_MAX_AGE_DAYS)"
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 3*time.Second)
+	}
+	m.showAllAges = !m.showAllAges
+	if m.ageSwitch != nil {
+		m.ageSwitch.SetShowAllAges(m.showAllAges)
+	}
+	if !m.showAllAges {
+		m.status = "Showing entries from the " + m.maxAgeLabel()
+		anchorID := m.anchorEntryID()
+		m.applyCurrentFilter()
+		m.restoreSelection(anchorID)
+		return m, nil
+	}
+	m.status = "Showing entries of every age"
+	if m.service == nil || (m.filter != "all" && m.filter != "unread") {
+		return m, nil
+	}
+	m.loading = true
+	return m, tuiactions.LoadFilterCmd(m.service, m.filter, m.currentLimit())
+}
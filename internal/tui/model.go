@@ -2,9 +2,9 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
-	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -30,6 +30,47 @@ type Service interface {
 	LoadMore(ctx context.Context, page, perPage int, filter string, limit int) ([]feedbin.Entry, int, error)
 	ToggleUnread(ctx context.Context, entryID int64, currentUnread bool) (bool, error)
 	ToggleStarred(ctx context.Context, entryID int64, currentStarred bool) (bool, error)
+	SetEntriesUnread(ctx context.Context, entryIDs []int64, unread bool) ([]int64, []int64, error)
+	SetEntriesStarred(ctx context.Context, entryIDs []int64, starred bool) ([]int64, []int64, error)
+	SyncWarning() string
+}
+
+// FeedManager backs the subscription manager overlay (F).
+type FeedManager interface {
+	ListFeeds(ctx context.Context) ([]feedbin.FeedSummary, error)
+	RenameFeed(ctx context.Context, feedID, subscriptionID int64, title string) error
+	SetFeedMuted(ctx context.Context, feedID int64, muted bool) error
+	Unsubscribe(ctx context.Context, feedID, subscriptionID int64) error
+	RefreshFeed(ctx context.Context, feedID int64) (int, error)
+}
+
+// Snoozer hides an entry until a wake time (z).
+type Snoozer interface {
+	SnoozeEntry(ctx context.Context, entryID int64, wakeAt time.Time) error
+}
+
+// Dismisser hides an entry locally without marking it read (x).
+type Dismisser interface {
+	SetEntryDismissed(ctx context.Context, entryID int64, dismissed bool) error
+}
+
+// LocalReader marks a feed read without syncing to Feedbin (ctrl+u), and
+// clears that mark again (ctrl+u on a feed with nothing unread).
+type LocalReader interface {
+	MarkFeedReadLocally(ctx context.Context, feedID int64, entryIDs []int64) error
+	ClearFeedReadLocally(ctx context.Context, feedID int64) (bool, error)
+}
+
+// DiagnosticsSource renders the diagnostic bundle copied from the help view
+// (c).
+type DiagnosticsSource interface {
+	Diagnostics(ctx context.Context, lastErr error) string
+}
+
+// SearchModeSwitcher switches the cache search backend at runtime (b).
+type SearchModeSwitcher interface {
+	SearchMode() string
+	SetSearchMode(ctx context.Context, mode string) (string, error)
 }
 
 type clearStatusMsg struct {
@@ -50,15 +91,45 @@ type inlineImagePreviewErrorMsg struct {
 	err     error
 }
 
+type autoPreviewMsg struct {
+	seq     int
+	entryID int64
+}
+
 type Preferences struct {
-	Compact         bool
-	MarkReadOnOpen  bool
-	ConfirmOpenRead bool
-	RelativeTime    bool
-	ShowNumbers     bool
+	Compact              bool
+	MarkReadOnOpen       bool
+	ConfirmOpenRead      bool
+	TimeFormat           string
+	ShowNumbers          bool
+	AutoPreview          bool
+	ConfirmBulkActions   bool
+	DateColumn           string
+	IncrementalSearch    bool
+	CompactCounts        bool
+	MergePages           bool
+	CompactTree          bool
+	TagsAsFolders        bool
+	HideRead             bool
+	CompactUnreadFirst   bool
+	PreviewLines         int
+	PreviewSource        string
+	PreferSummary        bool
+	ShowReadToday        bool
+	MarkReadOnDetailOpen bool
+	StripFeedPrefix      bool
+}
+
+// ViewState is the reading position and tree layout that can be carried to
+// another device through a state file.
+type ViewState struct {
+	LastEntryID       int64
+	Filter            string
+	CollapsedFolders  []string
+	CollapsedFeeds    []string
+	CollapsedSections []string
 }
 
-var reANSICodes = regexp.MustCompile(`\x1b\[[0-9;]*m`)
 var uiTheme = tuitheme.Default()
 
 type Model struct {
@@ -68,17 +139,21 @@ type Model struct {
 	selectedID             int64
 	filter                 string
 	searchQuery            string
+	feedScopeTitle         string
+	feedScopeAnchorID      int64
 	searchMatchCount       int
 	searchInput            string
 	searchInputMode        bool
 	page                   int
 	perPage                int
 	lastFetchCount         int
+	pagesExhausted         bool
 	compact                bool
 	showNumbers            bool
 	markReadOnOpen         bool
+	markReadOnDetailOpen   bool
 	confirmOpenRead        bool
-	relativeTime           bool
+	timeFormat             tuiview.TimeFormat
 	pendingOpenReadEntryID int64
 	lastOpenReadEntryID    int64
 	lastOpenReadAt         time.Time
@@ -94,30 +169,161 @@ type Model struct {
 	err                    error
 	openURLFn              func(string) error
 	copyURLFn              func(string) error
+	// copyOnOpenReason, when set, makes the open action copy the URL and
+	// explains why in the status.
+	copyOnOpenReason       string
+	offline                bool
+	readOnly               bool
 	nowFn                  func() time.Time
 	savePreferencesFn      func(Preferences) error
 	renderImageFn          func(string, int) (string, error)
 	imagePreview           map[int64]string
 	imagePreviewErr        map[int64]string
 	imagePreviewLoading    map[int64]bool
+	imageQueue             *imageRenderQueue
 	articleOptions         article.Options
+	articleIndent          int
+	stackedListWidth       int
 	inlineImagePreview     bool
+	imagePlacement         tuiview.ImagePlacement
+	imageChoice            article.ImageChoice
+	location               *time.Location
+	treeVersion            int
+	treeCache              *treeRowsCache
+	commentCounts          *commentCountCache
+	hideImageLabels        bool
 	cacheLoadDuration      time.Duration
 	cacheLoadedEntries     int
 	initialRefreshDuration time.Duration
 	initialRefreshDone     bool
 	initialRefreshFailed   bool
+	initRetryLimit         int
+	initRetryAttempt       int
+	lastSyncedAt           time.Time
+	refreshedThisSession   bool
 	collapsedFolders       map[string]bool
 	collapsedFeeds         map[string]bool
 	collapsedSections      map[string]bool
+	treeRules              *treeRules
 	nerdIcons              bool
 	nerdMode               bool
 	treeCursor             int
+	defaultFolder          string
+	batchRetry             *batchRetry
+	lastMutation           *entryMutation
+	autoPreview            bool
+	autoPreviewDelay       time.Duration
+	previewSeq             int
+	previewPendingID       int64
+	previewEntryID         int64
+	feedManager            FeedManager
+	snoozer                Snoozer
+	folderAssigner         FolderAssigner
+	folderPrompt           *folderPrompt
+	dismisser              Dismisser
+	searchSwitcher         SearchModeSwitcher
+	localReader            LocalReader
+	searchMode             string
+	progressStore          ReadProgressStore
+	readProgress           map[int64]float64
+	filterAnchorStore      FilterAnchorStore
+	pinStore               PinStore
+	windowLoader           EntryWindowLoader
+	diagnostics            DiagnosticsSource
+	windowSize             int
+	windowOffset           int
+	windowFilled           bool
+	pinnedFeeds            map[int64]bool
+	filterAnchors          map[string]int64
+	pendingSnoozeID        int64
+	feedsOpen              bool
+	feeds                  []feedbin.FeedSummary
+	feedsCursor            int
+	feedsRenaming          bool
+	feedsRenameInput       string
+	confirmBulkActions     bool
+	dateColumn             tuiview.DateColumn
+	incrementalSearch      bool
+	escClearsSearch        bool
+	preferCanonicalURL     bool
+	readAdvanceBackward    bool
+	detailBoundary         string
+	stickySearch           bool
+	searchExpand           bool
+	searchCollapse         *collapseState
+	searchFocused          bool
+	compactCounts          bool
+	mergePages             bool
+	compactTree            bool
+	tagsAsFolders          bool
+	hideRead               bool
+	compactUnreadFirst     bool
+	previewLines           int
+	previewSource          string
+	previewCache           *previewSnippetCache
+	preferSummary          bool
+	extractor              ContentExtractor
+	extractedContent       map[int64]string
+	extractedEntryID       int64
+	showReadToday          bool
+	stripFeedPrefix        bool
+	readToday              int
+	readTodayDay           string
+	muteRules              []MuteRule
+	showMuted              bool
+	mutedCount             int
+	autoOpenFirstUnread    bool
+	autoNextFeed           bool
+	infiniteScroll         bool
+	infiniteScrollArmed    bool
+	describeKeyPending     bool
+	refreshing             bool
+	refreshQueued          bool
+	queueRepeatRefresh     bool
+	keepScrollPosition     bool
+	maxAge                 time.Duration
+	showAllAges            bool
+	ageSwitch              AgeCutoffSwitch
+	listStartPinned        bool
+	pinnedListStart        int
+	feedLimit              int
+	feedOrder              string
+	expandedFeedLists      map[string]bool
+	autoRefreshInterval    time.Duration
+	quietHours             QuietHours
+	// bellFn announces new entries from an auto-refresh; nil disables it.
+	bellFn           func(newEntries int) error
+	newestSeenID     int64
+	lastBellAt       time.Time
+	feedEndShown     bool
+	pendingToggles   map[pendingToggleKey]bool
+	searchSeq        int
+	searchBase       []feedbin.Entry
+	searchBaseAnchor int64
+	pendingBulk      *bulkAction
+}
+
+// batchRetry remembers the entries a bulk update failed on so "r" can retry
+// them instead of refreshing. A retry that fails again is not re-armed, so
+// the next "r" refreshes.
+type batchRetry struct {
+	field    string
+	value    bool
+	entryIDs []int64
+	retrying bool
+}
+
+// entryMutation records the most recent read/star toggle so ctrl+z can
+// restore the entry's prior value.
+type entryMutation struct {
+	entryID int64
+	field   string
+	prior   bool
 }
 
 func NewModel(service Service, entries []feedbin.Entry) Model {
 	seed := append([]feedbin.Entry(nil), entries...)
-	sortEntriesForTree(seed)
+	sortEntriesForTree(seed, "")
 	initialPerPage := defaultPerPageFromEnv()
 	seed = limitEntries(seed, initialPerPage)
 	m := Model{
@@ -130,16 +336,28 @@ func NewModel(service Service, entries []feedbin.Entry) Model {
 		copyURLFn:           tuiplatform.CopyURLToClipboard,
 		nowFn:               time.Now,
 		autoReadDebounce:    5 * time.Second,
-		relativeTime:        true,
+		autoPreviewDelay:    600 * time.Millisecond,
+		timeFormat:          tuiview.TimeFormatRelative,
+		confirmBulkActions:  true,
+		dateColumn:          tuiview.DateColumnFull,
 		renderImageFn:       tuiview.RenderInlineImagePreview,
 		imagePreview:        make(map[int64]string),
 		imagePreviewErr:     make(map[int64]string),
 		imagePreviewLoading: make(map[int64]bool),
+		imageQueue:          newImageRenderQueue(imageRenderConcurrencyFromEnv()),
 		articleOptions:      article.DefaultOptions,
 		inlineImagePreview:  parseEnvBool("FEEDBIN_INLINE_IMAGE_PREVIEW"),
+		imagePlacement:      tuiview.ParseImagePlacement(os.Getenv("FEEDBIN_INLINE_IMAGE_PLACEMENT")),
+		imageChoice:         article.ParseImageChoice(os.Getenv("FEEDBIN_INLINE_IMAGE_CHOICE")),
+		hideImageLabels:     parseEnvBool("FEEDBIN_INLINE_IMAGE_HIDE_LABELS"),
 		collapsedFolders:    make(map[string]bool),
 		collapsedFeeds:      make(map[string]bool),
 		collapsedSections:   make(map[string]bool),
+		expandedFeedLists:   make(map[string]bool),
+		treeCache:           &treeRowsCache{},
+		commentCounts:       &commentCountCache{},
+		previewSource:       previewSourceSummary,
+		previewCache:        &previewSnippetCache{},
 		nerdIcons:           parseEnvBool("FEEDBIN_NERD_ICONS"),
 	}
 	rows := m.treeRows()
@@ -157,11 +375,61 @@ func (m *Model) SetNerdMode(nerd bool) {
 	m.nerdMode = nerd
 }
 
+// SetDefaultFolder groups untagged feeds under the given folder name.
+// An empty name keeps the separate top-level Feeds section.
+func (m *Model) SetDefaultFolder(name string) {
+	anchorID := m.anchorEntryID()
+	m.defaultFolder = strings.TrimSpace(name)
+	m.sortEntries()
+	m.restoreSelection(anchorID)
+}
+
+// SetLocation sets the zone absolute dates are shown in. A nil location
+// keeps the UTC default.
+func (m *Model) SetLocation(loc *time.Location) {
+	m.location = loc
+}
+
+// SetOpenURLMode picks what the open action does: "browser" launches the local
+// browser, "copy" always copies the URL, and "auto" copies only inside SSH
+// sessions, where a browser would start on the remote host.
+func (m *Model) SetOpenURLMode(mode string) {
+	switch mode {
+	case "copy":
+		m.copyOnOpenReason = "Open mode is copy"
+	case "auto":
+		m.copyOnOpenReason = ""
+		if tuiplatform.IsSSHSession() {
+			m.copyOnOpenReason = "SSH session"
+		}
+	default:
+		m.copyOnOpenReason = ""
+	}
+}
+
+// SetOffline keeps the UI from starting network refreshes. The service queues
+// read/star changes itself; the model only skips refreshes and says so.
+func (m *Model) SetOffline(offline bool) {
+	m.offline = offline
+}
+
+// SetAutoOpenFirstUnread makes the initial load drop straight into the first
+// unread article of the current view, unless the user moves first.
+func (m *Model) SetAutoOpenFirstUnread(enabled bool) {
+	m.autoOpenFirstUnread = enabled
+}
+
 func (m Model) Init() tea.Cmd {
-	if m.service == nil {
+	if m.service == nil || m.offline {
+		if m.autoOpenFirstUnread {
+			// No startup refresh will settle the list; open from the cache.
+			return func() tea.Msg { return autoOpenMsg{} }
+		}
 		return nil
 	}
-	return tuiactions.RefreshCmd(m.service, m.perPage, "init")
+	// The refresh starts from Update so it counts as running and r waits for
+	// it like for any other.
+	return tea.Batch(func() tea.Msg { return initRefreshMsg{} }, autoRefreshTickCmd(m.autoRefreshInterval))
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -176,77 +444,225 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case tea.KeyMsg:
+		if m.autoOpenFirstUnread {
+			return m.watchNavigationBeforeAutoOpen(msg)
+		}
+		if m.pendingBulk != nil {
+			return m.handlePendingBulkKey(msg)
+		}
+		if m.pendingSnoozeID != 0 {
+			return m.handlePendingSnoozeKey(msg)
+		}
+		if m.folderPrompt != nil {
+			return m.handleFolderPromptKeys(msg)
+		}
+		if m.describeKeyPending {
+			return m.handleDescribeKey(msg)
+		}
 		if next, cmd, handled := m.handleGlobalKeys(msg); handled {
 			return next, cmd
 		}
+		if next, cmd, handled := m.handleStickySearchKey(msg); handled {
+			return next, cmd
+		}
 		if m.showHelp {
 			return m.handleHelpKeys(msg)
 		}
+		if m.refusesReadOnlyKey(msg.String()) {
+			return m.readOnlyNotice()
+		}
+		if m.feedsOpen {
+			return m.handleFeedsKeys(msg)
+		}
 		if m.searchInputMode {
 			return m.handleSearchInputKeys(msg)
 		}
 		if m.inDetail {
-			return m.handleDetailKeys(msg)
+			next, cmd := m.handleDetailKeys(msg)
+			if nextModel, ok := next.(Model); ok {
+				nextModel.dropStaleListPin()
+				return nextModel, cmd
+			}
+			return next, cmd
 		}
-		return m.handleListKeys(msg)
+		var hovered int64
+		if m.autoPreview {
+			hovered = m.hoveredEntryID()
+		}
+		next, cmd := m.handleListKeys(msg)
+		if nextModel, ok := next.(Model); ok {
+			nextModel.dropStaleListPin()
+			nextModel, windowCmd := nextModel.slideEntryWindow()
+			nextModel, moreCmd := nextModel.autoLoadMore()
+			return nextModel.trackAutoPreview(hovered, tea.Batch(cmd, windowCmd, moreCmd))
+		}
+		return next, cmd
 	case tuiactions.RefreshSuccessMsg:
 		anchorID := m.anchorEntryID()
+		rowOffset := m.listRowOffset()
 		m.loading = false
+		queued := tea.Batch(m.finishRefresh(msg.Source), m.noteNewEntries(msg.Entries, msg.Source))
+		readElsewhere := m.readElsewhereCount(msg.Entries)
+		openBefore := m.snapshotOpenArticle()
 		m.entries = limitEntries(msg.Entries, m.currentLimit())
+		m.resetEntryWindow(len(msg.Entries))
+		m.pagesExhausted = false
+		m.mutedCount = 0
+		m.reapplyPendingToggles()
 		m.applyCurrentFilter()
 		if m.searchQuery != "" {
 			m.searchMatchCount = len(m.entries)
 		}
 		m.restoreSelection(anchorID)
+		m.pinListWindow(rowOffset)
+		articleUpdated := m.reflowUpdatedArticle(openBefore)
 		m.err = nil
+		if msg.Warning != "" {
+			m.status = "Refreshed with warning: " + msg.Warning
+			m.statusID++
+			queued = tea.Batch(queued, clearStatusCmd(m.statusID, 5*time.Second))
+		} else if articleUpdated {
+			m.status = "Article updated"
+			m.statusID++
+			queued = tea.Batch(queued, clearStatusCmd(m.statusID, 3*time.Second))
+		} else if readElsewhere > 0 && msg.Source != "init" {
+			m.status = readElsewhereStatus(readElsewhere)
+			m.statusID++
+			queued = tea.Batch(queued, clearStatusCmd(m.statusID, 5*time.Second))
+		}
+		m.lastSyncedAt = m.nowFn()
+		m.refreshedThisSession = true
 		if msg.Source == "init" {
 			m.initialRefreshDuration = msg.Duration
 			m.initialRefreshDone = true
 			m.initialRefreshFailed = false
+			var openCmd tea.Cmd
+			m, openCmd = m.autoOpenFirstUnreadEntry()
+			queued = tea.Batch(queued, openCmd)
 		}
-		return m, nil
+		if (m.feedScoped() || m.filter == dismissedFilter) && m.service != nil {
+			// The refresh only returns the newest entries; reload the scope
+			// so older cached entries of the feed stay listed, and the
+			// dismissed view keeps showing only dismissed entries.
+			return m, tea.Batch(tuiactions.LoadFilterCmd(m.service, m.filter, m.currentLimit()), queued)
+		}
+		if m.reloadsAfterRefresh() {
+			return m, tea.Batch(tuiactions.ReloadFilterCmd(m.service, m.filter, m.currentLimit()), queued)
+		}
+		return m, queued
 	case tuiactions.LoadMoreSuccessMsg:
 		anchorID := m.anchorEntryID()
 		m.loading = false
 		m.err = nil
 		m.lastFetchCount = msg.FetchedCount
+		m.pagesExhausted = msg.FetchedCount < m.perPage
 		if msg.FetchedCount == 0 {
 			m.status = "No more entries"
 			return m, nil
 		}
 		m.page = msg.Page
-		m.entries = msg.Entries
-		m.applyCurrentFilter()
+		m.resetEntryWindow(len(msg.Entries))
+		if m.mergePages {
+			m.entries = tuitree.MergeEntries(m.entries, m.filterEntries(msg.Entries), m.defaultFolder)
+			m.reapplyPendingToggles()
+			m.dropMuted()
+		} else {
+			m.entries = msg.Entries
+			m.mutedCount = 0
+			m.reapplyPendingToggles()
+			m.applyCurrentFilter()
+			m.sortEntries()
+		}
 		if m.searchQuery != "" {
 			m.searchMatchCount = len(m.entries)
 		}
-		sortEntriesForTree(m.entries)
 		m.restoreSelection(anchorID)
 		m.status = fmt.Sprintf("Loaded page %d", msg.Page)
 		return m, nil
+	case tuiactions.FolderAssignedMsg:
+		return m.finishFolderAssign(msg)
+	case tuiactions.ExtractContentSuccessMsg:
+		return m.finishExtractContent(msg)
+	case tuiactions.ExtractContentErrorMsg:
+		return m.failExtractContent(msg)
 	case tuiactions.LoadMoreErrorMsg:
 		m.loading = false
 		m.status = ""
 		m.err = msg.Err
 		return m, nil
+	case autoRefreshMsg:
+		return m.startAutoRefresh()
+	case initRefreshMsg:
+		return m.startInitialRefresh()
+	case autoOpenMsg:
+		return m.autoOpenFirstUnreadEntry()
+	case bellErrorMsg:
+		m.status = msg.err.Error()
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 5*time.Second)
 	case tuiactions.RefreshErrorMsg:
 		m.loading = false
-		m.status = ""
-		m.err = msg.Err
+		if errors.Is(msg.Err, feedbin.ErrRequestBudget) {
+			return m.requestBudgetReached(msg)
+		}
+		if msg.Source == "init" {
+			// The startup refresh counts as running until its retries run out.
+			if retry, ok := m.retryInitialRefresh(msg.Err); ok {
+				return m, retry
+			}
+		}
+		queued := m.finishRefresh(msg.Source)
+		if msg.Source == "auto" {
+			// Keep the list on screen; the next tick tries again.
+			m.status = "Auto-refresh failed: " + msg.Err.Error()
+			m.statusID++
+			return m, tea.Batch(queued, clearStatusCmd(m.statusID, 5*time.Second))
+		}
 		if msg.Source == "init" {
 			m.initialRefreshDuration = msg.Duration
 			m.initialRefreshDone = true
 			m.initialRefreshFailed = true
+			m.status = m.workingOfflineStatus()
+			m.err = msg.Err
+			var openCmd tea.Cmd
+			m, openCmd = m.autoOpenFirstUnreadEntry()
+			return m, tea.Batch(queued, openCmd)
 		}
-		return m, nil
+		m.status = ""
+		m.err = msg.Err
+		return m, queued
 	case tuiactions.FilterLoadSuccessMsg:
-		anchorID := m.anchorEntryID()
+		if msg.Reload && msg.Filter != m.filter {
+			// The user switched filters while the post-refresh reload ran.
+			return m, nil
+		}
+		anchorID := m.filterAnchor(msg.Filter, m.anchorEntryID())
 		m.loading = false
 		m.err = nil
 		m.filter = msg.Filter
 		m.entries = msg.Entries
-		sortEntriesForTree(m.entries)
+		m.resetEntryWindow(len(msg.Entries))
+		m.pagesExhausted = false
+		m.mutedCount = 0
+		m.reapplyPendingToggles()
+		if m.hidingRead() {
+			m.entries = m.filterEntries(m.entries)
+		}
+		m.dropMuted()
+		m.sortEntries()
 		m.restoreSelection(anchorID)
+		if msg.Reload {
+			return m, nil
+		}
+		if m.feedScoped() {
+			if m.feedScopeAnchorID != 0 {
+				m.moveToNextUnread(m.feedScopeAnchorID)
+				m.feedScopeAnchorID = 0
+			}
+			m.status = fmt.Sprintf("Feed: %s (%d entries, ctrl+l to clear)", m.feedScopeTitle, len(m.entries))
+			return m, nil
+		}
+		m.feedScopeTitle = ""
 		if m.filter == "all" {
 			m.status = "Filter: all"
 		} else if m.filter == "unread" {
@@ -260,6 +676,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.status = ""
 		m.err = msg.Err
 		return m, nil
+	case tuiactions.EntryWindowSuccessMsg:
+		return m.finishEntryWindow(msg)
+	case tuiactions.EntryWindowErrorMsg:
+		m.loading = false
+		m.status = ""
+		m.err = msg.Err
+		return m, nil
 	case tuiactions.SearchLoadSuccessMsg:
 		anchorID := m.anchorEntryID()
 		m.loading = false
@@ -267,8 +690,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.filter = msg.Filter
 		m.searchQuery = strings.TrimSpace(msg.Query)
 		m.entries = msg.Entries
-		m.searchMatchCount = len(msg.Entries)
-		sortEntriesForTree(m.entries)
+		m.mutedCount = 0
+		m.reapplyPendingToggles()
+		m.dropMuted()
+		m.searchMatchCount = len(m.entries)
+		m.sortEntries()
+		if m.searchQuery == "" {
+			m.restoreSearchCollapse()
+		} else {
+			m.expandSearchMatches()
+		}
 		m.restoreSelection(anchorID)
 		if m.searchQuery == "" {
 			m.status = "Search cleared"
@@ -284,10 +715,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case tuiactions.ToggleUnreadSuccessMsg:
 		anchorID := m.anchorEntryID()
+		// Only advance when the cursor is still on the entry; the user may
+		// have moved on while the toggle was in flight.
+		if !msg.NextUnread && anchorID == msg.EntryID {
+			if nextID := m.readAdvanceAnchor(msg.EntryID); nextID != 0 {
+				anchorID = nextID
+			}
+		}
 		m.loading = false
 		m.err = nil
 		m.status = msg.Status
+		m.settleToggle(msg.EntryID, tuiactions.BatchFieldUnread)
 		m.setEntryUnread(msg.EntryID, msg.NextUnread)
+		if !msg.NextUnread {
+			m.countReads(1)
+		}
+		m.lastMutation = &entryMutation{entryID: msg.EntryID, field: tuiactions.BatchFieldUnread, prior: !msg.NextUnread}
 		m.applyCurrentFilter()
 		m.restoreSelection(anchorID)
 		return m, nil
@@ -296,12 +739,103 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		m.err = nil
 		m.status = msg.Status
+		m.settleToggle(msg.EntryID, tuiactions.BatchFieldStarred)
 		m.setEntryStarred(msg.EntryID, msg.NextStarred)
+		m.lastMutation = &entryMutation{entryID: msg.EntryID, field: tuiactions.BatchFieldStarred, prior: !msg.NextStarred}
 		m.applyCurrentFilter()
 		m.restoreSelection(anchorID)
 		return m, nil
+	case tuiactions.SnoozeSuccessMsg:
+		anchorID := m.anchorEntryID()
+		m.loading = false
+		m.err = nil
+		m.status = msg.Status
+		m.settleToggle(msg.EntryID, tuiactions.BatchFieldUnread)
+		m.removeSnoozedEntry(msg.EntryID)
+		m.restoreSelection(anchorID)
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 3*time.Second)
+	case tuiactions.DismissResultMsg:
+		return m.finishDismiss(msg)
+	case tuiactions.SearchModeResultMsg:
+		return m.finishSearchModeSwitch(msg)
+	case tuiactions.LocalReadResultMsg:
+		return m.finishLocalRead(msg)
+	case tuiactions.UndoSuccessMsg:
+		anchorID := m.anchorEntryID()
+		m.loading = false
+		m.err = nil
+		m.status = msg.Status
+		m.settleToggle(msg.EntryID, msg.Field)
+		if msg.Field == tuiactions.BatchFieldStarred {
+			m.setEntryStarred(msg.EntryID, msg.Value)
+		} else {
+			m.setEntryUnread(msg.EntryID, msg.Value)
+		}
+		m.applyCurrentFilter()
+		m.restoreSelection(anchorID)
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 3*time.Second)
+	case tuiactions.BatchUpdateResultMsg:
+		anchorID := m.anchorEntryID()
+		m.loading = false
+		for _, id := range msg.Succeeded {
+			if msg.Field == tuiactions.BatchFieldStarred {
+				m.setEntryStarred(id, msg.Value)
+			} else {
+				m.setEntryUnread(id, msg.Value)
+			}
+		}
+		if msg.Field == tuiactions.BatchFieldUnread && !msg.Value {
+			m.countReads(len(msg.Succeeded))
+		}
+		m.applyCurrentFilter()
+		m.restoreSelection(anchorID)
+		retried := m.batchRetry != nil && m.batchRetry.retrying
+		m.batchRetry = nil
+		if len(msg.Failed) > 0 {
+			m.err = nil
+			if retried {
+				m.status = strings.TrimSuffix(msg.Status, tuiactions.BatchRetryHint) + " again — press r to refresh"
+				return m, nil
+			}
+			m.batchRetry = &batchRetry{field: msg.Field, value: msg.Value, entryIDs: msg.Failed}
+			m.status = msg.Status
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.status = ""
+			m.err = msg.Err
+			return m, nil
+		}
+		m.err = nil
+		m.status = msg.Status
+		return m, nil
+	case tuiactions.FeedsLoadedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		m.feeds = msg.Feeds
+		m.feedsCursor = tuistate.ClampCursor(m.feedsCursor, len(m.feeds))
+		return m, nil
+	case tuiactions.FeedActionResultMsg:
+		m.loading = false
+		if msg.Err != nil {
+			m.status = ""
+			m.err = msg.Err
+			return m, nil
+		}
+		m.err = nil
+		m.status = msg.Status
+		cmds := []tea.Cmd{tuiactions.LoadFeedsCmd(m.feedManager)}
+		if m.service != nil {
+			cmds = append(cmds, tuiactions.LoadFilterCmd(m.service, m.filter, m.currentLimit()))
+		}
+		return m, tea.Batch(cmds...)
 	case tuiactions.ToggleActionErrorMsg:
 		m.loading = false
+		m.rollbackToggle(msg.EntryID, msg.Field)
 		m.status = ""
 		m.err = msg.Err
 		return m, nil
@@ -309,22 +843,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.err = nil
 		m.status = msg.Status
 		if msg.Opened && msg.UnreadBefore && m.markReadOnOpen && m.service != nil {
-			now := m.nowFn()
-			if m.lastOpenReadEntryID == msg.EntryID && now.Sub(m.lastOpenReadAt) < m.autoReadDebounce {
-				m.status = "Skipped mark-read (debounced)"
-				m.statusID++
-				return m, clearStatusCmd(m.statusID, 3*time.Second)
-			}
-			if m.confirmOpenRead {
-				m.pendingOpenReadEntryID = msg.EntryID
-				m.status = "Press Shift+M to confirm mark as read"
-				m.statusID++
-				return m, clearStatusCmd(m.statusID, 4*time.Second)
-			}
-			m.lastOpenReadEntryID = msg.EntryID
-			m.lastOpenReadAt = now
-			m.loading = true
-			return m, tuiactions.ToggleUnreadCmd(m.service, msg.EntryID, true)
+			return m, m.autoMarkRead(msg.EntryID)
 		}
 		m.statusID++
 		return m, clearStatusCmd(m.statusID, 3*time.Second)
@@ -333,6 +852,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.status = msg.Err.Error()
 		m.statusID++
 		return m, clearStatusCmd(m.statusID, 4*time.Second)
+	case incrementalSearchMsg:
+		return m.applyIncrementalSearch(msg)
+	case autoPreviewMsg:
+		if msg.seq != m.previewSeq || !m.autoPreview || m.inDetail {
+			return m, nil
+		}
+		m.previewPendingID = 0
+		if m.hoveredEntryID() == msg.entryID {
+			m.previewEntryID = msg.entryID
+		}
+		return m, nil
 	case clearStatusMsg:
 		if msg.id == m.statusID {
 			m.status = ""
@@ -342,6 +872,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.err = msg.err
 		m.status = "Could not persist UI preferences"
 		return m, nil
+	case readProgressSaveErrorMsg:
+		m.err = msg.err
+		m.status = "Could not save read progress"
+		return m, nil
+	case filterAnchorSaveErrorMsg:
+		m.err = msg.err
+		m.status = "Could not save filter position"
+		return m, nil
+	case pinnedFeedsSaveErrorMsg:
+		m.err = msg.err
+		m.status = "Could not save pinned feeds"
+		return m, nil
 	case inlineImagePreviewSuccessMsg:
 		delete(m.imagePreviewLoading, msg.entryID)
 		delete(m.imagePreviewErr, msg.entryID)
@@ -351,6 +893,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		delete(m.imagePreviewLoading, msg.entryID)
 		m.imagePreviewErr[msg.entryID] = msg.err.Error()
 		return m, tea.ClearScreen
+	case inlineImagePreviewCanceledMsg:
+		// Dropped before it started; reopening the article queues it again.
+		delete(m.imagePreviewLoading, msg.entryID)
+		return m, nil
 	}
 	return m, nil
 }
@@ -373,21 +919,39 @@ func (m Model) handleHelpKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "esc":
 		m.showHelp = false
 		return m, nil
+	case "c":
+		return m.copyDiagnostics()
 	case "ctrl+c", "q":
-		return m, tea.Quit
+		return m.quitSavingProgress()
 	default:
 		return m, nil
 	}
 }
 
+func (m *Model) SetDiagnosticsSource(source DiagnosticsSource) {
+	m.diagnostics = source
+}
+
+// copyDiagnostics copies the diagnostic bundle for a bug report, including
+// the error currently shown.
+func (m Model) copyDiagnostics() (tea.Model, tea.Cmd) {
+	if m.diagnostics == nil {
+		m.status = "Diagnostics unavailable"
+		return m, nil
+	}
+	return m, tuiactions.CopyDiagnosticsCmd(m.diagnostics, m.err, m.copyURLFn)
+}
+
 func (m Model) handleSearchInputKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter":
+		m.endIncrementalSearch(false)
 		return m.applySearchInput()
 	case "ctrl+l":
 		m.searchInput = ""
-		return m, nil
+		return m.scheduleIncrementalSearch()
 	case "esc":
+		m.endIncrementalSearch(true)
 		m.searchInputMode = false
 		m.searchInput = ""
 		m.status = "Search canceled"
@@ -400,27 +964,31 @@ func (m Model) handleSearchInputKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			_, size := utf8.DecodeLastRuneInString(m.searchInput)
 			m.searchInput = m.searchInput[:len(m.searchInput)-size]
 		}
-		return m, nil
+		return m.scheduleIncrementalSearch()
 	default:
 		if msg.Type == tea.KeyRunes && len(msg.Runes) > 0 {
-			m.searchInput += string(msg.Runes)
+			m.searchInput += searchInputText(msg.Runes, msg.Paste)
+			return m.scheduleIncrementalSearch()
 		}
 		return m, nil
 	}
 }
 
 func (m Model) handleDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The end-of-feed notice only arms the next "]".
+	feedEndShown := m.feedEndShown
+	m.feedEndShown = false
 	switch msg.String() {
 	case "esc", "backspace":
-		m.inDetail = false
-		m.detailTop = 0
-		return m, tea.ClearScreen
+		return m.closeDetail()
 	case "ctrl+c", "q":
-		return m, tea.Quit
+		return m.quitSavingProgress()
 	case "o":
 		return m.openCurrentURL()
 	case "y":
 		return m.copyCurrentURL()
+	case "s":
+		return m.cycleBodySource()
 	case "up", "k":
 		if m.detailTop > 0 {
 			m.detailTop--
@@ -434,6 +1002,20 @@ func (m Model) handleDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.detailTop++
 		}
 		return m, nil
+	case "ctrl+z":
+		return m.undoLastMutation()
+	case "z":
+		return m.startSnooze()
+	case "x":
+		return m.dismissCurrent()
+	case "f":
+		return m.scopeToCurrentFeed()
+	case "W":
+		return m.startDescribeKey()
+	case "}":
+		return m.jumpToUnreadFeed(1)
+	case "{":
+		return m.jumpToUnreadFeed(-1)
 	case "U":
 		return m.toggleUnreadCurrent()
 	case "S":
@@ -443,21 +1025,24 @@ func (m Model) handleDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		if m.cursor > 0 {
-			m.cursor--
-			m.selectedID = m.entries[m.cursor].ID
-			m.detailTop = 0
-			return m, m.ensureInlineImagePreviewCmd()
+			return m.showDetailEntry(m.cursor - 1)
 		}
-		return m, nil
+		return m.crossDetailBoundary(-1)
 	case "]":
 		if len(m.entries) == 0 {
 			return m, nil
 		}
+		if m.cursor == len(m.entries)-1 && m.detailBoundary != "" {
+			return m.crossDetailBoundary(1)
+		}
+		if m.atFeedEnd() && m.unreadFeedFollows() {
+			if feedEndShown || m.autoNextFeed {
+				return m.jumpToUnreadFeed(1)
+			}
+			return m.showFeedEnd()
+		}
 		if m.cursor < len(m.entries)-1 {
-			m.cursor++
-			m.selectedID = m.entries[m.cursor].ID
-			m.detailTop = 0
-			return m, m.ensureInlineImagePreviewCmd()
+			return m.showDetailEntry(m.cursor + 1)
 		}
 		return m, nil
 	default:
@@ -471,6 +1056,11 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, tea.Quit
 	case "ctrl+l":
 		return m.clearSearch()
+	case "esc":
+		if m.escClearsSearch && strings.TrimSpace(m.searchQuery) != "" {
+			return m.clearSearch()
+		}
+		return m, nil
 	case "pgup", "ctrl+b":
 		m.pageUpList()
 		return m, nil
@@ -503,6 +1093,16 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "]":
 		m.jumpToSection(1)
 		return m, nil
+	case "+":
+		return m.expandFeedList()
+	case ")":
+		return m.jumpToFeedGroup(1)
+	case "(":
+		return m.jumpToFeedGroup(-1)
+	case "}":
+		return m.jumpToUnreadFeed(1)
+	case "{":
+		return m.jumpToUnreadFeed(-1)
 	case "enter":
 		rows := m.treeRows()
 		if len(rows) == 0 {
@@ -516,25 +1116,32 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.selectedID = m.entries[m.cursor].ID
 		m.inDetail = true
-		m.detailTop = 0
-		return m, m.ensureInlineImagePreviewCmd()
+		m.resumeReadProgress()
+		markCmd := m.markReadOnDetailCmd()
+		return m, tea.Batch(m.ensureInlineImagePreviewCmd(), markCmd)
 	case "r", "R", "ctrl+r":
 		if m.service == nil {
 			return m, nil
 		}
-		m.loading = true
-		m.status = ""
-		m.err = nil
-		m.page = 1
-		return m, tuiactions.RefreshCmd(m.service, m.perPage, "manual")
+		if m.readOnly {
+			return m.readOnlyNotice()
+		}
+		if m.offline {
+			return m.offlineNotice("Offline — changes queued")
+		}
+		if m.batchRetry != nil && !m.batchRetry.retrying {
+			retry := *m.batchRetry
+			next, cmd := m.batchUpdate(retry.field, retry.entryIDs, retry.value)
+			model := next.(Model)
+			retry.retrying = true
+			model.batchRetry = &retry
+			return model, cmd
+		}
+		return m.startRefresh()
 	case "n":
 		return m.loadMore()
 	case "/":
-		m.searchInputMode = true
-		m.searchInput = m.searchQuery
-		m.status = "Search mode: type query and press enter"
-		m.err = nil
-		return m, nil
+		return m.startSearchInput()
 	case "a":
 		return m.switchFilter("all")
 	case "u":
@@ -547,24 +1154,45 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m.switchFilter("all")
 		}
 		return m.switchFilter("starred")
+	case "ctrl+z":
+		return m.undoLastMutation()
+	case "z":
+		return m.startSnooze()
+	case "x":
+		return m.dismissCurrent()
+	case "X":
+		return m.toggleDismissedView()
+	case "ctrl+u":
+		return m.markFeedReadLocally()
+	case "i":
+		return m.togglePinnedFeed()
+	case "m":
+		return m.startFolderAssign()
 	case "U":
 		m.ensureCursorVisible()
 		if !m.currentTreeRowIsArticle() {
-			return m, nil
+			return m.toggleCollectionRead()
 		}
 		return m.toggleUnreadCurrent()
 	case "S":
 		m.ensureCursorVisible()
 		if !m.currentTreeRowIsArticle() {
-			return m, nil
+			return m.starCollection()
 		}
 		return m.toggleStarredCurrent()
 	case "y":
 		m.ensureCursorVisible()
 		if !m.currentTreeRowIsArticle() {
-			return m, nil
+			return m.copyCurrentFeed(false)
 		}
 		return m.copyCurrentURL()
+	case "Y":
+		m.ensureCursorVisible()
+		return m.copyCurrentFeed(true)
+	case "C":
+		return m.copyCollectionUnread()
+	case "e":
+		return m.toggleShowAllAges()
 	case "left", "h":
 		m.collapseCurrentTreeNode()
 		return m, nil
@@ -580,6 +1208,18 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.status = "Compact mode: off"
 		}
 		return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
+	case "O":
+		m.compactUnreadFirst = !m.compactUnreadFirst
+		m.err = nil
+		if m.compactUnreadFirst {
+			m.status = "Compact sort: unread first, then date"
+		} else {
+			m.status = "Compact sort: date"
+		}
+		if !m.compact {
+			m.status += " (applies in compact mode, c)"
+		}
+		return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
 	case "N":
 		m.showNumbers = !m.showNumbers
 		m.err = nil
@@ -590,13 +1230,9 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
 	case "d":
-		m.relativeTime = !m.relativeTime
+		m.timeFormat = tuiview.NextTimeFormat(m.timeFormat)
 		m.err = nil
-		if m.relativeTime {
-			m.status = "Time format: relative"
-		} else {
-			m.status = "Time format: absolute"
-		}
+		m.status = "Time format: " + string(m.timeFormat)
 		return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
 	case "t":
 		m.markReadOnOpen = !m.markReadOnOpen
@@ -607,6 +1243,115 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.status = "Mark read on open: off"
 		}
 		return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
+	case "F":
+		return m.openFeedManager()
+	case "W":
+		return m.startDescribeKey()
+	case "L":
+		return m.cyclePreviewLines()
+	case "E":
+		return m.togglePreviewSource()
+	case "#":
+		return m.toggleShowReadToday()
+	case "V":
+		m.markReadOnDetailOpen = !m.markReadOnDetailOpen
+		m.err = nil
+		if m.markReadOnDetailOpen {
+			m.status = "Mark read on detail open: on"
+		} else {
+			m.status = "Mark read on detail open: off"
+		}
+		return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
+	case "~":
+		return m.toggleShowMuted()
+	case "Z":
+		m.stripFeedPrefix = !m.stripFeedPrefix
+		m.err = nil
+		if m.stripFeedPrefix {
+			m.status = "Feed name in titles: hidden"
+		} else {
+			m.status = "Feed name in titles: shown"
+		}
+		return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
+	case "b":
+		return m.toggleSearchMode()
+	case "v":
+		m.autoPreview = !m.autoPreview
+		m.err = nil
+		if m.autoPreview {
+			m.status = "Auto-preview: on"
+		} else {
+			m.status = "Auto-preview: off"
+		}
+		return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
+	case "I":
+		m.incrementalSearch = !m.incrementalSearch
+		m.err = nil
+		if m.incrementalSearch {
+			m.status = "Incremental search: on"
+		} else {
+			m.status = "Incremental search: off"
+		}
+		return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
+	case "P":
+		m.mergePages = !m.mergePages
+		m.err = nil
+		if m.mergePages {
+			m.status = "Page insert: merge"
+		} else {
+			m.status = "Page insert: full sort"
+		}
+		return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
+	case "K":
+		m.compactCounts = !m.compactCounts
+		m.err = nil
+		if m.compactCounts {
+			m.status = "Compact counts: on"
+		} else {
+			m.status = "Compact counts: off"
+		}
+		return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
+	case "D":
+		m.dateColumn = tuiview.NextDateColumn(m.dateColumn)
+		m.err = nil
+		m.status = "Date column: " + string(m.dateColumn)
+		return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
+	case "H":
+		m.compactTree = !m.compactTree
+		m.err = nil
+		// Row positions shift as headers come and go; stay on the entry.
+		m.setTreeCursorForEntry(m.cursor)
+		m.ensureTreeCursorValid()
+		m.ensureCursorVisible()
+		if m.compactTree {
+			m.status = "Compact tree: on"
+		} else {
+			m.status = "Compact tree: off"
+		}
+		return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
+	case "A":
+		return m.toggleHideRead()
+	case "T":
+		m.tagsAsFolders = !m.tagsAsFolders
+		m.err = nil
+		m.setTreeCursorForEntry(m.cursor)
+		m.ensureTreeCursorValid()
+		m.ensureCursorVisible()
+		if m.tagsAsFolders {
+			m.status = "Feeds under every tag: on"
+		} else {
+			m.status = "Feeds under every tag: off"
+		}
+		return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
+	case "B":
+		m.confirmBulkActions = !m.confirmBulkActions
+		m.err = nil
+		if m.confirmBulkActions {
+			m.status = "Confirm bulk actions: on"
+		} else {
+			m.status = "Confirm bulk actions: off"
+		}
+		return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
 	case "p":
 		m.confirmOpenRead = !m.confirmOpenRead
 		m.err = nil
@@ -640,6 +1385,15 @@ func (m Model) View() string {
 	}
 	b.WriteString(m.toolbar())
 	b.WriteString("\n\n")
+	if m.feedsOpen {
+		b.WriteString(m.feedManagerView())
+		b.WriteString("\n")
+		b.WriteString(m.messagePanel())
+		b.WriteString("\n")
+		b.WriteString(m.footer())
+		b.WriteString("\n")
+		return b.String()
+	}
 	if m.inDetail {
 		b.WriteString(m.detailView())
 		b.WriteString("\n")
@@ -659,7 +1413,7 @@ func (m Model) View() string {
 		b.WriteString("Loading entries...\n")
 	} else {
 		if len(m.entries) == 0 {
-			b.WriteString("No entries available.\n")
+			b.WriteString(m.emptyListMessage() + "\n")
 		} else {
 			rows := m.treeRows()
 			sectionUnreadCounts := m.unreadCountsBySection()
@@ -680,8 +1434,15 @@ func (m Model) View() string {
 				RenderSectionLine:   m.renderSectionLine,
 				RenderTreeNodeLine:  m.renderTreeNodeLine,
 				RenderEntryLine:     m.renderEntryLine,
+				RenderEntryPreview:  m.entryPreviewLines,
 				FeedKeyFn:           treeFeedKey,
 			}))
+			if preview := m.autoPreviewLines(); len(preview) > 0 {
+				b.WriteString(uiTheme.MetaLabel.Render("Preview"))
+				b.WriteString("\n")
+				b.WriteString(strings.Join(preview, "\n"))
+				b.WriteString("\n")
+			}
 		}
 	}
 	b.WriteString("\n")
@@ -721,17 +1482,25 @@ func (m Model) detailView() string {
 }
 
 func (m Model) detailLines(entry feedbin.Entry) []string {
+	entry, opts := m.detailSource(entry)
+	if m.timeFormat == tuiview.TimeFormatBoth {
+		opts.DateAge = relativeTimeLabel(m.nowFn(), entry.PublishedAt)
+	}
 	return tuiview.DetailLines(
 		entry,
 		m.detailContentWidth(),
-		m.detailHorizontalMargin(),
-		m.articleOptions,
+		m.detailHorizontalMargin()+m.articleIndent,
+		m.location,
+		opts,
 		wrapText,
 		tuiview.InlineImagePreviewState{
-			Enabled: m.inlineImagePreview,
-			Loading: m.imagePreviewLoading[entry.ID],
-			Raw:     m.imagePreview[entry.ID],
-			Err:     m.imagePreviewErr[entry.ID],
+			Enabled:    m.inlineImagePreview,
+			Loading:    m.imagePreviewLoading[entry.ID],
+			Raw:        m.imagePreview[entry.ID],
+			Err:        m.imagePreviewErr[entry.ID],
+			Placement:  m.imagePlacement,
+			HideLabels: m.hideImageLabels,
+			Anchor:     m.previewImageAnchor(entry),
 		},
 	)
 }
@@ -741,17 +1510,49 @@ func (m Model) toggleUnreadCurrent() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 	entry := m.entries[m.cursor]
+	m.beginToggle(entry.ID, tuiactions.BatchFieldUnread, !entry.IsUnread)
 	m.loading = true
 	m.status = ""
 	m.err = nil
 	return m, tuiactions.ToggleUnreadCmd(m.service, entry.ID, entry.IsUnread)
 }
 
+func (m Model) batchUpdate(field string, entryIDs []int64, value bool) (tea.Model, tea.Cmd) {
+	if m.service == nil || len(entryIDs) == 0 {
+		return m, nil
+	}
+	m.batchRetry = nil
+	m.loading = true
+	m.status = ""
+	m.err = nil
+	return m, tuiactions.BatchUpdateCmd(m.service, field, entryIDs, value)
+}
+
+// undoLastMutation reverts the most recent read/star toggle and empties the
+// single-level undo slot.
+func (m Model) undoLastMutation() (tea.Model, tea.Cmd) {
+	if m.service == nil {
+		return m, nil
+	}
+	if m.lastMutation == nil {
+		m.status = "Nothing to undo"
+		return m, nil
+	}
+	mutation := m.lastMutation
+	m.lastMutation = nil
+	m.beginToggle(mutation.entryID, mutation.field, mutation.prior)
+	m.loading = true
+	m.status = ""
+	m.err = nil
+	return m, tuiactions.UndoToggleCmd(m.service, mutation.entryID, mutation.field, mutation.prior)
+}
+
 func (m Model) toggleStarredCurrent() (tea.Model, tea.Cmd) {
 	if m.service == nil || len(m.entries) == 0 {
 		return m, nil
 	}
 	entry := m.entries[m.cursor]
+	m.beginToggle(entry.ID, tuiactions.BatchFieldStarred, !entry.IsStarred)
 	m.loading = true
 	m.status = ""
 	m.err = nil
@@ -762,13 +1563,14 @@ func (m Model) switchFilter(filter string) (tea.Model, tea.Cmd) {
 	if m.service == nil {
 		return m, nil
 	}
+	saveAnchors := m.rememberFilterAnchor()
 	m.loading = true
 	m.status = ""
 	m.err = nil
 	if m.searchQuery != "" {
 		return m, tuiactions.LoadSearchCmd(m.service, filter, m.searchQuery, m.currentLimit())
 	}
-	return m, tuiactions.LoadFilterCmd(m.service, filter, m.currentLimit())
+	return m, tea.Batch(tuiactions.LoadFilterCmd(m.service, filter, m.currentLimit()), saveAnchors)
 }
 
 func (m Model) applySearchInput() (tea.Model, tea.Cmd) {
@@ -778,6 +1580,7 @@ func (m Model) applySearchInput() (tea.Model, tea.Cmd) {
 	query := strings.TrimSpace(m.searchInput)
 	m.searchInputMode = false
 	m.searchInput = query
+	m.searchFocused = m.stickySearch && query != ""
 	m.loading = true
 	m.status = ""
 	m.err = nil
@@ -788,12 +1591,18 @@ func (m Model) clearSearch() (tea.Model, tea.Cmd) {
 	if m.service == nil {
 		return m, nil
 	}
-	if strings.TrimSpace(m.searchQuery) == "" && strings.TrimSpace(m.searchInput) == "" && !m.searchInputMode {
+	if strings.TrimSpace(m.searchQuery) == "" && strings.TrimSpace(m.searchInput) == "" && !m.searchInputMode && !m.feedScoped() {
 		return m, nil
 	}
+	if m.feedScoped() {
+		m.filter = "all"
+		m.feedScopeTitle = ""
+	}
 	m.searchQuery = ""
 	m.searchInput = ""
 	m.searchInputMode = false
+	m.searchFocused = false
+	m.restoreSearchCollapse()
 	m.searchMatchCount = 0
 	m.loading = true
 	m.status = ""
@@ -801,22 +1610,48 @@ func (m Model) clearSearch() (tea.Model, tea.Cmd) {
 	return m, tuiactions.LoadFilterCmd(m.service, m.filter, m.currentLimit())
 }
 
+// offlineNotice explains why a network action was skipped in offline mode.
+func (m Model) offlineNotice(text string) (tea.Model, tea.Cmd) {
+	m.status = text
+	m.err = nil
+	m.statusID++
+	return m, clearStatusCmd(m.statusID, 3*time.Second)
+}
+
 func (m Model) loadMore() (tea.Model, tea.Cmd) {
 	if m.service == nil {
 		return m, nil
 	}
+	if m.windowed() && m.windowFilled && m.searchQuery == "" {
+		// The window is full: read older cached entries instead of growing it.
+		return m.shiftEntryWindow(1)
+	}
+	if m.offline {
+		return m.offlineNotice("Offline — showing cached entries only")
+	}
 	m.loading = true
 	m.status = ""
 	m.err = nil
 	nextPage := m.page + 1
-	return m, tuiactions.LoadMoreCmd(m.service, nextPage, m.perPage, m.filter, m.currentLimit()+m.perPage)
+	return m, tuiactions.LoadMoreCmd(m.service, nextPage, m.perPage, m.filter, m.capToWindow(m.currentLimit()+m.perPage))
+}
+
+// entryURL is the URL o and y act on: under FEEDBIN_PREFER_CANONICAL_URL the
+// article's own page rather than an AMP or feed-proxy URL.
+func (m Model) entryURL(entry feedbin.Entry) string {
+	if m.preferCanonicalURL {
+		if canonical := entry.ResolveCanonicalURL(); canonical != "" {
+			return canonical
+		}
+	}
+	return entry.URL
 }
 
 func (m Model) openCurrentURL() (tea.Model, tea.Cmd) {
 	if len(m.entries) == 0 {
 		return m, nil
 	}
-	validURL, err := tuiplatform.ValidateEntryURL(m.entries[m.cursor].URL)
+	validURL, err := tuiplatform.ValidateEntryURL(m.entryURL(m.entries[m.cursor]))
 	if err != nil {
 		m.err = nil
 		m.status = err.Error()
@@ -824,6 +1659,9 @@ func (m Model) openCurrentURL() (tea.Model, tea.Cmd) {
 		return m, clearStatusCmd(m.statusID, 4*time.Second)
 	}
 	entry := m.entries[m.cursor]
+	if m.copyOnOpenReason != "" {
+		return m, tuiactions.CopyInsteadOfOpenCmd(entry.ID, entry.IsUnread, validURL, m.copyOnOpenReason, m.copyURLFn)
+	}
 	return m, tuiactions.OpenURLCmd(entry.ID, entry.IsUnread, validURL, m.openURLFn, m.copyURLFn)
 }
 
@@ -831,7 +1669,7 @@ func (m Model) copyCurrentURL() (tea.Model, tea.Cmd) {
 	if len(m.entries) == 0 {
 		return m, nil
 	}
-	validURL, err := tuiplatform.ValidateEntryURL(m.entries[m.cursor].URL)
+	validURL, err := tuiplatform.ValidateEntryURL(m.entryURL(m.entries[m.cursor]))
 	if err != nil {
 		m.err = nil
 		m.status = err.Error()
@@ -841,6 +1679,49 @@ func (m Model) copyCurrentURL() (tea.Model, tea.Cmd) {
 	return m, tuiactions.CopyURLCmd(validURL, m.copyURLFn)
 }
 
+// copyCurrentFeed copies the feed URL (or an OPML outline when opml is set)
+// for the feed row under the cursor, or for the feed of the current article.
+func (m Model) copyCurrentFeed(opml bool) (tea.Model, tea.Cmd) {
+	entry, ok := m.currentFeedEntry()
+	if !ok {
+		return m, nil
+	}
+	title := feedNameForEntry(entry)
+	feedURL := strings.TrimSpace(entry.FeedURL)
+	if feedURL == "" {
+		m.err = nil
+		m.status = fmt.Sprintf("No feed URL stored for %s (refresh to sync subscriptions)", title)
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 4*time.Second)
+	}
+	if opml {
+		return m, tuiactions.CopyFeedOPMLCmd(title, feedURL, m.copyURLFn)
+	}
+	return m, tuiactions.CopyFeedURLCmd(title, feedURL, m.copyURLFn)
+}
+
+func (m Model) currentFeedEntry() (feedbin.Entry, bool) {
+	rows := m.treeRows()
+	if m.treeCursor < 0 || m.treeCursor >= len(rows) {
+		return feedbin.Entry{}, false
+	}
+	row := rows[m.treeCursor]
+	switch row.Kind {
+	case treeRowArticle:
+		if row.EntryIndex < 0 || row.EntryIndex >= len(m.entries) {
+			return feedbin.Entry{}, false
+		}
+		return m.entries[row.EntryIndex], true
+	case treeRowFeed:
+		for _, entry := range m.entries {
+			if m.entryOnFeedRow(entry, row) {
+				return entry, true
+			}
+		}
+	}
+	return feedbin.Entry{}, false
+}
+
 func (m Model) confirmPendingOpenRead() (tea.Model, tea.Cmd) {
 	if m.pendingOpenReadEntryID == 0 || m.service == nil {
 		m.status = "No pending mark-read action"
@@ -857,6 +1738,7 @@ func (m Model) confirmPendingOpenRead() (tea.Model, tea.Cmd) {
 
 	m.lastOpenReadEntryID = entryID
 	m.lastOpenReadAt = m.nowFn()
+	m.beginToggle(entryID, tuiactions.BatchFieldUnread, false)
 	m.loading = true
 	m.status = ""
 	m.err = nil
@@ -929,7 +1811,16 @@ func (m *Model) pageUpList() {
 }
 
 func (m Model) listPageStep() int {
-	return tuistate.PageStep(m.height, m.status != "")
+	step := tuistate.PageStep(m.height, m.status != "")
+	if m.variableRowHeights() {
+		// Article rows take up to 1+previewLines lines each, plus one when
+		// stacked.
+		step /= m.maxArticleRowHeight()
+		if step < 1 {
+			step = 1
+		}
+	}
+	return step
 }
 
 func (m Model) anchorEntryID() int64 {
@@ -984,30 +1875,81 @@ func (m *Model) setTreeCursorForEntry(entryIndex int) {
 
 func (m Model) currentLimit() int {
 	if m.page < 1 {
-		return m.perPage
+		return m.capToWindow(m.perPage)
 	}
-	return m.page * m.perPage
+	return m.capToWindow(m.page * m.perPage)
 }
 
 func (m Model) footer() string {
+	footer := m.footerFields()
+	if m.showReadToday {
+		if m.nerdMode {
+			footer += " | " + m.readTodayLabel()
+		} else {
+			footer += " • " + uiTheme.MetaValue.Render(m.readTodayLabel())
+		}
+	}
+	if m.searchQuery != "" && m.searchMode != "" {
+		if m.nerdMode {
+			footer += " | " + m.searchModeLabel()
+		} else {
+			footer += " • " + uiTheme.MetaValue.Render(m.searchModeLabel())
+		}
+	}
+	if m.mutedCount > 0 && m.muteActive() {
+		if m.nerdMode {
+			footer += " | " + m.mutedLabel()
+		} else {
+			footer += " • " + uiTheme.MetaValue.Render(m.mutedLabel())
+		}
+	}
+	if !m.offline {
+		if m.service == nil {
+			return footer
+		}
+		if m.nerdMode {
+			return m.freshnessLabel() + " | " + footer
+		}
+		style := uiTheme.StateWarn
+		if m.refreshedThisSession {
+			style = uiTheme.StateIdle
+		}
+		return style.Render(m.freshnessLabel()) + " • " + footer
+	}
+	label := "OFFLINE"
+	if m.readOnly {
+		label = "READ-ONLY"
+	}
+	if m.nerdMode {
+		return label + " | " + footer
+	}
+	return uiTheme.StateWarn.Render(label) + " • " + footer
+}
+
+func (m Model) footerFields() string {
 	if !m.nerdMode {
 		mode := "list"
 		if m.inDetail {
 			mode = "detail"
+		} else if m.compact && m.compactUnreadFirst {
+			mode = "list, unread first"
 		}
 		return tuiview.CompactFooter(
 			mode,
-			m.filter,
+			m.filterLabel(),
 			m.page,
 			len(m.entries),
 			m.searchQuery,
 			m.searchMatchCount,
+			m.compactCounts,
 			uiTheme,
 		)
 	}
 	mode := "list"
 	if m.inDetail {
 		mode = "detail"
+	} else if m.compact && m.compactUnreadFirst {
+		mode = "list, unread first"
 	}
 	onOpen := "off"
 	if m.markReadOnOpen {
@@ -1017,9 +1959,9 @@ func (m Model) footer() string {
 	if m.confirmOpenRead {
 		confirm = "on"
 	}
-	timeFormat := "absolute"
-	if m.relativeTime {
-		timeFormat = "relative"
+	timeFormat := string(m.timeFormat)
+	if timeFormat == "" {
+		timeFormat = string(tuiview.TimeFormatAbsolute)
 	}
 	numbering := "off"
 	if m.showNumbers {
@@ -1027,7 +1969,7 @@ func (m Model) footer() string {
 	}
 	return tuiview.NerdFooter(
 		mode,
-		m.filter,
+		m.filterLabel(),
 		m.page,
 		len(m.entries),
 		m.lastFetchCount,
@@ -1078,59 +2020,95 @@ func (m Model) startupMetrics() string {
 		cachePart = fmt.Sprintf("cache %dms (%d entries)", m.cacheLoadDuration.Milliseconds(), m.cacheLoadedEntries)
 	}
 	refreshPart := "initial refresh pending"
-	if m.initialRefreshDone {
+	if m.readOnly {
+		refreshPart = "read-only, no refresh"
+	} else if m.offline {
+		refreshPart = "offline, no refresh"
+	} else if m.initialRefreshDone {
 		if m.initialRefreshFailed {
 			refreshPart = fmt.Sprintf("initial refresh failed in %dms", m.initialRefreshDuration.Milliseconds())
 		} else {
 			refreshPart = fmt.Sprintf("initial refresh %dms", m.initialRefreshDuration.Milliseconds())
 		}
+	} else if m.initRetryAttempt > 0 {
+		refreshPart = fmt.Sprintf("initial refresh retry %d/%d", m.initRetryAttempt, m.initRetryLimit)
 	}
-	return cachePart + ", " + refreshPart
+	if m.lastSyncedAt.IsZero() {
+		return cachePart + ", " + refreshPart
+	}
+	return cachePart + ", " + refreshPart + ", " + m.lastSyncedLabel()
 }
 
 func (m Model) helpView() string {
 	lines := []string{
 		"Navigation:",
-		"  j/k or arrows move, [ ] jump between sections, g/G jump top/bottom, pgup/pgdown jump page",
+		"  j/k or arrows move, [ ] jump between sections, ( ) jump to previous/next feed, { } jump to previous/next feed with unread articles, g/G jump top/bottom, pgup/pgdown jump page",
 		"Tree-style List:",
 		"  default list has Folders and Feeds sections",
-		"  left/h collapses current feed/folder, right/l expands",
+		"  left/h collapses current feed/folder, right/l expands, + shows every feed of a list cut short by FEEDBIN_TREE_FEED_LIMIT",
 		"  Section legend: ▦/■ section, ▾/▸ expandable group, indented rows are feeds/articles",
+		"  W then any key shows what that key does without running it",
 		"Modes:",
-		"  enter opens detail, esc/backspace returns to list",
+		"  enter opens detail, esc/backspace returns to list, s (detail) cycle feed content/feed summary/extracted full text, [ ] previous/next article (] twice at the end of a feed continues with the next unread feed)",
 		"Filters:",
-		"  a all, u unread, * starred, / search, n load next page",
+		"  a all, u unread, * starred, X dismissed entries, e show every age (with FEEDBIN_MAX_AGE_DAYS), / search, n load next page, f (detail) show the article's feed, ctrl+l clear search/feed",
 		"Actions:",
-		"  U toggle unread, S toggle starred, o open URL, y copy URL, r/R/ctrl+r refresh",
+		"  U toggle unread, S toggle starred (on section/folder/feed rows: mark all read, or all unread when already read; star all), ctrl+u mark a feed row read locally only (Feedbin keeps it unread), i pin/unpin a feed row at the top of the tree, m file a feed row under a new or existing folder (tab completes), ctrl+z undo last toggle, z snooze (1h/tomorrow/next week), x dismiss without marking read (restores in the dismissed view), o open URL, y copy URL (feed URL on feed rows), Y copy feed OPML, C copy the unread titles and URLs of a feed/folder row, r/R/ctrl+r refresh",
+		"  F manage subscriptions (e rename, m mute, x unsubscribe, r refresh feed, esc close)",
+		"Diagnostics:",
+		"  c (in this help) copies version, OS, terminal, chafa, search backend, cache stats and the last error for a bug report; credentials are never included",
 		"Options:",
-		"  c compact mode, N numbering, d time format, t mark-read-on-open, p confirm prompt, ctrl+l clear search, Shift+M confirm pending mark-read",
+		"  c compact mode, O unread first in compact mode, N numbering, d time format (relative/absolute/both), D date column (full/short/hidden), H compact tree, T feeds under every tag, A hide read entries in all, I incremental search, K compact counts, P page insert (full sort/merge), t mark-read-on-open, V mark read when detail opens, p confirm prompt, B confirm bulk actions, v auto-preview, L preview lines under entries (off/1/2/3), E preview source (summary/content), # read-today counter in the footer, ~ show muted entries, Z strip feed names from titles, b search backend (LIKE/FTS), ctrl+l clear search, Shift+M confirm pending mark-read or bulk action",
 	}
 	return strings.Join(lines, "\n")
 }
 
 func (m *Model) applyCurrentFilter() {
-	if m.filter == "all" && m.searchQuery == "" {
-		sortEntriesForTree(m.entries)
+	m.listStartPinned = false
+	m.dropMuted()
+	if m.filter == "all" && m.searchQuery == "" && !m.hidingRead() && !m.ageCutoffActive() {
+		m.sortEntries()
 		m.ensureCursorVisible()
 		return
 	}
+	m.entries = m.filterEntries(m.entries)
+	m.sortEntries()
+	m.ensureCursorVisible()
+}
+
+// filterEntries keeps the entries matching the active filter and search
+// query, preserving their order.
+func (m Model) filterEntries(entries []feedbin.Entry) []feedbin.Entry {
+	if m.filter == "all" && m.searchQuery == "" && !m.hidingRead() && !m.ageCutoffActive() {
+		return entries
+	}
+	hideRead := m.hidingRead()
+	ageCutoff := m.ageCutoffActive()
 	searchQuery := strings.ToLower(strings.TrimSpace(m.searchQuery))
-	filtered := make([]feedbin.Entry, 0, len(m.entries))
-	for _, entry := range m.entries {
+	scopeFeedID, scoped := feedbin.ParseFeedScope(m.filter)
+	filtered := make([]feedbin.Entry, 0, len(entries))
+	for _, entry := range entries {
+		if scoped && entry.FeedID != scopeFeedID {
+			continue
+		}
 		if m.filter == "unread" && !entry.IsUnread {
 			continue
 		}
 		if m.filter == "starred" && !entry.IsStarred {
 			continue
 		}
+		if hideRead && !entry.IsUnread && !entry.IsStarred {
+			continue
+		}
+		if ageCutoff && m.tooOld(entry) {
+			continue
+		}
 		if searchQuery != "" && !entryMatchesSearch(entry, searchQuery) {
 			continue
 		}
 		filtered = append(filtered, entry)
 	}
-	m.entries = filtered
-	sortEntriesForTree(m.entries)
-	m.ensureCursorVisible()
+	return filtered
 }
 
 func entryMatchesSearch(entry feedbin.Entry, query string) bool {
@@ -1149,8 +2127,13 @@ func entryMatchesSearch(entry feedbin.Entry, query string) bool {
 	return strings.Contains(haystack, query)
 }
 
-func folderNameForEntry(entry feedbin.Entry) string {
-	return tuitree.FolderName(entry)
+func folderNameForEntry(entry feedbin.Entry, defaultFolder string) string {
+	return tuitree.FolderNameOrDefault(entry, defaultFolder)
+}
+
+// entryFolders returns every folder the tree lists the entry under.
+func (m Model) entryFolders(entry feedbin.Entry) []string {
+	return tuitree.EntryFolders(entry, m.defaultFolder, m.tagsAsFolders)
 }
 
 func feedNameForEntry(entry feedbin.Entry) string {
@@ -1161,8 +2144,8 @@ func compactEntryLabel(entry feedbin.Entry) string {
 	return tuiview.CompactEntryLabel(entry)
 }
 
-func sortEntriesForTree(entries []feedbin.Entry) {
-	tuitree.SortEntries(entries)
+func sortEntriesForTree(entries []feedbin.Entry, defaultFolder string) {
+	tuitree.SortEntriesWithDefaultFolder(entries, defaultFolder)
 }
 
 func treeFeedKey(folder, feed string) string {
@@ -1179,37 +2162,52 @@ func (m Model) renderEntryLine(idx, visiblePos int, active bool) string {
 	if m.nowFn != nil {
 		now = m.nowFn()
 	}
+	commentCount, hasCommentCount := m.entryCommentCount(entry)
 	return tuiview.RenderEntryLine(tuiview.EntryLineParams{
-		Entry:        entry,
-		Now:          now,
-		RelativeTime: m.relativeTime,
-		Compact:      m.compact,
-		ShowNumbers:  m.showNumbers,
-		VisiblePos:   visiblePos,
-		Active:       active,
-		Selected:     entry.ID == m.selectedID,
-		Width:        m.contentWidth(),
+		Entry:           entry,
+		Now:             now,
+		TimeFormat:      m.timeFormat,
+		DateColumn:      m.dateColumn,
+		Compact:         m.compact,
+		ShowNumbers:     m.showNumbers,
+		VisiblePos:      visiblePos,
+		Active:          active,
+		Selected:        entry.ID == m.selectedID,
+		Width:           m.contentWidth(),
+		Location:        m.location,
+		Hyperlinks:      m.articleOptions.Hyperlinks,
+		ShortenURLs:     m.articleOptions.ShortenURLs,
+		Progress:        m.readProgress[entry.ID],
+		CommentCount:    commentCount,
+		HasCommentCount: hasCommentCount,
+		Stacked:         m.stackedList(),
+		StripFeedPrefix: m.stripFeedPrefix,
 	}, uiTheme)
 }
 
 func (m Model) renderTreeNodeLine(left string, unreadCount int, active bool) string {
-	return tuiview.RenderTreeNodeLine(left, unreadCount, m.contentWidth(), active, uiTheme)
+	return tuiview.RenderTreeNodeLine(left, unreadCount, m.contentWidth(), active, m.compactCounts, uiTheme)
 }
 
 func (m Model) renderSectionLine(label string, unreadCount int, active bool) string {
-	return tuiview.RenderSectionLine(label, unreadCount, m.contentWidth(), active, m.nerdIcons, uiTheme)
+	return tuiview.RenderSectionLine(label, unreadCount, m.contentWidth(), active, m.nerdIcons, m.compactCounts, uiTheme)
 }
 
 func (m Model) unreadCountsBySection() map[string]int {
 	sectionCounts := map[string]int{
-		"Folders": 0,
-		"Feeds":   0,
+		tuitree.PinnedSection: 0,
+		"Folders":             0,
+		"Feeds":               0,
 	}
 	for _, entry := range m.entries {
 		if !entry.IsUnread {
 			continue
 		}
-		if folderNameForEntry(entry) != "" {
+		if m.entryPinned(entry) {
+			sectionCounts[tuitree.PinnedSection]++
+			continue
+		}
+		if folderNameForEntry(entry, m.defaultFolder) != "" {
 			sectionCounts["Folders"]++
 			continue
 		}
@@ -1225,12 +2223,14 @@ func (m Model) unreadCountsByTreeNode() (map[string]int, map[string]int) {
 		if !entry.IsUnread {
 			continue
 		}
-		folder := folderNameForEntry(entry)
 		feed := feedNameForEntry(entry)
-		if folder != "" {
-			folderCounts[folder]++
+		for _, folder := range m.entryFolders(entry) {
+			// Pinned feeds sit in their own section, not under the folder.
+			if folder != "" && !m.entryPinned(entry) {
+				folderCounts[folder]++
+			}
+			feedCounts[tuitree.FeedCountKey(folder, feed, m.entryPinned(entry))]++
 		}
-		feedCounts[treeFeedKey(folder, feed)]++
 	}
 	return folderCounts, feedCounts
 }
@@ -1252,7 +2252,7 @@ func visibleLen(s string) int {
 }
 
 func stripANSI(s string) string {
-	return reANSICodes.ReplaceAllString(s, "")
+	return article.ANSICodes.ReplaceAllString(s, "")
 }
 
 func (m *Model) ensureCursorVisible() {
@@ -1338,6 +2338,79 @@ func (m Model) currentTreeRowIsArticle() bool {
 	return rows[m.treeCursor].Kind == treeRowArticle
 }
 
+// autoOpenMsg opens the first unread article when no startup refresh runs.
+type autoOpenMsg struct{}
+
+// autoOpenFirstUnreadEntry runs the startup auto-open once the initial load
+// has settled, whether the refresh worked or not.
+func (m Model) autoOpenFirstUnreadEntry() (Model, tea.Cmd) {
+	if !m.autoOpenFirstUnread {
+		return m, nil
+	}
+	m.autoOpenFirstUnread = false
+	if m.inDetail {
+		return m, nil
+	}
+	next, cmd := m.openFirstUnread()
+	return next.(Model), cmd
+}
+
+// watchNavigationBeforeAutoOpen handles a key while the startup auto-open is
+// still pending and cancels it once the key moves the cursor or opens an
+// article, so the auto-open never takes the view from the user.
+func (m Model) watchNavigationBeforeAutoOpen(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.autoOpenFirstUnread = false
+	treeCursor, cursor, inDetail := m.treeCursor, m.cursor, m.inDetail
+	next, cmd := m.Update(msg)
+	nextModel, ok := next.(Model)
+	if !ok {
+		return next, cmd
+	}
+	if nextModel.treeCursor == treeCursor && nextModel.cursor == cursor && nextModel.inDetail == inDetail {
+		nextModel.autoOpenFirstUnread = true
+	}
+	return nextModel, cmd
+}
+
+// openFirstUnread enters detail for the first unread article in tree order,
+// expanding the section, folder and feed holding it when they are collapsed,
+// or leaves the list in place with a status note when nothing is unread.
+func (m Model) openFirstUnread() (tea.Model, tea.Cmd) {
+	for _, row := range m.expandedTreeRows() {
+		if row.Kind != treeRowArticle || !m.entries[row.EntryIndex].IsUnread {
+			continue
+		}
+		m.revealFeed(row)
+		i := findTreeArticleRow(m.treeRows(), row)
+		if i < 0 {
+			continue
+		}
+		m.treeCursor = i
+		m.cursor = row.EntryIndex
+		m.selectedID = m.entries[m.cursor].ID
+		m.inDetail = true
+		m.resumeReadProgress()
+		m.ensureCursorVisible()
+		markCmd := m.markReadOnDetailCmd()
+		return m, tea.Batch(m.ensureInlineImagePreviewCmd(), markCmd)
+	}
+	m.status = "No unread articles to open"
+	m.statusID++
+	return m, clearStatusCmd(m.statusID, 3*time.Second)
+}
+
+// findTreeArticleRow finds the article row for the same entry and place as
+// row, or -1 when it is not shown.
+func findTreeArticleRow(rows []treeRow, row treeRow) int {
+	for i, candidate := range rows {
+		if candidate.Kind == treeRowArticle && candidate.EntryIndex == row.EntryIndex &&
+			candidate.Folder == row.Folder && candidate.Pinned == row.Pinned {
+			return i
+		}
+	}
+	return -1
+}
+
 func (m *Model) toggleCurrentTreeNode() {
 	rows := m.treeRows()
 	if len(rows) == 0 {
@@ -1347,10 +2420,10 @@ func (m *Model) toggleCurrentTreeNode() {
 	row := rows[m.treeCursor]
 	if row.Kind == treeRowSection {
 		if m.collapsedSections[row.Label] {
-			m.collapsedSections[row.Label] = false
+			m.setCollapsed(m.collapsedSections, row.Label, false)
 			m.status = "Expanded section: " + row.Label
 		} else {
-			m.collapsedSections[row.Label] = true
+			m.setCollapsed(m.collapsedSections, row.Label, true)
 			m.status = "Collapsed section: " + row.Label
 		}
 		m.ensureCursorVisible()
@@ -1359,21 +2432,27 @@ func (m *Model) toggleCurrentTreeNode() {
 	switch row.Kind {
 	case treeRowFolder:
 		if m.collapsedFolders[row.Folder] {
-			m.collapsedFolders[row.Folder] = false
+			m.setCollapsed(m.collapsedFolders, row.Folder, false)
 			m.status = "Expanded folder: " + row.Folder
 		} else {
-			m.collapsedFolders[row.Folder] = true
+			m.setCollapsed(m.collapsedFolders, row.Folder, true)
 			m.status = "Collapsed folder: " + row.Folder
 		}
 	case treeRowFeed:
 		key := treeFeedKey(row.Folder, row.Feed)
 		if m.collapsedFeeds[key] {
-			m.collapsedFeeds[key] = false
+			m.setCollapsed(m.collapsedFeeds, key, false)
 			m.status = "Expanded feed: " + row.Feed
 		} else {
-			m.collapsedFeeds[key] = true
+			m.setCollapsed(m.collapsedFeeds, key, true)
 			m.status = "Collapsed feed: " + row.Feed
 		}
+	case treeRowMore:
+		m.setCollapsed(m.expandedFeedLists, row.Folder, true)
+		m.status = "Showing every feed"
+		if row.Folder != "" {
+			m.status += " in " + row.Folder
+		}
 	}
 	m.ensureCursorVisible()
 }
@@ -1390,28 +2469,27 @@ func (m *Model) collapseCurrentTreeNode() {
 	row := rows[m.treeCursor]
 	if row.Kind == treeRowSection {
 		if !m.collapsedSections[row.Label] {
-			m.collapsedSections[row.Label] = true
+			m.setCollapsed(m.collapsedSections, row.Label, true)
 			m.status = "Collapsed section: " + row.Label
 		}
 		return
 	}
+	// Article rows carry their own folder, which matters when a feed is listed
+	// under several tags.
 	folder := row.Folder
 	feed := row.Feed
-	if row.Kind == treeRowArticle {
-		entry := m.entries[row.EntryIndex]
-		folder = folderNameForEntry(entry)
-		feed = feedNameForEntry(entry)
-	}
 	feedKey := treeFeedKey(folder, feed)
-	if feed != "" && !m.collapsedFeeds[feedKey] {
-		m.collapsedFeeds[feedKey] = true
+	// Headers hidden by the compact tree cannot be collapsed.
+	if feed != "" && !m.collapsedFeeds[feedKey] && m.hasTreeRow(treeRowFeed, folder, feed) {
+		m.setCollapsed(m.collapsedFeeds, feedKey, true)
 		m.status = "Collapsed feed: " + feed
 		m.setTreeCursorToFeed(folder, feed)
 		m.ensureCursorVisible()
 		return
 	}
-	if folder != "" && !m.collapsedFolders[folder] {
-		m.collapsedFolders[folder] = true
+	// Pinned feeds have no folder header above them.
+	if folder != "" && !row.Pinned && !m.collapsedFolders[folder] && m.hasTreeRow(treeRowFolder, folder, "") {
+		m.setCollapsed(m.collapsedFolders, folder, true)
 		m.status = "Collapsed folder: " + folder
 		m.setTreeCursorToFolder(folder)
 		m.ensureCursorVisible()
@@ -1440,6 +2518,16 @@ func (m *Model) setTreeCursorToFeed(folder, feed string) {
 	}
 }
 
+// hasTreeRow reports whether the visible tree has a folder or feed header.
+func (m Model) hasTreeRow(kind tuitree.RowKind, folder, feed string) bool {
+	for _, row := range m.treeRows() {
+		if row.Kind == kind && row.Folder == folder && (kind == treeRowFolder || row.Feed == feed) {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *Model) setTreeCursorToFolder(folder string) {
 	rows := m.treeRows()
 	for i, row := range rows {
@@ -1473,29 +2561,26 @@ func (m *Model) expandCurrentTreeNode() {
 	row := rows[m.treeCursor]
 	if row.Kind == treeRowSection {
 		if m.collapsedSections[row.Label] {
-			m.collapsedSections[row.Label] = false
+			m.setCollapsed(m.collapsedSections, row.Label, false)
 			m.status = "Expanded section: " + row.Label
 		}
 		m.ensureCursorVisible()
 		return
 	}
+	// Article rows carry their own folder, which matters when a feed is listed
+	// under several tags.
 	folder := row.Folder
 	feed := row.Feed
-	if row.Kind == treeRowArticle {
-		entry := m.entries[row.EntryIndex]
-		folder = folderNameForEntry(entry)
-		feed = feedNameForEntry(entry)
-	}
 	feedKey := treeFeedKey(folder, feed)
-	if folder != "" && m.collapsedFolders[folder] {
-		m.collapsedFolders[folder] = false
+	if folder != "" && !row.Pinned && m.collapsedFolders[folder] {
+		m.setCollapsed(m.collapsedFolders, folder, false)
 		m.status = "Expanded folder: " + folder
 		m.setTreeCursorToFeed(folder, "")
 		m.ensureCursorVisible()
 		return
 	}
 	if feed != "" && m.collapsedFeeds[feedKey] {
-		m.collapsedFeeds[feedKey] = false
+		m.setCollapsed(m.collapsedFeeds, feedKey, false)
 		m.status = "Expanded feed: " + feed
 		m.setTreeCursorToFirstArticle(folder, feed)
 		m.ensureCursorVisible()
@@ -1536,7 +2621,7 @@ func (m *Model) expandNextCollapsedFolder(preferred string) bool {
 		}
 	}
 
-	m.collapsedFolders[target] = false
+	m.setCollapsed(m.collapsedFolders, target, false)
 	m.status = "Expanded folder: " + target
 	return true
 }
@@ -1551,13 +2636,47 @@ const (
 	treeRowArticle treeRowKind = tuitree.RowArticle
 )
 
+// treeRows returns the visible tree, rebuilding it only when the entries,
+// collapse state, layout or default folder changed since the last call.
+// Callers must not modify the returned slice.
 func (m Model) treeRows() []treeRow {
-	return tuitree.BuildRows(m.entries, tuitree.BuildOptions{
+	key := m.treeRowsKey()
+	if m.treeCache != nil && m.treeCache.valid && m.treeCache.key == key {
+		return m.treeCache.rows
+	}
+	rows := tuitree.BuildRows(m.entries, m.treeBuildOptions())
+	if m.treeCache != nil {
+		*m.treeCache = treeRowsCache{valid: true, key: key, rows: rows}
+	}
+	return rows
+}
+
+func (m Model) treeBuildOptions() tuitree.BuildOptions {
+	return tuitree.BuildOptions{
 		Compact:           m.compact,
 		CollapsedFolders:  m.collapsedFolders,
 		CollapsedFeeds:    m.collapsedFeeds,
 		CollapsedSections: m.collapsedSections,
-	})
+		DefaultFolder:     m.defaultFolder,
+
+		OmitRedundantHeaders: m.compactTree,
+		TagsAsFolders:        m.tagsAsFolders,
+		UnreadFirst:          m.compactUnreadFirst,
+		FeedLimit:            m.feedLimit,
+		FeedOrder:            m.feedOrder,
+		ExpandedFeedLists:    m.expandedFeedLists,
+		PinnedFeeds:          m.pinnedFeeds,
+	}
+}
+
+// expandedTreeRows builds the tree as if no section, folder or feed were
+// collapsed, giving every article row its place in tree order.
+func (m Model) expandedTreeRows() []treeRow {
+	options := m.treeBuildOptions()
+	options.CollapsedFolders = nil
+	options.CollapsedFeeds = nil
+	options.CollapsedSections = nil
+	return tuitree.BuildRows(m.entries, options)
 }
 
 func firstArticleRow(rows []treeRow) int {
@@ -1584,7 +2703,7 @@ func (m *Model) expandNextCollapsedFeed(preferred string) bool {
 		}
 	}
 
-	m.collapsedFeeds[target] = false
+	m.setCollapsed(m.collapsedFeeds, target, false)
 	_, feed := splitTreeFeedKey(target)
 	m.status = "Expanded feed: " + feed
 	return true
@@ -1608,8 +2727,26 @@ func (m Model) detailHorizontalMargin() int {
 	return 6
 }
 
+// SetArticleIndent indents the detail view n more columns from the left
+// edge; the text wraps that much narrower so lines still fit.
+func (m *Model) SetArticleIndent(n int) {
+	m.articleIndent = max(n, 0)
+}
+
+// SetStackedListWidth stacks list entries over two lines, title then feed
+// and date, in terminals narrower than width columns; zero never stacks.
+func (m *Model) SetStackedListWidth(width int) {
+	m.stackedListWidth = max(width, 0)
+}
+
+// stackedList reports whether the terminal is too narrow for one-line
+// entries.
+func (m Model) stackedList() bool {
+	return m.width > 0 && m.width < m.stackedListWidth
+}
+
 func (m Model) detailContentWidth() int {
-	width := m.contentWidth() - (2 * m.detailHorizontalMargin())
+	width := m.contentWidth() - (2 * m.detailHorizontalMargin()) - m.articleIndent
 	if width < 20 {
 		return 20
 	}
@@ -1634,6 +2771,9 @@ func (m Model) listBodyHeight() int {
 	if m.searchInputMode || m.searchQuery != "" {
 		usedByHeader += 2
 	}
+	if m.autoPreviewVisible() {
+		usedByHeader += autoPreviewMaxLines + 1
+	}
 	if m.height > 0 {
 		if h := m.height - usedByHeader; h > 3 {
 			return h
@@ -1682,12 +2822,42 @@ func (m Model) listWindow(rows []treeRow) (int, int, int) {
 		return 0, 0, 0
 	}
 
-	height := m.listBodyHeight()
-	start, end := tuistate.CenteredWindow(len(rows), m.treeCursor, height)
+	start, end, pinned := m.pinnedListWindow(len(rows))
+	switch {
+	case pinned:
+	case m.variableRowHeights():
+		start, end = m.listWindowWithPreviews(rows)
+	default:
+		start, end = tuistate.CenteredWindow(len(rows), m.treeCursor, m.listBodyHeight())
+	}
 	visiblePos := tuistate.ArticleRowsBefore(rows, start)
 	return start, end, visiblePos
 }
 
+// pinnedListWindow is the window set by pinListWindow, if it still holds the
+// cursor.
+func (m Model) pinnedListWindow(totalRows int) (int, int, bool) {
+	if !m.listStartPinned || m.variableRowHeights() {
+		return 0, 0, false
+	}
+	height := m.listBodyHeight()
+	start := m.pinnedListStart
+	if start > totalRows-height {
+		start = totalRows - height
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + height
+	if end > totalRows {
+		end = totalRows
+	}
+	if m.treeCursor < start || m.treeCursor >= end {
+		return 0, 0, false
+	}
+	return start, end, true
+}
+
 func wrapText(text string, width int) []string {
 	if width < 1 {
 		return []string{text}
@@ -1755,11 +2925,14 @@ func (m *Model) ensureInlineImagePreviewCmd() tea.Cmd {
 		return nil
 	}
 	entry := m.entries[m.cursor]
+	if m.imageQueue != nil {
+		m.imageQueue.dropExcept(entry.ID)
+	}
 	if strings.TrimSpace(entry.Content) == "" {
 		return nil
 	}
-	imageURLs := article.ImageURLsFromContent(entry.Content)
-	if len(imageURLs) == 0 {
+	imageURL, _ := article.PreviewImage(entry.Content, m.imageChoice)
+	if imageURL == "" {
 		return nil
 	}
 	if _, ok := m.imagePreview[entry.ID]; ok {
@@ -1769,7 +2942,16 @@ func (m *Model) ensureInlineImagePreviewCmd() tea.Cmd {
 		return nil
 	}
 	m.imagePreviewLoading[entry.ID] = true
-	return inlineImagePreviewCmd(entry.ID, imageURLs[0], m.detailContentWidth(), m.renderImageFn)
+	return queuedInlineImagePreviewCmd(m.imageQueue, entry.ID, imageURL, m.detailContentWidth(), m.renderImageFn)
+}
+
+// previewImageAnchor is the image anchor the preview of entry is drawn at.
+func (m Model) previewImageAnchor(entry feedbin.Entry) int {
+	if !m.inlineImagePreview {
+		return 0
+	}
+	_, anchor := article.PreviewImage(entry.Content, m.imageChoice)
+	return anchor
 }
 
 func inlineImagePreviewCmd(entryID int64, imageURL string, width int, renderFn func(string, int) (string, error)) tea.Cmd {
@@ -1815,26 +2997,231 @@ func (m *Model) ApplyPreferences(prefs Preferences) {
 	m.compact = prefs.Compact
 	m.markReadOnOpen = prefs.MarkReadOnOpen
 	m.confirmOpenRead = prefs.ConfirmOpenRead
-	m.relativeTime = prefs.RelativeTime
+	// An unknown or empty format shows absolute dates, as a false
+	// RelativeTime did before the combined format existed.
+	switch format := tuiview.TimeFormat(prefs.TimeFormat); format {
+	case tuiview.TimeFormatRelative, tuiview.TimeFormatBoth:
+		m.timeFormat = format
+	default:
+		m.timeFormat = tuiview.TimeFormatAbsolute
+	}
 	m.showNumbers = prefs.ShowNumbers
+	m.autoPreview = prefs.AutoPreview
+	m.confirmBulkActions = prefs.ConfirmBulkActions
+	m.incrementalSearch = prefs.IncrementalSearch
+	m.compactCounts = prefs.CompactCounts
+	m.mergePages = prefs.MergePages
+	m.compactTree = prefs.CompactTree
+	m.tagsAsFolders = prefs.TagsAsFolders
+	m.compactUnreadFirst = prefs.CompactUnreadFirst
+	m.previewLines = prefs.PreviewLines
+	if m.previewLines < 0 || m.previewLines > maxPreviewLines {
+		m.previewLines = 0
+	}
+	m.preferSummary = prefs.PreferSummary
+	m.showReadToday = prefs.ShowReadToday
+	m.markReadOnDetailOpen = prefs.MarkReadOnDetailOpen
+	m.stripFeedPrefix = prefs.StripFeedPrefix
+	if prefs.PreviewSource == previewSourceContent {
+		m.previewSource = previewSourceContent
+	} else {
+		m.previewSource = previewSourceSummary
+	}
+	if prefs.HideRead != m.hideRead {
+		m.hideRead = prefs.HideRead
+		m.applyCurrentFilter()
+	}
+	switch column := tuiview.DateColumn(prefs.DateColumn); column {
+	case tuiview.DateColumnFull, tuiview.DateColumnShort, tuiview.DateColumnHidden:
+		m.dateColumn = column
+	default:
+		m.dateColumn = tuiview.DateColumnFull
+	}
 }
 
 func (m *Model) SetPreferencesSaver(saveFn func(Preferences) error) {
 	m.savePreferencesFn = saveFn
 }
 
+func (m *Model) SetFeedManager(manager FeedManager) {
+	m.feedManager = manager
+}
+
+// SetPreferCanonicalURL makes o and y use the canonical URL resolved from
+// the entry instead of its AMP or feed-proxy URL.
+func (m *Model) SetPreferCanonicalURL(enabled bool) {
+	m.preferCanonicalURL = enabled
+}
+
+func (m *Model) SetSnoozer(snoozer Snoozer) {
+	m.snoozer = snoozer
+}
+
 func (m *Model) SetArticleOptions(opts article.Options) {
 	m.articleOptions = opts
 }
 
+// renderOptions is the article options with the session's summary/content
+// choice applied.
+func (m Model) renderOptions() article.Options {
+	opts := m.articleOptions
+	opts.PreferSummary = m.preferSummary
+	return opts
+}
+
+// togglePreferSummary flips the detail view between an entry's summary and
+// its full content and keeps the choice as the default.
+func (m Model) togglePreferSummary() (tea.Model, tea.Cmd) {
+	m.preferSummary = !m.preferSummary
+	m.detailTop = 0
+	m.err = nil
+	if m.preferSummary {
+		m.status = "Article text: summary when available"
+	} else {
+		m.status = "Article text: full content"
+	}
+	return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
+}
+
 func (m Model) preferences() Preferences {
 	return Preferences{
-		Compact:         m.compact,
-		MarkReadOnOpen:  m.markReadOnOpen,
-		ConfirmOpenRead: m.confirmOpenRead,
-		RelativeTime:    m.relativeTime,
-		ShowNumbers:     m.showNumbers,
+		Compact:              m.compact,
+		MarkReadOnOpen:       m.markReadOnOpen,
+		ConfirmOpenRead:      m.confirmOpenRead,
+		TimeFormat:           string(m.timeFormat),
+		ShowNumbers:          m.showNumbers,
+		AutoPreview:          m.autoPreview,
+		ConfirmBulkActions:   m.confirmBulkActions,
+		DateColumn:           string(m.dateColumn),
+		IncrementalSearch:    m.incrementalSearch,
+		CompactCounts:        m.compactCounts,
+		MergePages:           m.mergePages,
+		CompactTree:          m.compactTree,
+		TagsAsFolders:        m.tagsAsFolders,
+		HideRead:             m.hideRead,
+		CompactUnreadFirst:   m.compactUnreadFirst,
+		PreviewLines:         m.previewLines,
+		PreviewSource:        m.previewSource,
+		PreferSummary:        m.preferSummary,
+		ShowReadToday:        m.showReadToday,
+		MarkReadOnDetailOpen: m.markReadOnDetailOpen,
+		StripFeedPrefix:      m.stripFeedPrefix,
+	}
+}
+
+// ViewState captures the current position and collapsed tree nodes.
+func (m Model) ViewState() ViewState {
+	collapsed := collapseState{folders: m.collapsedFolders, feeds: m.collapsedFeeds, sections: m.collapsedSections}
+	if m.searchCollapse != nil {
+		collapsed = *m.searchCollapse
+	}
+	return ViewState{
+		LastEntryID:       m.anchorEntryID(),
+		Filter:            m.filter,
+		CollapsedFolders:  collapsedKeys(collapsed.folders),
+		CollapsedFeeds:    collapsedKeys(collapsed.feeds),
+		CollapsedSections: collapsedKeys(collapsed.sections),
+	}
+}
+
+// ApplyViewState restores a saved position. Unknown filters are ignored and
+// a missing entry leaves the cursor where it is.
+func (m *Model) ApplyViewState(state ViewState) {
+	for _, key := range state.CollapsedFolders {
+		m.setCollapsed(m.collapsedFolders, key, true)
+	}
+	for _, key := range state.CollapsedFeeds {
+		m.setCollapsed(m.collapsedFeeds, key, true)
+	}
+	for _, key := range state.CollapsedSections {
+		m.setCollapsed(m.collapsedSections, key, true)
+	}
+	switch state.Filter {
+	case "all", "unread", "starred":
+		m.filter = state.Filter
+	}
+	m.applyCurrentFilter()
+	m.restoreSelection(state.LastEntryID)
+}
+
+func collapsedKeys(collapsed map[string]bool) []string {
+	keys := make([]string, 0, len(collapsed))
+	for key, ok := range collapsed {
+		if ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// autoPreviewMaxLines caps the inline preview shown beneath the list.
+const autoPreviewMaxLines = 4
+
+// hoveredEntryID returns the article under the tree cursor, or 0 when the
+// cursor rests on a section, folder or feed row.
+func (m Model) hoveredEntryID() int64 {
+	if !m.currentTreeRowIsArticle() || m.cursor < 0 || m.cursor >= len(m.entries) {
+		return 0
+	}
+	return m.entries[m.cursor].ID
+}
+
+// trackAutoPreview dismisses the preview when the hovered article changes and
+// schedules a debounced tick to show it for the new one. Stale ticks are
+// ignored via previewSeq.
+func (m Model) trackAutoPreview(previous int64, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	if !m.autoPreview {
+		m.previewEntryID = 0
+		m.previewPendingID = 0
+		return m, cmd
+	}
+	current := m.hoveredEntryID()
+	if current == previous && current != 0 && (m.previewEntryID == current || m.previewPendingID == current) {
+		return m, cmd
+	}
+	m.previewSeq++
+	m.previewEntryID = 0
+	m.previewPendingID = current
+	if current == 0 {
+		return m, cmd
+	}
+	return m, tea.Batch(cmd, autoPreviewCmd(m.previewSeq, current, m.autoPreviewDelay))
+}
+
+func autoPreviewCmd(seq int, entryID int64, after time.Duration) tea.Cmd {
+	return tea.Tick(after, func(time.Time) tea.Msg {
+		return autoPreviewMsg{seq: seq, entryID: entryID}
+	})
+}
+
+func (m Model) autoPreviewVisible() bool {
+	return m.autoPreview && !m.inDetail && !m.loading && m.previewEntryID != 0 && m.hoveredEntryID() == m.previewEntryID
+}
+
+// autoPreviewLines renders the first few non-blank lines of the hovered
+// article. It only uses cached content: no network or image fetches.
+func (m Model) autoPreviewLines() []string {
+	if !m.autoPreviewVisible() {
+		return nil
+	}
+	idx := tuistate.EntryIndexByID(m.entries, m.previewEntryID)
+	if idx < 0 {
+		return nil
+	}
+	opts := m.renderOptions()
+	opts.MaxLines = autoPreviewMaxLines * 4
+	lines := make([]string, 0, autoPreviewMaxLines)
+	for _, line := range article.ContentLinesWithOptions(m.entries[idx], m.contentWidth()-2, opts) {
+		if strings.TrimSpace(stripANSI(line)) == "" {
+			continue
+		}
+		lines = append(lines, "  "+line)
+		if len(lines) == autoPreviewMaxLines {
+			break
+		}
 	}
+	return lines
 }
 
 func (m *Model) SetStartupCacheStats(duration time.Duration, entries int) {
@@ -97,6 +97,14 @@ func TestModelUpdate_HandlesAllActionMessageTypes(t *testing.T) {
 				Status:      "Starred entry",
 			},
 		},
+		{
+			name: "snooze success",
+			msg: tuiactions.SnoozeSuccessMsg{
+				EntryID: 1,
+				WakeAt:  now.Add(time.Hour),
+				Status:  "Snoozed 1h",
+			},
+		},
 		{
 			name: "toggle action error",
 			msg:  tuiactions.ToggleActionErrorMsg{Err: assertErr("toggle failed")},
@@ -4,13 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"reflect"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/glabrego/reeder-cli/internal/feedbin"
+	tuiactions "github.com/glabrego/reeder-cli/internal/tui/actions"
+	tuistate "github.com/glabrego/reeder-cli/internal/tui/state"
+	tuitheme "github.com/glabrego/reeder-cli/internal/tui/theme"
+	tuiview "github.com/glabrego/reeder-cli/internal/tui/view"
 )
 
 type fakeRefresher struct {
@@ -19,6 +28,11 @@ type fakeRefresher struct {
 	unreadResult bool
 	starResult   bool
 	pageResults  map[int][]feedbin.Entry
+	warning      string
+}
+
+func (f fakeRefresher) SyncWarning() string {
+	return f.warning
 }
 
 func (f fakeRefresher) Refresh(context.Context, int, int) ([]feedbin.Entry, error) {
@@ -50,6 +64,15 @@ func (f fakeRefresher) ListCachedByFilter(_ context.Context, _ int, filter strin
 		}
 		return out, nil
 	default:
+		if feedID, ok := feedbin.ParseFeedScope(filter); ok {
+			out := make([]feedbin.Entry, 0, len(f.entries))
+			for _, entry := range f.entries {
+				if entry.FeedID == feedID {
+					out = append(out, entry)
+				}
+			}
+			return out, nil
+		}
 		return f.entries, nil
 	}
 }
@@ -102,9 +125,28 @@ func (f fakeRefresher) ToggleStarred(context.Context, int64, bool) (bool, error)
 	return f.starResult, nil
 }
 
+func (f fakeRefresher) SetEntriesUnread(_ context.Context, entryIDs []int64, _ bool) ([]int64, []int64, error) {
+	if f.err != nil {
+		return nil, entryIDs, f.err
+	}
+	return entryIDs, nil, nil
+}
+
+func (f fakeRefresher) SetEntriesStarred(_ context.Context, entryIDs []int64, _ bool) ([]int64, []int64, error) {
+	if f.err != nil {
+		return nil, entryIDs, f.err
+	}
+	return entryIDs, nil, nil
+}
+
 type openWorkflowService struct {
 	unreadResult bool
 	unreadCalls  int
+	batchIDs     []int64
+}
+
+func (s *openWorkflowService) SyncWarning() string {
+	return ""
 }
 
 func (s *openWorkflowService) Refresh(context.Context, int, int) ([]feedbin.Entry, error) {
@@ -132,12 +174,25 @@ func (s *openWorkflowService) ToggleStarred(context.Context, int64, bool) (bool,
 	return false, nil
 }
 
+func (s *openWorkflowService) SetEntriesUnread(_ context.Context, entryIDs []int64, _ bool) ([]int64, []int64, error) {
+	s.batchIDs = append([]int64(nil), entryIDs...)
+	return entryIDs, nil, nil
+}
+
+func (s *openWorkflowService) SetEntriesStarred(_ context.Context, entryIDs []int64, _ bool) ([]int64, []int64, error) {
+	return entryIDs, nil, nil
+}
+
 type initRefreshService struct {
 	called  bool
 	page    int
 	perPage int
 }
 
+func (s *initRefreshService) SyncWarning() string {
+	return ""
+}
+
 func (s *initRefreshService) Refresh(_ context.Context, page, perPage int) ([]feedbin.Entry, error) {
 	s.called = true
 	s.page = page
@@ -165,6 +220,14 @@ func (s *initRefreshService) ToggleStarred(context.Context, int64, bool) (bool,
 	return false, nil
 }
 
+func (s *initRefreshService) SetEntriesUnread(_ context.Context, entryIDs []int64, _ bool) ([]int64, []int64, error) {
+	return entryIDs, nil, nil
+}
+
+func (s *initRefreshService) SetEntriesStarred(_ context.Context, entryIDs []int64, _ bool) ([]int64, []int64, error) {
+	return entryIDs, nil, nil
+}
+
 func TestSortEntriesForTree_GroupsByFolderThenFeed(t *testing.T) {
 	entries := []feedbin.Entry{
 		{ID: 1, FeedTitle: "Z Feed", FeedFolder: "Folder B", URL: "https://b.example.com/a", PublishedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
@@ -172,13 +235,36 @@ func TestSortEntriesForTree_GroupsByFolderThenFeed(t *testing.T) {
 		{ID: 3, FeedTitle: "A Feed", FeedFolder: "Folder A", URL: "https://a.example.com/b", PublishedAt: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)},
 	}
 
-	sortEntriesForTree(entries)
+	sortEntriesForTree(entries, "")
 
 	if entries[0].ID != 3 || entries[1].ID != 2 || entries[2].ID != 1 {
 		t.Fatalf("unexpected sort order: %+v", []int64{entries[0].ID, entries[1].ID, entries[2].ID})
 	}
 }
 
+func TestModelSetDefaultFolder_UnifiesTreeIntoFolders(t *testing.T) {
+	now := time.Date(2026, 2, 11, 16, 0, 0, 0, time.UTC)
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Tagged", FeedTitle: "Race", FeedFolder: "Formula 1", IsUnread: true, PublishedAt: now},
+		{ID: 2, Title: "Untagged", FeedTitle: "Top Feed", IsUnread: true, PublishedAt: now},
+	}
+	m := NewModel(nil, entries)
+	m.SetDefaultFolder("Uncategorized")
+
+	for _, row := range m.treeRows() {
+		if row.Kind == treeRowSection && row.Label == "Feeds" {
+			t.Fatalf("expected no Feeds section with default folder, got %+v", m.treeRows())
+		}
+	}
+	if got := m.unreadCountsBySection(); got["Folders"] != 2 || got["Feeds"] != 0 {
+		t.Fatalf("expected all unread counted under Folders, got %+v", got)
+	}
+	folderCounts, _ := m.unreadCountsByTreeNode()
+	if folderCounts["Uncategorized"] != 1 {
+		t.Fatalf("expected Uncategorized folder unread count, got %+v", folderCounts)
+	}
+}
+
 func TestModelInit_RefreshesInBackgroundWithDefaultPageSize(t *testing.T) {
 	service := &initRefreshService{}
 	m := NewModel(service, []feedbin.Entry{{ID: 1, Title: "Cached", PublishedAt: time.Now().UTC()}})
@@ -187,8 +273,11 @@ func TestModelInit_RefreshesInBackgroundWithDefaultPageSize(t *testing.T) {
 	if cmd == nil {
 		t.Fatal("expected init refresh command")
 	}
-	msg := cmd()
-	updated, _ := m.Update(msg)
+	updated, cmd := m.Update(cmd())
+	if !updated.(Model).refreshing || cmd == nil {
+		t.Fatal("expected the startup refresh to start and count as running")
+	}
+	updated, _ = updated.Update(cmd())
 	model := updated.(Model)
 
 	if !service.called {
@@ -208,6 +297,321 @@ func TestModelInit_RefreshesInBackgroundWithDefaultPageSize(t *testing.T) {
 	}
 }
 
+func TestModelUpdate_RefreshSuccessShowsSyncWarning(t *testing.T) {
+	m := NewModel(fakeRefresher{}, nil)
+	entries := []feedbin.Entry{{ID: 1, Title: "One", FeedTitle: "Feed A", PublishedAt: time.Now().UTC()}}
+
+	updated, _ := m.Update(tuiactions.RefreshSuccessMsg{Entries: entries, Source: "manual", Warning: "folders not updated: timeout"})
+	got := updated.(Model)
+	if got.status != "Refreshed with warning: folders not updated: timeout" {
+		t.Fatalf("unexpected status %q", got.status)
+	}
+	if len(got.entries) != 1 || got.err != nil {
+		t.Fatalf("expected refresh to apply despite warning, entries=%d err=%v", len(got.entries), got.err)
+	}
+}
+
+func TestModelTimeFormat_DCyclesToBothInListAndDetail(t *testing.T) {
+	now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+	entry := feedbin.Entry{ID: 1, Title: "Combined", FeedTitle: "Feed", PublishedAt: now.Add(-2 * time.Hour)}
+	m := NewModel(fakeRefresher{}, []feedbin.Entry{entry})
+	m.nowFn = func() time.Time { return now }
+	m.width = 80
+	m.height = 20
+
+	var updated tea.Model = m
+	for _, want := range []string{"absolute", "both"} {
+		updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+		if status := updated.(Model).status; status != "Time format: "+want {
+			t.Fatalf("expected %q, got %q", "Time format: "+want, status)
+		}
+	}
+	model := updated.(Model)
+	if got := model.preferences().TimeFormat; got != "both" {
+		t.Fatalf("expected both persisted, got %q", got)
+	}
+	if view := stripANSI(model.View()); !strings.Contains(view, "[2h ago (Feb 10)]") {
+		t.Fatalf("expected combined date in the list, got:\n%s", view)
+	}
+	header := strings.Join(model.detailLines(entry), "\n")
+	if !strings.Contains(header, "Date: 2026-02-10T10:00:00Z (2 hours ago)") {
+		t.Fatalf("expected combined date in the detail header, got:\n%s", header)
+	}
+}
+
+func TestModel_NewEntryBellRingsOnlyForAutoRefreshArrivals(t *testing.T) {
+	now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+	var rung []int
+	m := NewModel(fakeRefresher{}, nil)
+	m.nowFn = func() time.Time { return now }
+	m.bellFn = func(newEntries int) error {
+		rung = append(rung, newEntries)
+		return nil
+	}
+
+	initial := []feedbin.Entry{{ID: 10}, {ID: 11}}
+	if cmd := m.noteNewEntries(initial, "init"); cmd != nil {
+		t.Fatal("expected no bell on the initial load")
+	}
+	if cmd := m.noteNewEntries(initial, "auto"); cmd != nil {
+		t.Fatal("expected no bell when nothing new arrived")
+	}
+	cmd := m.noteNewEntries([]feedbin.Entry{{ID: 13}, {ID: 12}, {ID: 11}, {ID: 5}}, "auto")
+	if cmd == nil {
+		t.Fatal("expected a bell for new arrivals")
+	}
+	cmd()
+	if len(rung) != 1 || rung[0] != 2 {
+		t.Fatalf("expected one bell for 2 new entries, got %v", rung)
+	}
+
+	now = now.Add(30 * time.Second)
+	if cmd := m.noteNewEntries([]feedbin.Entry{{ID: 14}}, "auto"); cmd != nil {
+		t.Fatal("expected bells within a minute to be throttled")
+	}
+	now = now.Add(time.Minute)
+	if cmd := m.noteNewEntries([]feedbin.Entry{{ID: 15}}, "manual"); cmd != nil {
+		t.Fatal("expected manual refreshes not to ring")
+	}
+	if cmd := m.noteNewEntries([]feedbin.Entry{{ID: 16}}, "auto"); cmd == nil {
+		t.Fatal("expected a bell once the throttle passed")
+	}
+	if m.newestSeenID != 16 {
+		t.Fatalf("expected newest seen ID 16, got %d", m.newestSeenID)
+	}
+}
+
+func TestModel_AutoRefreshTickSkipsWhileRefreshing(t *testing.T) {
+	m := NewModel(fakeRefresher{entries: []feedbin.Entry{{ID: 1, Title: "One"}}}, nil)
+	m.SetAutoRefresh(5 * time.Minute)
+
+	m.refreshing = true
+	updated, cmd := m.Update(autoRefreshMsg{})
+	if cmd == nil {
+		t.Fatal("expected the next tick to be scheduled")
+	}
+	if got := updated.(Model); !got.refreshing {
+		t.Fatal("expected the running refresh to stay in charge")
+	}
+
+	m.refreshing = false
+	updated, cmd = m.Update(autoRefreshMsg{})
+	if !updated.(Model).refreshing || cmd == nil {
+		t.Fatal("expected an auto-refresh to start")
+	}
+
+	failed, _ := updated.(Model).Update(tuiactions.RefreshErrorMsg{Err: errors.New("offline"), Source: "auto"})
+	got := failed.(Model)
+	if got.refreshing || got.err != nil || got.status != "Auto-refresh failed: offline" {
+		t.Fatalf("expected auto-refresh failure as status only, refreshing=%v err=%v status=%q", got.refreshing, got.err, got.status)
+	}
+}
+
+func TestQuietHours_Contains(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 1, hour, minute, 0, 0, time.UTC)
+	}
+	overnight, err := ParseQuietHours("22:00-07:00")
+	if err != nil {
+		t.Fatalf("ParseQuietHours returned error: %v", err)
+	}
+	daytime, err := ParseQuietHours(" 09:30 - 17:00 ")
+	if err != nil {
+		t.Fatalf("ParseQuietHours returned error: %v", err)
+	}
+	cases := []struct {
+		name  string
+		hours QuietHours
+		t     time.Time
+		want  bool
+	}{
+		{"overnight start", overnight, at(22, 0), true},
+		{"overnight before midnight", overnight, at(23, 59), true},
+		{"overnight after midnight", overnight, at(0, 30), true},
+		{"overnight last minute", overnight, at(6, 59), true},
+		{"overnight end", overnight, at(7, 0), false},
+		{"overnight afternoon", overnight, at(15, 0), false},
+		{"daytime inside", daytime, at(12, 0), true},
+		{"daytime before", daytime, at(9, 29), false},
+		{"daytime night", daytime, at(23, 0), false},
+		{"unset", QuietHours{}, at(23, 0), false},
+	}
+	for _, tc := range cases {
+		if got := tc.hours.Contains(tc.t); got != tc.want {
+			t.Fatalf("%s: Contains(%s) = %v, want %v", tc.name, tc.t.Format("15:04"), got, tc.want)
+		}
+	}
+
+	for _, raw := range []string{"22:00", "25:00-07:00", "22:00-22:00", "late-early"} {
+		if _, err := ParseQuietHours(raw); err == nil {
+			t.Fatalf("expected %q to be rejected", raw)
+		}
+	}
+}
+
+func TestModel_QuietHoursHoldBackAutoRefreshAndBell(t *testing.T) {
+	m := NewModel(fakeRefresher{entries: []feedbin.Entry{{ID: 1, Title: "One"}}}, nil)
+	m.SetAutoRefresh(5 * time.Minute)
+	rang := 0
+	m.bellFn = func(int) error { rang++; return nil }
+	quiet, _ := ParseQuietHours("22:00-07:00")
+	m.SetQuietHours(quiet)
+	m.SetLocation(time.UTC)
+	now := time.Date(2026, 3, 1, 23, 30, 0, 0, time.UTC)
+	m.nowFn = func() time.Time { return now }
+
+	updated, cmd := m.Update(autoRefreshMsg{})
+	if cmd == nil || updated.(Model).refreshing {
+		t.Fatal("expected only the next tick during quiet hours")
+	}
+	m.noteNewEntries([]feedbin.Entry{{ID: 10}}, "auto")
+	if cmd := m.noteNewEntries([]feedbin.Entry{{ID: 11}}, "auto"); cmd != nil {
+		t.Fatal("expected no bell during quiet hours")
+	}
+
+	now = time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC)
+	updated, _ = m.Update(autoRefreshMsg{})
+	if !updated.(Model).refreshing {
+		t.Fatal("expected auto-refresh to resume after quiet hours")
+	}
+	if cmd := m.noteNewEntries([]feedbin.Entry{{ID: 12}}, "auto"); cmd == nil {
+		t.Fatal("expected the bell to resume after quiet hours")
+	}
+}
+
+func TestModelInit_AutoOpensFirstUnreadInCurrentFilter(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Read", FeedTitle: "Feed A", PublishedAt: now, IsStarred: true},
+		{ID: 2, Title: "Unread", FeedTitle: "Feed A", PublishedAt: now.Add(-time.Minute), IsUnread: true},
+		{ID: 3, Title: "Unread starred", FeedTitle: "Feed A", PublishedAt: now.Add(-2 * time.Minute), IsUnread: true, IsStarred: true},
+	}
+	m := NewModel(fakeRefresher{}, nil)
+	m.SetAutoOpenFirstUnread(true)
+
+	updated, _ := m.Update(tuiactions.RefreshSuccessMsg{Entries: entries, Source: "init"})
+	m = updated.(Model)
+	if !m.inDetail || m.selectedID != 2 {
+		t.Fatalf("expected detail for first unread entry, got inDetail=%v selected=%d", m.inDetail, m.selectedID)
+	}
+
+	starred := NewModel(fakeRefresher{}, nil)
+	starred.SetAutoOpenFirstUnread(true)
+	starred.filter = "starred"
+	updated, _ = starred.Update(tuiactions.RefreshSuccessMsg{Entries: entries, Source: "init"})
+	if got := updated.(Model); !got.inDetail || got.selectedID != 3 {
+		t.Fatalf("expected starred filter respected, got inDetail=%v selected=%d", got.inDetail, got.selectedID)
+	}
+
+	allRead := NewModel(fakeRefresher{}, nil)
+	allRead.SetAutoOpenFirstUnread(true)
+	updated, _ = allRead.Update(tuiactions.RefreshSuccessMsg{Entries: entries[:1], Source: "init"})
+	if got := updated.(Model); got.inDetail || got.status != "No unread articles to open" {
+		t.Fatalf("expected list with status note, got inDetail=%v status=%q", got.inDetail, got.status)
+	}
+
+	manual := NewModel(fakeRefresher{}, nil)
+	manual.SetAutoOpenFirstUnread(true)
+	updated, _ = manual.Update(tuiactions.RefreshSuccessMsg{Entries: entries, Source: "manual"})
+	if updated.(Model).inDetail {
+		t.Fatal("expected only the initial refresh to auto-open")
+	}
+}
+
+func TestModelInit_AutoOpenExpandsCollapsedFolderAndFeed(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Read", FeedTitle: "Feed A", FeedFolder: "Dev", PublishedAt: now},
+		{ID: 2, Title: "Unread", FeedTitle: "Race", FeedFolder: "Formula 1", PublishedAt: now.Add(-time.Minute), IsUnread: true},
+	}
+	m := NewModel(fakeRefresher{}, nil)
+	m.SetAutoOpenFirstUnread(true)
+	m.collapsedFolders["Formula 1"] = true
+	m.collapsedFeeds[treeFeedKey("Formula 1", "Race")] = true
+
+	updated, _ := m.Update(tuiactions.RefreshSuccessMsg{Entries: entries, Source: "init"})
+	m = updated.(Model)
+	if !m.inDetail || m.selectedID != 2 {
+		t.Fatalf("expected detail for the unread entry in the collapsed folder, got inDetail=%v selected=%d", m.inDetail, m.selectedID)
+	}
+	if m.collapsedFolders["Formula 1"] || m.collapsedFeeds[treeFeedKey("Formula 1", "Race")] {
+		t.Fatal("expected the folder and feed holding the entry to be expanded")
+	}
+	if row := m.treeRows()[m.treeCursor]; row.Kind != treeRowArticle || row.EntryIndex != m.cursor {
+		t.Fatalf("expected the tree cursor on the opened article, got %+v", row)
+	}
+}
+
+func TestModelInit_AutoOpenKeepsQueuedRefreshDuringInit(t *testing.T) {
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Unread", FeedTitle: "Feed A", PublishedAt: time.Now().UTC(), IsUnread: true},
+	}
+	m := NewModel(fakeRefresher{entries: entries}, nil)
+	m.SetAutoOpenFirstUnread(true)
+	m.SetQueueRepeatRefresh(true)
+	press := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}}
+
+	updated, _ := m.Update(initRefreshMsg{})
+	updated, _ = updated.Update(press)
+	updated, cmd := updated.Update(tuiactions.RefreshSuccessMsg{Entries: entries, Source: "init"})
+	model := updated.(Model)
+	if !model.inDetail || model.selectedID != 1 {
+		t.Fatalf("expected auto-open after init, got inDetail=%v selected=%d", model.inDetail, model.selectedID)
+	}
+	if cmd == nil || !model.refreshing || model.refreshQueued {
+		t.Fatal("expected the queued refresh to start alongside the auto-open")
+	}
+
+	updated, _ = model.Update(tuiactions.RefreshSuccessMsg{Entries: entries, Source: "manual"})
+	if model = updated.(Model); model.refreshing || model.loading {
+		t.Fatal("expected the queued refresh to finish")
+	}
+}
+
+func TestModelInit_AutoOpenAfterFailedOrSkippedRefresh(t *testing.T) {
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Unread", FeedTitle: "Feed A", PublishedAt: time.Now().UTC(), IsUnread: true},
+	}
+
+	offline := NewModel(fakeRefresher{}, entries)
+	offline.SetOffline(true)
+	offline.SetAutoOpenFirstUnread(true)
+	cmd := offline.Init()
+	if cmd == nil {
+		t.Fatal("expected offline mode to still auto-open")
+	}
+	updated, _ := offline.Update(cmd())
+	if got := updated.(Model); !got.inDetail || got.selectedID != 1 {
+		t.Fatalf("expected offline auto-open, got inDetail=%v selected=%d", got.inDetail, got.selectedID)
+	}
+
+	failed := NewModel(fakeRefresher{}, entries)
+	failed.SetAutoOpenFirstUnread(true)
+	updated, _ = failed.Update(initRefreshMsg{})
+	updated, _ = updated.Update(tuiactions.RefreshErrorMsg{Err: errors.New("offline"), Source: "init"})
+	if got := updated.(Model); !got.inDetail || got.selectedID != 1 {
+		t.Fatalf("expected auto-open after a failed init refresh, got inDetail=%v selected=%d", got.inDetail, got.selectedID)
+	}
+}
+
+func TestModelInit_AutoOpenSkippedAfterUserNavigates(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "First", FeedTitle: "Feed A", PublishedAt: now, IsUnread: true},
+		{ID: 2, Title: "Second", FeedTitle: "Feed A", PublishedAt: now.Add(-time.Minute), IsUnread: true},
+	}
+	m := NewModel(fakeRefresher{entries: entries}, entries)
+	m.SetAutoOpenFirstUnread(true)
+
+	updated, _ := m.Update(initRefreshMsg{})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	moved := updated.(Model)
+	updated, _ = moved.Update(tuiactions.RefreshSuccessMsg{Entries: entries, Source: "init"})
+	if got := updated.(Model); got.inDetail || got.treeCursor != moved.treeCursor {
+		t.Fatalf("expected the list to stay where the user moved, got inDetail=%v cursor=%d", got.inDetail, got.treeCursor)
+	}
+}
+
 func TestModelInit_RefreshesInBackgroundWithDynamicPageSizeFromEnv(t *testing.T) {
 	t.Setenv("LINES", "40")
 	service := &initRefreshService{}
@@ -217,8 +621,11 @@ func TestModelInit_RefreshesInBackgroundWithDynamicPageSizeFromEnv(t *testing.T)
 	if cmd == nil {
 		t.Fatal("expected init refresh command")
 	}
-	msg := cmd()
-	updated, _ := m.Update(msg)
+	updated, cmd := m.Update(cmd())
+	if !updated.(Model).refreshing || cmd == nil {
+		t.Fatal("expected the startup refresh to start and count as running")
+	}
+	updated, _ = updated.Update(cmd())
 	model := updated.(Model)
 
 	if !service.called {
@@ -335,6 +742,52 @@ func TestModelUpdate_RefreshWithUppercaseR(t *testing.T) {
 	}
 }
 
+func TestModelUpdate_RepeatedRefreshIgnoredWhileInFlight(t *testing.T) {
+	entries := []feedbin.Entry{{ID: 1, Title: "One", PublishedAt: time.Now().UTC()}}
+	m := NewModel(fakeRefresher{entries: entries}, entries)
+	press := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}}
+
+	updated, first := m.Update(press)
+	if first == nil {
+		t.Fatal("expected refresh command")
+	}
+	updated, _ = updated.Update(press)
+	model := updated.(Model)
+	if model.status != "Refresh already in progress" || model.refreshQueued {
+		t.Fatalf("expected the second press ignored, got status %q", model.status)
+	}
+
+	updated, queued := model.Update(first())
+	if queued != nil || updated.(Model).refreshing {
+		t.Fatal("expected no follow-up refresh in ignore mode")
+	}
+	if _, cmd := updated.Update(press); cmd == nil {
+		t.Fatal("expected refresh allowed again once finished")
+	}
+}
+
+func TestModelUpdate_RepeatedRefreshQueuedOnce(t *testing.T) {
+	entries := []feedbin.Entry{{ID: 1, Title: "One", PublishedAt: time.Now().UTC()}}
+	m := NewModel(fakeRefresher{entries: entries}, entries)
+	m.SetQueueRepeatRefresh(true)
+	press := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}}
+
+	updated, first := m.Update(press)
+	updated, _ = updated.Update(press)
+	updated, _ = updated.Update(press)
+	updated, queued := updated.Update(first())
+	if queued == nil {
+		t.Fatal("expected the queued refresh to start")
+	}
+	if _, ok := queued().(tuiactions.RefreshSuccessMsg); !ok {
+		t.Fatal("expected queued command to be a refresh")
+	}
+	model := updated.(Model)
+	if !model.refreshing || model.refreshQueued {
+		t.Fatal("expected exactly one queued refresh running")
+	}
+}
+
 func TestModelUpdate_NavigateAndSelect(t *testing.T) {
 	m := NewModel(nil, []feedbin.Entry{
 		{ID: 1, Title: "First", PublishedAt: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)},
@@ -419,6 +872,140 @@ func TestModelUpdate_ToggleUnreadAction(t *testing.T) {
 	}
 }
 
+func TestModelUpdate_ReadInUnreadViewAdvancesToNextUnread(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "A1", FeedID: 10, FeedTitle: "Alpha", IsUnread: true, PublishedAt: now},
+		{ID: 2, Title: "A2", FeedID: 10, FeedTitle: "Alpha", IsUnread: true, PublishedAt: now.Add(-time.Minute)},
+		{ID: 3, Title: "A3", FeedID: 10, FeedTitle: "Alpha", IsUnread: true, PublishedAt: now.Add(-2 * time.Minute)},
+		{ID: 4, Title: "B1", FeedID: 20, FeedTitle: "Beta", IsUnread: true, PublishedAt: now.Add(-time.Hour)},
+	}
+	newModel := func() Model {
+		m := NewModel(fakeRefresher{unreadResult: false}, slices.Clone(entries))
+		m.filter = "unread"
+		m.applyCurrentFilter()
+		return m
+	}
+	markRead := func(m Model, id int64) Model {
+		t.Helper()
+		for i, row := range m.treeRows() {
+			if row.Kind == treeRowArticle && m.entries[row.EntryIndex].ID == id {
+				m.treeCursor = i
+				m.syncCursorFromTree()
+			}
+		}
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+		updated, _ = updated.Update(cmd())
+		return updated.(Model)
+	}
+	current := func(m Model) int64 {
+		row := m.treeRows()[m.treeCursor]
+		if row.Kind != treeRowArticle {
+			t.Fatalf("expected the cursor on an article, got %+v", row)
+		}
+		return m.entries[row.EntryIndex].ID
+	}
+
+	m := markRead(newModel(), 2)
+	if got := current(m); got != 3 || len(m.entries) != 3 {
+		t.Fatalf("expected the cursor on the following unread entry 3, got %d (%d listed)", got, len(m.entries))
+	}
+	m = markRead(m, 3)
+	if got := current(m); got != 4 {
+		t.Fatalf("expected the cursor to cross into the next feed, got %d", got)
+	}
+	m = markRead(m, 4)
+	if got := current(m); got != 1 {
+		t.Fatalf("expected the previous unread entry at the end of the list, got %d", got)
+	}
+
+	// A pinned feed leads the tree, so tree order differs from list order.
+	pinned := newModel()
+	pinned.pinnedFeeds = map[int64]bool{20: true}
+	pinned.treeVersion++
+	if got := current(markRead(pinned, 3)); got != 2 {
+		t.Fatalf("expected the previous unread entry in tree order, got %d", got)
+	}
+
+	backward := newModel()
+	backward.SetReadAdvanceBackward(true)
+	if got := current(markRead(backward, 2)); got != 1 {
+		t.Fatalf("expected the previous unread entry first when backward, got %d", got)
+	}
+
+	// Moving on while the toggle is in flight keeps the cursor where it went.
+	moved := newModel()
+	for i, row := range moved.treeRows() {
+		if row.Kind == treeRowArticle && moved.entries[row.EntryIndex].ID == 2 {
+			moved.treeCursor = i
+			moved.syncCursorFromTree()
+		}
+	}
+	updated, cmd := moved.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+	moved = updated.(Model)
+	for i, row := range moved.treeRows() {
+		if row.Kind == treeRowArticle && moved.entries[row.EntryIndex].ID == 4 {
+			moved.treeCursor = i
+			moved.syncCursorFromTree()
+		}
+	}
+	updated, _ = moved.Update(cmd())
+	if got := current(updated.(Model)); got != 4 {
+		t.Fatalf("expected the cursor to stay on entry 4, got %d", got)
+	}
+}
+
+func TestModelUpdate_PendingToggleSurvivesInterleavedRefresh(t *testing.T) {
+	now := time.Now().UTC()
+	stale := []feedbin.Entry{
+		{ID: 1, Title: "Entry", IsUnread: true, PublishedAt: now},
+		{ID: 2, Title: "Other", IsUnread: true, PublishedAt: now.Add(-time.Minute)},
+	}
+	m := NewModel(fakeRefresher{unreadResult: false, starResult: true}, stale)
+
+	updated, toggleCmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+	updated, starCmd := updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	if toggleCmd == nil || starCmd == nil {
+		t.Fatal("expected toggle commands")
+	}
+	m = updated.(Model)
+	if m.entries[0].IsUnread || !m.entries[0].IsStarred {
+		t.Fatalf("expected optimistic read+starred state, got %+v", m.entries[0])
+	}
+
+	// A refresh issued before the toggles still carries the old server state.
+	updated, _ = m.Update(tuiactions.RefreshSuccessMsg{Entries: append([]feedbin.Entry(nil), stale...), Source: "manual"})
+	m = updated.(Model)
+	if m.entries[0].IsUnread || !m.entries[0].IsStarred {
+		t.Fatalf("expected pending toggles reapplied over refresh, got %+v", m.entries[0])
+	}
+	if !m.entries[1].IsUnread {
+		t.Fatal("expected untouched entry to keep refreshed state")
+	}
+
+	updated, _ = m.Update(toggleCmd())
+	updated, _ = updated.(Model).Update(starCmd())
+	m = updated.(Model)
+	if len(m.pendingToggles) != 0 {
+		t.Fatalf("expected confirmations to settle pending toggles, got %v", m.pendingToggles)
+	}
+	updated, _ = m.Update(tuiactions.RefreshSuccessMsg{Entries: append([]feedbin.Entry(nil), stale...), Source: "manual"})
+	if !updated.(Model).entries[0].IsUnread {
+		t.Fatal("expected settled toggles to stop overriding refreshed entries")
+	}
+}
+
+func TestModelUpdate_FailedToggleRollsBack(t *testing.T) {
+	m := NewModel(fakeRefresher{}, []feedbin.Entry{{ID: 1, Title: "Entry", IsUnread: true, PublishedAt: time.Now().UTC()}})
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+	updated, _ = updated.(Model).Update(tuiactions.ToggleActionErrorMsg{EntryID: 1, Field: tuiactions.BatchFieldUnread, Err: errors.New("boom")})
+	m = updated.(Model)
+	if !m.entries[0].IsUnread || len(m.pendingToggles) != 0 {
+		t.Fatalf("expected rollback to unread, got unread=%v pending=%v", m.entries[0].IsUnread, m.pendingToggles)
+	}
+}
+
 func TestModelUpdate_ToggleStarredActionInDetail(t *testing.T) {
 	m := NewModel(fakeRefresher{starResult: true}, []feedbin.Entry{{
 		ID:          2,
@@ -545,55 +1132,373 @@ func TestModelUpdate_ClearSearchWithCtrlL(t *testing.T) {
 	}
 }
 
-func TestModelUpdate_SearchAndFilterPersistAcrossRefreshAndLoadMore(t *testing.T) {
-	base := []feedbin.Entry{
-		{ID: 1, Title: "Go unread", IsUnread: true, PublishedAt: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)},
-		{ID: 2, Title: "Go read", IsUnread: false, PublishedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
-		{ID: 3, Title: "Rust unread", IsUnread: true, PublishedAt: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)},
-	}
-	service := fakeRefresher{
-		entries: base,
-		pageResults: map[int][]feedbin.Entry{
-			2: {
-				{ID: 1, Title: "Go unread", IsUnread: true, PublishedAt: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)},
-				{ID: 2, Title: "Go read", IsUnread: false, PublishedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
-				{ID: 3, Title: "Rust unread", IsUnread: true, PublishedAt: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)},
-				{ID: 4, Title: "Go new unread", IsUnread: true, PublishedAt: time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)},
-			},
-		},
-	}
-	m := NewModel(service, base)
+func TestModelUpdate_SearchPasteIsCleanedToOneLine(t *testing.T) {
+	service := fakeRefresher{entries: []feedbin.Entry{
+		{ID: 1, Title: "Go release notes", PublishedAt: time.Now().UTC()},
+		{ID: 2, Title: "Rust update", PublishedAt: time.Now().UTC()},
+	}}
+	m := NewModel(service, service.entries)
 
-	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
-	if cmd == nil {
-		t.Fatal("expected unread filter command")
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("  go\r\n\trelease\n"), Paste: true})
+	if got := updated.(Model).searchInput; got != "go release" {
+		t.Fatalf("expected the paste cleaned to one line, got %q", got)
 	}
-	msg := cmd()
-	updated, _ = updated.Update(msg)
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("\x1b[200~ notes\x1b[201~")})
+	updated, cmd := updated.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updated, _ = updated.Update(cmd())
 	model := updated.(Model)
-
-	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
-	model = updated.(Model)
-	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
-	model = updated.(Model)
-	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
-	model = updated.(Model)
-	updated, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
-	if cmd == nil {
-		t.Fatal("expected search command")
+	if model.searchQuery != "go release notes" {
+		t.Fatalf("expected a clean single-line query, got %q", model.searchQuery)
 	}
-	msg = cmd()
-	updated, _ = updated.Update(msg)
-	model = updated.(Model)
 	if len(model.entries) != 1 || model.entries[0].ID != 1 {
-		t.Fatalf("unexpected filtered+searched entries: %+v", model.entries)
+		t.Fatalf("expected the pasted query to match, got %+v", model.entries)
 	}
+}
 
-	updated, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
-	if cmd == nil {
-		t.Fatal("expected refresh command")
-	}
-	msg = cmd()
+func TestModelSearchExpand_OpensCollapsedMatchesAndRestores(t *testing.T) {
+	now := time.Now().UTC()
+	service := fakeRefresher{entries: []feedbin.Entry{
+		{ID: 1, Title: "Go release notes", FeedTitle: "Golang", FeedFolder: "Tech", PublishedAt: now},
+		{ID: 2, Title: "Rust update", FeedTitle: "Rustacean", FeedFolder: "Tech", PublishedAt: now.Add(-time.Minute)},
+		{ID: 3, Title: "Go to the park", FeedTitle: "Outdoors", FeedFolder: "Life", PublishedAt: now.Add(-time.Hour)},
+	}}
+	articleIDs := func(m Model) []int64 {
+		ids := []int64{}
+		for _, row := range m.treeRows() {
+			if row.Kind == treeRowArticle {
+				ids = append(ids, m.entries[row.EntryIndex].ID)
+			}
+		}
+		slices.Sort(ids)
+		return ids
+	}
+	collapsed := func() Model {
+		m := NewModel(service, service.entries)
+		m.ApplyViewState(ViewState{
+			CollapsedFolders: []string{"Tech"},
+			CollapsedFeeds:   []string{treeFeedKey("Life", "Outdoors")},
+		})
+		return m
+	}
+	runSearch := func(m Model, query string) Model {
+		t.Helper()
+		matches := make([]feedbin.Entry, 0, len(service.entries))
+		for _, entry := range service.entries {
+			if strings.Contains(strings.ToLower(entry.Title), query) {
+				matches = append(matches, entry)
+			}
+		}
+		updated, _ := m.Update(tuiactions.SearchLoadSuccessMsg{Filter: "all", Query: query, Entries: matches})
+		return updated.(Model)
+	}
+
+	if ids := articleIDs(runSearch(collapsed(), "go")); len(ids) != 0 {
+		t.Fatalf("expected collapsed matches hidden without search expand, got %v", ids)
+	}
+
+	m := collapsed()
+	m.SetSearchExpand(true)
+	m = runSearch(m, "go")
+	if ids := articleIDs(m); !slices.Equal(ids, []int64{1, 3}) {
+		t.Fatalf("expected both matches visible, got %v", ids)
+	}
+	if state := m.ViewState(); !slices.Equal(state.CollapsedFolders, []string{"Tech"}) || len(state.CollapsedFeeds) != 1 {
+		t.Fatalf("expected the saved view to keep the pre-search collapse state, got %+v", state)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	if cmd == nil {
+		t.Fatal("expected clearing the search to reload")
+	}
+	updated, _ = updated.Update(cmd())
+	m = updated.(Model)
+	if !m.collapsedFolders["Tech"] || !m.collapsedFeeds[treeFeedKey("Life", "Outdoors")] {
+		t.Fatalf("expected the collapse state restored, got folders=%v feeds=%v", m.collapsedFolders, m.collapsedFeeds)
+	}
+	if ids := articleIDs(m); len(ids) != 0 {
+		t.Fatalf("expected the restored tree to hide the articles again, got %v", ids)
+	}
+}
+
+func TestModelUpdate_StickySearchKeepsTypingInTheQuery(t *testing.T) {
+	service := fakeRefresher{entries: []feedbin.Entry{
+		{ID: 1, Title: "Go release notes", PublishedAt: time.Now().UTC()},
+		{ID: 2, Title: "Go update", PublishedAt: time.Now().UTC().Add(-time.Minute)},
+		{ID: 3, Title: "Rust update", PublishedAt: time.Now().UTC().Add(-time.Hour)},
+	}}
+	press := func(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+		t.Helper()
+		updated, cmd := m.Update(msg)
+		return updated.(Model), cmd
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	switchesFilter := func(m Model) bool {
+		t.Helper()
+		m, cmd := press(m, runes("u"))
+		if m.searchInputMode || cmd == nil {
+			return false
+		}
+		updated, _ := m.Update(cmd())
+		return updated.(Model).filter == "unread"
+	}
+	search := func(m Model, query string) Model {
+		t.Helper()
+		m, _ = press(m, runes("/"))
+		m, _ = press(m, runes(query))
+		m, cmd := press(m, tea.KeyMsg{Type: tea.KeyEnter})
+		updated, _ := m.Update(cmd())
+		return updated.(Model)
+	}
+
+	if !switchesFilter(search(NewModel(service, service.entries), "go")) {
+		t.Fatal("expected u to switch filters without sticky search")
+	}
+
+	m := NewModel(service, service.entries)
+	m.SetStickySearch(true)
+	m = search(m, "go")
+	if !m.searchFocused {
+		t.Fatal("expected the committed search to keep focus")
+	}
+	m, _ = press(m, runes("j"))
+	if m.searchInputMode || m.cursor != 1 {
+		t.Fatalf("expected j to move the cursor, got input=%v cursor=%d", m.searchInputMode, m.cursor)
+	}
+	m, _ = press(m, runes("u"))
+	if !m.searchInputMode || m.searchInput != "gou" || m.filter != "all" {
+		t.Fatalf("expected u to continue the query, got input=%v %q filter=%q", m.searchInputMode, m.searchInput, m.filter)
+	}
+	m, _ = press(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	m, cmd := press(m, tea.KeyMsg{Type: tea.KeyEnter})
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+	if !m.searchFocused || m.searchQuery != "go" {
+		t.Fatalf("expected the re-committed search to keep focus, got %v %q", m.searchFocused, m.searchQuery)
+	}
+
+	// Global keys and q keep working; "/" reopens the input as it is.
+	if help, _ := press(m, runes("?")); !help.showHelp || help.searchInputMode {
+		t.Fatal("expected ? to open the help while the search has focus")
+	}
+	if _, cmd := press(m, runes("q")); cmd == nil {
+		t.Fatal("expected q to quit while the search has focus")
+	} else if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Fatal("expected q to quit while the search has focus")
+	}
+	if reopened, _ := press(m, runes("/")); !reopened.searchInputMode || reopened.searchInput != "go" {
+		t.Fatalf("expected / to reopen the input on %q, got input=%v %q", "go", reopened.searchInputMode, reopened.searchInput)
+	}
+	if star, _ := press(m, runes("*")); star.searchInputMode {
+		t.Fatal("expected symbol keys not to be typed into the query")
+	}
+
+	m, _ = press(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.searchFocused || m.searchQuery != "go" {
+		t.Fatalf("expected esc to release focus and keep the search, got %v %q", m.searchFocused, m.searchQuery)
+	}
+	if !switchesFilter(m) {
+		t.Fatal("expected u to switch filters after esc")
+	}
+}
+
+func TestModelUpdate_EscClearsSearchWhenEnabled(t *testing.T) {
+	service := fakeRefresher{entries: []feedbin.Entry{
+		{ID: 1, Title: "Go release notes", PublishedAt: time.Now().UTC()},
+		{ID: 2, Title: "Rust update", PublishedAt: time.Now().UTC()},
+	}}
+	search := func(m Model) Model {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+		updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("go")})
+		updated, cmd := updated.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		updated, _ = updated.Update(cmd())
+		model := updated.(Model)
+		if model.searchQuery != "go" {
+			t.Fatalf("expected active search, got %q", model.searchQuery)
+		}
+		return model
+	}
+
+	model := search(NewModel(service, service.entries))
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd != nil || updated.(Model).searchQuery != "go" {
+		t.Fatal("expected esc to leave the search alone by default")
+	}
+
+	m := NewModel(service, service.entries)
+	m.SetEscClearsSearch(true)
+	updated, cmd = search(m).Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil {
+		t.Fatal("expected esc to clear the search")
+	}
+	updated, _ = updated.Update(cmd())
+	model = updated.(Model)
+	if model.searchQuery != "" || len(model.entries) != 2 {
+		t.Fatalf("expected full list after esc, got %q with %d entries", model.searchQuery, len(model.entries))
+	}
+	if _, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc}); cmd != nil {
+		t.Fatal("expected esc without a search to do nothing")
+	}
+}
+
+func TestModelUpdate_FeedScopeFromDetail(t *testing.T) {
+	service := fakeRefresher{entries: []feedbin.Entry{
+		{ID: 1, Title: "A newest", FeedID: 10, FeedTitle: "Feed A", IsUnread: true, PublishedAt: time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Title: "B post", FeedID: 20, FeedTitle: "Feed B", IsUnread: true, PublishedAt: time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)},
+		{ID: 3, Title: "A middle", FeedID: 10, FeedTitle: "Feed A", PublishedAt: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)},
+		{ID: 4, Title: "A oldest", FeedID: 10, FeedTitle: "Feed A", IsUnread: true, PublishedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}}
+	m := NewModel(service, service.entries)
+	m.inDetail = true
+	m.cursor = 0
+	m.selectedID = 1
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if cmd == nil {
+		t.Fatal("expected feed scope load command")
+	}
+	updated, _ = updated.Update(cmd())
+	model := updated.(Model)
+	if model.inDetail {
+		t.Fatal("expected feed scope to return to the list")
+	}
+	if model.filter != feedbin.FeedScopeFilter(10) || len(model.entries) != 3 {
+		t.Fatalf("unexpected feed scope: filter=%q entries=%+v", model.filter, model.entries)
+	}
+	if got := model.entries[model.cursor].ID; got != 4 {
+		t.Fatalf("expected cursor on next unread entry 4, got %d", got)
+	}
+	if !strings.Contains(stripANSI(model.footer()), "feed: Feed A") {
+		t.Fatalf("expected feed scope in footer, got %q", stripANSI(model.footer()))
+	}
+
+	updated, cmd = model.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	if cmd == nil {
+		t.Fatal("expected ctrl+l to clear the feed scope")
+	}
+	updated, _ = updated.Update(cmd())
+	model = updated.(Model)
+	if model.filter != "all" || model.feedScopeTitle != "" || len(model.entries) != 4 {
+		t.Fatalf("expected full list after clearing scope, got filter=%q entries=%d", model.filter, len(model.entries))
+	}
+}
+
+func TestModelOffline_SkipsRefreshAndShowsBadge(t *testing.T) {
+	service := fakeRefresher{entries: []feedbin.Entry{{ID: 1, Title: "Cached", PublishedAt: time.Now().UTC()}}}
+	m := NewModel(service, service.entries)
+	m.SetOffline(true)
+
+	if cmd := m.Init(); cmd != nil {
+		t.Fatal("expected no initial refresh offline")
+	}
+	if !strings.HasPrefix(stripANSI(m.footer()), "OFFLINE • ") {
+		t.Fatalf("expected OFFLINE badge in footer, got %q", stripANSI(m.footer()))
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	model := updated.(Model)
+	if model.loading {
+		t.Fatal("expected refresh to be skipped offline")
+	}
+	if model.status != "Offline — changes queued" {
+		t.Fatalf("unexpected status: %q", model.status)
+	}
+	if cmd == nil {
+		t.Fatal("expected a status-clear command")
+	}
+}
+
+func TestModelReadOnly_RefusesMutationKeys(t *testing.T) {
+	service := fakeRefresher{entries: []feedbin.Entry{{ID: 1, Title: "Cached", IsUnread: true, PublishedAt: time.Now().UTC()}}}
+	m := NewModel(service, service.entries)
+	m.SetReadOnly(true)
+
+	if cmd := m.Init(); cmd != nil {
+		t.Fatal("expected no initial refresh read-only")
+	}
+	if !strings.HasPrefix(stripANSI(m.footer()), "READ-ONLY • ") {
+		t.Fatalf("expected READ-ONLY badge in footer, got %q", stripANSI(m.footer()))
+	}
+
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'U'}},
+		{Type: tea.KeyRunes, Runes: []rune{'S'}},
+		{Type: tea.KeyRunes, Runes: []rune{'x'}},
+		{Type: tea.KeyRunes, Runes: []rune{'r'}},
+		{Type: tea.KeyCtrlU},
+	} {
+		updated, cmd := m.Update(key)
+		model := updated.(Model)
+		if model.status != "Read-only mode" {
+			t.Fatalf("key %q: expected read-only notice, got %q", key.String(), model.status)
+		}
+		if model.loading || model.pendingBulk != nil {
+			t.Fatalf("key %q: expected no mutation to start", key.String())
+		}
+		if cmd == nil {
+			t.Fatalf("key %q: expected a status-clear command", key.String())
+		}
+		if !model.entries[0].IsUnread || model.entries[0].IsStarred {
+			t.Fatalf("key %q: expected entry unchanged, got %+v", key.String(), model.entries[0])
+		}
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if model := updated.(Model); model.status == "Read-only mode" {
+		t.Fatal("expected view options to keep working read-only")
+	}
+}
+
+func TestModelUpdate_SearchAndFilterPersistAcrossRefreshAndLoadMore(t *testing.T) {
+	base := []feedbin.Entry{
+		{ID: 1, Title: "Go unread", IsUnread: true, PublishedAt: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Title: "Go read", IsUnread: false, PublishedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 3, Title: "Rust unread", IsUnread: true, PublishedAt: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)},
+	}
+	service := fakeRefresher{
+		entries: base,
+		pageResults: map[int][]feedbin.Entry{
+			2: {
+				{ID: 1, Title: "Go unread", IsUnread: true, PublishedAt: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)},
+				{ID: 2, Title: "Go read", IsUnread: false, PublishedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+				{ID: 3, Title: "Rust unread", IsUnread: true, PublishedAt: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)},
+				{ID: 4, Title: "Go new unread", IsUnread: true, PublishedAt: time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+	m := NewModel(service, base)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	if cmd == nil {
+		t.Fatal("expected unread filter command")
+	}
+	msg := cmd()
+	updated, _ = updated.Update(msg)
+	model := updated.(Model)
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	model = updated.(Model)
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	model = updated.(Model)
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	model = updated.(Model)
+	updated, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected search command")
+	}
+	msg = cmd()
+	updated, _ = updated.Update(msg)
+	model = updated.(Model)
+	if len(model.entries) != 1 || model.entries[0].ID != 1 {
+		t.Fatalf("unexpected filtered+searched entries: %+v", model.entries)
+	}
+
+	updated, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if cmd == nil {
+		t.Fatal("expected refresh command")
+	}
+	msg = cmd()
 	updated, _ = updated.Update(msg)
 	model = updated.(Model)
 	if model.filter != "unread" || model.searchQuery != "go" {
@@ -701,6 +1606,41 @@ func TestModelUpdate_LoadMore(t *testing.T) {
 	}
 }
 
+func TestModelUpdate_LoadMoreMergesPageWhenEnabled(t *testing.T) {
+	now := time.Now().UTC()
+	m := NewModel(fakeRefresher{pageResults: map[int][]feedbin.Entry{
+		2: {
+			{ID: 1, Title: "First (edited)", FeedTitle: "Feed A", PublishedAt: now},
+			{ID: 2, Title: "Older", FeedTitle: "Feed A", PublishedAt: now.Add(-time.Hour)},
+			{ID: 3, Title: "Search miss", FeedTitle: "Feed B", PublishedAt: now},
+		},
+	}}, []feedbin.Entry{
+		{ID: 1, Title: "First", FeedTitle: "Feed A", PublishedAt: now},
+	})
+	m.ApplyPreferences(Preferences{MergePages: true})
+	m.searchQuery = "first"
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if cmd == nil {
+		t.Fatal("expected load more command")
+	}
+	updated, _ = updated.Update(cmd())
+	model := updated.(Model)
+	if len(model.entries) != 1 || model.entries[0].Title != "First (edited)" {
+		t.Fatalf("expected page merged by ID through the search filter, got %+v", model.entries)
+	}
+
+	model.searchQuery = ""
+	updated, _ = model.Update(tuiactions.LoadMoreSuccessMsg{Page: 3, FetchedCount: 3, Entries: []feedbin.Entry{
+		{ID: 2, Title: "Older", FeedTitle: "Feed A", PublishedAt: now.Add(-time.Hour)},
+		{ID: 1, Title: "First (edited)", FeedTitle: "Feed A", PublishedAt: now},
+	}})
+	model = updated.(Model)
+	if len(model.entries) != 2 || model.entries[0].ID != 1 || model.entries[1].ID != 2 {
+		t.Fatalf("expected merged entries in tree order, got %+v", model.entries)
+	}
+}
+
 func TestModelUpdate_LoadMoreNoMoreEntries(t *testing.T) {
 	m := NewModel(fakeRefresher{pageResults: map[int][]feedbin.Entry{
 		2: {},
@@ -799,11 +1739,156 @@ func TestModelUpdate_OpenURLFallbackToCopy(t *testing.T) {
 	msg := cmd()
 	updated, _ = updated.Update(msg)
 	model := updated.(Model)
-	if !strings.Contains(model.status, "copied to clipboard") {
+	if model.status != "Could not open browser, copied URL: 19 B (19 chars)" {
 		t.Fatalf("expected copy fallback status, got %s", model.status)
 	}
 }
 
+func TestModelUpdate_OpenURLPrefersCanonicalWhenEnabled(t *testing.T) {
+	entry := feedbin.Entry{ID: 1, URL: "https://www.google.com/amp/s/example.com/story", PublishedAt: time.Now().UTC()}
+	open := func(m Model) string {
+		t.Helper()
+		var opened string
+		m.inDetail = true
+		m.openURLFn = func(url string) error { opened = url; return nil }
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+		if cmd == nil {
+			t.Fatal("expected open URL command")
+		}
+		cmd()
+		return opened
+	}
+
+	if got := open(NewModel(nil, []feedbin.Entry{entry})); got != entry.URL {
+		t.Fatalf("expected the entry URL by default, got %q", got)
+	}
+	m := NewModel(nil, []feedbin.Entry{entry})
+	m.SetPreferCanonicalURL(true)
+	if got := open(m); got != "https://example.com/story" {
+		t.Fatalf("expected the canonical URL, got %q", got)
+	}
+}
+
+func TestModelUpdate_DetailTogglesSummaryAndContent(t *testing.T) {
+	entry := feedbin.Entry{ID: 1, Title: "Post", Summary: "Short summary", Content: "<p>Full content body</p>", PublishedAt: time.Now().UTC()}
+	m := NewModel(nil, []feedbin.Entry{entry})
+	m.width = 100
+	m.height = 30
+	m.inDetail = true
+	var saved Preferences
+	m.SetPreferencesSaver(func(p Preferences) error { saved = p; return nil })
+	if body := m.detailView(); !strings.Contains(body, "Full content body") {
+		t.Fatalf("expected full content by default, got %q", body)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = updated.(Model)
+	if body := m.detailView(); !strings.Contains(body, "Short summary") || strings.Contains(body, "Full content body") {
+		t.Fatalf("expected the summary after toggling, got %q", body)
+	}
+	if cmd == nil {
+		t.Fatal("expected the choice to be persisted")
+	}
+	cmd()
+	if !saved.PreferSummary {
+		t.Fatal("expected prefer-summary saved as the default")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = updated.(Model)
+	if m.preferSummary || m.status != "Article text: full content" {
+		t.Fatalf("expected toggling back to content, got prefer=%v status=%q", m.preferSummary, m.status)
+	}
+}
+
+type fakeExtractor struct {
+	content string
+	calls   *int
+}
+
+func (f fakeExtractor) ExtractedContent(context.Context, feedbin.Entry) (string, error) {
+	*f.calls++
+	return f.content, nil
+}
+
+func TestModelUpdate_DetailCyclesBodySources(t *testing.T) {
+	entry := feedbin.Entry{ID: 1, Title: "Post", Summary: "Short summary", Content: "<p>Feed content body</p>", ExtractedContentURL: "https://extract.example.com/1", PublishedAt: time.Now().UTC()}
+	m := NewModel(nil, []feedbin.Entry{entry})
+	m.width = 100
+	m.height = 30
+	m.inDetail = true
+	calls := 0
+	m.SetContentExtractor(fakeExtractor{content: "<p>Extracted article body</p>", calls: &calls})
+	press := func(model Model) (Model, tea.Cmd) {
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+		return updated.(Model), cmd
+	}
+	expect := func(model Model, label, body string) {
+		t.Helper()
+		view := model.detailView()
+		if !strings.Contains(view, "Source: "+label) || !strings.Contains(view, body) {
+			t.Fatalf("expected %s showing %q, got %q", label, body, view)
+		}
+	}
+
+	expect(m, "feed content", "Feed content body")
+	m, _ = press(m)
+	expect(m, "feed summary", "Short summary")
+
+	m, cmd := press(m)
+	if cmd == nil || m.status != "Extracting full text…" {
+		t.Fatalf("expected the extraction to start, got status %q", m.status)
+	}
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+	expect(m, "extracted full text", "Extracted article body")
+
+	m, _ = press(m)
+	expect(m, "feed content", "Feed content body")
+	m, _ = press(m)
+	m, cmd = press(m)
+	if cmd != nil {
+		t.Fatal("expected the extracted text reused from the session cache")
+	}
+	expect(m, "extracted full text", "Extracted article body")
+	if calls != 1 {
+		t.Fatalf("expected one extraction request, got %d", calls)
+	}
+}
+
+func TestModelUpdate_OpenURLOverSSHCopiesInstead(t *testing.T) {
+	t.Setenv("SSH_CONNECTION", "10.0.0.2 52100 10.0.0.1 22")
+	m := NewModel(nil, []feedbin.Entry{{ID: 1, URL: "https://example.com", PublishedAt: time.Now().UTC()}})
+	m.SetOpenURLMode("auto")
+	m.inDetail = true
+	opened := false
+	var copied string
+	m.openURLFn = func(string) error { opened = true; return nil }
+	m.copyURLFn = func(url string) error { copied = url; return nil }
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if cmd == nil {
+		t.Fatal("expected copy command")
+	}
+	updated, _ = updated.Update(cmd())
+	model := updated.(Model)
+	if opened || copied != "https://example.com" {
+		t.Fatalf("expected URL copied without launching a browser, opened=%v copied=%q", opened, copied)
+	}
+	if !strings.Contains(model.status, "SSH session") {
+		t.Fatalf("expected status to explain the SSH fallback, got %q", model.status)
+	}
+
+	model.SetOpenURLMode("browser")
+	if _, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}}); cmd == nil {
+		t.Fatal("expected open command")
+	}
+	cmd()
+	if !opened {
+		t.Fatal("expected browser mode to launch the browser even over SSH")
+	}
+}
+
 func TestModelUpdate_CopyURLDirectly(t *testing.T) {
 	m := NewModel(nil, []feedbin.Entry{{ID: 1, URL: "https://example.com", PublishedAt: time.Now().UTC()}})
 	m.copyURLFn = func(string) error { return nil }
@@ -815,7 +1900,7 @@ func TestModelUpdate_CopyURLDirectly(t *testing.T) {
 	msg := cmd()
 	updated, _ = updated.Update(msg)
 	model := updated.(Model)
-	if !strings.Contains(model.status, "URL copied to clipboard") {
+	if model.status != "Copied URL: 19 B (19 chars)" {
 		t.Fatalf("unexpected status: %s", model.status)
 	}
 }
@@ -891,6 +1976,83 @@ func TestModelUpdate_CollapseExpandTreeWithHL(t *testing.T) {
 	}
 }
 
+func TestModelSetTreeRules_SeedsCollapseStateOnce(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Race", FeedTitle: "Pit Wall", FeedFolder: "Formula 1", IsUnread: true, PublishedAt: now},
+		{ID: 2, Title: "Release", FeedTitle: "Go Blog", FeedFolder: "Dev", PublishedAt: now.Add(-time.Minute)},
+		{ID: 3, Title: "Noise", FeedTitle: "Chatter", PublishedAt: now.Add(-2 * time.Minute)},
+	}
+	m := NewModel(nil, entries)
+	m.ApplyViewState(ViewState{CollapsedFolders: []string{"Dev"}})
+	m.SetTreeRules([]string{"formula 1", "CHATTER", "go blog"}, []string{"Dev", "Go Blog"})
+
+	if !m.collapsedFolders["Formula 1"] {
+		t.Fatal("expected Formula 1 collapsed even though it holds unread entries")
+	}
+	if m.collapsedFolders["Dev"] {
+		t.Fatal("expected auto-expand to override the restored collapsed state")
+	}
+	if !m.collapsedFeeds[treeFeedKey("", "Chatter")] {
+		t.Fatal("expected top-level feed Chatter collapsed")
+	}
+	if m.collapsedFeeds[treeFeedKey("Dev", "Go Blog")] {
+		t.Fatal("expected a name in both lists to be expanded")
+	}
+
+	m.setCollapsed(m.collapsedFolders, "Formula 1", false)
+	m.entries = append(m.entries, feedbin.Entry{ID: 4, Title: "Late", FeedTitle: "Late Feed", FeedFolder: "Formula 1", PublishedAt: now.Add(-time.Hour)})
+	m.sortEntries()
+	if m.collapsedFolders["Formula 1"] {
+		t.Fatal("expected a user toggle to survive later entry loads")
+	}
+
+	m.entries = append(m.entries, feedbin.Entry{ID: 5, Title: "Quiet", FeedTitle: "Chatter", FeedFolder: "Misc", PublishedAt: now.Add(-2 * time.Hour)})
+	m.sortEntries()
+	if !m.collapsedFeeds[treeFeedKey("Misc", "Chatter")] {
+		t.Fatal("expected a feed first seen after startup to pick up its rule")
+	}
+}
+
+func TestModelUpdate_JumpToUnreadFeedSkipsReadFeedsAndWraps(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "A read", FeedTitle: "Feed A", FeedFolder: "Dev", PublishedAt: now},
+		{ID: 2, Title: "B unread", FeedTitle: "Feed B", FeedFolder: "Dev", IsUnread: true, PublishedAt: now.Add(-time.Minute)},
+		{ID: 3, Title: "C read", FeedTitle: "Feed C", FeedFolder: "News", PublishedAt: now.Add(-2 * time.Minute)},
+		{ID: 4, Title: "D newer read", FeedTitle: "Feed D", FeedFolder: "News", PublishedAt: now.Add(-3 * time.Minute)},
+		{ID: 5, Title: "D older unread", FeedTitle: "Feed D", FeedFolder: "News", IsUnread: true, PublishedAt: now.Add(-4 * time.Minute)},
+	}
+	m := NewModel(nil, entries)
+	m.setCollapsed(m.collapsedFolders, "News", true)
+	m.treeCursor = 0
+	m.syncCursorFromTree()
+
+	next := func(model Model, key rune) Model {
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}})
+		return updated.(Model)
+	}
+	m = next(m, '}')
+	if got := m.entries[m.cursor].ID; got != 2 {
+		t.Fatalf("expected first unread feed's article 2, got %d", got)
+	}
+	m = next(m, '}')
+	if got := m.entries[m.cursor].ID; got != 5 {
+		t.Fatalf("expected to skip read Feed C and land on unread article 5, got %d", got)
+	}
+	if m.collapsedFolders["News"] || !m.currentTreeRowIsArticle() {
+		t.Fatal("expected the collapsed News folder to be expanded for the jump")
+	}
+	m = next(m, '}')
+	if got := m.entries[m.cursor].ID; got != 2 || !strings.Contains(m.status, "Visited all unread feeds") {
+		t.Fatalf("expected wrap to article 2 with a note, got %d and %q", got, m.status)
+	}
+	m = next(m, '{')
+	if got := m.entries[m.cursor].ID; got != 5 {
+		t.Fatalf("expected previous unread feed to wrap to article 5, got %d", got)
+	}
+}
+
 func TestModelUpdate_CollapseWithHMovesCursorToParents(t *testing.T) {
 	entries := []feedbin.Entry{
 		{ID: 1, Title: "One", FeedTitle: "Race", FeedFolder: "Formula 1", URL: "https://example.com/1", PublishedAt: time.Now().UTC()},
@@ -943,7 +2105,7 @@ func TestModelUpdate_ExpandWithLMovesCursorToChildren(t *testing.T) {
 		{ID: 2, Title: "Two", FeedTitle: "Race", FeedFolder: "Formula 1", URL: "https://example.com/2", PublishedAt: time.Now().UTC().Add(-time.Minute)},
 	}
 	m := NewModel(nil, entries)
-	m.collapsedFolders["Formula 1"] = true
+	m.setCollapsed(m.collapsedFolders, "Formula 1", true)
 	m.ensureCursorVisible()
 
 	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
@@ -967,7 +2129,7 @@ func TestModelUpdate_ExpandTopFeedWithLMovesCursorToArticle(t *testing.T) {
 		{ID: 2, Title: "Two", FeedTitle: "Top Feed", URL: "https://example.com/2", PublishedAt: time.Now().UTC().Add(-time.Minute)},
 	}
 	m := NewModel(nil, entries)
-	m.collapsedFeeds[treeFeedKey("", "Top Feed")] = true
+	m.setCollapsed(m.collapsedFeeds, treeFeedKey("", "Top Feed"), true)
 	m.ensureCursorVisible()
 
 	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
@@ -1008,8 +2170,8 @@ func TestModelUpdate_ExpandCanRecoverAllCollapsedFolders(t *testing.T) {
 	}
 	m := NewModel(nil, entries)
 
-	m.collapsedFolders["Formula 1"] = true
-	m.collapsedFolders["Motorsport"] = true
+	m.setCollapsed(m.collapsedFolders, "Formula 1", true)
+	m.setCollapsed(m.collapsedFolders, "Motorsport", true)
 
 	if len(m.visibleEntryIndices()) != 0 {
 		t.Fatalf("expected no visible entries, got %d", len(m.visibleEntryIndices()))
@@ -1133,10 +2295,571 @@ func TestTreeRows_CollectionsAlphabeticalRegardlessOfStatus(t *testing.T) {
 	}
 }
 
-func TestTreeRows_FolderFeedsAlphabeticalRegardlessOfStatus(t *testing.T) {
-	entries := []feedbin.Entry{
-		{ID: 1, Title: "Read race", FeedTitle: "Race", FeedFolder: "Formula 1", IsUnread: false, PublishedAt: time.Now().UTC().Add(-3 * time.Minute)},
-		{ID: 2, Title: "Unread autosport", FeedTitle: "Autosport", FeedFolder: "Formula 1", IsUnread: true, PublishedAt: time.Now().UTC().Add(-2 * time.Minute)},
+func TestModelRefresh_DropsEntriesReadElsewhereFromUnreadView(t *testing.T) {
+	now := time.Now().UTC()
+	cached := []feedbin.Entry{
+		{ID: 1, Title: "Still unread", IsUnread: true, PublishedAt: now},
+		{ID: 2, Title: "Read on the web", PublishedAt: now.Add(-time.Minute)},
+		{ID: 3, Title: "Older unread", IsUnread: true, PublishedAt: now.Add(-time.Hour)},
+	}
+	svc := fakeRefresher{entries: cached}
+	m := NewModel(svc, []feedbin.Entry{
+		{ID: 1, Title: "Still unread", IsUnread: true, PublishedAt: now},
+		{ID: 2, Title: "Read on the web", IsUnread: true, PublishedAt: now.Add(-time.Minute)},
+	})
+	m.filter = "unread"
+
+	// The refresh page only holds the newest entries, with entry 2 now read.
+	updated, cmd := m.Update(tuiactions.RefreshSuccessMsg{Entries: cached[:2], Source: "manual"})
+	m = updated.(Model)
+	if len(m.entries) != 1 || m.entries[0].ID != 1 {
+		t.Fatalf("expected the externally read entry to leave the unread view, got %+v", m.entries)
+	}
+	if m.status != "1 entry read elsewhere" {
+		t.Fatalf("expected a read-elsewhere status, got %q", m.status)
+	}
+	if cmd == nil {
+		t.Fatal("expected the unread view to reload from the cache")
+	}
+
+	updated, _ = m.Update(tuiactions.ReloadFilterCmd(svc, "unread", 100)())
+	m = updated.(Model)
+	if len(m.entries) != 2 || m.entries[0].ID != 1 || m.entries[1].ID != 3 {
+		t.Fatalf("expected the reload to list every cached unread entry, got %+v", m.entries)
+	}
+	if m.status != "1 entry read elsewhere" {
+		t.Fatalf("expected the reload to keep the refresh status, got %q", m.status)
+	}
+
+	m.filter = "starred"
+	m.entries = nil
+	updated, _ = m.Update(tuiactions.ReloadFilterCmd(svc, "unread", 100)())
+	m = updated.(Model)
+	if m.filter != "starred" || len(m.entries) != 0 {
+		t.Fatalf("expected a stale reload dropped after a filter switch, got filter=%q entries=%+v", m.filter, m.entries)
+	}
+}
+
+func TestModelRefresh_PendingReadIsNotReportedAsReadElsewhere(t *testing.T) {
+	entry := feedbin.Entry{ID: 1, Title: "Mine", IsUnread: true, PublishedAt: time.Now().UTC()}
+	m := NewModel(nil, []feedbin.Entry{entry})
+	m.filter = "unread"
+	m.beginToggle(1, tuiactions.BatchFieldUnread, false)
+	m.setEntryUnread(1, true)
+
+	entry.IsUnread = false
+	if got := m.readElsewhereCount([]feedbin.Entry{entry}); got != 0 {
+		t.Fatalf("expected an in-flight read to be ours, got %d", got)
+	}
+}
+
+func TestModelFeedLimit_TruncatesTreeUntilPlusExpands(t *testing.T) {
+	now := time.Now().UTC()
+	m := NewModel(nil, []feedbin.Entry{
+		{ID: 1, Title: "A1", FeedFolder: "Tech", FeedTitle: "Alpha", PublishedAt: now},
+		{ID: 2, Title: "B1", FeedFolder: "Tech", FeedTitle: "Beta", PublishedAt: now},
+		{ID: 3, Title: "C1", FeedFolder: "Tech", FeedTitle: "Gamma", PublishedAt: now},
+	})
+	m.SetFeedLimit(1, "name")
+	if !m.hasMoreRow("Tech") {
+		t.Fatal("expected a more row for the truncated folder")
+	}
+	view := m.View()
+	if !strings.Contains(view, "… 2 more feeds (press + to show)") || strings.Contains(view, "Gamma") {
+		t.Fatalf("expected the folder cut to one feed, got:\n%s", view)
+	}
+
+	// The cursor starts on the first article, inside the truncated folder.
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	m = updated.(Model)
+	if m.hasMoreRow("Tech") || m.status != "Showing every feed in Tech" {
+		t.Fatalf("expected the folder expanded, status %q", m.status)
+	}
+	if view := m.View(); !strings.Contains(view, "Gamma") || strings.Contains(view, "more feeds") {
+		t.Fatalf("expected every feed listed, got:\n%s", view)
+	}
+}
+
+func TestModelFeedLimit_EnterOnMoreRowExpands(t *testing.T) {
+	now := time.Now().UTC()
+	m := NewModel(nil, []feedbin.Entry{
+		{ID: 1, Title: "One", FeedTitle: "One", PublishedAt: now},
+		{ID: 2, Title: "Two", FeedTitle: "Two", PublishedAt: now},
+	})
+	m.SetFeedLimit(1, "name")
+	rows := m.treeRows()
+	last := rows[len(rows)-1]
+	if last.Kind != treeRowMore || last.Hidden != 1 {
+		t.Fatalf("expected a trailing more row, got %+v", last)
+	}
+	m.treeCursor = len(rows) - 1
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.hasMoreRow("") || m.status != "Showing every feed" {
+		t.Fatalf("expected the Feeds section expanded, status %q", m.status)
+	}
+}
+
+func TestModelFeedGroupJump_VisitsEveryFeedRowAndWraps(t *testing.T) {
+	now := time.Now().UTC()
+	m := NewModel(nil, []feedbin.Entry{
+		{ID: 1, Title: "A1", FeedFolder: "Tech", FeedTitle: "Alpha", PublishedAt: now},
+		{ID: 2, Title: "A2", FeedFolder: "Tech", FeedTitle: "Alpha", PublishedAt: now.Add(-time.Minute)},
+		{ID: 3, Title: "B1", FeedFolder: "Tech", FeedTitle: "Beta", PublishedAt: now},
+		{ID: 4, Title: "T1", FeedTitle: "Top", PublishedAt: now},
+	})
+	press := func(key rune) treeRow {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}})
+		m = updated.(Model)
+		row := m.treeRows()[m.treeCursor]
+		if row.Kind != treeRowFeed {
+			t.Fatalf("expected the cursor on a feed row, got %+v", row)
+		}
+		return row
+	}
+
+	m.treeCursor = 0
+	var visited []string
+	for i := 0; i < 3; i++ {
+		visited = append(visited, press(')').Feed)
+	}
+	if strings.Join(visited, ",") != "Alpha,Beta,Top" {
+		t.Fatalf("expected feeds in tree order across sections, got %v", visited)
+	}
+	if row := press(')'); row.Feed != "Alpha" || m.status != "Wrapped to the first feed: Alpha" {
+		t.Fatalf("expected a wrap to the first feed, got %q with status %q", row.Feed, m.status)
+	}
+	if row := press('('); row.Feed != "Top" || m.status != "Wrapped to the last feed: Top" {
+		t.Fatalf("expected a wrap to the last feed, got %q with status %q", row.Feed, m.status)
+	}
+	if row := press('('); row.Feed != "Beta" || m.status != "Feed: Beta" {
+		t.Fatalf("expected the previous feed, got %q with status %q", row.Feed, m.status)
+	}
+}
+
+func TestModelReadToday_CountsReadsAndResetsAtMidnight(t *testing.T) {
+	day := time.Date(2026, 3, 2, 23, 0, 0, 0, time.Local)
+	m := NewModel(nil, []feedbin.Entry{{ID: 1, Title: "One", IsUnread: true}, {ID: 2, Title: "Two", IsUnread: true}})
+	m.nowFn = func() time.Time { return day }
+	m.SetReadToday(5, day)
+
+	if strings.Contains(m.footer(), "Read today") {
+		t.Fatalf("expected the counter hidden by default, got %q", m.footer())
+	}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'#'}})
+	m = updated.(Model)
+	if !m.showReadToday || !m.preferences().ShowReadToday {
+		t.Fatal("expected # to show the counter")
+	}
+
+	updated, _ = m.Update(tuiactions.ToggleUnreadSuccessMsg{EntryID: 1, NextUnread: false})
+	m = updated.(Model)
+	updated, _ = m.Update(tuiactions.BatchUpdateResultMsg{Field: tuiactions.BatchFieldUnread, Value: false, Succeeded: []int64{2}})
+	m = updated.(Model)
+	updated, _ = m.Update(tuiactions.ToggleUnreadSuccessMsg{EntryID: 1, NextUnread: true})
+	m = updated.(Model)
+	if !strings.Contains(m.footer(), "Read today: 7") {
+		t.Fatalf("expected 7 reads today, got %q", m.footer())
+	}
+
+	day = day.Add(2 * time.Hour)
+	if !strings.Contains(m.footer(), "Read today: 0") {
+		t.Fatalf("expected the count to reset after midnight, got %q", m.footer())
+	}
+	updated, _ = m.Update(tuiactions.ToggleUnreadSuccessMsg{EntryID: 2, NextUnread: false})
+	m = updated.(Model)
+	if !strings.Contains(m.footer(), "Read today: 1") {
+		t.Fatalf("expected the new day's first read, got %q", m.footer())
+	}
+}
+
+func TestModelStripFeedPrefix_TogglesTitlePrefixAndPersists(t *testing.T) {
+	m := NewModel(nil, []feedbin.Entry{{ID: 1, Title: "TechBlog: New phone released", FeedTitle: "TechBlog", PublishedAt: time.Now().UTC()}})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	m = updated.(Model)
+	if !strings.Contains(stripANSI(m.View()), "TechBlog: New phone released") {
+		t.Fatalf("expected the full title by default, got %q", stripANSI(m.View()))
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Z'}})
+	m = updated.(Model)
+	if !m.stripFeedPrefix || !m.preferences().StripFeedPrefix {
+		t.Fatal("expected Z to enable and persist stripping feed prefixes")
+	}
+	view := stripANSI(m.View())
+	if strings.Contains(view, "TechBlog: New") || !strings.Contains(view, "New phone released") {
+		t.Fatalf("expected the feed prefix stripped from the title, got %q", view)
+	}
+	if !strings.Contains(view, "TechBlog") {
+		t.Fatalf("expected the feed still named in the tree, got %q", view)
+	}
+}
+
+func TestParseMuteRules_SubstringAndRegex(t *testing.T) {
+	rules, err := ParseMuteRules([]string{"Season Finale", " ", `/\bscore[sd]?\b/`})
+	if err != nil {
+		t.Fatalf("ParseMuteRules returned error: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+	cases := []struct {
+		entry feedbin.Entry
+		rule  int
+		want  bool
+	}{
+		{feedbin.Entry{Title: "Before the season finale"}, 0, true},
+		{feedbin.Entry{Summary: "SEASON FINALE recap"}, 0, true},
+		{feedbin.Entry{Title: "Season opener"}, 0, false},
+		{feedbin.Entry{Title: "Final scores are in"}, 1, true},
+		{feedbin.Entry{Content: "<p>He scored twice</p>"}, 1, true},
+		{feedbin.Entry{Title: "Scoreboard redesign"}, 1, false},
+	}
+	for _, tc := range cases {
+		if got := rules[tc.rule].matches(tc.entry); got != tc.want {
+			t.Fatalf("rule %d on %+v: got %v, want %v", tc.rule, tc.entry, got, tc.want)
+		}
+	}
+
+	if _, err := ParseMuteRules([]string{"/([a-z/"}); err == nil {
+		t.Fatal("expected error for an invalid regex rule")
+	}
+}
+
+func TestModelMute_HidesMatchingEntriesUntilToggled(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Launch day", FeedTitle: "Feed", IsUnread: true, PublishedAt: now},
+		{ID: 2, Title: "Finale spoilers", FeedTitle: "Feed", IsUnread: true, PublishedAt: now.Add(-time.Minute)},
+		{ID: 3, Title: "Match report", Summary: "Final score 3-1", FeedTitle: "Feed", PublishedAt: now.Add(-2 * time.Minute)},
+	}
+	svc := &fakeRefresher{entries: entries}
+	m := NewModel(svc, entries)
+	rules, err := ParseMuteRules([]string{"spoiler", "/score \\d/"})
+	if err != nil {
+		t.Fatalf("ParseMuteRules returned error: %v", err)
+	}
+	m.SetMuteRules(rules)
+	if len(m.entries) != 1 || m.entries[0].ID != 1 {
+		t.Fatalf("expected only the unmuted entry, got %+v", m.entries)
+	}
+	if !strings.Contains(m.footer(), "2 muted") {
+		t.Fatalf("expected the muted count in the footer, got %q", m.footer())
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'~'}})
+	m = updated.(Model)
+	if !m.showMuted || m.status != "Showing muted entries" || cmd == nil {
+		t.Fatalf("expected muted entries shown with a reload, got show=%v status=%q", m.showMuted, m.status)
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if len(m.entries) != 3 {
+		t.Fatalf("expected every entry after the reload, got %d", len(m.entries))
+	}
+	if strings.Contains(m.footer(), "muted") {
+		t.Fatalf("expected no muted count while showing muted, got %q", m.footer())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'~'}})
+	m = updated.(Model)
+	if m.showMuted || m.status != "Hiding muted entries" || len(m.entries) != 1 {
+		t.Fatalf("expected muted entries hidden again, got show=%v status=%q entries=%d", m.showMuted, m.status, len(m.entries))
+	}
+	if !strings.Contains(m.footer(), "2 muted") {
+		t.Fatalf("expected the muted count back, got %q", m.footer())
+	}
+}
+
+func TestModelView_EmptySearchDiffersFromNoEntries(t *testing.T) {
+	m := NewModel(nil, nil)
+	if view := m.View(); !strings.Contains(view, "No entries available.") {
+		t.Fatalf("expected the no-data message, got %q", view)
+	}
+
+	m.filter = "unread"
+	m.searchQuery = "foo"
+	view := m.View()
+	if !strings.Contains(view, "No matches for 'foo' in unread — press ctrl+l to clear.") {
+		t.Fatalf("expected the search-empty message, got %q", view)
+	}
+	if strings.Contains(view, "No entries available.") {
+		t.Fatalf("expected no no-data message while searching, got %q", view)
+	}
+}
+
+func TestModelDetailLines_ArticleIndentNarrowsWrap(t *testing.T) {
+	body := "<p>" + strings.Repeat("word ", 80) + "</p>"
+	entries := []feedbin.Entry{{ID: 1, Title: "Indented", FeedTitle: "Feed", Content: body, PublishedAt: time.Now().UTC()}}
+	m := NewModel(nil, entries)
+	m.width = 80
+	baseWidth := m.detailContentWidth()
+	baseMargin := m.detailHorizontalMargin()
+
+	m.SetArticleIndent(4)
+	if got := m.detailContentWidth(); got != baseWidth-4 {
+		t.Fatalf("expected the wrap width to shrink by 4 to %d, got %d", baseWidth-4, got)
+	}
+	prefix := strings.Repeat(" ", baseMargin+4)
+	for _, line := range m.detailLines(entries[0]) {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, prefix) {
+			t.Fatalf("expected every line indented by %d, got %q", len(prefix), line)
+		}
+		if width := len([]rune(strings.TrimRight(line, " "))); strings.Contains(line, "word") && width > baseMargin+baseWidth {
+			t.Fatalf("expected body lines to fit the original width %d, got %d: %q", baseMargin+baseWidth, width, line)
+		}
+	}
+}
+
+type fakeLocalReader struct {
+	marked map[int64][]int64
+}
+
+func (f *fakeLocalReader) MarkFeedReadLocally(_ context.Context, feedID int64, entryIDs []int64) error {
+	f.marked[feedID] = append(f.marked[feedID], entryIDs...)
+	return nil
+}
+
+func (f *fakeLocalReader) ClearFeedReadLocally(_ context.Context, feedID int64) (bool, error) {
+	_, ok := f.marked[feedID]
+	delete(f.marked, feedID)
+	return ok, nil
+}
+
+func TestModelMarkFeedReadLocally_VersusSyncedMarkRead(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "One", FeedID: 10, FeedTitle: "Feed A", IsUnread: true, PublishedAt: now},
+		{ID: 2, Title: "Two", FeedID: 10, FeedTitle: "Feed A", IsUnread: true, PublishedAt: now.Add(-time.Minute)},
+		{ID: 3, Title: "Three", FeedID: 20, FeedTitle: "Feed B", IsUnread: true, PublishedAt: now.Add(-2 * time.Minute)},
+	}
+	m := NewModel(fakeRefresher{entries: entries}, entries)
+	reader := &fakeLocalReader{marked: map[int64][]int64{}}
+	m.SetLocalReader(reader)
+	for i, row := range m.treeRows() {
+		if row.Kind == treeRowFeed && row.Feed == "Feed A" {
+			m.treeCursor = i
+		}
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	if !strings.Contains(updated.(Model).status, "Mark 2 entries read locally in Feed A?") {
+		t.Fatalf("expected the bulk confirmation, got %q", updated.(Model).status)
+	}
+	updated, cmd = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	if cmd == nil {
+		t.Fatal("expected a local read command")
+	}
+	msg := cmd()
+	if _, ok := msg.(tuiactions.LocalReadResultMsg); !ok {
+		t.Fatalf("expected a local-only mark, got %T", msg)
+	}
+	updated, _ = updated.Update(msg)
+	model := updated.(Model)
+	if len(reader.marked[10]) != 2 || len(reader.marked) != 1 {
+		t.Fatalf("expected feed 10 marked locally, got %+v", reader.marked)
+	}
+	for _, entry := range model.entries {
+		if entry.IsUnread != (entry.FeedID == 20) {
+			t.Fatalf("expected only Feed A read, got entry %d unread=%v", entry.ID, entry.IsUnread)
+		}
+	}
+	if !strings.Contains(model.status, "read locally in Feed A") {
+		t.Fatalf("unexpected status %q", model.status)
+	}
+
+	// ctrl+u again on the now fully read feed clears the local mark.
+	for i, row := range model.treeRows() {
+		if row.Kind == treeRowFeed && row.Feed == "Feed A" {
+			model.treeCursor = i
+		}
+	}
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	if cmd == nil {
+		t.Fatal("expected a clear-mark command")
+	}
+	cleared, _ := model.Update(cmd())
+	if len(reader.marked[10]) != 0 || !strings.Contains(cleared.(Model).status, "Cleared the local read mark on Feed A") {
+		t.Fatalf("expected feed 10's mark cleared, got %+v status %q", reader.marked, cleared.(Model).status)
+	}
+
+	for i, row := range model.treeRows() {
+		if row.Kind == treeRowFeed && row.Feed == "Feed B" {
+			model.treeCursor = i
+		}
+	}
+	model.confirmBulkActions = false
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+	if cmd == nil {
+		t.Fatal("expected a synced mark-read command")
+	}
+	if _, ok := cmd().(tuiactions.BatchUpdateResultMsg); !ok {
+		t.Fatal("expected U to sync the feed mark-read to Feedbin")
+	}
+	if len(reader.marked[20]) != 0 {
+		t.Fatalf("expected U not to mark locally, got %+v", reader.marked)
+	}
+}
+
+type fakeSearchSwitcher struct {
+	mode     string
+	requests []string
+}
+
+func (f *fakeSearchSwitcher) SearchMode() string { return f.mode }
+
+func (f *fakeSearchSwitcher) SetSearchMode(_ context.Context, mode string) (string, error) {
+	f.requests = append(f.requests, mode)
+	f.mode = mode
+	return f.mode, nil
+}
+
+func TestModelSearchMode_ToggleShowsBackendAndReruns(t *testing.T) {
+	entries := []feedbin.Entry{{ID: 1, Title: "Golang tips", FeedTitle: "Feed", PublishedAt: time.Now().UTC()}}
+	m := NewModel(fakeRefresher{entries: entries}, entries)
+	switcher := &fakeSearchSwitcher{mode: "like"}
+	m.SetSearchModeSwitcher(switcher)
+	if strings.Contains(m.footer(), "Search: LIKE") {
+		t.Fatalf("expected no backend in the footer without a search, got %q", m.footer())
+	}
+	m.searchQuery = "lang"
+	if !strings.Contains(m.footer(), "Search: LIKE") {
+		t.Fatalf("expected the LIKE backend in the footer, got %q", m.footer())
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	if cmd == nil {
+		t.Fatal("expected a search mode command")
+	}
+	updated, cmd = updated.Update(cmd())
+	m = updated.(Model)
+	if len(switcher.requests) != 1 || switcher.requests[0] != "fts" {
+		t.Fatalf("expected a switch to fts, got %v", switcher.requests)
+	}
+	if !strings.Contains(m.footer(), "Search: FTS") {
+		t.Fatalf("expected the FTS backend in the footer, got %q", m.footer())
+	}
+	if cmd == nil {
+		t.Fatal("expected the active search to rerun")
+	}
+	batch := cmd().(tea.BatchMsg)
+	if len(batch) != 2 || !m.loading {
+		t.Fatalf("expected a status clear and a search reload, got %d commands", len(batch))
+	}
+	if _, ok := batch[1]().(tuiactions.SearchLoadSuccessMsg); !ok {
+		t.Fatal("expected the search to reload with the new backend")
+	}
+
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	updated, _ = updated.Update(cmd())
+	if got := updated.(Model).searchMode; got != "like" || switcher.requests[1] != "like" {
+		t.Fatalf("expected a switch back to like, got %q (%v)", got, switcher.requests)
+	}
+}
+
+func TestModelSearchMode_WaitsForRefresh(t *testing.T) {
+	entries := []feedbin.Entry{{ID: 1, Title: "Golang tips", FeedTitle: "Feed", PublishedAt: time.Now().UTC()}}
+	m := NewModel(fakeRefresher{entries: entries}, entries)
+	switcher := &fakeSearchSwitcher{mode: "like"}
+	m.SetSearchModeSwitcher(switcher)
+	m.refreshing = true
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	if model := updated.(Model); !strings.HasPrefix(model.status, "Refresh in progress") || model.loading {
+		t.Fatalf("expected the switch refused, got status %q loading=%v", model.status, model.loading)
+	}
+	if len(switcher.requests) != 0 {
+		t.Fatalf("expected no switch requested, got %v", switcher.requests)
+	}
+}
+
+type fakeAgeSwitch struct{ showAll bool }
+
+func (f *fakeAgeSwitch) SetShowAllAges(show bool) { f.showAll = show }
+
+func TestModelMaxAge_HidesOldEntriesUntilToggled(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Recent", FeedTitle: "Feed", IsUnread: true, PublishedAt: now.Add(-time.Hour)},
+		{ID: 2, Title: "Old", FeedTitle: "Feed", IsUnread: true, IsStarred: true, PublishedAt: now.Add(-30 * 24 * time.Hour)},
+	}
+	m := NewModel(nil, entries)
+	sw := &fakeAgeSwitch{}
+	m.SetMaxAge(7*24*time.Hour, sw)
+	if len(m.entries) != 1 || m.entries[0].ID != 1 {
+		t.Fatalf("expected only the recent entry, got %+v", m.entries)
+	}
+	if !strings.Contains(m.footer(), "all, last 7d") {
+		t.Fatalf("expected the cutoff in the footer, got %q", m.footer())
+	}
+
+	m.searchQuery = "old"
+	if m.ageCutoffActive() {
+		t.Fatal("expected search to ignore the age cutoff")
+	}
+	m.searchQuery = ""
+	m.filter = "starred"
+	if m.ageCutoffActive() {
+		t.Fatal("expected starred to ignore the age cutoff")
+	}
+	m.filter = "all"
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = updated.(Model)
+	if !m.showAllAges || !sw.showAll || m.status != "Showing entries of every age" {
+		t.Fatalf("expected every age shown, got show=%v switch=%v status=%q", m.showAllAges, sw.showAll, m.status)
+	}
+	if strings.Contains(m.footer(), "last 7d") {
+		t.Fatalf("expected no cutoff in the footer, got %q", m.footer())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = updated.(Model)
+	if m.showAllAges || sw.showAll || m.status != "Showing entries from the last 7d" {
+		t.Fatalf("expected the cutoff back, got show=%v switch=%v status=%q", m.showAllAges, sw.showAll, m.status)
+	}
+}
+
+func TestModelCopyFeedUnreadList_SkipsReadEntries(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Read race", URL: "https://example.com/1", FeedTitle: "Feed A", PublishedAt: now.Add(-3 * time.Minute)},
+		{ID: 2, Title: "Quali report", URL: "https://example.com/2", FeedTitle: "Feed A", IsUnread: true, PublishedAt: now.Add(-2 * time.Minute)},
+		{ID: 3, Title: "Race report", URL: "https://example.com/3", FeedTitle: "Feed A", IsUnread: true, PublishedAt: now.Add(-time.Minute)},
+		{ID: 4, Title: "Other feed", URL: "https://example.com/4", FeedTitle: "Feed B", IsUnread: true, PublishedAt: now},
+	}
+	m := NewModel(nil, entries)
+	var copied string
+	m.copyURLFn = func(text string) error { copied = text; return nil }
+	for i, row := range m.treeRows() {
+		if row.Kind == treeRowFeed && row.Feed == "Feed A" {
+			m.treeCursor = i
+		}
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	if cmd == nil {
+		t.Fatal("expected copy command")
+	}
+	updated, _ = updated.Update(cmd())
+	model := updated.(Model)
+	want := "Feed A (2 unread)\n- Race report\n  https://example.com/3\n- Quali report\n  https://example.com/2\n"
+	if copied != want {
+		t.Fatalf("unexpected copied text:\n%s\n--- want ---\n%s", copied, want)
+	}
+	if !strings.HasPrefix(model.status, "Copied 2 unread titles from Feed A") {
+		t.Fatalf("expected copied count in status, got %q", model.status)
+	}
+}
+
+func TestTreeRows_FolderFeedsAlphabeticalRegardlessOfStatus(t *testing.T) {
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Read race", FeedTitle: "Race", FeedFolder: "Formula 1", IsUnread: false, PublishedAt: time.Now().UTC().Add(-3 * time.Minute)},
+		{ID: 2, Title: "Unread autosport", FeedTitle: "Autosport", FeedFolder: "Formula 1", IsUnread: true, PublishedAt: time.Now().UTC().Add(-2 * time.Minute)},
 		{ID: 3, Title: "Unread boxbox", FeedTitle: "Boxbox", FeedFolder: "Formula 1", IsUnread: true, PublishedAt: time.Now().UTC().Add(-time.Minute)},
 	}
 	m := NewModel(nil, entries)
@@ -1203,6 +2926,44 @@ func TestModelUpdate_HelpToggle(t *testing.T) {
 	}
 }
 
+type fakeDiagnostics struct {
+	lastErr error
+}
+
+func (f *fakeDiagnostics) Diagnostics(_ context.Context, lastErr error) string {
+	f.lastErr = lastErr
+	return "reeder diagnostics\nversion: test\n"
+}
+
+func TestModelUpdate_HelpCopiesDiagnostics(t *testing.T) {
+	m := NewModel(nil, []feedbin.Entry{{ID: 1, Title: "One", PublishedAt: time.Now().UTC()}})
+	source := &fakeDiagnostics{}
+	m.SetDiagnosticsSource(source)
+	var copied string
+	m.copyURLFn = func(text string) error {
+		copied = text
+		return nil
+	}
+	m.err = errors.New("sync failed")
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	updated, cmd := updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if cmd == nil {
+		t.Fatal("expected a copy command")
+	}
+	updated, _ = updated.Update(cmd())
+	model := updated.(Model)
+	if copied != "reeder diagnostics\nversion: test\n" {
+		t.Fatalf("unexpected copied text %q", copied)
+	}
+	if source.lastErr == nil || source.lastErr.Error() != "sync failed" {
+		t.Fatalf("expected the shown error passed along, got %v", source.lastErr)
+	}
+	if !model.showHelp || !strings.HasPrefix(model.status, "Copied diagnostic info") {
+		t.Fatalf("expected help kept open with a copy status, got %v %q", model.showHelp, model.status)
+	}
+}
+
 func TestModelUpdate_DetailPrevNext(t *testing.T) {
 	m := NewModel(nil, []feedbin.Entry{
 		{ID: 1, Title: "One", FeedTitle: "Feed A", PublishedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
@@ -1266,28 +3027,126 @@ func TestModelUpdate_OpenWithConfirmMarkRead(t *testing.T) {
 	}
 }
 
-func TestModelUpdate_OpenDebounceSkipsSecondMarkRead(t *testing.T) {
-	service := &openWorkflowService{unreadResult: false}
-	m := NewModel(service, []feedbin.Entry{{
-		ID:          1,
-		Title:       "Entry",
-		URL:         "https://example.com",
-		IsUnread:    true,
-		PublishedAt: time.Now().UTC(),
-	}})
-	m.markReadOnOpen = true
-	m.inDetail = true
-	m.openURLFn = func(string) error { return nil }
-	now := time.Date(2026, 2, 9, 12, 0, 0, 0, time.UTC)
-	m.nowFn = func() time.Time { return now }
+func TestModelUpdate_EnterDetailMarksReadOnlyWhenEnabled(t *testing.T) {
+	enterDetail := func(markOnDetail bool) Model {
+		t.Helper()
+		m := NewModel(fakeRefresher{unreadResult: false}, []feedbin.Entry{{
+			ID:          1,
+			Title:       "Entry",
+			FeedTitle:   "Feed",
+			IsUnread:    true,
+			PublishedAt: time.Now().UTC(),
+		}})
+		m.markReadOnDetailOpen = markOnDetail
+		for i, row := range m.treeRows() {
+			if row.Kind == treeRowArticle {
+				m.treeCursor = i
+			}
+		}
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if !updated.(Model).inDetail {
+			t.Fatal("expected enter to open detail")
+		}
+		if cmd == nil {
+			return updated.(Model)
+		}
+		msgs := []tea.Msg{cmd()}
+		if batch, ok := msgs[0].(tea.BatchMsg); ok {
+			msgs = msgs[:0]
+			for _, c := range batch {
+				msgs = append(msgs, c())
+			}
+		}
+		for _, msg := range msgs {
+			if msg != nil {
+				updated, _ = updated.Update(msg)
+			}
+		}
+		return updated.(Model)
+	}
 
-	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
-	if cmd == nil {
-		t.Fatal("expected open command")
+	if model := enterDetail(false); !model.entries[0].IsUnread {
+		t.Fatal("expected detail to leave the entry unread by default")
 	}
-	msg := cmd()
-	updated, cmd = updated.Update(msg)
-	if cmd == nil {
+	if model := enterDetail(true); model.entries[0].IsUnread {
+		t.Fatal("expected detail to mark the entry read when enabled")
+	}
+}
+
+func TestModelUpdate_DetailNavigationMarksReadWhenEnabled(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "A one", FeedTitle: "Feed A", IsUnread: true, PublishedAt: now},
+		{ID: 2, Title: "A two", FeedTitle: "Feed A", IsUnread: true, PublishedAt: now.Add(-time.Minute)},
+		{ID: 3, Title: "B one", FeedTitle: "Feed B", IsUnread: true, PublishedAt: now.Add(-time.Hour)},
+	}
+	m := NewModel(fakeRefresher{entries: entries}, entries)
+	m.markReadOnDetailOpen = true
+	m.inDetail = true
+	m.cursor, m.selectedID = 1, 2
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'['}})
+	m = updated.(Model)
+	if m.selectedID != 1 || m.lastOpenReadEntryID != 1 {
+		t.Fatalf("expected [ to mark entry 1 read, got selected %d marked %d", m.selectedID, m.lastOpenReadEntryID)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{']'}})
+	m = updated.(Model)
+	if m.selectedID != 2 || m.lastOpenReadEntryID != 2 {
+		t.Fatalf("expected ] to mark entry 2 read, got selected %d marked %d", m.selectedID, m.lastOpenReadEntryID)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'}'}})
+	if m = updated.(Model); m.selectedID != 3 || m.lastOpenReadEntryID != 3 {
+		t.Fatalf("expected } to mark entry 3 read, got selected %d marked %d", m.selectedID, m.lastOpenReadEntryID)
+	}
+}
+
+func TestModelUpdate_EnterDetailMarkReadRespectsConfirm(t *testing.T) {
+	m := NewModel(fakeRefresher{unreadResult: false}, []feedbin.Entry{{
+		ID:          1,
+		Title:       "Entry",
+		FeedTitle:   "Feed",
+		IsUnread:    true,
+		PublishedAt: time.Now().UTC(),
+	}})
+	m.markReadOnDetailOpen = true
+	m.confirmOpenRead = true
+	updated, _ := m.openFirstUnread()
+	model := updated.(Model)
+	if model.pendingOpenReadEntryID != 1 || !model.entries[0].IsUnread {
+		t.Fatalf("expected a pending mark-read awaiting confirm, got pending=%d unread=%v", model.pendingOpenReadEntryID, model.entries[0].IsUnread)
+	}
+
+	model.inDetail = false
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
+	model = updated.(Model)
+	if model.markReadOnDetailOpen || model.preferences().MarkReadOnDetailOpen {
+		t.Fatal("expected V to turn mark read on detail open off")
+	}
+}
+
+func TestModelUpdate_OpenDebounceSkipsSecondMarkRead(t *testing.T) {
+	service := &openWorkflowService{unreadResult: false}
+	m := NewModel(service, []feedbin.Entry{{
+		ID:          1,
+		Title:       "Entry",
+		URL:         "https://example.com",
+		IsUnread:    true,
+		PublishedAt: time.Now().UTC(),
+	}})
+	m.markReadOnOpen = true
+	m.inDetail = true
+	m.openURLFn = func(string) error { return nil }
+	now := time.Date(2026, 2, 9, 12, 0, 0, 0, time.UTC)
+	m.nowFn = func() time.Time { return now }
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if cmd == nil {
+		t.Fatal("expected open command")
+	}
+	msg := cmd()
+	updated, cmd = updated.Update(msg)
+	if cmd == nil {
 		t.Fatal("expected mark-read command")
 	}
 	msg = cmd()
@@ -1376,8 +3235,8 @@ func TestModelUpdate_PreferenceTogglesPersist(t *testing.T) {
 	if !saved[2].ConfirmOpenRead {
 		t.Fatalf("expected confirm true after third save, got %+v", saved[2])
 	}
-	if saved[3].RelativeTime {
-		t.Fatalf("expected relative-time false after fourth save, got %+v", saved[3])
+	if saved[3].TimeFormat != "absolute" {
+		t.Fatalf("expected absolute time format after fourth save, got %+v", saved[3])
 	}
 	if !saved[4].ShowNumbers {
 		t.Fatalf("expected show-numbers true after fifth save, got %+v", saved[4])
@@ -1436,3 +3295,2119 @@ func TestModelUpdate_InlineImagePreviewError(t *testing.T) {
 		t.Fatalf("expected inline preview error in detail view, got %s", view)
 	}
 }
+
+func TestModelBatchUpdate_PartialFailureOffersRetry(t *testing.T) {
+	service := &openWorkflowService{}
+	now := time.Now().UTC()
+	m := NewModel(service, []feedbin.Entry{
+		{ID: 1, Title: "One", IsUnread: true, PublishedAt: now},
+		{ID: 2, Title: "Two", IsUnread: true, PublishedAt: now.Add(-time.Minute)},
+		{ID: 3, Title: "Three", IsUnread: true, PublishedAt: now.Add(-2 * time.Minute)},
+	})
+
+	updated, _ := m.Update(tuiactions.BatchUpdateResultMsg{
+		Field:     tuiactions.BatchFieldUnread,
+		Value:     false,
+		Succeeded: []int64{1, 2},
+		Failed:    []int64{3},
+		Err:       errors.New("chunk failed"),
+		Status:    tuiactions.BatchStatus(tuiactions.BatchFieldUnread, false, 2, 1),
+	})
+	model := updated.(Model)
+	if model.status != "Marked 2/3 read, 1 failed — press r to retry" {
+		t.Fatalf("unexpected status: %q", model.status)
+	}
+	for _, entry := range model.entries {
+		if entry.ID == 3 && !entry.IsUnread {
+			t.Fatal("failed entry must stay unread")
+		}
+		if entry.ID != 3 && entry.IsUnread {
+			t.Fatalf("expected entry %d marked read", entry.ID)
+		}
+	}
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if cmd == nil {
+		t.Fatal("expected retry command")
+	}
+	updated, _ = updated.Update(cmd())
+	model = updated.(Model)
+	if len(service.batchIDs) != 1 || service.batchIDs[0] != 3 {
+		t.Fatalf("expected retry of failed entry only, got %v", service.batchIDs)
+	}
+	if model.batchRetry != nil {
+		t.Fatal("expected retry state cleared after success")
+	}
+	if model.status != "Marked 1 entry read" {
+		t.Fatalf("unexpected status after retry: %q", model.status)
+	}
+}
+
+func TestModelBatchUpdate_FailedRetryFallsBackToRefresh(t *testing.T) {
+	now := time.Now().UTC()
+	m := NewModel(&fakeRefresher{}, []feedbin.Entry{
+		{ID: 1, Title: "One", IsUnread: true, PublishedAt: now},
+		{ID: 2, Title: "Two", IsUnread: true, PublishedAt: now.Add(-time.Minute)},
+	})
+	failed := tuiactions.BatchUpdateResultMsg{
+		Field:     tuiactions.BatchFieldUnread,
+		Value:     false,
+		Succeeded: []int64{1},
+		Failed:    []int64{2},
+		Err:       errors.New("chunk failed"),
+		Status:    tuiactions.BatchStatus(tuiactions.BatchFieldUnread, false, 1, 1),
+	}
+	updated, _ := m.Update(failed)
+
+	updated, cmd := updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	model := updated.(Model)
+	if cmd == nil || model.refreshing {
+		t.Fatal("expected first r to retry the failed entries")
+	}
+	failed.Succeeded = nil
+	failed.Status = tuiactions.BatchStatus(tuiactions.BatchFieldUnread, false, 0, 1)
+	updated, _ = model.Update(failed)
+	model = updated.(Model)
+	if model.batchRetry != nil {
+		t.Fatal("expected retry state cleared after the retry failed again")
+	}
+	if model.status != "Marked 0/1 read, 1 failed again — press r to refresh" {
+		t.Fatalf("unexpected status: %q", model.status)
+	}
+
+	updated, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if cmd == nil || !updated.(Model).refreshing {
+		t.Fatal("expected r to refresh after the failed retry")
+	}
+}
+
+func TestModelAutoPreview_ShowsAfterDelayAndDismissesOnMove(t *testing.T) {
+	now := time.Now().UTC()
+	m := NewModel(fakeRefresher{}, []feedbin.Entry{
+		{ID: 1, Title: "First", FeedTitle: "Feed", Content: "<p>First body</p>", PublishedAt: now},
+		{ID: 2, Title: "Second", FeedTitle: "Feed", Content: "<p>Second body</p>", PublishedAt: now.Add(-time.Minute)},
+	})
+	m.ApplyPreferences(Preferences{AutoPreview: true})
+	if !m.currentTreeRowIsArticle() {
+		t.Fatal("expected cursor to start on an article")
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	model := updated.(Model)
+	if cmd == nil {
+		t.Fatal("expected debounced preview command")
+	}
+	hovered := model.hoveredEntryID()
+	if hovered == 0 || model.previewPendingID != hovered {
+		t.Fatalf("expected pending preview for hovered entry, got pending=%d hovered=%d", model.previewPendingID, hovered)
+	}
+	if strings.Contains(model.View(), "Preview") {
+		t.Fatal("preview must not render before the delay elapses")
+	}
+	staleSeq := model.previewSeq
+
+	updated, _ = model.Update(autoPreviewMsg{seq: model.previewSeq, entryID: hovered})
+	model = updated.(Model)
+	view := model.View()
+	if !strings.Contains(view, "Preview") || !strings.Contains(view, "body") {
+		t.Fatalf("expected inline preview in view, got:\n%s", view)
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	model = updated.(Model)
+	if model.previewEntryID != 0 || strings.Contains(model.View(), "Preview") {
+		t.Fatal("expected preview dismissed after cursor move")
+	}
+
+	updated, _ = model.Update(autoPreviewMsg{seq: staleSeq, entryID: hovered})
+	model = updated.(Model)
+	if model.previewEntryID != 0 {
+		t.Fatal("expected stale preview tick to be ignored")
+	}
+}
+
+func TestModelAutoPreview_DisabledByDefault(t *testing.T) {
+	m := NewModel(fakeRefresher{}, []feedbin.Entry{
+		{ID: 1, Title: "First", Content: "<p>Body</p>", PublishedAt: time.Now().UTC()},
+	})
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	model := updated.(Model)
+	if model.previewPendingID != 0 || model.previewSeq != 0 {
+		t.Fatalf("expected no preview scheduling when disabled, got pending=%d seq=%d", model.previewPendingID, model.previewSeq)
+	}
+}
+
+func TestModelCopyFeedURL_OnFeedRow(t *testing.T) {
+	now := time.Now().UTC()
+	m := NewModel(fakeRefresher{}, []feedbin.Entry{
+		{ID: 1, Title: "One", FeedTitle: "Feed A", FeedFolder: "Tech", FeedURL: "https://a.example.com/feed.xml", PublishedAt: now},
+		{ID: 2, Title: "Two", FeedTitle: "Feed B", FeedFolder: "Tech", PublishedAt: now.Add(-time.Minute)},
+	})
+	var copied string
+	m.copyURLFn = func(text string) error {
+		copied = text
+		return nil
+	}
+
+	m.setTreeCursorToFeed("Tech", "Feed A")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatal("expected copy command")
+	}
+	msg, ok := cmd().(tuiactions.OpenURLSuccessMsg)
+	if !ok || msg.Status != "Copied feed URL for Feed A: 30 B (30 chars)" {
+		t.Fatalf("unexpected copy result: %+v", msg)
+	}
+	if copied != "https://a.example.com/feed.xml" {
+		t.Fatalf("unexpected clipboard content: %q", copied)
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}})
+	if cmd == nil {
+		t.Fatal("expected OPML copy command")
+	}
+	cmd()
+	if !strings.HasPrefix(copied, "<outline ") || !strings.Contains(copied, `xmlUrl="https://a.example.com/feed.xml"`) {
+		t.Fatalf("unexpected OPML snippet: %q", copied)
+	}
+
+	m.setTreeCursorToFeed("Tech", "Feed B")
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	model := updated.(Model)
+	if model.status != "No feed URL stored for Feed B (refresh to sync subscriptions)" {
+		t.Fatalf("unexpected status for missing feed URL: %q", model.status)
+	}
+}
+
+type fakeFeedManager struct {
+	feeds     []feedbin.FeedSummary
+	renamed   string
+	muted     map[int64]bool
+	unsubbed  []int64
+	refreshed []int64
+}
+
+func (f *fakeFeedManager) ListFeeds(context.Context) ([]feedbin.FeedSummary, error) {
+	return f.feeds, nil
+}
+
+func (f *fakeFeedManager) RenameFeed(_ context.Context, _, _ int64, title string) error {
+	f.renamed = title
+	return nil
+}
+
+func (f *fakeFeedManager) SetFeedMuted(_ context.Context, feedID int64, muted bool) error {
+	if f.muted == nil {
+		f.muted = map[int64]bool{}
+	}
+	f.muted[feedID] = muted
+	return nil
+}
+
+func (f *fakeFeedManager) Unsubscribe(_ context.Context, feedID, _ int64) error {
+	f.unsubbed = append(f.unsubbed, feedID)
+	return nil
+}
+
+func (f *fakeFeedManager) RefreshFeed(_ context.Context, feedID int64) (int, error) {
+	f.refreshed = append(f.refreshed, feedID)
+	return 3, nil
+}
+
+func TestModelFeedManager_ManagesSelectedFeed(t *testing.T) {
+	manager := &fakeFeedManager{feeds: []feedbin.FeedSummary{
+		{Subscription: feedbin.Subscription{ID: 1, SubscriptionID: 10, Title: "Feed A"}, UnreadCount: 2},
+		{Subscription: feedbin.Subscription{ID: 2, SubscriptionID: 20, Title: "Feed B"}},
+	}}
+	m := NewModel(fakeRefresher{}, nil)
+	m.SetFeedManager(manager)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	if cmd == nil {
+		t.Fatal("expected feeds load command")
+	}
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if !m.feedsOpen || len(m.feeds) != 2 {
+		t.Fatalf("expected feed manager open with two feeds, got open=%v feeds=%d", m.feedsOpen, len(m.feeds))
+	}
+	if view := m.View(); !strings.Contains(view, "Feeds (2)") || !strings.Contains(view, "Feed B") {
+		t.Fatalf("expected feed manager view, got %q", view)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	updated, cmd = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	if cmd == nil {
+		t.Fatal("expected mute command")
+	}
+	cmd()
+	if !manager.muted[2] {
+		t.Fatalf("expected feed 2 muted, got %+v", manager.muted)
+	}
+
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	for range "Feed B" {
+		updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Renamed")})
+	_, cmd = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected rename command")
+	}
+	cmd()
+	if manager.renamed != "Renamed" {
+		t.Fatalf("expected rename to Renamed, got %q", manager.renamed)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if view := updated.(Model).View(); !strings.Contains(view, "Unsubscribe from Feed B? Press Shift+M to confirm") {
+		t.Fatalf("expected unsubscribe prompt, got %q", view)
+	}
+	updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if updated.(Model).pendingBulk != nil || len(manager.unsubbed) != 0 {
+		t.Fatalf("expected unsubscribe canceled, got %v", manager.unsubbed)
+	}
+	updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	_, cmd = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	if cmd == nil {
+		t.Fatal("expected unsubscribe command")
+	}
+	cmd()
+	if len(manager.unsubbed) != 1 || manager.unsubbed[0] != 2 {
+		t.Fatalf("expected feed 2 unsubscribed, got %v", manager.unsubbed)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.(Model).feedsOpen {
+		t.Fatal("expected esc to close feed manager")
+	}
+}
+
+func TestModelFeedManager_RefusesRenameAndUnsubscribeWithoutSubscription(t *testing.T) {
+	manager := &fakeFeedManager{feeds: []feedbin.FeedSummary{
+		{Subscription: feedbin.Subscription{ID: 3, Title: "Orphan"}},
+	}}
+	m := NewModel(fakeRefresher{}, nil)
+	m.SetFeedManager(manager)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	updated, _ = updated.(Model).Update(cmd())
+
+	for _, key := range []rune{'e', 'x'} {
+		next, cmd := updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}})
+		model := next.(Model)
+		if cmd != nil || model.feedsRenaming || model.pendingBulk != nil {
+			t.Fatalf("expected %q refused for a feed without subscription", key)
+		}
+		if model.status != "Not subscribed to Orphan — rename and unsubscribe are unavailable" {
+			t.Fatalf("unexpected status for %q: %q", key, model.status)
+		}
+	}
+}
+
+func TestModelBulkMarkRead_RequiresConfirmationByDefault(t *testing.T) {
+	svc := &openWorkflowService{}
+	now := time.Now().UTC()
+	m := NewModel(svc, []feedbin.Entry{
+		{ID: 1, Title: "One", FeedTitle: "Feed A", FeedFolder: "Tech", IsUnread: true, PublishedAt: now},
+		{ID: 2, Title: "Two", FeedTitle: "Feed A", FeedFolder: "Tech", IsUnread: true, PublishedAt: now.Add(-time.Minute)},
+		{ID: 3, Title: "Three", FeedTitle: "Feed A", FeedFolder: "Tech", PublishedAt: now.Add(-2 * time.Minute)},
+	})
+	m.setTreeCursorToFeed("Tech", "Feed A")
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+	if cmd != nil {
+		t.Fatal("expected no command before confirmation")
+	}
+	m = updated.(Model)
+	if m.status != "Mark 2 entries read in Feed A? Press Shift+M to confirm, any other key cancels" {
+		t.Fatalf("unexpected confirm prompt: %q", m.status)
+	}
+
+	canceled, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if got := canceled.(Model); got.pendingBulk != nil || got.status != "Canceled: Mark 2 entries read in Feed A" {
+		t.Fatalf("expected bulk action canceled, got status %q", got.status)
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	if cmd == nil {
+		t.Fatal("expected batch command after confirmation")
+	}
+	msg, ok := cmd().(tuiactions.BatchUpdateResultMsg)
+	if !ok || msg.Field != tuiactions.BatchFieldUnread || msg.Value || len(msg.Succeeded) != 2 {
+		t.Fatalf("unexpected batch result: %+v", msg)
+	}
+}
+
+func TestModelBulkMarkRead_RunsImmediatelyWhenConfirmOff(t *testing.T) {
+	now := time.Now().UTC()
+	m := NewModel(&openWorkflowService{}, []feedbin.Entry{
+		{ID: 1, Title: "One", FeedTitle: "Feed A", FeedFolder: "Tech", IsUnread: true, PublishedAt: now},
+	})
+	m.ApplyPreferences(Preferences{TimeFormat: "relative"})
+	m.setTreeCursorToFeed("Tech", "Feed A")
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+	if cmd == nil {
+		t.Fatal("expected batch command without confirmation")
+	}
+	if updated.(Model).pendingBulk != nil {
+		t.Fatal("expected no pending bulk action when confirmation is off")
+	}
+	if msg, ok := cmd().(tuiactions.BatchUpdateResultMsg); !ok || len(msg.Succeeded) != 1 {
+		t.Fatalf("unexpected batch result: %+v", msg)
+	}
+}
+
+func TestModelUndo_RevertsLastToggleOnce(t *testing.T) {
+	now := time.Now().UTC()
+	m := NewModel(fakeRefresher{unreadResult: true}, []feedbin.Entry{
+		{ID: 1, Title: "One", FeedTitle: "Feed A", IsUnread: true, PublishedAt: now},
+	})
+
+	updated, _ := m.Update(tuiactions.ToggleUnreadSuccessMsg{EntryID: 1, NextUnread: false, Status: "Marked as read"})
+	m = updated.(Model)
+	if m.entries[0].IsUnread || m.lastMutation == nil {
+		t.Fatalf("expected entry marked read and undo recorded, got %+v", m.lastMutation)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	if cmd == nil {
+		t.Fatal("expected undo command")
+	}
+	m = updated.(Model)
+	if m.lastMutation != nil {
+		t.Fatal("expected undo slot cleared")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if !m.entries[0].IsUnread || m.status != "Undid: marked read" {
+		t.Fatalf("expected entry restored to unread, got unread=%v status=%q", m.entries[0].IsUnread, m.status)
+	}
+	if m.lastMutation != nil {
+		t.Fatal("expected undo not to record a new mutation")
+	}
+
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	if cmd != nil || updated.(Model).status != "Nothing to undo" {
+		t.Fatalf("expected empty undo slot, got status %q", updated.(Model).status)
+	}
+}
+
+func TestModelViewState_RoundTrip(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "One", FeedTitle: "Feed A", FeedFolder: "Tech", IsUnread: true, PublishedAt: now},
+		{ID: 2, Title: "Two", FeedTitle: "Feed B", FeedFolder: "News", PublishedAt: now.Add(-time.Minute)},
+		{ID: 3, Title: "Three", FeedTitle: "Feed A", FeedFolder: "Tech", IsUnread: true, PublishedAt: now.Add(-2 * time.Minute)},
+	}
+	m := NewModel(fakeRefresher{}, entries)
+	m.setCollapsed(m.collapsedFolders, "News", true)
+	m.filter = "unread"
+	m.applyCurrentFilter()
+	m.restoreSelection(3)
+
+	state := m.ViewState()
+	if state.LastEntryID != 3 || state.Filter != "unread" || len(state.CollapsedFolders) != 1 || state.CollapsedFolders[0] != "News" {
+		t.Fatalf("unexpected view state: %+v", state)
+	}
+
+	restored := NewModel(fakeRefresher{}, entries)
+	restored.ApplyViewState(state)
+	if restored.filter != "unread" || len(restored.entries) != 2 {
+		t.Fatalf("expected unread filter restored, got filter=%q entries=%d", restored.filter, len(restored.entries))
+	}
+	if restored.entries[restored.cursor].ID != 3 {
+		t.Fatalf("expected cursor on entry 3, got %d", restored.entries[restored.cursor].ID)
+	}
+	if !restored.collapsedFolders["News"] {
+		t.Fatal("expected News folder collapsed")
+	}
+}
+
+func TestModelDateColumn_CyclesAndPersists(t *testing.T) {
+	m := NewModel(fakeRefresher{}, []feedbin.Entry{{ID: 1, Title: "One", FeedTitle: "Feed A", PublishedAt: time.Now().UTC()}})
+	var saved Preferences
+	m.SetPreferencesSaver(func(p Preferences) error {
+		saved = p
+		return nil
+	})
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	m = updated.(Model)
+	if m.dateColumn != tuiview.DateColumnShort || m.status != "Date column: short" {
+		t.Fatalf("expected short date column, got %q (%q)", m.dateColumn, m.status)
+	}
+	if cmd == nil {
+		t.Fatal("expected preference persistence command")
+	}
+	cmd()
+	if saved.DateColumn != "short" {
+		t.Fatalf("expected short date column persisted, got %q", saved.DateColumn)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	if updated.(Model).dateColumn != tuiview.DateColumnHidden {
+		t.Fatalf("expected hidden date column, got %q", updated.(Model).dateColumn)
+	}
+
+	m.ApplyPreferences(Preferences{DateColumn: "bogus"})
+	if m.dateColumn != tuiview.DateColumnFull {
+		t.Fatalf("expected unknown date column to fall back to full, got %q", m.dateColumn)
+	}
+}
+
+func TestModelIncrementalSearch_FiltersLiveAndEscRestores(t *testing.T) {
+	now := time.Now().UTC()
+	m := NewModel(fakeRefresher{}, []feedbin.Entry{
+		{ID: 1, Title: "Go generics", FeedTitle: "Feed A", PublishedAt: now},
+		{ID: 2, Title: "Rust traits", FeedTitle: "Feed A", PublishedAt: now.Add(-time.Minute)},
+		{ID: 3, Title: "Go modules", FeedTitle: "Feed B", PublishedAt: now.Add(-2 * time.Minute)},
+	})
+	m.ApplyPreferences(Preferences{TimeFormat: "relative", IncrementalSearch: true})
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	updated, first := updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	updated, second := updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if first == nil || second == nil {
+		t.Fatal("expected debounced search ticks")
+	}
+	m = updated.(Model)
+
+	stale, _ := m.Update(incrementalSearchMsg{seq: m.searchSeq - 1})
+	if len(stale.(Model).entries) != 3 {
+		t.Fatalf("expected stale tick ignored, got %d entries", len(stale.(Model).entries))
+	}
+
+	updated, cmd := m.Update(second())
+	if cmd != nil {
+		t.Fatal("expected live filtering without a service round-trip")
+	}
+	m = updated.(Model)
+	if len(m.entries) != 2 || m.searchMatchCount != 2 {
+		t.Fatalf("expected two live matches, got %d (count %d)", len(m.entries), m.searchMatchCount)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.searchInputMode || len(m.entries) != 3 || m.searchBase != nil {
+		t.Fatalf("expected esc to restore the pre-search view, got %d entries", len(m.entries))
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	updated, cmd = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected enter to run the full cached search")
+	}
+	if updated.(Model).searchBase != nil {
+		t.Fatal("expected enter to drop the incremental snapshot")
+	}
+	if _, ok := cmd().(tuiactions.SearchLoadSuccessMsg); !ok {
+		t.Fatal("expected full search result message")
+	}
+}
+
+func TestModelToggleCollectionRead_BothDirections(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "One", FeedTitle: "Feed A", FeedFolder: "Tech", IsUnread: true, PublishedAt: now},
+		{ID: 2, Title: "Two", FeedTitle: "Feed A", FeedFolder: "Tech", PublishedAt: now.Add(-time.Minute)},
+	}
+	m := NewModel(&openWorkflowService{}, entries)
+	m.ApplyPreferences(Preferences{TimeFormat: "relative"})
+	m.setTreeCursorToFolder("Tech")
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+	if cmd == nil {
+		t.Fatal("expected mark-read batch command")
+	}
+	msg := cmd().(tuiactions.BatchUpdateResultMsg)
+	if msg.Value || len(msg.Succeeded) != 1 || msg.Succeeded[0] != 1 {
+		t.Fatalf("expected only the unread entry marked read, got %+v", msg)
+	}
+	updated, _ = updated.(Model).Update(msg)
+	m = updated.(Model)
+	if m.status != "Marked 1 entry read" {
+		t.Fatalf("unexpected mark-read status: %q", m.status)
+	}
+
+	m.setTreeCursorToFolder("Tech")
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+	if cmd == nil {
+		t.Fatal("expected mark-unread batch command")
+	}
+	msg = cmd().(tuiactions.BatchUpdateResultMsg)
+	if !msg.Value || len(msg.Succeeded) != 2 || msg.Status != "Marked 2 entries unread" {
+		t.Fatalf("expected the fully read folder marked unread, got %+v", msg)
+	}
+
+	m.ApplyPreferences(Preferences{TimeFormat: "relative", ConfirmBulkActions: true})
+	m.setTreeCursorToFolder("Tech")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+	if got := updated.(Model).status; !strings.HasPrefix(got, "All read: mark 2 entries unread in Tech?") {
+		t.Fatalf("expected prompt to explain the unread direction, got %q", got)
+	}
+}
+
+type fakeSnoozer struct {
+	entryID int64
+	wakeAt  time.Time
+}
+
+func (f *fakeSnoozer) SnoozeEntry(_ context.Context, entryID int64, wakeAt time.Time) error {
+	f.entryID = entryID
+	f.wakeAt = wakeAt
+	return nil
+}
+
+func TestModelSnooze_PresetHidesEntryUntilWake(t *testing.T) {
+	now := time.Date(2026, 2, 11, 21, 30, 0, 0, time.UTC) // a Wednesday
+	m := NewModel(fakeRefresher{}, []feedbin.Entry{
+		{ID: 1, Title: "Later", FeedTitle: "Feed A", PublishedAt: now, IsUnread: true},
+		{ID: 2, Title: "Now", FeedTitle: "Feed A", PublishedAt: now.Add(-time.Minute), IsUnread: true},
+	})
+	m.nowFn = func() time.Time { return now }
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	if got := updated.(Model); got.status != "Snooze unavailable" || got.pendingSnoozeID != 0 {
+		t.Fatalf("expected snooze unavailable without a snoozer, got %q", got.status)
+	}
+
+	snoozer := &fakeSnoozer{}
+	m.SetSnoozer(snoozer)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	updated, cancel := updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if got := updated.(Model); got.pendingSnoozeID != 0 || got.status != "Canceled snooze" || cancel == nil {
+		t.Fatalf("expected other key to cancel, got %q", got.status)
+	}
+
+	updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	updated, cmd := updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'3'}})
+	if cmd == nil {
+		t.Fatal("expected snooze command")
+	}
+	m = updated.(Model)
+	if m.entries[0].IsUnread {
+		t.Fatal("expected optimistic mark-read while snoozing")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if want := time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC); snoozer.entryID != 1 || !snoozer.wakeAt.Equal(want) {
+		t.Fatalf("expected entry 1 snoozed until %v, got %d until %v", want, snoozer.entryID, snoozer.wakeAt)
+	}
+	if len(m.entries) != 1 || m.entries[0].ID != 2 {
+		t.Fatalf("expected snoozed entry hidden, got %+v", m.entries)
+	}
+	if !strings.HasPrefix(m.status, "Snoozed next week") {
+		t.Fatalf("unexpected status: %q", m.status)
+	}
+}
+
+type fakeDismisser struct {
+	calls map[int64]bool
+}
+
+func (f *fakeDismisser) SetEntryDismissed(_ context.Context, entryID int64, dismissed bool) error {
+	if f.calls == nil {
+		f.calls = make(map[int64]bool)
+	}
+	f.calls[entryID] = dismissed
+	return nil
+}
+
+func TestModelDismiss_HidesAndRestoresWithoutMarkingRead(t *testing.T) {
+	now := time.Now().UTC()
+	m := NewModel(fakeRefresher{}, []feedbin.Entry{
+		{ID: 1, Title: "Noise", FeedTitle: "Feed A", PublishedAt: now, IsUnread: true},
+		{ID: 2, Title: "Signal", FeedTitle: "Feed A", PublishedAt: now.Add(-time.Minute), IsUnread: true},
+	})
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if got := updated.(Model); got.status != "Dismiss unavailable" {
+		t.Fatalf("expected dismiss unavailable without a dismisser, got %q", got.status)
+	}
+
+	dismisser := &fakeDismisser{}
+	m.SetDismisser(dismisser)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if cmd == nil {
+		t.Fatal("expected dismiss command")
+	}
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if dismissed, ok := dismisser.calls[1]; !ok || !dismissed {
+		t.Fatalf("expected entry 1 dismissed, got %v", dismisser.calls)
+	}
+	if len(m.entries) != 1 || m.entries[0].ID != 2 {
+		t.Fatalf("expected dismissed entry hidden, got %+v", m.entries)
+	}
+	if m.status != "Dismissed (X shows dismissed entries)" {
+		t.Fatalf("unexpected status: %q", m.status)
+	}
+
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	if cmd == nil {
+		t.Fatal("expected the dismissed view to load")
+	}
+	updated, _ = updated.(Model).Update(tuiactions.FilterLoadSuccessMsg{
+		Filter:  "dismissed",
+		Entries: []feedbin.Entry{{ID: 1, Title: "Noise", FeedTitle: "Feed A", PublishedAt: now, IsUnread: true}},
+	})
+	m = updated.(Model)
+	if m.filter != "dismissed" || len(m.entries) != 1 || !m.entries[0].IsUnread {
+		t.Fatalf("expected dismissed view with the unread entry, filter=%q entries=%+v", m.filter, m.entries)
+	}
+
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if dismissed := dismisser.calls[1]; dismissed {
+		t.Fatalf("expected entry 1 restored, got %v", dismisser.calls)
+	}
+	if len(m.entries) != 0 || m.status != "Restored dismissed entry" {
+		t.Fatalf("expected restored entry gone from the dismissed view, entries=%+v status=%q", m.entries, m.status)
+	}
+
+	if _, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}}); cmd == nil {
+		t.Fatal("expected X to return to the all view")
+	}
+}
+
+func TestSnoozePresets_WakeTimes(t *testing.T) {
+	sunday := time.Date(2026, 2, 15, 23, 0, 0, 0, time.UTC)
+	monday := time.Date(2026, 2, 16, 7, 0, 0, 0, time.UTC)
+	cases := []struct {
+		preset int
+		now    time.Time
+		want   time.Time
+	}{
+		{0, sunday, sunday.Add(time.Hour)},
+		{1, sunday, time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC)},
+		{2, sunday, time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC)},
+		{2, monday, time.Date(2026, 2, 23, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		if got := snoozePresets[tc.preset].wakeAt(tc.now); !got.Equal(tc.want) {
+			t.Fatalf("preset %s from %v: got %v, want %v", snoozePresets[tc.preset].label, tc.now, got, tc.want)
+		}
+	}
+}
+
+func TestModelTreeRows_CacheInvalidatesOnInputChanges(t *testing.T) {
+	now := time.Now().UTC()
+	m := NewModel(nil, []feedbin.Entry{
+		{ID: 1, Title: "One", FeedTitle: "Feed A", FeedFolder: "Tech", PublishedAt: now},
+		{ID: 2, Title: "Two", FeedTitle: "Feed B", FeedFolder: "News", PublishedAt: now.Add(-time.Minute)},
+	})
+	first := m.treeRows()
+	if again := m.treeRows(); &again[0] != &first[0] {
+		t.Fatal("expected unchanged inputs to reuse cached rows")
+	}
+
+	m.setCollapsed(m.collapsedFolders, "News", true)
+	collapsed := m.treeRows()
+	if len(collapsed) != len(first)-2 {
+		t.Fatalf("expected collapsed folder to hide its feed and article, got %d rows (was %d)", len(collapsed), len(first))
+	}
+
+	m.entries = append([]feedbin.Entry(nil), m.entries[:1]...)
+	if rows := m.treeRows(); len(rows) != 2 {
+		t.Fatalf("expected rebuilt rows with only the collapsed News folder, got %d", len(rows))
+	}
+
+	m.compact = true
+	if rows := m.treeRows(); len(rows) != 1 || rows[0].Kind != treeRowArticle {
+		t.Fatalf("expected compact rows after layout change, got %+v", rows)
+	}
+}
+
+func TestModelUpdate_RefreshReflowsUpdatedOpenArticle(t *testing.T) {
+	body := strings.Repeat("<p>Paragraph of article text.</p>", 40)
+	entries := []feedbin.Entry{{ID: 1, Title: "Long read", FeedTitle: "Feed", Content: body, PublishedAt: time.Now().UTC()}}
+	m := NewModel(fakeRefresher{entries: entries}, entries)
+	m.width = 80
+	m.height = 20
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model := updated.(Model)
+	maxTop := model.detailMaxTop()
+	if maxTop < 4 {
+		t.Fatalf("expected a scrollable article, max top %d", maxTop)
+	}
+	model.detailTop = maxTop / 2
+
+	updated, _ = model.Update(tuiactions.RefreshSuccessMsg{Entries: entries, Source: "manual"})
+	model = updated.(Model)
+	if model.status == "Article updated" || model.detailTop != maxTop/2 {
+		t.Fatalf("expected an unchanged article to keep its place, got status %q top %d", model.status, model.detailTop)
+	}
+
+	corrected := entries[0]
+	corrected.Content = strings.Repeat("<p>Paragraph of article text.</p>", 80)
+	updated, _ = model.Update(tuiactions.RefreshSuccessMsg{Entries: []feedbin.Entry{corrected}, Source: "manual"})
+	model = updated.(Model)
+	if !model.inDetail || model.status != "Article updated" {
+		t.Fatalf("expected the open article updated in place, got detail=%v status %q", model.inDetail, model.status)
+	}
+	if !strings.Contains(strings.Join(model.detailLines(model.entries[model.cursor]), "\n"), "Paragraph") {
+		t.Fatal("expected the corrected content rendered")
+	}
+	newMax := model.detailMaxTop()
+	if newMax <= maxTop {
+		t.Fatalf("expected the longer article to scroll further, max top %d -> %d", maxTop, newMax)
+	}
+	want := int(math.Round(float64(maxTop/2) / float64(maxTop) * float64(newMax)))
+	if model.detailTop < want-1 || model.detailTop > want+1 {
+		t.Fatalf("expected the scroll position kept proportionally at %d, got %d", want, model.detailTop)
+	}
+}
+
+type flakyRefresher struct {
+	fakeRefresher
+	failures *int
+}
+
+func (f flakyRefresher) Refresh(ctx context.Context, page, perPage int) ([]feedbin.Entry, error) {
+	if *f.failures > 0 {
+		*f.failures--
+		return nil, errors.New("network unreachable")
+	}
+	return f.fakeRefresher.Refresh(ctx, page, perPage)
+}
+
+func TestModelUpdate_InitialRefreshRetriesAfterFailure(t *testing.T) {
+	entries := []feedbin.Entry{{ID: 1, Title: "Cached", FeedTitle: "Feed", PublishedAt: time.Now().UTC()}}
+	failures := 1
+	m := NewModel(flakyRefresher{fakeRefresher: fakeRefresher{entries: entries}, failures: &failures}, entries)
+	m.SetInitialRefreshRetries(2)
+
+	updated, _ := m.Update(tuiactions.RefreshCmd(m.service, m.perPage, "init")())
+	m = updated.(Model)
+	if m.initialRefreshDone || m.err != nil {
+		t.Fatalf("expected a retry instead of giving up, got done=%v err=%v", m.initialRefreshDone, m.err)
+	}
+	if !strings.Contains(m.status, "retrying in 2s (1/2)") {
+		t.Fatalf("expected the retry status, got %q", m.status)
+	}
+	if !strings.Contains(m.startupMetrics(), "initial refresh retry 1/2") {
+		t.Fatalf("expected the retry in startup metrics, got %q", m.startupMetrics())
+	}
+
+	updated, cmd := m.Update(initRefreshMsg{})
+	if cmd == nil {
+		t.Fatal("expected the retried refresh")
+	}
+	updated, _ = updated.Update(cmd())
+	m = updated.(Model)
+	if !m.initialRefreshDone || m.initialRefreshFailed || m.err != nil {
+		t.Fatalf("expected the retry to succeed, got done=%v failed=%v err=%v", m.initialRefreshDone, m.initialRefreshFailed, m.err)
+	}
+	if !strings.Contains(m.startupMetrics(), "last synced") {
+		t.Fatalf("expected the sync time in startup metrics, got %q", m.startupMetrics())
+	}
+}
+
+func TestModelUpdate_RefreshKeyWaitsForStartupRefresh(t *testing.T) {
+	failures := 1
+	entries := []feedbin.Entry{{ID: 1, Title: "Cached", PublishedAt: time.Now().UTC()}}
+	m := NewModel(flakyRefresher{fakeRefresher: fakeRefresher{entries: entries}, failures: &failures}, entries)
+	m.SetInitialRefreshRetries(1)
+	refreshKey := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}}
+
+	updated, startCmd := m.Update(m.Init()())
+	m = updated.(Model)
+	if updated, _ = m.Update(refreshKey); updated.(Model).status != "Refresh already in progress" {
+		t.Fatalf("expected r refused during the startup refresh, got %q", updated.(Model).status)
+	}
+
+	// Still refused while waiting to retry.
+	updated, _ = m.Update(startCmd())
+	m = updated.(Model)
+	if updated, _ = m.Update(refreshKey); updated.(Model).status != "Refresh already in progress" {
+		t.Fatalf("expected r refused while the startup refresh retries, got %q", updated.(Model).status)
+	}
+
+	updated, retryCmd := m.Update(initRefreshMsg{})
+	updated, _ = updated.Update(retryCmd())
+	m = updated.(Model)
+	if !m.initialRefreshDone || m.refreshing {
+		t.Fatalf("expected the startup refresh finished, got done=%v refreshing=%v", m.initialRefreshDone, m.refreshing)
+	}
+	_, cmd := m.Update(refreshKey)
+	if cmd == nil {
+		t.Fatal("expected r to refresh once the startup refresh is done")
+	}
+	if _, ok := cmd().(tuiactions.RefreshSuccessMsg); !ok {
+		t.Fatal("expected r to refresh once the startup refresh is done")
+	}
+}
+
+func TestModelUpdate_InitialRefreshGivesUpWorkingOffline(t *testing.T) {
+	failures := 5
+	m := NewModel(flakyRefresher{failures: &failures}, nil)
+	m.SetInitialRefreshRetries(1)
+	m.SetLastSynced(time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC))
+
+	updated, _ := m.Update(tuiactions.RefreshErrorMsg{Err: errors.New("network unreachable"), Source: "init"})
+	updated, _ = updated.Update(tuiactions.RefreshErrorMsg{Err: errors.New("network unreachable"), Source: "init"})
+	m = updated.(Model)
+	if !m.initialRefreshFailed || m.err == nil {
+		t.Fatalf("expected the startup refresh to give up, got failed=%v err=%v", m.initialRefreshFailed, m.err)
+	}
+	if m.status != "Working offline, last synced 2026-03-01 09:30" {
+		t.Fatalf("unexpected offline status %q", m.status)
+	}
+}
+
+func TestModelUpdate_RequestBudgetSwitchesToCacheOnly(t *testing.T) {
+	failures := 5
+	m := NewModel(flakyRefresher{failures: &failures}, nil)
+	m.SetInitialRefreshRetries(3)
+	budgetErr := fmt.Errorf("sync page 1: %w", feedbin.ErrRequestBudget)
+
+	updated, cmd := m.Update(tuiactions.RefreshErrorMsg{Err: budgetErr, Source: "init"})
+	m = updated.(Model)
+	if cmd != nil {
+		t.Fatal("expected no retry once the request budget is spent")
+	}
+	if !m.offline || !m.initialRefreshDone || m.err != nil || m.status != "Request budget reached — cache only" {
+		t.Fatalf("expected cache-only mode, got offline=%v done=%v err=%v status=%q", m.offline, m.initialRefreshDone, m.err, m.status)
+	}
+
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if cmd == nil || updated.(Model).loading {
+		t.Fatal("expected r to explain the cache-only mode instead of refreshing")
+	}
+	if got := updated.(Model).status; !strings.HasPrefix(got, "Offline") {
+		t.Fatalf("expected the offline notice, got %q", got)
+	}
+}
+
+func TestModelFooter_FreshnessFollowsRefreshState(t *testing.T) {
+	entries := []feedbin.Entry{{ID: 1, Title: "Cached", FeedTitle: "Feed", PublishedAt: time.Now().UTC()}}
+	m := NewModel(fakeRefresher{entries: entries}, entries)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m.nowFn = func() time.Time { return now }
+	m.SetLastSynced(now.Add(-3 * time.Hour))
+
+	if footer := m.footer(); !strings.Contains(footer, "CACHED — last sync 3 hours ago") {
+		t.Fatalf("expected the cached indicator before the first refresh, got %q", footer)
+	}
+
+	updated, _ := m.Update(tuiactions.RefreshCmd(m.service, m.perPage, "init")())
+	m = updated.(Model)
+	footer := m.footer()
+	if !strings.Contains(footer, "LIVE") || strings.Contains(footer, "CACHED") {
+		t.Fatalf("expected the live indicator after a refresh, got %q", footer)
+	}
+}
+
+type fakeProgressStore struct {
+	saved map[int64]float64
+}
+
+func (f *fakeProgressStore) SaveReadProgress(_ context.Context, entryID int64, fraction float64) error {
+	f.saved[entryID] = fraction
+	return nil
+}
+
+func TestModelUpdate_ReadProgressResumesScrollPosition(t *testing.T) {
+	body := strings.Repeat("<p>Paragraph of article text.</p>", 60)
+	entries := []feedbin.Entry{{ID: 1, Title: "Long read", FeedTitle: "Feed", Content: body, PublishedAt: time.Now().UTC()}}
+	m := NewModel(fakeRefresher{entries: entries}, entries)
+	m.width = 80
+	m.height = 20
+	store := &fakeProgressStore{saved: make(map[int64]float64)}
+	m.SetReadProgress(store, nil)
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model := updated.(Model)
+	maxTop := model.detailMaxTop()
+	if maxTop < 4 {
+		t.Fatalf("expected a scrollable article, max top %d", maxTop)
+	}
+	model.detailTop = maxTop / 2
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	model = updated.(Model)
+	if cmd == nil {
+		t.Fatal("expected save command when leaving the article")
+	}
+	for _, msg := range cmd().(tea.BatchMsg) {
+		if msg != nil {
+			msg()
+		}
+	}
+	want := float64(maxTop/2) / float64(maxTop)
+	if store.saved[1] != want {
+		t.Fatalf("expected progress %v saved, got %v", want, store.saved)
+	}
+	if !strings.Contains(stripANSI(model.renderEntryLine(0, 0, false)), "%") {
+		t.Fatal("expected progress marker in the list")
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(Model)
+	if model.detailTop != maxTop/2 {
+		t.Fatalf("expected resumed detail top %d, got %d", maxTop/2, model.detailTop)
+	}
+}
+
+func TestModel_CommentCountOnlyWhenEnabledAndPresent(t *testing.T) {
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Show HN", FeedTitle: "HN", Content: "<p># Comments: 42</p>", PublishedAt: time.Now().UTC()},
+		{ID: 2, Title: "Plain post", FeedTitle: "Blog", Content: "<p>We got 300 comments.</p>", PublishedAt: time.Now().UTC()},
+	}
+	m := NewModel(fakeRefresher{}, entries)
+	if _, ok := m.entryCommentCount(entries[0]); ok {
+		t.Fatal("expected no comment count when disabled")
+	}
+	m.articleOptions.CommentCounts = true
+	if count, ok := m.entryCommentCount(entries[0]); !ok || count != 42 {
+		t.Fatalf("expected 42 comments, got %d/%v", count, ok)
+	}
+	if _, ok := m.entryCommentCount(entries[1]); ok {
+		t.Fatal("expected nothing for entries without a count")
+	}
+
+	// A refresh that rewrites the entry's content updates the cached count.
+	m.entries[0].Content = "<p># Comments: 43</p>"
+	if count, _ := m.entryCommentCount(m.entries[0]); count != 43 {
+		t.Fatalf("expected the updated count, got %d", count)
+	}
+	m.entries = m.entries[1:]
+	m.entryCommentCount(m.entries[0])
+	if len(m.commentCounts.counts) != 1 {
+		t.Fatalf("expected the cache to drop entries no longer loaded, got %v", m.commentCounts.counts)
+	}
+}
+
+func TestModelUpdate_QuitFromDetailSavesReadProgress(t *testing.T) {
+	body := strings.Repeat("<p>Paragraph of article text.</p>", 60)
+	entries := []feedbin.Entry{{ID: 1, Title: "Long read", FeedTitle: "Feed", Content: body, PublishedAt: time.Now().UTC()}}
+	m := NewModel(fakeRefresher{entries: entries}, entries)
+	m.width = 80
+	m.height = 20
+	store := &fakeProgressStore{saved: make(map[int64]float64)}
+	m.SetReadProgress(store, nil)
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model := updated.(Model)
+	maxTop := model.detailMaxTop()
+	model.detailTop = maxTop / 2
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	if cmd == nil {
+		t.Fatal("expected a quit command")
+	}
+	// tea.Sequence runs the save before the quit.
+	steps := reflect.ValueOf(cmd())
+	if steps.Kind() != reflect.Slice || steps.Len() != 2 {
+		t.Fatalf("expected a save then quit sequence, got %T", cmd())
+	}
+	steps.Index(0).Interface().(tea.Cmd)()
+	if _, ok := steps.Index(1).Interface().(tea.Cmd)().(tea.QuitMsg); !ok {
+		t.Fatal("expected the sequence to end by quitting")
+	}
+	if want := float64(maxTop/2) / float64(maxTop); store.saved[1] != want {
+		t.Fatalf("expected progress %v saved on quit, got %v", want, store.saved)
+	}
+}
+
+func TestModelCompactTree_SingleFeedFilterShowsArticlesOnly(t *testing.T) {
+	service := fakeRefresher{entries: []feedbin.Entry{
+		{ID: 1, Title: "A newest", FeedID: 10, FeedTitle: "Feed A", PublishedAt: time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Title: "B post", FeedID: 20, FeedTitle: "Feed B", PublishedAt: time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)},
+		{ID: 3, Title: "A older", FeedID: 10, FeedTitle: "Feed A", PublishedAt: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)},
+	}}
+	m := NewModel(service, service.entries)
+	m.ApplyPreferences(Preferences{CompactTree: true})
+	if rows := m.treeRows(); rows[0].Kind != treeRowFeed {
+		t.Fatalf("expected feed headers kept for two feeds, got %+v", rows)
+	}
+
+	m.inDetail = true
+	m.cursor = 0
+	m.selectedID = 1
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	updated, _ = updated.Update(cmd())
+	model := updated.(Model)
+
+	rows := model.treeRows()
+	if len(rows) != 2 || rows[0].Kind != treeRowArticle || rows[1].Kind != treeRowArticle {
+		t.Fatalf("expected header-less article list, got %+v", rows)
+	}
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	model = updated.(Model)
+	if len(model.treeRows()) != 2 || model.collapsedFeeds[treeFeedKey("", "Feed A")] {
+		t.Fatal("expected collapse to be a no-op without a feed header")
+	}
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	model = updated.(Model)
+	if got := model.entries[model.cursor].ID; got != 3 {
+		t.Fatalf("expected j to move to entry 3, got %d", got)
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+	model = updated.(Model)
+	if rows := model.treeRows(); len(rows) != 4 || model.entries[model.cursor].ID != 3 {
+		t.Fatalf("expected headers back with cursor kept on entry 3, got rows=%+v cursor=%d", rows, model.cursor)
+	}
+	if model.treeRows()[model.treeCursor].EntryIndex != model.cursor {
+		t.Fatal("expected tree cursor to follow the entry")
+	}
+}
+
+func TestModelUpdate_DetailAdvancesAcrossFeedEnd(t *testing.T) {
+	now := time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "A one", FeedTitle: "Feed A", IsUnread: true, PublishedAt: now},
+		{ID: 2, Title: "A two", FeedTitle: "Feed A", PublishedAt: now.Add(-time.Hour)},
+		{ID: 3, Title: "B read", FeedTitle: "Feed B", PublishedAt: now},
+		{ID: 4, Title: "C newest", FeedTitle: "Feed C", PublishedAt: now},
+		{ID: 5, Title: "C unread", FeedTitle: "Feed C", IsUnread: true, PublishedAt: now.Add(-time.Hour)},
+	}
+	m := NewModel(fakeRefresher{entries: entries}, entries)
+	m.inDetail = true
+	m.cursor = 0
+	m.selectedID = 1
+	next := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{']'}}
+
+	updated, _ := m.Update(next)
+	model := updated.(Model)
+	if model.entries[model.cursor].ID != 2 {
+		t.Fatalf("expected ] to move within the feed, got entry %d", model.entries[model.cursor].ID)
+	}
+
+	updated, _ = model.Update(next)
+	model = updated.(Model)
+	if model.entries[model.cursor].ID != 2 || model.status != "End of Feed A — press ] for next feed" {
+		t.Fatalf("expected end-of-feed notice on entry 2, got entry %d status %q", model.entries[model.cursor].ID, model.status)
+	}
+
+	updated, _ = model.Update(next)
+	model = updated.(Model)
+	if !model.inDetail || model.entries[model.cursor].ID != 5 || model.selectedID != 5 {
+		t.Fatalf("expected second ] to open the next unread feed's article 5, got entry %d", model.entries[model.cursor].ID)
+	}
+
+	// Any other key disarms the notice.
+	m.SetAutoNextFeed(false)
+	m.cursor, m.selectedID = 1, 2
+	updated, _ = m.Update(next)
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	updated, _ = updated.Update(next)
+	if model = updated.(Model); model.entries[model.cursor].ID != 2 {
+		t.Fatalf("expected notice again after another key, got entry %d", model.entries[model.cursor].ID)
+	}
+
+	m.SetAutoNextFeed(true)
+	updated, _ = m.Update(next)
+	if model = updated.(Model); model.entries[model.cursor].ID != 5 {
+		t.Fatalf("expected auto advance to entry 5, got entry %d", model.entries[model.cursor].ID)
+	}
+}
+
+func TestModelUpdate_DetailAdvancesToNextEntryWhenNoUnreadFeedFollows(t *testing.T) {
+	now := time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "A one", FeedTitle: "Feed A", IsUnread: true, PublishedAt: now},
+		{ID: 2, Title: "B read", FeedTitle: "Feed B", PublishedAt: now},
+		{ID: 3, Title: "C read", FeedTitle: "Feed C", PublishedAt: now},
+	}
+	m := NewModel(fakeRefresher{entries: entries}, entries)
+	m.inDetail = true
+	m.cursor, m.selectedID = 0, 1
+	next := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{']'}}
+
+	updated, _ := m.Update(next)
+	model := updated.(Model)
+	if model.entries[model.cursor].ID != 2 || strings.Contains(model.status, "End of") {
+		t.Fatalf("expected ] to open the read feed's entry 2 without a notice, got entry %d status %q", model.entries[model.cursor].ID, model.status)
+	}
+	updated, _ = model.Update(next)
+	if model = updated.(Model); model.entries[model.cursor].ID != 3 {
+		t.Fatalf("expected ] to keep advancing through read feeds, got entry %d", model.entries[model.cursor].ID)
+	}
+
+	// "}" still jumps to feeds with unread articles.
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'}'}})
+	if model = updated.(Model); model.entries[model.cursor].ID != 1 {
+		t.Fatalf("expected } to wrap to the unread feed, got entry %d", model.entries[model.cursor].ID)
+	}
+}
+
+func TestModelUpdate_DetailBoundaryStopWrapExit(t *testing.T) {
+	now := time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "One", FeedTitle: "Feed A", PublishedAt: now},
+		{ID: 2, Title: "Two", FeedTitle: "Feed A", PublishedAt: now.Add(-time.Hour)},
+		{ID: 3, Title: "Three", FeedTitle: "Feed A", PublishedAt: now.Add(-2 * time.Hour)},
+	}
+	prev := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'['}}
+	next := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{']'}}
+	openAt := func(mode string, cursor int) Model {
+		m := NewModel(fakeRefresher{entries: entries}, entries)
+		m.SetDetailBoundary(mode)
+		m.inDetail = true
+		m.cursor = cursor
+		m.selectedID = entries[cursor].ID
+		return m
+	}
+	press := func(m Model, msg tea.KeyMsg) Model {
+		t.Helper()
+		updated, _ := m.Update(msg)
+		return updated.(Model)
+	}
+
+	if m := press(openAt("stop", 0), prev); !m.inDetail || m.cursor != 0 || m.status != "" {
+		t.Fatalf("expected [ to stop silently on the first entry, got cursor %d status %q", m.cursor, m.status)
+	}
+	if m := press(openAt("stop", 2), next); !m.inDetail || m.cursor != 2 || m.status != "" {
+		t.Fatalf("expected ] to stop silently on the last entry when no unread feed follows, got cursor %d status %q", m.cursor, m.status)
+	}
+
+	m := press(openAt("wrap", 2), next)
+	if !m.inDetail || m.cursor != 0 || m.selectedID != 1 || m.status != "Wrapped to first entry" {
+		t.Fatalf("expected ] to wrap to the first entry, got cursor %d selected %d status %q", m.cursor, m.selectedID, m.status)
+	}
+	m = press(m, prev)
+	if m.cursor != 2 || m.selectedID != 3 || m.status != "Wrapped to last entry" {
+		t.Fatalf("expected [ to wrap to the last entry, got cursor %d selected %d status %q", m.cursor, m.selectedID, m.status)
+	}
+	if m = press(m, prev); m.cursor != 1 {
+		t.Fatalf("expected [ to move normally away from the boundary, got cursor %d", m.cursor)
+	}
+
+	for _, at := range []struct {
+		cursor int
+		key    tea.KeyMsg
+	}{{0, prev}, {2, next}} {
+		m := press(openAt("exit", at.cursor), at.key)
+		if m.inDetail || m.cursor != at.cursor || m.status != "No more entries" {
+			t.Fatalf("expected %s at entry %d to leave the detail view, got inDetail=%v cursor %d status %q", at.key, at.cursor, m.inDetail, m.cursor, m.status)
+		}
+	}
+}
+
+func TestImageRenderQueue_CapsConcurrentRenders(t *testing.T) {
+	queue := newImageRenderQueue(2)
+	var active, peak atomic.Int32
+	release := make(chan struct{})
+	render := func(string, int) (string, error) {
+		n := active.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		<-release
+		active.Add(-1)
+		return "preview", nil
+	}
+
+	var wg sync.WaitGroup
+	msgs := make(chan tea.Msg, 6)
+	for id := int64(1); id <= 6; id++ {
+		cmd := queuedInlineImagePreviewCmd(queue, id, "https://example.com/image.png", 40, render)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			msgs <- cmd()
+		}()
+	}
+	deadline := time.Now().Add(time.Second)
+	for active.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if got := active.Load(); got != 2 {
+		t.Fatalf("expected 2 renders running while 4 wait, got %d", got)
+	}
+	close(release)
+	wg.Wait()
+	close(msgs)
+	if got := peak.Load(); got != 2 {
+		t.Fatalf("expected at most 2 concurrent renders, peak was %d", got)
+	}
+	for msg := range msgs {
+		if _, ok := msg.(inlineImagePreviewSuccessMsg); !ok {
+			t.Fatalf("expected every queued render to finish, got %T", msg)
+		}
+	}
+}
+
+func TestImageRenderQueue_DropsWaitingRendersOnNavigation(t *testing.T) {
+	queue := newImageRenderQueue(1)
+	started := make(chan int64, 3)
+	release := make(chan struct{})
+	render := func(url string, _ int) (string, error) {
+		<-release
+		return url, nil
+	}
+	first := queuedInlineImagePreviewCmd(queue, 1, "one", 40, func(url string, width int) (string, error) {
+		started <- 1
+		return render(url, width)
+	})
+	second := queuedInlineImagePreviewCmd(queue, 2, "two", 40, render)
+	third := queuedInlineImagePreviewCmd(queue, 3, "three", 40, render)
+
+	results := make(chan tea.Msg, 3)
+	go func() { results <- first() }()
+	<-started
+	go func() { results <- second() }()
+
+	queue.dropExcept(3)
+	if msg := <-results; msg != (inlineImagePreviewCanceledMsg{entryID: 2}) {
+		t.Fatalf("expected waiting render for entry 2 dropped, got %#v", msg)
+	}
+	go func() { results <- third() }()
+	close(release)
+	got := map[int64]bool{}
+	for i := 0; i < 2; i++ {
+		if msg, ok := (<-results).(inlineImagePreviewSuccessMsg); ok {
+			got[msg.entryID] = true
+		}
+	}
+	if !got[1] || !got[3] {
+		t.Fatalf("expected running and current renders to finish, got %v", got)
+	}
+}
+
+func TestModelUpdate_DescribeKeyShowsBindingWithoutRunningIt(t *testing.T) {
+	entries := []feedbin.Entry{{ID: 1, Title: "One", FeedTitle: "Feed", PublishedAt: time.Now().UTC()}}
+	m := NewModel(fakeRefresher{entries: entries}, entries)
+	m.inDetail = true
+	m.selectedID = 1
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'W'}})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	model := updated.(Model)
+	if model.entries[0].IsStarred || model.loading {
+		t.Fatal("expected the described key not to run")
+	}
+	if !strings.HasPrefix(model.status, "S: toggle star — ") {
+		t.Fatalf("expected star binding described, got %q", model.status)
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if model = updated.(Model); model.describeKeyPending {
+		t.Fatal("expected normal mode after one described key")
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'W'}})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if model = updated.(Model); !model.inDetail || model.status != "esc: back — return to the list (also backspace)" {
+		t.Fatalf("expected esc described in detail view, got inDetail=%v status=%q", model.inDetail, model.status)
+	}
+
+	model.inDetail = false
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'W'}})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if model = updated.(Model); !strings.HasPrefix(model.status, "esc: release search — ") {
+		t.Fatalf("expected esc described in the list, got %q", model.status)
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'W'}})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if model = updated.(Model); model.status != "o does nothing in the list" {
+		t.Fatalf("expected unbound key reported, got %q", model.status)
+	}
+}
+
+type fakeWindowLoader struct {
+	entries []feedbin.Entry
+	offsets []int
+}
+
+func (f *fakeWindowLoader) ListCachedWindow(_ context.Context, _ string, offset, limit int) ([]feedbin.Entry, error) {
+	f.offsets = append(f.offsets, offset)
+	window := f.entries[min(offset, len(f.entries)):]
+	return append([]feedbin.Entry(nil), window[:min(limit, len(window))]...), nil
+}
+
+func TestModelEntryWindow_SlidesNearEitherEnd(t *testing.T) {
+	now := time.Now().UTC()
+	all := make([]feedbin.Entry, 0, 30)
+	for i := 0; i < 30; i++ {
+		all = append(all, feedbin.Entry{ID: int64(i + 1), Title: fmt.Sprintf("Entry %02d", i), FeedTitle: "Feed", PublishedAt: now.Add(-time.Duration(i) * time.Minute)})
+	}
+	loader := &fakeWindowLoader{entries: all}
+	m := NewModel(fakeRefresher{}, nil)
+	m.compact = true
+	m.entries = append([]feedbin.Entry(nil), all[:10]...)
+	m.sortEntries()
+	m.SetEntryWindow(loader, 10)
+
+	press := func(model Model, key string) Model {
+		t.Helper()
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		if cmd == nil {
+			t.Fatalf("expected a window load after %q", key)
+		}
+		updated, _ = updated.Update(cmd())
+		return updated.(Model)
+	}
+
+	model := press(m, "G")
+	if model.windowOffset != 5 || len(model.entries) != 10 {
+		t.Fatalf("expected the window moved 5 entries older, got offset %d with %d entries", model.windowOffset, len(model.entries))
+	}
+	if got := model.entries[model.cursor].ID; got != 10 {
+		t.Fatalf("expected the cursor kept on entry 10, got %d", got)
+	}
+	if model.status != "Showing entries 6–15" {
+		t.Fatalf("unexpected status %q", model.status)
+	}
+
+	model = press(model, "n")
+	if model.windowOffset != 10 || model.entries[0].ID != 11 {
+		t.Fatalf("expected n to read the next window once it is full, got offset %d", model.windowOffset)
+	}
+
+	model = press(model, "g")
+	if model.windowOffset != 5 {
+		t.Fatalf("expected the window moved back towards the newest entries, got offset %d", model.windowOffset)
+	}
+	if !slices.Equal(loader.offsets, []int{5, 10, 5}) {
+		t.Fatalf("unexpected window reads: %v", loader.offsets)
+	}
+
+	model.treeCursor = len(model.treeRows()) / 2
+	if _, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")}); cmd != nil {
+		t.Fatal("expected no window read away from the edges")
+	}
+}
+
+func TestModelEntryWindow_ScrollsBackUpFromPartialTail(t *testing.T) {
+	now := time.Now().UTC()
+	all := make([]feedbin.Entry, 0, 23)
+	for i := 0; i < 23; i++ {
+		all = append(all, feedbin.Entry{ID: int64(i + 1), Title: fmt.Sprintf("Entry %02d", i), FeedTitle: "Feed", PublishedAt: now.Add(-time.Duration(i) * time.Minute)})
+	}
+	loader := &fakeWindowLoader{entries: all}
+	m := NewModel(fakeRefresher{}, nil)
+	m.compact = true
+	m.entries = append([]feedbin.Entry(nil), all[:10]...)
+	m.sortEntries()
+	m.SetEntryWindow(loader, 10)
+
+	press := func(model Model, key string) Model {
+		t.Helper()
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		if cmd == nil {
+			t.Fatalf("expected a window load after %q at offset %d", key, model.windowOffset)
+		}
+		updated, _ = updated.Update(cmd())
+		return updated.(Model)
+	}
+
+	model := m
+	for model.windowFilled {
+		model = press(model, "G")
+	}
+	if model.windowOffset != 15 || len(model.entries) != 8 {
+		t.Fatalf("expected a partial tail window at offset 15, got offset %d with %d entries", model.windowOffset, len(model.entries))
+	}
+	if _, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")}); cmd != nil {
+		t.Fatal("expected no read past the tail")
+	}
+
+	for model.windowOffset > 0 {
+		model = press(model, "g")
+	}
+	if model.entries[0].ID != 1 || !model.windowFilled {
+		t.Fatalf("expected to scroll back to the newest window, got first entry %d", model.entries[0].ID)
+	}
+	if !slices.Equal(loader.offsets, []int{5, 10, 15, 10, 5, 0}) {
+		t.Fatalf("unexpected window reads: %v", loader.offsets)
+	}
+}
+
+func TestModelEntryWindow_KeepsCursorEntryInTreeMode(t *testing.T) {
+	now := time.Now().UTC()
+	all := make([]feedbin.Entry, 0, 30)
+	for i := 0; i < 30; i++ {
+		// Alternate feeds so the tree's last feed holds the newest entry.
+		feed := "B feed"
+		if i%2 == 1 {
+			feed = "A feed"
+		}
+		all = append(all, feedbin.Entry{ID: int64(i + 1), Title: fmt.Sprintf("Entry %02d", i), FeedTitle: feed, PublishedAt: now.Add(-time.Duration(i) * time.Minute)})
+	}
+	loader := &fakeWindowLoader{entries: all}
+	m := NewModel(fakeRefresher{}, nil)
+	m.entries = append([]feedbin.Entry(nil), all[:10]...)
+	m.sortEntries()
+	m.SetEntryWindow(loader, 10)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	if cmd != nil {
+		t.Fatal("expected no window read from the bottom of the folder tree")
+	}
+	model := updated.(Model)
+	model.selectedID = 0
+	for i, row := range model.treeRows() {
+		if row.Kind == treeRowArticle && model.entries[row.EntryIndex].ID == 3 {
+			model.treeCursor = i
+			model.cursor = row.EntryIndex
+		}
+	}
+
+	updated, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if cmd == nil {
+		t.Fatal("expected n to read the next window")
+	}
+	updated, _ = updated.Update(cmd())
+	model = updated.(Model)
+	if model.windowOffset != 2 {
+		t.Fatalf("expected the window to stop at the cursor entry, got offset %d", model.windowOffset)
+	}
+	if got := model.entries[model.cursor].ID; got != 3 {
+		t.Fatalf("expected the cursor kept on entry 3, got %d", got)
+	}
+}
+
+func TestModelEntryWindow_InfiniteScrollReadsNextWindowInTreeMode(t *testing.T) {
+	now := time.Now().UTC()
+	all := make([]feedbin.Entry, 0, 30)
+	for i := 0; i < 30; i++ {
+		all = append(all, feedbin.Entry{ID: int64(i + 1), Title: fmt.Sprintf("Entry %02d", i), FeedTitle: "Feed", PublishedAt: now.Add(-time.Duration(i) * time.Minute)})
+	}
+	loader := &fakeWindowLoader{entries: all}
+	m := NewModel(fakeRefresher{}, nil)
+	m.entries = append([]feedbin.Entry(nil), all[:10]...)
+	m.sortEntries()
+	m.SetEntryWindow(loader, 10)
+	m.SetInfiniteScroll(true)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	if cmd == nil {
+		t.Fatal("expected infinite scroll to read the next window at the bottom of the tree")
+	}
+	updated, _ = updated.Update(cmd())
+	model := updated.(Model)
+	if model.windowOffset == 0 || !slices.Equal(loader.offsets, []int{model.windowOffset}) {
+		t.Fatalf("expected one older window read, got offset %d after reads %v", model.windowOffset, loader.offsets)
+	}
+	if got := model.entries[model.cursor].ID; got != 10 {
+		t.Fatalf("expected the cursor kept on entry 10, got %d", got)
+	}
+}
+
+func TestModelUpdate_InfiniteScrollLoadsMoreOncePerCrossing(t *testing.T) {
+	now := time.Now().UTC()
+	var entries []feedbin.Entry
+	for i := 1; i <= 10; i++ {
+		entries = append(entries, feedbin.Entry{ID: int64(i), Title: fmt.Sprintf("Entry %d", i), FeedTitle: "Feed", PublishedAt: now.Add(-time.Duration(i) * time.Minute)})
+	}
+	m := NewModel(fakeRefresher{entries: entries}, entries)
+	m.SetInfiniteScroll(true)
+	key := func(model Model, k string) (Model, tea.Cmd) {
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		return updated.(Model), cmd
+	}
+
+	model, cmd := key(m, "G")
+	if cmd == nil {
+		t.Fatal("expected a load-more at the bottom of the list")
+	}
+	if _, ok := cmd().(tuiactions.LoadMoreSuccessMsg); !ok {
+		t.Fatal("expected the command to load the next page")
+	}
+	if !model.loading {
+		t.Fatal("expected the model to be loading")
+	}
+
+	updated, _ := model.Update(tuiactions.LoadMoreErrorMsg{Err: errors.New("timeout")})
+	model = updated.(Model)
+	if model, cmd = key(model, "k"); cmd != nil {
+		t.Fatal("expected no second load-more before the cursor leaves the bottom rows")
+	}
+
+	model, _ = key(model, "g")
+	if _, cmd = key(model, "G"); cmd == nil {
+		t.Fatal("expected another load-more after crossing the threshold again")
+	}
+}
+
+type fakePinStore struct {
+	saved []int64
+}
+
+func (f *fakePinStore) SavePinnedFeeds(_ context.Context, feedIDs []int64) error {
+	f.saved = feedIDs
+	return nil
+}
+
+type fakeFolderAssigner struct {
+	feedID int64
+	folder string
+}
+
+func (f *fakeFolderAssigner) AssignFolder(_ context.Context, feedID int64, folder string) (feedbin.FolderAssignment, error) {
+	f.feedID, f.folder = feedID, folder
+	return feedbin.FolderAssignment{Folder: folder, Folders: []string{folder}, Created: folder != "Tech"}, nil
+}
+
+func TestModelAssignFolder_MovesFeedInTreeImmediately(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, FeedID: 10, FeedFolder: "Tech", FeedTitle: "Alpha", Title: "A", PublishedAt: now},
+		{ID: 2, FeedID: 30, FeedTitle: "Top", Title: "T", PublishedAt: now},
+		{ID: 3, FeedID: 30, FeedTitle: "Top", Title: "T2", PublishedAt: now},
+	}
+	assigner := &fakeFolderAssigner{}
+	m := NewModel(fakeRefresher{entries: entries}, entries)
+	m.SetFolderAssigner(assigner)
+	for i, row := range m.treeRows() {
+		if row.Kind == treeRowFeed && row.Feed == "Top" {
+			m.treeCursor = i
+		}
+	}
+
+	press := func(model Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+		t.Helper()
+		updated, cmd := model.Update(msg)
+		return updated.(Model), cmd
+	}
+	model, _ := press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	model, _ = press(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	model, _ = press(model, tea.KeyMsg{Type: tea.KeyTab})
+	if model.folderPrompt == nil || model.folderPrompt.input != "Tech" {
+		t.Fatalf("expected tab to complete the existing folder, got %+v", model.folderPrompt)
+	}
+	model, cmd := press(model, tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || model.folderPrompt != nil {
+		t.Fatal("expected enter to submit the folder")
+	}
+	updated, _ := model.Update(cmd())
+	model = updated.(Model)
+	if assigner.feedID != 30 || assigner.folder != "Tech" {
+		t.Fatalf("expected Top filed under Tech, got %d %q", assigner.feedID, assigner.folder)
+	}
+	if row := model.treeRows()[model.treeCursor]; row.Kind != treeRowFeed || row.Feed != "Top" || row.Folder != "Tech" {
+		t.Fatalf("expected cursor on Top inside Tech, got %+v", row)
+	}
+	if model.hasTreeRow(treeRowFeed, "", "Top") || model.status != "Filed Top under Tech" {
+		t.Fatalf("expected Top to leave the Feeds section, got status %q", model.status)
+	}
+
+	model, _ = press(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	model, _ = press(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("News")})
+	if !strings.Contains(model.status, "new folder") {
+		t.Fatalf("expected the prompt to flag a new folder, got %q", model.status)
+	}
+	model, cmd = press(model, tea.KeyMsg{Type: tea.KeyEnter})
+	updated, _ = model.Update(cmd())
+	model = updated.(Model)
+	if !model.hasTreeRow(treeRowFeed, "News", "Top") || model.status != "Filed Top under new folder News" {
+		t.Fatalf("expected Top under the new News folder, got status %q", model.status)
+	}
+	for _, entry := range model.entries {
+		if entry.FeedID == 30 && (entry.FeedFolder != "News" || !slices.Equal(entry.FeedFolders, []string{"News"})) {
+			t.Fatalf("expected loaded entries moved to News, got %+v", entry)
+		}
+	}
+}
+
+func TestModelTogglePinnedFeed_MovesFeedToPinnedSection(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, FeedID: 10, FeedFolder: "Tech", FeedTitle: "Alpha", Title: "A", PublishedAt: now},
+		{ID: 2, FeedID: 20, FeedFolder: "Tech", FeedTitle: "Beta", Title: "B", IsUnread: true, PublishedAt: now},
+		{ID: 3, FeedID: 30, FeedTitle: "Top", Title: "T", PublishedAt: now},
+	}
+	store := &fakePinStore{}
+	m := NewModel(fakeRefresher{entries: entries}, entries)
+	m.SetPinnedFeeds(store, nil)
+	for i, row := range m.treeRows() {
+		if row.Kind == treeRowFeed && row.Feed == "Beta" {
+			m.treeCursor = i
+		}
+	}
+
+	pin := func(model Model) Model {
+		t.Helper()
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+		batch, ok := cmd().(tea.BatchMsg)
+		if !ok || len(batch) == 0 {
+			t.Fatalf("expected save and status commands, got %T", cmd())
+		}
+		if msg := batch[0](); msg != nil {
+			t.Fatalf("expected pinned feeds saved, got %#v", msg)
+		}
+		return updated.(Model)
+	}
+
+	model := pin(m)
+	rows := model.treeRows()
+	if rows[0].Kind != treeRowSection || rows[0].Label != "Pinned" || rows[1].Feed != "Beta" || !rows[1].Pinned {
+		t.Fatalf("expected Beta at the top in the Pinned section, got %+v", rows[:2])
+	}
+	if row := rows[model.treeCursor]; row.Kind != treeRowFeed || row.Feed != "Beta" {
+		t.Fatalf("expected cursor to follow the pinned feed, got %+v", row)
+	}
+	if !slices.Equal(store.saved, []int64{20}) || model.status != "Pinned Beta" {
+		t.Fatalf("expected pin saved with status, got %v %q", store.saved, model.status)
+	}
+	if counts := model.unreadCountsBySection(); counts["Pinned"] != 1 || counts["Folders"] != 0 {
+		t.Fatalf("expected Beta's unread entry counted under Pinned, got %v", counts)
+	}
+	if label, collected, ok := model.currentCollection(); !ok || label != "Beta" || len(collected) != 1 {
+		t.Fatalf("expected the pinned feed row to collect its entries, got %q %d", label, len(collected))
+	}
+
+	model.treeCursor = 0
+	model.toggleCurrentTreeNode()
+	if rows := model.treeRows(); rows[1].Kind != treeRowSection || rows[1].Label != "Folders" {
+		t.Fatalf("expected the collapsed Pinned section to hide its feeds, got %+v", rows[:2])
+	}
+	model.toggleCurrentTreeNode()
+
+	model.treeCursor = 1
+	model = pin(model)
+	for _, row := range model.treeRows() {
+		if row.Pinned {
+			t.Fatalf("expected no Pinned rows after unpinning, got %+v", row)
+		}
+	}
+	if row := model.treeRows()[model.treeCursor]; row.Feed != "Beta" || row.Folder != "Tech" {
+		t.Fatalf("expected cursor on Beta back in Tech, got %+v", row)
+	}
+	if len(store.saved) != 0 || model.status != "Unpinned Beta" {
+		t.Fatalf("expected unpin saved with status, got %v %q", store.saved, model.status)
+	}
+}
+
+type fakeFilterAnchorStore struct {
+	saved map[string]int64
+}
+
+func (f *fakeFilterAnchorStore) SaveFilterAnchors(_ context.Context, anchors map[string]int64) error {
+	f.saved = anchors
+	return nil
+}
+
+func TestModelUpdate_SwitchFilterRestoresRememberedEntry(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Read", PublishedAt: now},
+		{ID: 2, Title: "Unread one", IsUnread: true, PublishedAt: now.Add(-time.Hour)},
+		{ID: 3, Title: "Unread two", IsUnread: true, PublishedAt: now.Add(-2 * time.Hour)},
+	}
+	store := &fakeFilterAnchorStore{}
+	m := NewModel(fakeRefresher{entries: entries}, entries)
+	m.SetFilterAnchors(store, map[string]int64{"unread": 3})
+	m.cursor = tuistate.EntryIndexByID(m.entries, 1)
+
+	switchTo := func(model Model, key rune) Model {
+		t.Helper()
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}})
+		if cmd == nil {
+			t.Fatalf("expected filter command for %q", key)
+		}
+		msgs := []tea.Msg{cmd()}
+		if batch, ok := msgs[0].(tea.BatchMsg); ok {
+			msgs = msgs[:0]
+			for _, c := range batch {
+				msgs = append(msgs, c())
+			}
+		}
+		for _, msg := range msgs {
+			if msg != nil {
+				updated, _ = updated.Update(msg)
+			}
+		}
+		return updated.(Model)
+	}
+
+	model := switchTo(m, 'u')
+	if got := model.entries[model.cursor].ID; got != 3 {
+		t.Fatalf("expected remembered unread entry 3, got %d", got)
+	}
+	if store.saved["all"] != 1 {
+		t.Fatalf("expected all-filter anchor saved, got %+v", store.saved)
+	}
+
+	model.cursor = tuistate.EntryIndexByID(model.entries, 2)
+	model = switchTo(model, 'a')
+	if got := model.entries[model.cursor].ID; got != 1 {
+		t.Fatalf("expected cursor back on entry 1 in all, got %d", got)
+	}
+
+	model = switchTo(model, 'u')
+	if got := model.entries[model.cursor].ID; got != 2 {
+		t.Fatalf("expected cursor back on entry 2 in unread, got %d", got)
+	}
+}
+
+func TestModelTagsAsFolders_FeedUnderEachTagWithCounts(t *testing.T) {
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Story", FeedID: 10, FeedTitle: "Wire", FeedFolder: "News", FeedFolders: []string{"News", "Tech"}, IsUnread: true, PublishedAt: time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)},
+	}
+	m := NewModel(nil, entries)
+	if m.hasTreeRow(treeRowFolder, "Tech", "") {
+		t.Fatal("expected feed only under its first tag by default")
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+	model := updated.(Model)
+	if !model.tagsAsFolders || model.status != "Feeds under every tag: on" {
+		t.Fatalf("expected tags-as-folders on, got %v %q", model.tagsAsFolders, model.status)
+	}
+	if !model.hasTreeRow(treeRowFeed, "News", "Wire") || !model.hasTreeRow(treeRowFeed, "Tech", "Wire") {
+		t.Fatalf("expected Wire under both tags, got %+v", model.treeRows())
+	}
+	folderCounts, feedCounts := model.unreadCountsByTreeNode()
+	if folderCounts["News"] != 1 || folderCounts["Tech"] != 1 || feedCounts[treeFeedKey("Tech", "Wire")] != 1 {
+		t.Fatalf("expected unread counted under both tags, got %v %v", folderCounts, feedCounts)
+	}
+	if !model.preferences().TagsAsFolders {
+		t.Fatal("expected preference to be saved")
+	}
+}
+
+func TestModelHideRead_AllViewShowsUnreadAndStarredOnly(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Unread story", FeedTitle: "Feed", IsUnread: true, PublishedAt: now},
+		{ID: 2, Title: "Read story", FeedTitle: "Feed", PublishedAt: now.Add(-time.Hour)},
+		{ID: 3, Title: "Starred story", FeedTitle: "Feed", IsStarred: true, PublishedAt: now.Add(-2 * time.Hour)},
+	}
+	service := fakeRefresher{entries: entries}
+	m := NewModel(service, entries)
+	m.width = 120
+	m.height = 30
+	m.ApplyPreferences(Preferences{HideRead: true})
+
+	view := m.View()
+	if strings.Contains(view, "Read story") {
+		t.Fatalf("expected read entry hidden from the all view, got:\n%s", view)
+	}
+	if !strings.Contains(view, "Unread story") || !strings.Contains(view, "Starred story") {
+		t.Fatalf("expected unread and starred entries listed, got:\n%s", view)
+	}
+	if m.filterLabel() != "all (unread+starred)" {
+		t.Fatalf("expected footer to name the reduced view, got %q", m.filterLabel())
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+	model := updated.(Model)
+	if model.hideRead || model.status != "Read entries in all: shown" || cmd == nil {
+		t.Fatalf("expected A to show read entries again with a reload, got hide=%v status=%q", model.hideRead, model.status)
+	}
+	updated, _ = model.Update(cmd())
+	model = updated.(Model)
+	if len(model.entries) != 3 {
+		t.Fatalf("expected read entry back after reload, got %+v", model.entries)
+	}
+}
+
+func TestModelCompactUnreadFirst_TogglesSortAndFooter(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Read", FeedTitle: "Feed", PublishedAt: now},
+		{ID: 2, Title: "Unread", FeedTitle: "Feed", PublishedAt: now.Add(-time.Hour), IsUnread: true},
+	}
+	m := NewModel(fakeRefresher{}, entries)
+	m.width = 120
+	m.height = 30
+	m.ApplyPreferences(Preferences{Compact: true})
+	if first := m.entries[m.treeRows()[0].EntryIndex].ID; first != 1 {
+		t.Fatalf("expected date order before toggling, got entry %d first", first)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+	m = updated.(Model)
+	if !m.compactUnreadFirst || m.status != "Compact sort: unread first, then date" {
+		t.Fatalf("expected O to enable unread first, got on=%v status=%q", m.compactUnreadFirst, m.status)
+	}
+	if first := m.entries[m.treeRows()[0].EntryIndex].ID; first != 2 {
+		t.Fatalf("expected unread entry first, got entry %d", first)
+	}
+	if !strings.Contains(m.footer(), "unread first") {
+		t.Fatalf("expected footer to show the sort, got %q", m.footer())
+	}
+	if !m.preferences().CompactUnreadFirst {
+		t.Fatal("expected unread-first preference set")
+	}
+}
+
+func TestModelRefresh_KeepScrollPositionHoldsSelectedRow(t *testing.T) {
+	now := time.Now().UTC()
+	entryAt := func(id int) feedbin.Entry {
+		return feedbin.Entry{ID: int64(id), Title: fmt.Sprintf("Entry %d", id), FeedTitle: "Feed", PublishedAt: now.Add(-time.Duration(id) * time.Minute)}
+	}
+	var before, after []feedbin.Entry
+	for id := 10; id < 30; id++ {
+		before = append(before, entryAt(id))
+	}
+	for id := 5; id < 30; id++ {
+		after = append(after, entryAt(id))
+	}
+
+	screenRows := func(keep bool) (int, int) {
+		m := NewModel(fakeRefresher{}, before)
+		m.width = 100
+		m.height = 16
+		m.perPage = 50
+		m.ApplyPreferences(Preferences{Compact: true})
+		m.SetKeepScrollPosition(keep)
+		m.restoreSelection(12)
+		start, _, _ := m.listWindow(m.treeRows())
+		rowBefore := m.treeCursor - start
+
+		updated, _ := m.Update(tuiactions.RefreshSuccessMsg{Entries: after, Source: "manual"})
+		m = updated.(Model)
+		if m.entries[m.cursor].ID != 12 {
+			t.Fatalf("expected entry 12 still selected, got %d", m.entries[m.cursor].ID)
+		}
+		start, _, _ = m.listWindow(m.treeRows())
+		return rowBefore, m.treeCursor - start
+	}
+
+	if rowBefore, rowAfter := screenRows(true); rowBefore != rowAfter {
+		t.Fatalf("expected the selected entry to stay on screen row %d, got %d", rowBefore, rowAfter)
+	}
+	if rowBefore, rowAfter := screenRows(false); rowBefore == rowAfter {
+		t.Fatalf("expected the default refresh to recenter the list, row stayed %d", rowBefore)
+	}
+}
+
+func TestModelRefresh_KeepScrollPositionPinDropsOnceCursorLeaves(t *testing.T) {
+	now := time.Now().UTC()
+	var entries []feedbin.Entry
+	for id := 1; id <= 40; id++ {
+		entries = append(entries, feedbin.Entry{ID: int64(id), Title: fmt.Sprintf("Entry %d", id), FeedTitle: "Feed", PublishedAt: now.Add(-time.Duration(id) * time.Minute)})
+	}
+	m := NewModel(fakeRefresher{}, entries)
+	m.width = 100
+	m.height = 16
+	m.perPage = 50
+	m.ApplyPreferences(Preferences{Compact: true})
+	m.SetKeepScrollPosition(true)
+	m.restoreSelection(12)
+
+	updated, _ := m.Update(tuiactions.RefreshSuccessMsg{Entries: entries, Source: "manual"})
+	m = updated.(Model)
+	if !m.listStartPinned {
+		t.Fatal("expected the refresh to pin the list window")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if !updated.(Model).listStartPinned {
+		t.Fatal("expected the pin kept while the cursor stays inside the window")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	m = updated.(Model)
+	if m.listStartPinned {
+		t.Fatal("expected the pin dropped once the cursor left the window")
+	}
+	m.restoreSelection(12)
+	start, end, _ := m.listWindow(m.treeRows())
+	if want, wantEnd := tuistate.CenteredWindow(len(m.treeRows()), m.treeCursor, m.listBodyHeight()); start != want || end != wantEnd {
+		t.Fatalf("expected coming back to recenter, got window %d-%d want %d-%d", start, end, want, wantEnd)
+	}
+}
+
+func TestModelPreviewLines_WindowKeepsCursorVisible(t *testing.T) {
+	now := time.Now().UTC()
+	entries := make([]feedbin.Entry, 0, 20)
+	for i := 1; i <= 20; i++ {
+		entries = append(entries, feedbin.Entry{
+			ID:          int64(i),
+			Title:       fmt.Sprintf("Entry %d", i),
+			FeedTitle:   "Feed",
+			Summary:     strings.Repeat("summary words ", 20),
+			PublishedAt: now.Add(-time.Duration(i) * time.Minute),
+		})
+	}
+	m := NewModel(fakeRefresher{}, entries)
+	m.width = 80
+	m.height = 20
+	m.ApplyPreferences(Preferences{Compact: true})
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	m = updated.(Model)
+	if m.previewLines != 2 || m.status != "Preview lines: 2" || m.preferences().PreviewLines != 2 {
+		t.Fatalf("expected two preview lines, got %d status=%q", m.previewLines, m.status)
+	}
+
+	rows := m.treeRows()
+	for i := 0; i < len(rows)-1; i++ {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		m = updated.(Model)
+		start, end, _ := m.listWindow(rows)
+		if m.treeCursor < start || m.treeCursor >= end {
+			t.Fatalf("cursor %d outside window [%d,%d)", m.treeCursor, start, end)
+		}
+		lines := 0
+		for r := start; r < end; r++ {
+			lines += m.listRowHeight(rows, r)
+		}
+		if lines > m.listBodyHeight() {
+			t.Fatalf("window [%d,%d) uses %d lines, body has %d", start, end, lines, m.listBodyHeight())
+		}
+	}
+	if got := strings.Count(m.View(), "summary words"); got == 0 {
+		t.Fatal("expected preview snippets in the list")
+	}
+
+	step := m.listPageStep()
+	if want := tuistate.PageStep(m.height, m.status != "") / 3; step != want {
+		t.Fatalf("expected page step %d with 3-line rows, got %d", want, step)
+	}
+}
+
+func TestModelStackedList_NarrowTerminalUsesTwoLinesPerEntry(t *testing.T) {
+	now := time.Now().UTC()
+	entries := make([]feedbin.Entry, 0, 20)
+	for i := 1; i <= 20; i++ {
+		entries = append(entries, feedbin.Entry{
+			ID:          int64(i),
+			Title:       fmt.Sprintf("Entry %d long title", i),
+			FeedTitle:   "Feed",
+			PublishedAt: now.Add(-time.Duration(i) * time.Minute),
+		})
+	}
+	m := NewModel(fakeRefresher{}, entries)
+	m.SetStackedListWidth(40)
+	m.width = 50
+	m.height = 20
+	m.ApplyPreferences(Preferences{Compact: true})
+	if m.stackedList() || strings.Count(m.View(), "Entry 1 long") != 1 {
+		t.Fatal("expected one-line entries at 50 columns")
+	}
+
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 30, Height: 20})
+	m = updated.(Model)
+	if !m.stackedList() {
+		t.Fatal("expected stacked entries below 40 columns")
+	}
+	if view := stripANSI(m.View()); strings.Contains(view, "Feed | ") || !strings.Contains(view, "Entry 1 long title") {
+		t.Fatalf("expected the title alone on its line, got %q", view)
+	}
+
+	rows := m.treeRows()
+	for i := 0; i < len(rows)-1; i++ {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		m = updated.(Model)
+		start, end, _ := m.listWindow(rows)
+		if m.treeCursor < start || m.treeCursor >= end {
+			t.Fatalf("cursor %d outside window [%d,%d)", m.treeCursor, start, end)
+		}
+		lines := 0
+		for r := start; r < end; r++ {
+			lines += m.listRowHeight(rows, r)
+		}
+		if lines > m.listBodyHeight() {
+			t.Fatalf("window [%d,%d) uses %d lines, body has %d", start, end, lines, m.listBodyHeight())
+		}
+	}
+	if want := tuistate.PageStep(m.height, m.status != "") / 2; m.listPageStep() != want {
+		t.Fatalf("expected page step %d with 2-line rows, got %d", want, m.listPageStep())
+	}
+}
+
+func TestModelSetTitleStyles_HiddenReadStartsHidingRead(t *testing.T) {
+	defer func() { uiTheme = tuitheme.Default() }()
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Unread", IsUnread: true, PublishedAt: time.Now().UTC()},
+		{ID: 2, Title: "Read", PublishedAt: time.Now().UTC()},
+	}
+	m := NewModel(nil, entries)
+	m.SetTitleStyles("hidden", "color")
+	if !m.hideRead || len(m.entries) != 1 || m.entries[0].ID != 1 {
+		t.Fatalf("expected read entries hidden, got hide=%v entries=%+v", m.hideRead, m.entries)
+	}
+	if !m.preferences().HideRead {
+		t.Fatal("expected hide-read preference set")
+	}
+}
+
+func TestModelSameTitledFeeds_OperationsStayInTheirFolder(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []feedbin.Entry{
+		{ID: 1, FeedID: 10, FeedTitle: "Blog", FeedFolder: "Tech", FeedURL: "https://tech.example/feed", Title: "Tech one", IsUnread: true, PublishedAt: now},
+		{ID: 2, FeedID: 10, FeedTitle: "Blog", FeedFolder: "Tech", FeedURL: "https://tech.example/feed", Title: "Tech two", IsUnread: true, PublishedAt: now.Add(-time.Minute)},
+		{ID: 3, FeedID: 20, FeedTitle: "Blog", FeedFolder: "Personal", FeedURL: "https://home.example/feed", Title: "Home one", IsUnread: true, PublishedAt: now.Add(-2 * time.Minute)},
+		{ID: 4, FeedID: 30, FeedTitle: "Blog", FeedFolder: "Tech", FeedURL: "https://other.example/feed", Title: "Pinned one", IsUnread: true, PublishedAt: now.Add(-3 * time.Minute)},
+	}
+	m := NewModel(&openWorkflowService{}, entries)
+	m.SetPinnedFeeds(&fakePinStore{}, []int64{30})
+	var copied string
+	m.copyURLFn = func(url string) error { copied = url; return nil }
+
+	feedRows := map[string]int{}
+	for i, row := range m.treeRows() {
+		if row.Kind == treeRowFeed {
+			key := row.Folder
+			if row.Pinned {
+				key = "Pinned"
+			}
+			feedRows[key] = i
+		}
+	}
+	if len(feedRows) != 3 {
+		t.Fatalf("expected three Blog feed rows, got %v", feedRows)
+	}
+
+	_, feedCounts := m.unreadCountsByTreeNode()
+	if got := feedCounts[treeFeedKey("Tech", "Blog")]; got != 2 {
+		t.Fatalf("expected Tech/Blog to count only its own 2 unread entries, got %d", got)
+	}
+	if got := feedCounts[treeFeedKey("Personal", "Blog")]; got != 1 {
+		t.Fatalf("expected Personal/Blog to count 1 unread entry, got %d", got)
+	}
+	if got := m.unreadCountsBySection()["Pinned"]; got != 1 {
+		t.Fatalf("expected the pinned Blog to count 1 unread entry, got %d", got)
+	}
+
+	for key, wantIDs := range map[string][]int64{"Tech": {1, 2}, "Personal": {3}, "Pinned": {4}} {
+		m.treeCursor = feedRows[key]
+		_, collected, _ := m.currentCollection()
+		ids := make([]int64, 0, len(collected))
+		for _, entry := range collected {
+			ids = append(ids, entry.ID)
+		}
+		if !slices.Equal(ids, wantIDs) {
+			t.Fatalf("expected %s/Blog to collect %v, got %v", key, wantIDs, ids)
+		}
+		if got, want := m.feedIDForRow(m.treeRows()[m.treeCursor]), m.entries[slices.IndexFunc(m.entries, func(e feedbin.Entry) bool { return e.ID == wantIDs[0] })].FeedID; got != want {
+			t.Fatalf("expected %s/Blog to resolve to feed %d, got %d", key, want, got)
+		}
+	}
+
+	m.treeCursor = feedRows["Pinned"]
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatal("expected copy feed command")
+	}
+	updated.Update(cmd())
+	if copied != "https://other.example/feed" {
+		t.Fatalf("expected the pinned feed's URL copied, got %q", copied)
+	}
+
+	m.ApplyPreferences(Preferences{ConfirmBulkActions: false})
+	m.treeCursor = feedRows["Personal"]
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+	if cmd == nil {
+		t.Fatal("expected mark-read batch command")
+	}
+	if msg := cmd().(tuiactions.BatchUpdateResultMsg); !slices.Equal(msg.Succeeded, []int64{3}) {
+		t.Fatalf("expected only Personal/Blog's entry marked read, got %v", msg.Succeeded)
+	}
+
+	m.ApplyPreferences(Preferences{Compact: true})
+	labels := make(map[string]bool)
+	for _, entry := range m.entries {
+		labels[strings.SplitN(compactEntryLabel(entry), " | ", 3)[0]] = true
+	}
+	if !labels["Tech"] || !labels["Personal"] {
+		t.Fatalf("expected compact labels to name each Blog's folder, got %v", labels)
+	}
+}
@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+	tuiactions "github.com/glabrego/reeder-cli/internal/tui/actions"
+)
+
+// MuteRule hides entries whose title, summary or content matches it: a
+// case-insensitive substring, or a regular expression written as /regex/.
+type MuteRule struct {
+	substring string
+	pattern   *regexp.Regexp
+}
+
+// ParseMuteRules turns rule lines into mute rules, rejecting invalid
+// /regex/ forms.
+func ParseMuteRules(lines []string) ([]MuteRule, error) {
+	rules := make([]MuteRule, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if len(line) > 2 && strings.HasPrefix(line, "/") && strings.HasSuffix(line, "/") {
+			pattern, err := regexp.Compile(line[1 : len(line)-1])
+			if err != nil {
+				return nil, fmt.Errorf("mute rule %q: %w", line, err)
+			}
+			rules = append(rules, MuteRule{pattern: pattern})
+			continue
+		}
+		rules = append(rules, MuteRule{substring: strings.ToLower(line)})
+	}
+	return rules, nil
+}
+
+func (r MuteRule) matches(entry feedbin.Entry) bool {
+	for _, text := range []string{entry.Title, entry.Summary, entry.Content} {
+		if text == "" {
+			continue
+		}
+		if r.pattern != nil {
+			if r.pattern.MatchString(text) {
+				return true
+			}
+		} else if strings.Contains(strings.ToLower(text), r.substring) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetMuteRules hides the entries matching rules from every view until ~
+// shows them.
+func (m *Model) SetMuteRules(rules []MuteRule) {
+	m.muteRules = rules
+	m.applyCurrentFilter()
+}
+
+func (m Model) muteActive() bool {
+	return len(m.muteRules) > 0 && !m.showMuted
+}
+
+func (m Model) muted(entry feedbin.Entry) bool {
+	for _, rule := range m.muteRules {
+		if rule.matches(entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// dropMuted removes muted entries from the list, adding them to the
+// footer's hidden count.
+func (m *Model) dropMuted() {
+	if !m.muteActive() {
+		return
+	}
+	kept := m.entries[:0:0]
+	for _, entry := range m.entries {
+		if m.muted(entry) {
+			m.mutedCount++
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	m.entries = kept
+}
+
+func (m Model) mutedLabel() string {
+	return strconv.Itoa(m.mutedCount) + " muted"
+}
+
+// toggleShowMuted shows the entries hidden by mute rules until pressed
+// again. Hidden entries are no longer in the list, so showing them reloads
+// the filter or search.
+func (m Model) toggleShowMuted() (tea.Model, tea.Cmd) {
+	m.err = nil
+	if len(m.muteRules) == 0 {
+		m.status = "No mute rules set (FEEDBIN_MUTE_KEYWORDS)"
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 3*time.Second)
+	}
+	m.showMuted = !m.showMuted
+	if !m.showMuted {
+		m.status = "Hiding muted entries"
+		anchorID := m.anchorEntryID()
+		m.applyCurrentFilter()
+		if m.searchQuery != "" {
+			m.searchMatchCount = len(m.entries)
+		}
+		m.restoreSelection(anchorID)
+		return m, nil
+	}
+	m.status = "Showing muted entries"
+	m.mutedCount = 0
+	if m.service == nil {
+		return m, nil
+	}
+	m.loading = true
+	if m.searchQuery != "" {
+		return m, tuiactions.LoadSearchCmd(m.service, m.filter, m.searchQuery, m.currentLimit())
+	}
+	return m, tuiactions.LoadFilterCmd(m.service, m.filter, m.currentLimit())
+}
@@ -0,0 +1,51 @@
+package tui
+
+import tuiactions "github.com/glabrego/reeder-cli/internal/tui/actions"
+
+// pendingToggleKey identifies an in-flight read/star toggle for one entry.
+type pendingToggleKey struct {
+	entryID int64
+	field   string
+}
+
+// beginToggle applies a read/star change locally before the service confirms
+// it. The change is remembered until settleToggle or rollbackToggle so that
+// refreshes landing in between do not overwrite it with stale server state.
+func (m *Model) beginToggle(entryID int64, field string, value bool) {
+	if m.pendingToggles == nil {
+		m.pendingToggles = make(map[pendingToggleKey]bool)
+	}
+	m.pendingToggles[pendingToggleKey{entryID: entryID, field: field}] = value
+	m.setEntryField(entryID, field, value)
+}
+
+// settleToggle forgets a toggle once the service has confirmed it.
+func (m *Model) settleToggle(entryID int64, field string) {
+	delete(m.pendingToggles, pendingToggleKey{entryID: entryID, field: field})
+}
+
+// rollbackToggle restores the value an entry had before a rejected toggle.
+func (m *Model) rollbackToggle(entryID int64, field string) {
+	key := pendingToggleKey{entryID: entryID, field: field}
+	value, ok := m.pendingToggles[key]
+	if !ok {
+		return
+	}
+	delete(m.pendingToggles, key)
+	m.setEntryField(entryID, field, !value)
+}
+
+// reapplyPendingToggles layers unconfirmed toggles over freshly loaded entries.
+func (m *Model) reapplyPendingToggles() {
+	for key, value := range m.pendingToggles {
+		m.setEntryField(key.entryID, key.field, value)
+	}
+}
+
+func (m *Model) setEntryField(entryID int64, field string, value bool) {
+	if field == tuiactions.BatchFieldStarred {
+		m.setEntryStarred(entryID, value)
+		return
+	}
+	m.setEntryUnread(entryID, value)
+}
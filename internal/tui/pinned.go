@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+// PinStore persists the feeds pinned to the top of the tree (i).
+type PinStore interface {
+	SavePinnedFeeds(ctx context.Context, feedIDs []int64) error
+}
+
+type pinnedFeedsSaveErrorMsg struct {
+	err error
+}
+
+// SetPinnedFeeds wires the store used to persist pinned feeds and seeds the
+// ones loaded at startup.
+func (m *Model) SetPinnedFeeds(store PinStore, feedIDs []int64) {
+	m.pinStore = store
+	m.pinnedFeeds = make(map[int64]bool, len(feedIDs))
+	for _, id := range feedIDs {
+		m.pinnedFeeds[id] = true
+	}
+	m.treeVersion++
+}
+
+func (m Model) entryPinned(entry feedbin.Entry) bool {
+	return m.pinnedFeeds[entry.FeedID]
+}
+
+// feedIDForRow returns the ID of the feed behind a feed or article row, or
+// zero when no loaded entry belongs to it.
+func (m Model) feedIDForRow(row treeRow) int64 {
+	if row.Kind == treeRowArticle {
+		return m.entries[row.EntryIndex].FeedID
+	}
+	for _, entry := range m.entries {
+		if m.entryOnFeedRow(entry, row) {
+			return entry.FeedID
+		}
+	}
+	return 0
+}
+
+// entryOnFeedRow reports whether entry is listed under feed row. Feeds with
+// the same title are told apart by folder, and a pinned feed from a
+// same-titled feed still in its folder.
+func (m Model) entryOnFeedRow(entry feedbin.Entry, row treeRow) bool {
+	return feedNameForEntry(entry) == row.Feed &&
+		m.entryPinned(entry) == row.Pinned &&
+		slices.Contains(m.entryFolders(entry), row.Folder)
+}
+
+// togglePinnedFeed moves the feed at the cursor into the Pinned section at
+// the top of the tree, or back to its folder, keeping the cursor on it.
+func (m Model) togglePinnedFeed() (tea.Model, tea.Cmd) {
+	rows := m.treeRows()
+	if len(rows) == 0 {
+		return m, nil
+	}
+	m.ensureTreeCursorValid()
+	m.err = nil
+	row := rows[m.treeCursor]
+	feedID := int64(0)
+	if row.Kind == treeRowFeed {
+		feedID = m.feedIDForRow(row)
+	}
+	if feedID == 0 {
+		m.status = "Move to a feed row to pin it"
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 3*time.Second)
+	}
+	if m.pinnedFeeds == nil {
+		m.pinnedFeeds = make(map[int64]bool)
+	}
+	if m.pinnedFeeds[feedID] {
+		delete(m.pinnedFeeds, feedID)
+		m.status = "Unpinned " + row.Feed
+	} else {
+		m.pinnedFeeds[feedID] = true
+		m.status = "Pinned " + row.Feed
+	}
+	m.treeVersion++
+	m.revealFeed(treeRow{Kind: treeRowFeed, Folder: row.Folder, Feed: row.Feed, Pinned: m.pinnedFeeds[feedID]})
+	m.setTreeCursorToFeed(row.Folder, row.Feed)
+	m.ensureCursorVisible()
+	m.statusID++
+	return m, tea.Batch(savePinnedFeedsCmd(m.pinStore, m.pinnedFeedIDs()), clearStatusCmd(m.statusID, 3*time.Second))
+}
+
+func (m Model) pinnedFeedIDs() []int64 {
+	ids := make([]int64, 0, len(m.pinnedFeeds))
+	for id := range m.pinnedFeeds {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func savePinnedFeedsCmd(store PinStore, feedIDs []int64) tea.Cmd {
+	if store == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := store.SavePinnedFeeds(ctx, feedIDs); err != nil {
+			return pinnedFeedsSaveErrorMsg{err: err}
+		}
+		return nil
+	}
+}
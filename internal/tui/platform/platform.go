@@ -2,13 +2,40 @@ package platform
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
+	"io"
 	"net/url"
+	"os"
 	"os/exec"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 )
 
+// Output is the terminal the program renders to; pass it to tea.WithOutput.
+// Its writes are serialized, so escape sequences sent from commands never land
+// inside a frame the renderer is writing.
+var Output = &terminalOutput{file: os.Stdout}
+
+// terminalOutput locks around each write and keeps Fd so Bubble Tea still
+// detects the terminal.
+type terminalOutput struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func (t *terminalOutput) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.file.Write(p)
+}
+
+func (t *terminalOutput) Read(p []byte) (int, error) { return t.file.Read(p) }
+func (t *terminalOutput) Close() error               { return t.file.Close() }
+func (t *terminalOutput) Fd() uintptr                { return t.file.Fd() }
+
 func ValidateEntryURL(raw string) (string, error) {
 	trimmed := strings.TrimSpace(raw)
 	if trimmed == "" {
@@ -33,19 +60,72 @@ func OpenURLInBrowser(url string) error {
 	return cmd.Run()
 }
 
+// IsSSHSession reports whether the process runs over SSH, where a browser
+// launcher would open on the remote host instead of the user's machine.
+func IsSSHSession() bool {
+	return isSSHSession(os.Getenv)
+}
+
+// CopyURLToClipboard copies with the first available clipboard command. Over
+// SSH, or when no command exists, it falls back to an OSC 52 escape so the
+// local terminal sets its clipboard.
 func CopyURLToClipboard(url string) error {
+	if IsSSHSession() {
+		return copyViaOSC52(url)
+	}
 	selected, err := selectClipboardCommand(exec.LookPath)
 	if err != nil {
-		return err
+		return copyViaOSC52(url)
 	}
 	cmd := exec.Command(selected[0], selected[1:]...)
 	cmd.Stdin = bytes.NewBufferString(url)
 	if err := cmd.Run(); err != nil {
-		return err
+		return fmt.Errorf("clipboard command %s failed: %w", selected[0], err)
+	}
+	return nil
+}
+
+// copyViaOSC52 asks the terminal to set its clipboard. Terminals without
+// OSC 52 support ignore the sequence, so success is not guaranteed.
+func copyViaOSC52(text string) error {
+	if _, err := io.WriteString(Output, osc52Sequence(text, os.Getenv("TMUX") != "")); err != nil {
+		return fmt.Errorf("OSC 52 clipboard escape failed: %w", err)
 	}
 	return nil
 }
 
+func osc52Sequence(text string, inTmux bool) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+	if !inTmux {
+		return seq
+	}
+	// tmux only forwards escapes wrapped in a DCS passthrough with every ESC
+	// doubled.
+	return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+}
+
+// RingBell announces newEntries new articles: it writes the terminal bell,
+// or runs command through the shell with FEEDBIN_NEW_ENTRIES set when one is
+// configured.
+func RingBell(command string, newEntries int) error {
+	if command == "" {
+		if _, err := io.WriteString(Output, "\a"); err != nil {
+			return fmt.Errorf("terminal bell failed: %w", err)
+		}
+		return nil
+	}
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Env = append(os.Environ(), "FEEDBIN_NEW_ENTRIES="+strconv.Itoa(newEntries))
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("bell command failed: %w", err)
+	}
+	return nil
+}
+
+func isSSHSession(getenv func(string) string) bool {
+	return getenv("SSH_CONNECTION") != "" || getenv("SSH_TTY") != ""
+}
+
 func browserCommand(goos, rawURL string) (string, []string) {
 	switch goos {
 	case "darwin":
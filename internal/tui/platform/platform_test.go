@@ -2,6 +2,8 @@ package platform
 
 import (
 	"errors"
+	"io"
+	"os"
 	"reflect"
 	"strings"
 	"testing"
@@ -67,3 +69,45 @@ func TestSelectClipboardCommand(t *testing.T) {
 		t.Fatal("expected error when no clipboard command is available")
 	}
 }
+
+func TestOSC52Sequence(t *testing.T) {
+	if got := osc52Sequence("hi", false); got != "\x1b]52;c;aGk=\a" {
+		t.Fatalf("unexpected OSC 52 sequence: %q", got)
+	}
+	if got := osc52Sequence("hi", true); got != "\x1bPtmux;\x1b\x1b]52;c;aGk=\a\x1b\\" {
+		t.Fatalf("unexpected tmux passthrough sequence: %q", got)
+	}
+}
+
+func TestIsSSHSession(t *testing.T) {
+	env := map[string]string{}
+	getenv := func(k string) string { return env[k] }
+	if isSSHSession(getenv) {
+		t.Fatal("expected no SSH session without SSH variables")
+	}
+	env["SSH_CONNECTION"] = "10.0.0.2 52100 10.0.0.1 22"
+	if !isSSHSession(getenv) {
+		t.Fatal("expected SSH session when SSH_CONNECTION is set")
+	}
+}
+
+func TestTerminalOutputWritesThroughAndKeepsFd(t *testing.T) {
+	file, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := &terminalOutput{file: file}
+	if out.Fd() != file.Fd() {
+		t.Fatal("expected the file descriptor of the wrapped terminal")
+	}
+	if _, err := io.WriteString(out, "\a"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := out.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	got, err := os.ReadFile(file.Name())
+	if err != nil || string(got) != "\a" {
+		t.Fatalf("expected bell written through, got %q (%v)", got, err)
+	}
+}
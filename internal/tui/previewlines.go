@@ -0,0 +1,165 @@
+package tui
+
+import (
+	"html"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+	"github.com/glabrego/reeder-cli/internal/render/article"
+	tuistate "github.com/glabrego/reeder-cli/internal/tui/state"
+	tuitree "github.com/glabrego/reeder-cli/internal/tui/tree"
+)
+
+const (
+	maxPreviewLines = 3
+
+	previewSourceSummary = "summary"
+	previewSourceContent = "content"
+)
+
+// previewSnippetKey identifies a wrapped snippet. Text lengths stand in for
+// the entry's text so a refresh that rewrites it is not served stale lines.
+type previewSnippetKey struct {
+	id         int64
+	source     string
+	width      int
+	summaryLen int
+	contentLen int
+}
+
+// previewSnippetCache is shared by pointer across Model copies, like
+// treeRowsCache, so rendering content once serves every later frame.
+type previewSnippetCache struct {
+	lines map[previewSnippetKey][]string
+}
+
+// cyclePreviewLines steps the snippet under each list entry through off, 1,
+// 2 and 3 lines.
+func (m Model) cyclePreviewLines() (tea.Model, tea.Cmd) {
+	m.previewLines = (m.previewLines + 1) % (maxPreviewLines + 1)
+	m.err = nil
+	if m.previewLines == 0 {
+		m.status = "Preview lines: off"
+	} else {
+		m.status = "Preview lines: " + strconv.Itoa(m.previewLines)
+	}
+	return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
+}
+
+// togglePreviewSource switches preview snippets between the feed's summary
+// and the start of the rendered article.
+func (m Model) togglePreviewSource() (tea.Model, tea.Cmd) {
+	if m.previewSource == previewSourceContent {
+		m.previewSource = previewSourceSummary
+	} else {
+		m.previewSource = previewSourceContent
+	}
+	m.err = nil
+	m.status = "Preview source: " + m.previewSource
+	if m.previewLines == 0 {
+		m.status += " (preview lines are off, L)"
+	}
+	return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
+}
+
+// entryPreviewLines returns the styled snippet lines shown under the entry at
+// idx, at most m.previewLines of them.
+func (m Model) entryPreviewLines(idx int) []string {
+	if m.previewLines <= 0 || idx < 0 || idx >= len(m.entries) {
+		return nil
+	}
+	lines := m.previewSnippet(m.entries[idx])
+	if len(lines) > m.previewLines {
+		lines = lines[:m.previewLines]
+	}
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		out[i] = "    " + uiTheme.MetaValue.Render(line)
+	}
+	return out
+}
+
+// previewSnippet wraps the entry's summary or content, flattened to a single
+// paragraph, to the list width.
+func (m Model) previewSnippet(entry feedbin.Entry) []string {
+	width := m.contentWidth() - 4
+	if width < 10 {
+		width = 10
+	}
+	key := previewSnippetKey{
+		id:         entry.ID,
+		source:     m.previewSource,
+		width:      width,
+		summaryLen: len(entry.Summary),
+		contentLen: len(entry.Content),
+	}
+	if m.previewCache != nil {
+		if lines, ok := m.previewCache.lines[key]; ok {
+			return lines
+		}
+	}
+
+	var text string
+	if m.previewSource == previewSourceContent {
+		opts := m.articleOptions
+		opts.Hyperlinks = false
+		opts.MaxLines = maxPreviewLines * 4
+		text = stripANSI(article.TextFromEntryWithOptions(entry, opts))
+	} else {
+		text = html.UnescapeString(entry.Summary)
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	var lines []string
+	if text != "" {
+		lines = wrapText(text, width)
+		if len(lines) > maxPreviewLines {
+			lines = lines[:maxPreviewLines]
+		}
+	}
+
+	if m.previewCache != nil {
+		if m.previewCache.lines == nil {
+			m.previewCache.lines = make(map[previewSnippetKey][]string)
+		}
+		m.previewCache.lines[key] = lines
+	}
+	return lines
+}
+
+// listRowHeight is how many screen lines tree row i takes: one, plus the
+// stacked feed line and the preview snippet under articles.
+func (m Model) listRowHeight(rows []treeRow, i int) int {
+	if !m.variableRowHeights() || i < 0 || i >= len(rows) || rows[i].Kind != tuitree.RowArticle {
+		return 1
+	}
+	height := 1 + len(m.entryPreviewLines(rows[i].EntryIndex))
+	if m.stackedList() {
+		height++
+	}
+	return height
+}
+
+// variableRowHeights reports whether article rows may take more than one
+// line, so the list window is laid out by height.
+func (m Model) variableRowHeights() bool {
+	return m.previewLines > 0 || m.stackedList()
+}
+
+// maxArticleRowHeight is the most lines one article row can take.
+func (m Model) maxArticleRowHeight() int {
+	height := 1 + m.previewLines
+	if m.stackedList() {
+		height++
+	}
+	return height
+}
+
+// listWindowWithPreviews is listWindow for rows of varying height.
+func (m Model) listWindowWithPreviews(rows []treeRow) (int, int) {
+	return tuistate.HeightWindow(len(rows), m.treeCursor, m.listBodyHeight(), func(i int) int {
+		return m.listRowHeight(rows, i)
+	})
+}
@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// QuietHours is a daily window, possibly crossing midnight, during which
+// auto-refreshes and new-entry bells are held back. The zero value is never
+// quiet.
+type QuietHours struct {
+	start, end int // minutes since midnight
+	set        bool
+}
+
+// ParseQuietHours reads a range such as "22:00-07:00". An empty range turns
+// quiet hours off.
+func ParseQuietHours(raw string) (QuietHours, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return QuietHours{}, nil
+	}
+	from, to, ok := strings.Cut(raw, "-")
+	if !ok {
+		return QuietHours{}, fmt.Errorf("quiet hours %q: expected HH:MM-HH:MM", raw)
+	}
+	start, err := parseClock(from)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("quiet hours %q: %w", raw, err)
+	}
+	end, err := parseClock(to)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("quiet hours %q: %w", raw, err)
+	}
+	if start == end {
+		return QuietHours{}, fmt.Errorf("quiet hours %q: start and end are the same", raw)
+	}
+	return QuietHours{start: start, end: end, set: true}, nil
+}
+
+func parseClock(raw string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(raw))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a HH:MM time", strings.TrimSpace(raw))
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Contains reports whether the wall-clock time of t falls in the window.
+// The start is inclusive and the end exclusive.
+func (q QuietHours) Contains(t time.Time) bool {
+	if !q.set {
+		return false
+	}
+	minute := t.Hour()*60 + t.Minute()
+	if q.start < q.end {
+		return minute >= q.start && minute < q.end
+	}
+	return minute >= q.start || minute < q.end
+}
+
+// SetQuietHours skips scheduled refreshes and new-entry bells during q, in
+// the display time zone.
+func (m *Model) SetQuietHours(q QuietHours) {
+	m.quietHours = q
+}
+
+func (m Model) inQuietHours() bool {
+	now := m.nowFn()
+	if m.location != nil {
+		now = now.In(m.location)
+	}
+	return m.quietHours.Contains(now)
+}
@@ -0,0 +1,49 @@
+package tui
+
+// SetReadAdvanceBackward makes the list cursor prefer the previous unread
+// article, rather than the next one, after an article marked read drops out
+// of the unread view.
+func (m *Model) SetReadAdvanceBackward(backward bool) {
+	m.readAdvanceBackward = backward
+}
+
+// readAdvanceAnchor picks the article the list cursor lands on once entryID,
+// just marked read, is filtered out: the next unread article in tree order,
+// else the previous one (the other way round under SetReadAdvanceBackward).
+// It returns 0 when the entry stays listed or no unread article is visible.
+func (m Model) readAdvanceAnchor(entryID int64) int64 {
+	if m.inDetail || (m.filter != "unread" && !m.hidingRead()) {
+		return 0
+	}
+	rows := m.treeRows()
+	at := -1
+	for i, row := range rows {
+		if row.Kind == treeRowArticle && m.entries[row.EntryIndex].ID == entryID {
+			at = i
+			break
+		}
+	}
+	// Hidden read entries keep the starred ones listed.
+	if at < 0 || (m.filter != "unread" && m.entries[rows[at].EntryIndex].IsStarred) {
+		return 0
+	}
+	find := func(step int) int64 {
+		for i := at + step; i >= 0 && i < len(rows); i += step {
+			if rows[i].Kind != treeRowArticle {
+				continue
+			}
+			if entry := m.entries[rows[i].EntryIndex]; entry.ID != entryID && entry.IsUnread {
+				return entry.ID
+			}
+		}
+		return 0
+	}
+	first, second := 1, -1
+	if m.readAdvanceBackward {
+		first, second = -1, 1
+	}
+	if id := find(first); id != 0 {
+		return id
+	}
+	return find(second)
+}
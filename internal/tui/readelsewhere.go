@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+	tuiactions "github.com/glabrego/reeder-cli/internal/tui/actions"
+)
+
+// readElsewhereCount counts listed unread entries that a refresh brought back
+// read without a toggle of ours in flight: entries read in the Feedbin web UI
+// or another client since the last sync.
+func (m Model) readElsewhereCount(refreshed []feedbin.Entry) int {
+	unread := make(map[int64]bool, len(m.entries))
+	for _, entry := range m.entries {
+		if entry.IsUnread {
+			unread[entry.ID] = true
+		}
+	}
+	count := 0
+	for _, entry := range refreshed {
+		if entry.IsUnread || !unread[entry.ID] {
+			continue
+		}
+		if _, pending := m.pendingToggles[pendingToggleKey{entryID: entry.ID, field: tuiactions.BatchFieldUnread}]; pending {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+// reloadsAfterRefresh reports whether the list must be re-read from the cache
+// after a refresh. A refresh returns only the newest entries; unread and
+// starred views reload so entries read or unstarred elsewhere drop out and
+// older ones still matching stay listed.
+func (m Model) reloadsAfterRefresh() bool {
+	if m.service == nil || m.searchQuery != "" {
+		return false
+	}
+	return m.filter == "unread" || m.filter == "starred"
+}
+
+func readElsewhereStatus(count int) string {
+	if count == 1 {
+		return "1 entry read elsewhere"
+	}
+	return fmt.Sprintf("%d entries read elsewhere", count)
+}
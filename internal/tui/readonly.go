@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// readOnlyListKeys change the cache from the list or detail view: read/star
+// toggles and their undo, snooze, dismiss, local mark-read, folder
+// assignment and the search backend switch, which rebuilds the FTS index.
+var readOnlyListKeys = map[string]bool{
+	"U": true, "S": true, "ctrl+z": true, "z": true, "x": true, "ctrl+u": true, "m": true, "b": true,
+}
+
+// readOnlyFeedsKeys change subscriptions from the feed manager.
+var readOnlyFeedsKeys = map[string]bool{
+	"e": true, "m": true, "x": true, "r": true,
+}
+
+// SetReadOnly browses the cache without changing it: the UI works offline,
+// mutation keys only say "Read-only mode" and opening an entry does not mark
+// it read. Preferences, read progress, filter positions and pins still change
+// for the session but are not saved, so call it after their stores are set.
+func (m *Model) SetReadOnly(readOnly bool) {
+	m.readOnly = readOnly
+	if !readOnly {
+		return
+	}
+	m.offline = true
+	m.savePreferencesFn = nil
+	m.progressStore = nil
+	m.filterAnchorStore = nil
+	m.pinStore = nil
+}
+
+// refusesReadOnlyKey reports whether key would change the cache in the
+// current view while read-only. Text inputs keep every key.
+func (m Model) refusesReadOnlyKey(key string) bool {
+	if !m.readOnly || m.showHelp || m.searchInputMode {
+		return false
+	}
+	if m.feedsOpen {
+		return readOnlyFeedsKeys[key]
+	}
+	return readOnlyListKeys[key]
+}
+
+func (m Model) readOnlyNotice() (tea.Model, tea.Cmd) {
+	m.status = "Read-only mode"
+	m.err = nil
+	m.statusID++
+	return m, clearStatusCmd(m.statusID, 3*time.Second)
+}
@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"context"
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	tuiview "github.com/glabrego/reeder-cli/internal/tui/view"
+)
+
+// ReadProgressStore persists how far each entry was scrolled.
+type ReadProgressStore interface {
+	SaveReadProgress(ctx context.Context, entryID int64, fraction float64) error
+}
+
+type readProgressSaveErrorMsg struct {
+	err error
+}
+
+// SetReadProgress wires the store used to persist scroll positions and seeds
+// the progress loaded at startup.
+func (m *Model) SetReadProgress(store ReadProgressStore, progress map[int64]float64) {
+	m.progressStore = store
+	m.readProgress = make(map[int64]float64, len(progress))
+	for id, fraction := range progress {
+		m.readProgress[id] = fraction
+	}
+}
+
+// detailMaxTop is the furthest the open article can scroll.
+func (m Model) detailMaxTop() int {
+	if len(m.entries) == 0 {
+		return 0
+	}
+	return tuiview.DetailMaxTop(len(m.detailLines(m.entries[m.cursor])), m.detailBodyHeight())
+}
+
+// recordReadProgress remembers the scroll position of the open article
+// before leaving it. Articles left at the top or read to the end have no
+// progress to resume.
+func (m *Model) recordReadProgress() tea.Cmd {
+	if m.readProgress == nil || len(m.entries) == 0 {
+		return nil
+	}
+	entryID := m.entries[m.cursor].ID
+	fraction := 0.0
+	if maxTop := m.detailMaxTop(); maxTop > 0 && m.detailTop > 0 && m.detailTop < maxTop {
+		fraction = float64(m.detailTop) / float64(maxTop)
+	}
+	previous, had := m.readProgress[entryID]
+	if fraction == 0 {
+		if !had {
+			return nil
+		}
+		delete(m.readProgress, entryID)
+	} else {
+		if had && previous == fraction {
+			return nil
+		}
+		m.readProgress[entryID] = fraction
+	}
+	return saveReadProgressCmd(m.progressStore, entryID, fraction)
+}
+
+// quitSavingProgress quits, first saving the position in an open article the
+// way closing it does, since quitting mid-article is how long reads stop.
+func (m Model) quitSavingProgress() (tea.Model, tea.Cmd) {
+	if !m.inDetail {
+		return m, tea.Quit
+	}
+	return m, tea.Sequence(m.recordReadProgress(), tea.Quit)
+}
+
+// resumeReadProgress scrolls a freshly opened article back to where it was
+// left.
+func (m *Model) resumeReadProgress() {
+	m.detailTop = 0
+	if len(m.entries) == 0 {
+		return
+	}
+	fraction, ok := m.readProgress[m.entries[m.cursor].ID]
+	if !ok {
+		return
+	}
+	m.detailTop = int(math.Round(fraction * float64(m.detailMaxTop())))
+}
+
+func saveReadProgressCmd(store ReadProgressStore, entryID int64, fraction float64) tea.Cmd {
+	if store == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := store.SaveReadProgress(ctx, entryID, fraction); err != nil {
+			return readProgressSaveErrorMsg{err: err}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SetReadToday seeds the footer's read-today count with the stored count for
+// now's local day.
+func (m *Model) SetReadToday(count int, now time.Time) {
+	m.readToday = max(count, 0)
+	m.readTodayDay = now.Local().Format("2006-01-02")
+}
+
+// countReads adds n entries marked read, starting over when the local day
+// changed since the last read.
+func (m *Model) countReads(n int) {
+	if n <= 0 {
+		return
+	}
+	m.readToday = m.readTodayAt(m.nowFn()) + n
+	m.readTodayDay = m.nowFn().Local().Format("2006-01-02")
+}
+
+// readTodayAt is the read count for now's local day: zero once midnight has
+// passed since the last counted read.
+func (m Model) readTodayAt(now time.Time) int {
+	if m.readTodayDay != now.Local().Format("2006-01-02") {
+		return 0
+	}
+	return m.readToday
+}
+
+func (m Model) readTodayLabel() string {
+	return "Read today: " + strconv.Itoa(m.readTodayAt(m.nowFn()))
+}
+
+// toggleShowReadToday shows or hides the read-today count in the footer.
+func (m Model) toggleShowReadToday() (tea.Model, tea.Cmd) {
+	m.showReadToday = !m.showReadToday
+	m.err = nil
+	if m.showReadToday {
+		m.status = "Read-today counter: shown"
+	} else {
+		m.status = "Read-today counter: hidden"
+	}
+	return m, persistPreferencesCmd(m.savePreferencesFn, m.preferences())
+}
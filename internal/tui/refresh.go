@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	tuiactions "github.com/glabrego/reeder-cli/internal/tui/actions"
+)
+
+// SetQueueRepeatRefresh picks what pressing r does while a refresh is still
+// running: ignore it (the default), or queue one more refresh to run after
+// the current one.
+func (m *Model) SetQueueRepeatRefresh(enabled bool) {
+	m.queueRepeatRefresh = enabled
+}
+
+// SetKeepScrollPosition keeps the selected entry on the same screen row
+// across a refresh instead of recentering the list around it.
+func (m *Model) SetKeepScrollPosition(enabled bool) {
+	m.keepScrollPosition = enabled
+}
+
+// listRowOffset is how many rows above the cursor the list window starts.
+func (m Model) listRowOffset() int {
+	start, _, _ := m.listWindow(m.treeRows())
+	return m.treeCursor - start
+}
+
+// pinListWindow starts the list window offset rows above the cursor. The
+// pin holds while the cursor stays inside that window; any other change to
+// the entry list drops it.
+func (m *Model) pinListWindow(offset int) {
+	if !m.keepScrollPosition {
+		return
+	}
+	m.listStartPinned = true
+	m.pinnedListStart = m.treeCursor - offset
+}
+
+// dropStaleListPin drops the pin once the cursor has left the pinned window,
+// so coming back to it later recenters instead of restoring an old start.
+func (m *Model) dropStaleListPin() {
+	if !m.listStartPinned {
+		return
+	}
+	if _, _, held := m.pinnedListWindow(len(m.treeRows())); !held {
+		m.listStartPinned = false
+	}
+}
+
+// startRefresh runs a manual refresh unless one is already in flight.
+func (m Model) startRefresh() (tea.Model, tea.Cmd) {
+	if m.refreshing {
+		if m.queueRepeatRefresh {
+			m.refreshQueued = true
+			m.status = "Refresh already in progress, another one queued"
+		} else {
+			m.status = "Refresh already in progress"
+		}
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 3*time.Second)
+	}
+	m.refreshing = true
+	m.loading = true
+	m.status = ""
+	m.err = nil
+	m.page = 1
+	return m, tuiactions.RefreshCmd(m.service, m.perPage, "manual")
+}
+
+// requestBudgetReached switches the UI to the cache once the client refused
+// a refresh for the session's request budget; the service works offline from
+// then on, so queued and retried refreshes are dropped.
+func (m Model) requestBudgetReached(msg tuiactions.RefreshErrorMsg) (tea.Model, tea.Cmd) {
+	m.refreshQueued = false
+	m.finishRefresh(msg.Source)
+	if msg.Source == "init" {
+		m.initialRefreshDuration = msg.Duration
+		m.initialRefreshDone = true
+		m.initialRefreshFailed = true
+	}
+	m.offline = true
+	m.err = nil
+	m.status = "Request budget reached — cache only"
+	if msg.Source == "init" {
+		return m.autoOpenFirstUnreadEntry()
+	}
+	return m, nil
+}
+
+// finishRefresh marks a startup, manual or auto refresh done and starts the
+// queued one, if any.
+func (m *Model) finishRefresh(source string) tea.Cmd {
+	if source != "manual" && source != "auto" && source != "init" {
+		return nil
+	}
+	m.refreshing = false
+	if !m.refreshQueued || m.service == nil {
+		return nil
+	}
+	m.refreshQueued = false
+	m.refreshing = true
+	m.loading = true
+	return tuiactions.RefreshCmd(m.service, m.perPage, "manual")
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+// scopeToCurrentFeed leaves the detail view for a list of every cached entry
+// from the open article's feed. The cursor lands on the next unread entry
+// once the scoped list loads.
+func (m Model) scopeToCurrentFeed() (tea.Model, tea.Cmd) {
+	if m.service == nil || len(m.entries) == 0 {
+		return m, nil
+	}
+	entry := m.entries[m.cursor]
+	if entry.FeedID == 0 {
+		m.status = "This article has no feed to show"
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 3*time.Second)
+	}
+	saveCmd := m.recordReadProgress()
+	m.inDetail = false
+	m.selectedID = 0
+	m.detailTop = 0
+	m.feedScopeTitle = feedNameForEntry(entry)
+	m.feedScopeAnchorID = entry.ID
+	next, loadCmd := m.switchFilter(feedbin.FeedScopeFilter(entry.FeedID))
+	return next, tea.Batch(saveCmd, loadCmd)
+}
+
+func (m Model) feedScoped() bool {
+	_, ok := feedbin.ParseFeedScope(m.filter)
+	return ok
+}
+
+// filterLabel names the active filter for the footer.
+func (m Model) filterLabel() string {
+	if m.feedScoped() {
+		return "feed: " + m.feedScopeTitle
+	}
+	label := m.filter
+	if m.hidingRead() {
+		label = "all (unread+starred)"
+	}
+	if m.ageCutoffActive() {
+		label += ", " + m.maxAgeLabel()
+	}
+	return label
+}
+
+// moveToNextUnread puts the cursor on the first unread article after anchorID
+// in tree order, wrapping to the top. It stays on the anchor when nothing
+// else is unread.
+func (m *Model) moveToNextUnread(anchorID int64) {
+	rows := m.treeRows()
+	start := 0
+	for i, row := range rows {
+		if row.Kind == treeRowArticle && m.entries[row.EntryIndex].ID == anchorID {
+			start = i + 1
+			break
+		}
+	}
+	for k := range rows {
+		i := (start + k) % len(rows)
+		row := rows[i]
+		if row.Kind != treeRowArticle {
+			continue
+		}
+		entry := m.entries[row.EntryIndex]
+		if entry.IsUnread && entry.ID != anchorID {
+			m.treeCursor = i
+			m.cursor = row.EntryIndex
+			m.ensureCursorVisible()
+			return
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/glabrego/reeder-cli/internal/feedbin"
+	tuiview "github.com/glabrego/reeder-cli/internal/tui/view"
 )
 
 var updateScreenGolden = flag.Bool("update-tui-screen-golden", false, "update TUI screen golden files")
@@ -48,7 +49,7 @@ func TestScreenGolden_ListAndDetail(t *testing.T) {
 
 	m := NewModel(nil, entries)
 	m.nowFn = func() time.Time { return now }
-	m.relativeTime = false
+	m.timeFormat = tuiview.TimeFormatAbsolute
 	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 26})
 	model := updated.(Model)
 	listView := ansiScreenStrip.ReplaceAllString(model.View(), "")
@@ -91,7 +92,7 @@ func TestScreenGolden_NerdModeListAndDetail(t *testing.T) {
 	m := NewModel(nil, entries)
 	m.SetNerdMode(true)
 	m.nowFn = func() time.Time { return now }
-	m.relativeTime = false
+	m.timeFormat = tuiview.TimeFormatAbsolute
 	m.SetStartupCacheStats(123*time.Millisecond, len(entries))
 	updated, _ := m.Update(tea.WindowSizeMsg{Width: 110, Height: 28})
 	model := updated.(Model)
@@ -0,0 +1,181 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+// incrementalSearchDelay debounces live filtering while typing a query.
+const incrementalSearchDelay = 150 * time.Millisecond
+
+type incrementalSearchMsg struct {
+	seq int
+}
+
+// bracketedPasteMarkers are the escape sequences around a paste, which some
+// terminals pass through as runes when the program does not decode them.
+var bracketedPasteMarkers = strings.NewReplacer("\x1b[200~", "", "\x1b[201~", "")
+
+// searchInputText cleans runes typed or pasted into the search input so the
+// query stays on one line: control characters become spaces, and a paste
+// has its whitespace runs collapsed and its ends trimmed.
+func searchInputText(runes []rune, paste bool) string {
+	text := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, bracketedPasteMarkers.Replace(string(runes)))
+	if paste {
+		return strings.Join(strings.Fields(text), " ")
+	}
+	return text
+}
+
+// SetEscClearsSearch lets esc in the list clear a committed search, as
+// ctrl+l does. Without a search esc still does nothing.
+func (m *Model) SetEscClearsSearch(enabled bool) {
+	m.escClearsSearch = enabled
+}
+
+// SetStickySearch keeps a committed search focused: until esc, letter and
+// digit keys other than navigation and q reopen the search input and
+// continue the query instead of running their list actions, such as
+// switching filters.
+func (m *Model) SetStickySearch(enabled bool) {
+	m.stickySearch = enabled
+}
+
+// stickySearchNavigation are the letter keys that still move around the
+// list, or quit, while the search keeps focus.
+var stickySearchNavigation = map[string]bool{"j": true, "k": true, "h": true, "l": true, "g": true, "G": true, "q": true}
+
+// handleStickySearchKey sends typing after a committed search back into the
+// search input while the search has focus.
+func (m Model) handleStickySearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	if !m.searchFocused || m.searchInputMode || m.inDetail || m.showHelp || m.feedsOpen {
+		return m, nil, false
+	}
+	if msg.String() == "esc" {
+		m.searchFocused = false
+		m.err = nil
+		m.status = "Search focus released"
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 3*time.Second), true
+	}
+	if msg.String() == "/" {
+		// "/" reopens the input on the query as it is.
+		m.searchFocused = false
+		next, cmd := m.startSearchInput()
+		return next, cmd, true
+	}
+	if msg.Type != tea.KeyRunes || msg.Alt || stickySearchNavigation[msg.String()] || !msg.Paste && !lettersOrDigits(msg.Runes) {
+		return m, nil, false
+	}
+	m.searchFocused = false
+	next, _ := m.startSearchInput()
+	m = next.(Model)
+	m.searchInput += searchInputText(msg.Runes, msg.Paste)
+	next, cmd := m.scheduleIncrementalSearch()
+	return next, cmd, true
+}
+
+func lettersOrDigits(runes []rune) bool {
+	for _, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return len(runes) > 0
+}
+
+func (m Model) startSearchInput() (tea.Model, tea.Cmd) {
+	m.searchInputMode = true
+	m.searchInput = m.searchQuery
+	m.err = nil
+	if !m.incrementalSearch {
+		m.status = "Search mode: type query and press enter"
+		return m, nil
+	}
+	m.searchBase = append([]feedbin.Entry(nil), m.entries...)
+	m.searchBaseAnchor = m.anchorEntryID()
+	m.status = "Search mode: results update as you type, enter searches the full cache"
+	return m, nil
+}
+
+// scheduleIncrementalSearch queues a live re-filter for the current input.
+// Each keystroke bumps searchSeq so only the last tick within the debounce
+// window applies.
+func (m Model) scheduleIncrementalSearch() (tea.Model, tea.Cmd) {
+	if !m.incrementalSearch || m.searchBase == nil {
+		return m, nil
+	}
+	m.searchSeq++
+	seq := m.searchSeq
+	return m, tea.Tick(incrementalSearchDelay, func(time.Time) tea.Msg {
+		return incrementalSearchMsg{seq: seq}
+	})
+}
+
+// applyIncrementalSearch filters the pre-search entries locally; it never
+// calls the service.
+func (m Model) applyIncrementalSearch(msg incrementalSearchMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.searchSeq || !m.searchInputMode || m.searchBase == nil {
+		return m, nil
+	}
+	anchorID := m.anchorEntryID()
+	query := strings.ToLower(strings.TrimSpace(m.searchInput))
+	filtered := make([]feedbin.Entry, 0, len(m.searchBase))
+	for _, entry := range m.searchBase {
+		if entryMatchesSearch(entry, query) {
+			filtered = append(filtered, entry)
+		}
+	}
+	m.entries = filtered
+	m.sortEntries()
+	m.expandSearchMatches()
+	m.searchMatchCount = len(m.entries)
+	m.restoreSelection(anchorID)
+	return m, nil
+}
+
+// endIncrementalSearch drops the snapshot and invalidates pending ticks. When
+// restore is set the list goes back to how it looked before "/".
+func (m *Model) endIncrementalSearch(restore bool) {
+	if m.searchBase == nil {
+		return
+	}
+	m.searchSeq++
+	if restore {
+		m.entries = m.searchBase
+		if m.searchQuery == "" {
+			m.searchMatchCount = 0
+			m.restoreSearchCollapse()
+		} else {
+			m.searchMatchCount = len(m.entries)
+		}
+		m.restoreSelection(m.searchBaseAnchor)
+	}
+	m.searchBase = nil
+	m.searchBaseAnchor = 0
+}
+
+// emptyListMessage explains an empty list: a search without matches reads
+// differently from a view with nothing cached.
+func (m Model) emptyListMessage() string {
+	if m.searchInputMode {
+		if input := strings.TrimSpace(m.searchInput); input != "" {
+			return fmt.Sprintf("No matches for '%s' in %s so far.", input, m.filterLabel())
+		}
+	}
+	if m.searchQuery == "" {
+		return "No entries available."
+	}
+	return fmt.Sprintf("No matches for '%s' in %s — press ctrl+l to clear.", m.searchQuery, m.filterLabel())
+}
@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"maps"
+
+	tuitree "github.com/glabrego/reeder-cli/internal/tui/tree"
+)
+
+// collapseState is a copy of the collapsed folders, feeds and sections,
+// kept in Model.searchCollapse while a search has nodes expanded.
+type collapseState struct {
+	folders  map[string]bool
+	feeds    map[string]bool
+	sections map[string]bool
+}
+
+// SetSearchExpand makes searches expand the collapsed sections, folders and
+// feeds holding matches; clearing the search collapses them again.
+func (m *Model) SetSearchExpand(enabled bool) {
+	m.searchExpand = enabled
+}
+
+// expandSearchMatches recomputes the collapse maps from the matching
+// entries: every node holding one is expanded. The state from before the
+// first search is kept for restoreSearchCollapse, so refining a search does
+// not lose it.
+func (m *Model) expandSearchMatches() {
+	if !m.searchExpand || len(m.entries) == 0 {
+		return
+	}
+	if m.searchCollapse == nil {
+		m.searchCollapse = &collapseState{
+			folders:  maps.Clone(m.collapsedFolders),
+			feeds:    maps.Clone(m.collapsedFeeds),
+			sections: maps.Clone(m.collapsedSections),
+		}
+	}
+	for _, entry := range m.entries {
+		feed := feedNameForEntry(entry)
+		for _, folder := range m.entryFolders(entry) {
+			section := "Feeds"
+			switch {
+			case m.entryPinned(entry):
+				section = tuitree.PinnedSection
+			case folder != "":
+				section = "Folders"
+				delete(m.collapsedFolders, folder)
+			}
+			delete(m.collapsedSections, section)
+			delete(m.collapsedFeeds, treeFeedKey(folder, feed))
+		}
+	}
+	m.treeVersion++
+}
+
+// restoreSearchCollapse puts back the collapse state from before
+// expandSearchMatches.
+func (m *Model) restoreSearchCollapse() {
+	if m.searchCollapse == nil {
+		return
+	}
+	m.collapsedFolders = m.searchCollapse.folders
+	m.collapsedFeeds = m.searchCollapse.feeds
+	m.collapsedSections = m.searchCollapse.sections
+	m.searchCollapse = nil
+	m.treeVersion++
+}
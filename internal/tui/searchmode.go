@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	tuiactions "github.com/glabrego/reeder-cli/internal/tui/actions"
+)
+
+// SetSearchModeSwitcher lets b switch the cache search backend and shows
+// the active one in the footer while searching.
+func (m *Model) SetSearchModeSwitcher(switcher SearchModeSwitcher) {
+	m.searchSwitcher = switcher
+	if switcher != nil {
+		m.searchMode = switcher.SearchMode()
+	}
+}
+
+func (m Model) searchModeLabel() string {
+	return "Search: " + strings.ToUpper(m.searchMode)
+}
+
+// toggleSearchMode asks for the other search backend; the switch runs in
+// the background because enabling FTS rebuilds its index. It waits for a
+// running refresh, which is still saving entries to the index.
+func (m Model) toggleSearchMode() (tea.Model, tea.Cmd) {
+	m.err = nil
+	if m.searchSwitcher == nil {
+		m.status = "Search mode switch unavailable"
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 3*time.Second)
+	}
+	if m.refreshing {
+		m.status = "Refresh in progress — switch search once it finishes"
+		m.statusID++
+		return m, clearStatusCmd(m.statusID, 3*time.Second)
+	}
+	next := "fts"
+	if m.searchMode == "fts" {
+		next = "like"
+	}
+	m.loading = true
+	m.status = "Switching search to " + strings.ToUpper(next) + "..."
+	return m, tuiactions.SetSearchModeCmd(m.searchSwitcher, next)
+}
+
+// finishSearchModeSwitch records the active backend and reruns an active
+// search so its results come from it.
+func (m Model) finishSearchModeSwitch(msg tuiactions.SearchModeResultMsg) (tea.Model, tea.Cmd) {
+	m.loading = false
+	if msg.Mode != "" {
+		m.searchMode = msg.Mode
+	}
+	if msg.Err != nil {
+		m.status = ""
+		m.err = msg.Err
+		return m, nil
+	}
+	m.status = m.searchModeLabel()
+	m.statusID++
+	cmd := clearStatusCmd(m.statusID, 3*time.Second)
+	if m.searchQuery == "" || m.service == nil {
+		return m, cmd
+	}
+	m.loading = true
+	return m, tea.Batch(cmd, tuiactions.LoadSearchCmd(m.service, m.filter, m.searchQuery, m.currentLimit()))
+}
@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	tuiactions "github.com/glabrego/reeder-cli/internal/tui/actions"
+)
+
+// snoozeMorningHour is when "tomorrow" and "next week" snoozes wake up.
+const snoozeMorningHour = 8
+
+// snoozePreset is one choice offered after pressing z.
+type snoozePreset struct {
+	key    string
+	label  string
+	wakeAt func(now time.Time) time.Time
+}
+
+var snoozePresets = []snoozePreset{
+	{key: "1", label: "1h", wakeAt: func(now time.Time) time.Time { return now.Add(time.Hour) }},
+	{key: "2", label: "tomorrow", wakeAt: func(now time.Time) time.Time { return morningAfter(now, 1) }},
+	{key: "3", label: "next week", wakeAt: func(now time.Time) time.Time {
+		days := (8 - int(now.Weekday())) % 7
+		if days == 0 {
+			days = 7
+		}
+		return morningAfter(now, days)
+	}},
+}
+
+// morningAfter returns snoozeMorningHour local time, days after now's date.
+func morningAfter(now time.Time, days int) time.Time {
+	y, mo, d := now.Date()
+	return time.Date(y, mo, d+days, snoozeMorningHour, 0, 0, 0, now.Location())
+}
+
+// startSnooze asks which preset to snooze the current article for.
+func (m Model) startSnooze() (tea.Model, tea.Cmd) {
+	if len(m.entries) == 0 || (!m.inDetail && !m.currentTreeRowIsArticle()) {
+		return m, nil
+	}
+	if m.snoozer == nil {
+		m.status = "Snooze unavailable"
+		return m, nil
+	}
+	m.pendingSnoozeID = m.entries[m.cursor].ID
+	m.err = nil
+	m.status = "Snooze: 1 = 1h, 2 = tomorrow, 3 = next week (any other key cancels)"
+	return m, nil
+}
+
+// handlePendingSnoozeKey consumes the preset choice after z.
+func (m Model) handlePendingSnoozeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	entryID := m.pendingSnoozeID
+	m.pendingSnoozeID = 0
+	for _, preset := range snoozePresets {
+		if msg.String() != preset.key {
+			continue
+		}
+		wakeAt := preset.wakeAt(m.nowFn())
+		m.beginToggle(entryID, tuiactions.BatchFieldUnread, false)
+		m.loading = true
+		m.status = ""
+		m.err = nil
+		return m, tuiactions.SnoozeCmd(m.snoozer, entryID, wakeAt, preset.label)
+	}
+	m.status = "Canceled snooze"
+	m.statusID++
+	return m, clearStatusCmd(m.statusID, 3*time.Second)
+}
+
+// removeSnoozedEntry drops a snoozed entry from the all and unread views; it
+// stays listed under starred, now marked read.
+func (m *Model) removeSnoozedEntry(entryID int64) {
+	m.setEntryUnread(entryID, false)
+	if m.filter == "starred" {
+		return
+	}
+	kept := m.entries[:0]
+	for _, entry := range m.entries {
+		if entry.ID != entryID {
+			kept = append(kept, entry)
+		}
+	}
+	m.entries = kept
+}
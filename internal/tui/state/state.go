@@ -52,6 +52,50 @@ func CenteredWindow(totalRows, cursor, height int) (int, int) {
 	return start, start + height
 }
 
+// HeightWindow is CenteredWindow for rows taking rowHeight(i) lines each. It
+// returns the rows [start, end) that fit in height lines around cursor: up
+// to half the spare lines above it, the rest below, and any lines left over
+// at the bottom of the list are given back to rows above. The cursor row is
+// always included, even when it alone is taller than height.
+func HeightWindow(totalRows, cursor, height int, rowHeight func(int) int) (int, int) {
+	if totalRows <= 0 {
+		return 0, 0
+	}
+	if height <= 0 {
+		return 0, totalRows
+	}
+	cursor = ClampCursor(cursor, totalRows)
+	start, end := cursor, cursor+1
+	used := rowHeight(cursor)
+	above := (height - used) / 2
+	for start > 0 {
+		h := rowHeight(start - 1)
+		if h > above || used+h > height {
+			break
+		}
+		above -= h
+		used += h
+		start--
+	}
+	for end < totalRows {
+		h := rowHeight(end)
+		if used+h > height {
+			break
+		}
+		used += h
+		end++
+	}
+	for start > 0 {
+		h := rowHeight(start - 1)
+		if used+h > height {
+			break
+		}
+		used += h
+		start--
+	}
+	return start, end
+}
+
 func ArticleRowsBefore(rows []tuitree.Row, end int) int {
 	if end <= 0 || len(rows) == 0 {
 		return 0
@@ -54,6 +54,44 @@ func TestCenteredWindowAndVisibleCounts(t *testing.T) {
 	}
 }
 
+func TestHeightWindow_CountsMultiLineRows(t *testing.T) {
+	heights := []int{1, 3, 3, 3, 1, 3, 3, 3, 3, 3}
+	rowHeight := func(i int) int { return heights[i] }
+	lines := func(start, end int) int {
+		n := 0
+		for i := start; i < end; i++ {
+			n += heights[i]
+		}
+		return n
+	}
+
+	for cursor := range heights {
+		start, end := HeightWindow(len(heights), cursor, 10, rowHeight)
+		if cursor < start || cursor >= end {
+			t.Fatalf("cursor %d outside window [%d,%d)", cursor, start, end)
+		}
+		if used := lines(start, end); used > 10 {
+			t.Fatalf("cursor %d: window [%d,%d) uses %d lines, want <= 10", cursor, start, end, used)
+		}
+	}
+
+	if start, end := HeightWindow(len(heights), 0, 10, rowHeight); start != 0 || end != 4 {
+		t.Fatalf("expected top window [0,4), got [%d,%d)", start, end)
+	}
+	if start, end := HeightWindow(len(heights), 9, 10, rowHeight); start != 7 || end != 10 {
+		t.Fatalf("expected bottom window [7,10), got [%d,%d)", start, end)
+	}
+	if start, end := HeightWindow(len(heights), 5, 10, rowHeight); start != 4 || end != 8 {
+		t.Fatalf("expected centered window [4,8), got [%d,%d)", start, end)
+	}
+	if start, end := HeightWindow(3, 1, 2, func(int) int { return 4 }); start != 1 || end != 2 {
+		t.Fatalf("expected a too-tall cursor row alone, got [%d,%d)", start, end)
+	}
+	if start, end := HeightWindow(3, 1, 20, rowHeight); start != 0 || end != 3 {
+		t.Fatalf("expected every row when all fit, got [%d,%d)", start, end)
+	}
+}
+
 func TestSelectionHelpers(t *testing.T) {
 	entries := []feedbin.Entry{
 		{ID: 10, PublishedAt: time.Now().UTC()},
@@ -24,21 +24,45 @@ type Theme struct {
 	TitleBoth    lipgloss.Style
 }
 
-func Default() Theme {
-	cpRosewater := lipgloss.Color("#f5e0dc")
-	cpMauve := lipgloss.Color("#cba6f7")
-	cpRed := lipgloss.Color("#f38ba8")
-	cpPeach := lipgloss.Color("#fab387")
-	cpYellow := lipgloss.Color("#f9e2af")
-	cpGreen := lipgloss.Color("#a6e3a1")
-	cpTeal := lipgloss.Color("#94e2d5")
-	cpLavender := lipgloss.Color("#b4befe")
-	cpText := lipgloss.Color("#cdd6f4")
-	cpSubtext0 := lipgloss.Color("#a6adc8")
-	cpSubtext1 := lipgloss.Color("#bac2de")
-	cpOverlay1 := lipgloss.Color("#7f849c")
-	cpSurface0 := lipgloss.Color("#313244")
+// ReadStyle is how read entries are shown: dimmed (the default), like
+// unread text, or hidden from the all view.
+type ReadStyle string
+
+const (
+	ReadDim    ReadStyle = "dim"
+	ReadNormal ReadStyle = "normal"
+	ReadHidden ReadStyle = "hidden"
+)
+
+// UnreadStyle is how unread titles stand out: bold (the default), in an
+// accent color, both, or not at all.
+type UnreadStyle string
 
+const (
+	UnreadBold  UnreadStyle = "bold"
+	UnreadColor UnreadStyle = "color"
+	UnreadBoth  UnreadStyle = "both"
+	UnreadPlain UnreadStyle = "plain"
+)
+
+var (
+	cpRosewater = lipgloss.Color("#f5e0dc")
+	cpMauve     = lipgloss.Color("#cba6f7")
+	cpRed       = lipgloss.Color("#f38ba8")
+	cpPeach     = lipgloss.Color("#fab387")
+	cpYellow    = lipgloss.Color("#f9e2af")
+	cpGreen     = lipgloss.Color("#a6e3a1")
+	cpTeal      = lipgloss.Color("#94e2d5")
+	cpSky       = lipgloss.Color("#89dceb")
+	cpLavender  = lipgloss.Color("#b4befe")
+	cpText      = lipgloss.Color("#cdd6f4")
+	cpSubtext0  = lipgloss.Color("#a6adc8")
+	cpSubtext1  = lipgloss.Color("#bac2de")
+	cpOverlay1  = lipgloss.Color("#7f849c")
+	cpSurface0  = lipgloss.Color("#313244")
+)
+
+func Default() Theme {
 	return Theme{
 		Title:       lipgloss.NewStyle().Bold(true).Foreground(cpMauve),
 		ModePill:    lipgloss.NewStyle().Foreground(cpLavender).Background(cpSurface0).Padding(0, 1),
@@ -59,6 +83,26 @@ func Default() Theme {
 	}
 }
 
+// WithTitleStyles restyles read and unread titles. Hidden read entries are
+// styled like dimmed ones wherever they still show (other filters, search).
+// Empty values keep the defaults.
+func (t Theme) WithTitleStyles(read ReadStyle, unread UnreadStyle) Theme {
+	if read == ReadNormal {
+		t.TitleRead = lipgloss.NewStyle().Foreground(cpText)
+	}
+	switch unread {
+	case UnreadColor:
+		t.TitleUnread = lipgloss.NewStyle().Foreground(cpSky)
+		t.TitleBoth = t.TitleBoth.Bold(false)
+	case UnreadBoth:
+		t.TitleUnread = lipgloss.NewStyle().Bold(true).Foreground(cpSky)
+	case UnreadPlain:
+		t.TitleUnread = lipgloss.NewStyle().Foreground(cpText)
+		t.TitleBoth = t.TitleBoth.Bold(false)
+	}
+	return t
+}
+
 func (t Theme) StyleArticleTitle(entry feedbin.Entry, title string) string {
 	if title == "" {
 		return title
@@ -34,3 +34,21 @@ func TestStyleArticleTitle_ByState(t *testing.T) {
 		t.Fatalf("expected styled unread+starred title, got %q", unreadStarred)
 	}
 }
+
+func TestWithTitleStyles_PlainUnreadDropsBold(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.ANSI)
+	isBold := func(s string) bool {
+		return strings.Contains(s, "\x1b[1;") || strings.Contains(s, "\x1b[1m")
+	}
+
+	if !isBold(Default().StyleArticleTitle(feedbin.Entry{IsUnread: true}, "Unread")) {
+		t.Fatal("expected bold unread titles by default")
+	}
+	th := Default().WithTitleStyles(ReadNormal, UnreadPlain)
+	if got := th.StyleArticleTitle(feedbin.Entry{IsUnread: true}, "Unread"); isBold(got) {
+		t.Fatalf("expected plain unread title, got %q", got)
+	}
+	if got := th.StyleArticleTitle(feedbin.Entry{}, "Read"); got != th.TitleUnread.Render("Read") {
+		t.Fatalf("expected read titles drawn like plain unread ones, got %q", got)
+	}
+}
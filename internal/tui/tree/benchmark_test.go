@@ -38,6 +38,29 @@ func BenchmarkBuildRows_Compact(b *testing.B) {
 	}
 }
 
+// The LoadMore benchmarks model paging a 5k-entry cache: the full path sorts
+// the whole refreshed list, the merge path folds it into the sorted slice.
+func BenchmarkLoadMore_FullSort(b *testing.B) {
+	incoming := benchmarkEntries(5000)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		entries := append([]feedbin.Entry(nil), incoming...)
+		SortEntries(entries)
+	}
+}
+
+func BenchmarkLoadMore_Merge(b *testing.B) {
+	incoming := benchmarkEntries(5000)
+	existing := append([]feedbin.Entry(nil), incoming[:4900]...)
+	SortEntries(existing)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = MergeEntries(existing, incoming, "")
+	}
+}
+
 func benchmarkEntries(n int) []feedbin.Entry {
 	out := make([]feedbin.Entry, 0, n)
 	base := time.Date(2026, 2, 11, 12, 0, 0, 0, time.UTC)
@@ -1,6 +1,8 @@
 package tree
 
 import (
+	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -14,14 +16,29 @@ const (
 	RowFolder  RowKind = "folder"
 	RowFeed    RowKind = "feed"
 	RowArticle RowKind = "article"
+	// RowMore stands in for the feeds a FeedLimit left out of a folder, or
+	// of the Feeds section when Folder is empty.
+	RowMore RowKind = "more"
 )
 
+// FeedOrderUnread lists feeds with the most unread entries first when a
+// FeedLimit is set; any other order is alphabetical.
+const FeedOrderUnread = "unread"
+
+// PinnedSection labels the section listing pinned feeds above the others.
+const PinnedSection = "Pinned"
+
 type Row struct {
 	Kind       RowKind
 	Label      string
 	Folder     string
 	Feed       string
 	EntryIndex int
+	// Hidden is how many feeds a RowMore row stands in for.
+	Hidden int
+	// Pinned marks the rows of the Pinned section. Its feed and article rows
+	// keep the feed's own folder, but no folder header sits above them.
+	Pinned bool
 }
 
 type BuildOptions struct {
@@ -29,6 +46,38 @@ type BuildOptions struct {
 	CollapsedFolders  map[string]bool
 	CollapsedFeeds    map[string]bool
 	CollapsedSections map[string]bool
+	// DefaultFolder, when set, groups untagged feeds under this folder
+	// instead of the top-level Feeds section.
+	DefaultFolder string
+	// OmitRedundantHeaders drops headers that have nothing to tell apart: the
+	// section header when only one section exists, the folder header when it
+	// is that section's only folder and the feed header when it is the only
+	// feed. Omitted headers cannot be collapsed, so their collapse state is
+	// ignored.
+	OmitRedundantHeaders bool
+	// TagsAsFolders lists a feed with several tags under each of its folders,
+	// like Feedbin's web UI, instead of only under the first. Its articles
+	// then appear once per folder.
+	TagsAsFolders bool
+	// UnreadFirst lists unread entries before read ones in compact mode,
+	// each group newest first.
+	UnreadFirst bool
+	// FeedLimit shows at most this many feeds per folder and in the Feeds
+	// section, followed by a RowMore row; zero shows every feed.
+	FeedLimit int
+	// FeedOrder picks which feeds a FeedLimit keeps: FeedOrderUnread or
+	// alphabetical.
+	FeedOrder string
+	// ExpandedFeedLists lists folders, and "" for the Feeds section, whose
+	// feeds are shown in full despite FeedLimit.
+	ExpandedFeedLists map[string]bool
+	// PinnedFeeds lists feed IDs shown in a Pinned section at the top of the
+	// tree instead of under their folder or the Feeds section.
+	PinnedFeeds map[int64]bool
+}
+
+func (o BuildOptions) pinned(entry feedbin.Entry) bool {
+	return o.PinnedFeeds[entry.FeedID]
 }
 
 type feedGroup struct {
@@ -36,6 +85,11 @@ type feedGroup struct {
 	EntryIndices []int
 }
 
+type pinnedFeed struct {
+	Folder string
+	feedGroup
+}
+
 type collection struct {
 	Kind  string // "folder" or "top_feed"
 	Key   string
@@ -44,33 +98,99 @@ type collection struct {
 }
 
 func SortEntries(entries []feedbin.Entry) {
+	SortEntriesWithDefaultFolder(entries, "")
+}
+
+func SortEntriesWithDefaultFolder(entries []feedbin.Entry, defaultFolder string) {
 	sort.SliceStable(entries, func(i, j int) bool {
-		ai := entries[i]
-		aj := entries[j]
-		fi, fkindi := topCollectionLabelForEntry(ai)
-		fj, fkindj := topCollectionLabelForEntry(aj)
-		if fkindi != fkindj {
-			return fkindi < fkindj
-		}
-		if fi != fj {
-			return fi < fj
+		return entryLess(entries[i], entries[j], defaultFolder)
+	})
+}
+
+// MergeEntries folds incoming into existing without re-sorting the whole
+// slice: entries already present are replaced in place by ID and only the new
+// ones are sorted before a linear merge. When existing is not in tree order
+// (for example because an update moved an entry to another feed) it falls
+// back to a full sort. existing is not modified.
+func MergeEntries(existing, incoming []feedbin.Entry, defaultFolder string) []feedbin.Entry {
+	merged := append([]feedbin.Entry(nil), existing...)
+	index := make(map[int64]int, len(merged)+len(incoming))
+	for i, entry := range merged {
+		index[entry.ID] = i
+	}
+	var fresh []feedbin.Entry
+	for _, entry := range incoming {
+		i, ok := index[entry.ID]
+		if !ok {
+			index[entry.ID] = -1
+			fresh = append(fresh, entry)
+			continue
 		}
-		ti := strings.ToLower(FeedName(ai))
-		tj := strings.ToLower(FeedName(aj))
-		if ti != tj {
-			return ti < tj
+		if i >= 0 {
+			merged[i] = entry
 		}
-		if !ai.PublishedAt.Equal(aj.PublishedAt) {
-			return ai.PublishedAt.After(aj.PublishedAt)
+	}
+
+	less := func(a, b feedbin.Entry) bool { return entryLess(a, b, defaultFolder) }
+	if !sort.SliceIsSorted(merged, func(i, j int) bool { return less(merged[i], merged[j]) }) {
+		merged = append(merged, fresh...)
+		SortEntriesWithDefaultFolder(merged, defaultFolder)
+		return merged
+	}
+	if len(fresh) == 0 {
+		return merged
+	}
+	SortEntriesWithDefaultFolder(fresh, defaultFolder)
+	out := make([]feedbin.Entry, 0, len(merged)+len(fresh))
+	i, j := 0, 0
+	for i < len(merged) && j < len(fresh) {
+		if less(fresh[j], merged[i]) {
+			out = append(out, fresh[j])
+			j++
+			continue
 		}
-		return false
-	})
+		out = append(out, merged[i])
+		i++
+	}
+	out = append(out, merged[i:]...)
+	return append(out, fresh[j:]...)
+}
+
+// entryLess orders entries by collection kind and label, then feed name, then
+// newest first.
+func entryLess(ai, aj feedbin.Entry, defaultFolder string) bool {
+	fi, fkindi := topCollectionLabelForEntry(ai, defaultFolder)
+	fj, fkindj := topCollectionLabelForEntry(aj, defaultFolder)
+	if fkindi != fkindj {
+		return fkindi < fkindj
+	}
+	if fi != fj {
+		return fi < fj
+	}
+	ti := strings.ToLower(FeedName(ai))
+	tj := strings.ToLower(FeedName(aj))
+	if ti != tj {
+		return ti < tj
+	}
+	if !ai.PublishedAt.Equal(aj.PublishedAt) {
+		return ai.PublishedAt.After(aj.PublishedAt)
+	}
+	return false
 }
 
 func FeedKey(folder, feed string) string {
 	return folder + "\x00" + feed
 }
 
+// FeedCountKey keys a feed row's unread count. Pinned rows get their own key
+// so a pinned feed and a same-titled feed left in its folder count apart.
+func FeedCountKey(folder, feed string, pinned bool) string {
+	if pinned {
+		return PinnedSection + "\x00" + FeedKey(folder, feed)
+	}
+	return FeedKey(folder, feed)
+}
+
 func SplitFeedKey(key string) (string, string) {
 	parts := strings.SplitN(key, "\x00", 2)
 	if len(parts) != 2 {
@@ -83,12 +203,52 @@ func FolderName(entry feedbin.Entry) string {
 	return strings.TrimSpace(entry.FeedFolder)
 }
 
+// EntryFolders returns the folders the entry is listed under: all of its
+// feed's tags when tagsAsFolders is set, otherwise the single folder from
+// FolderNameOrDefault. The empty string stands for the top-level Feeds
+// section.
+func EntryFolders(entry feedbin.Entry, defaultFolder string, tagsAsFolders bool) []string {
+	if tagsAsFolders {
+		folders := make([]string, 0, len(entry.FeedFolders))
+		for _, folder := range entry.FeedFolders {
+			if folder = strings.TrimSpace(folder); folder != "" && !slices.Contains(folders, folder) {
+				folders = append(folders, folder)
+			}
+		}
+		if len(folders) > 1 {
+			return folders
+		}
+	}
+	return []string{FolderNameOrDefault(entry, defaultFolder)}
+}
+
+// FolderNameOrDefault returns the entry folder, falling back to defaultFolder
+// for feeds without a tagging. Entries of unknown feeds never take the default
+// folder so they stay in their own group.
+func FolderNameOrDefault(entry feedbin.Entry, defaultFolder string) string {
+	if folder := FolderName(entry); folder != "" {
+		return folder
+	}
+	if IsUnknownFeed(entry) {
+		return ""
+	}
+	return strings.TrimSpace(defaultFolder)
+}
+
+// UnknownFeedLabel names the group for entries whose feed is missing from
+// the cache (e.g. hydrated before subscriptions synced).
+const UnknownFeedLabel = "Unknown feed"
+
+// IsUnknownFeed reports whether the entry's feed metadata is missing.
+func IsUnknownFeed(entry feedbin.Entry) bool {
+	return strings.TrimSpace(entry.FeedTitle) == ""
+}
+
 func FeedName(entry feedbin.Entry) string {
-	name := strings.TrimSpace(entry.FeedTitle)
-	if name == "" {
-		return "unknown feed"
+	if IsUnknownFeed(entry) {
+		return UnknownFeedLabel
 	}
-	return name
+	return strings.TrimSpace(entry.FeedTitle)
 }
 
 func BuildRows(entries []feedbin.Entry, opts BuildOptions) []Row {
@@ -100,6 +260,9 @@ func BuildRows(entries []feedbin.Entry, opts BuildOptions) []Row {
 		sort.SliceStable(indices, func(i, j int) bool {
 			ei := entries[indices[i]]
 			ej := entries[indices[j]]
+			if opts.UnreadFirst && ei.IsUnread != ej.IsUnread {
+				return ei.IsUnread
+			}
 			if !ei.PublishedAt.Equal(ej.PublishedAt) {
 				return ei.PublishedAt.After(ej.PublishedAt)
 			}
@@ -116,7 +279,7 @@ func BuildRows(entries []feedbin.Entry, opts BuildOptions) []Row {
 			entry := entries[idx]
 			rows = append(rows, Row{
 				Kind:       RowArticle,
-				Folder:     FolderName(entry),
+				Folder:     FolderNameOrDefault(entry, opts.DefaultFolder),
 				Feed:       FeedName(entry),
 				EntryIndex: idx,
 			})
@@ -124,7 +287,8 @@ func BuildRows(entries []feedbin.Entry, opts BuildOptions) []Row {
 		return rows
 	}
 
-	tree := buildCollections(entries)
+	pinnedFeeds := buildPinnedFeeds(entries, opts)
+	tree := buildCollections(entries, opts.DefaultFolder, opts.TagsAsFolders, opts.pinned)
 	folderCollections := make([]collection, 0, len(tree))
 	topFeedCollections := make([]collection, 0, len(tree))
 	for _, c := range tree {
@@ -135,32 +299,72 @@ func BuildRows(entries []feedbin.Entry, opts BuildOptions) []Row {
 		topFeedCollections = append(topFeedCollections, c)
 	}
 
-	rows := make([]Row, 0, len(entries)+len(tree)*2+2)
-	if len(folderCollections) > 0 {
+	if opts.FeedLimit > 0 && opts.FeedOrder == FeedOrderUnread {
+		for _, c := range folderCollections {
+			unread := make(map[string]int, len(c.Feeds))
+			for _, fg := range c.Feeds {
+				unread[fg.Name] = unreadCount(fg, entries)
+			}
+			sort.SliceStable(c.Feeds, func(i, j int) bool {
+				return unread[c.Feeds[i].Name] > unread[c.Feeds[j].Name]
+			})
+		}
+		unread := make(map[string]int, len(topFeedCollections))
+		for _, c := range topFeedCollections {
+			if len(c.Feeds) > 0 {
+				unread[c.Kind+"\x00"+c.Label] = unreadCount(c.Feeds[0], entries)
+			}
+		}
+		sort.SliceStable(topFeedCollections, func(i, j int) bool {
+			ci, cj := topFeedCollections[i], topFeedCollections[j]
+			return unread[ci.Kind+"\x00"+ci.Label] > unread[cj.Kind+"\x00"+cj.Label]
+		})
+	}
+
+	omitSection, omitFolder, omitFeed := false, false, false
+	if opts.OmitRedundantHeaders && len(pinnedFeeds) == 0 && (len(folderCollections) == 0 || len(topFeedCollections) == 0) {
+		omitSection = true
+		omitFolder = len(folderCollections) == 1
+		omitFeed = len(tree) == 1 && len(tree[0].Feeds) == 1
+	}
+
+	rows := make([]Row, 0, len(entries)+len(tree)*2+len(pinnedFeeds)+3)
+	if len(pinnedFeeds) > 0 {
+		rows = append(rows, Row{Kind: RowSection, Label: PinnedSection, Pinned: true})
+		if !opts.CollapsedSections[PinnedSection] {
+			rows = appendPinnedRows(rows, pinnedFeeds, opts)
+		}
+	}
+	if len(folderCollections) > 0 && !omitSection {
 		rows = append(rows, Row{Kind: RowSection, Label: "Folders"})
 		if opts.CollapsedSections["Folders"] {
 			goto topFeeds
 		}
 	}
 	for _, c := range folderCollections {
-		rows = append(rows, Row{
-			Kind:   RowFolder,
-			Label:  c.Label,
-			Folder: c.Key,
-		})
-		if opts.CollapsedFolders[c.Key] {
-			continue
-		}
-		for _, fg := range c.Feeds {
+		if !omitFolder {
 			rows = append(rows, Row{
-				Kind:   RowFeed,
-				Label:  fg.Name,
+				Kind:   RowFolder,
+				Label:  c.Label,
 				Folder: c.Key,
-				Feed:   fg.Name,
 			})
-			if opts.CollapsedFeeds[FeedKey(c.Key, fg.Name)] {
+			if opts.CollapsedFolders[c.Key] {
 				continue
 			}
+		}
+		feeds, hidden := limitFeeds(c.Feeds, c.Key, opts)
+		for _, fg := range feeds {
+			if !omitFeed {
+				rows = append(rows, Row{
+					Kind:   RowFeed,
+					Label:  fg.Name,
+					Folder: c.Key,
+					Feed:   fg.Name,
+				})
+				if opts.CollapsedFeeds[FeedKey(c.Key, fg.Name)] {
+					continue
+				}
+			}
 			for _, idx := range fg.EntryIndices {
 				rows = append(rows, Row{
 					Kind:       RowArticle,
@@ -170,23 +374,29 @@ func BuildRows(entries []feedbin.Entry, opts BuildOptions) []Row {
 				})
 			}
 		}
+		if hidden > 0 {
+			rows = append(rows, moreRow(c.Key, hidden))
+		}
 	}
 
 topFeeds:
-	if len(topFeedCollections) > 0 {
+	if len(topFeedCollections) > 0 && !omitSection {
 		rows = append(rows, Row{Kind: RowSection, Label: "Feeds"})
 		if opts.CollapsedSections["Feeds"] {
 			return rows
 		}
 	}
-	for _, c := range topFeedCollections {
-		rows = append(rows, Row{
-			Kind:  RowFeed,
-			Label: c.Label,
-			Feed:  c.Label,
-		})
-		if opts.CollapsedFeeds[FeedKey("", c.Label)] {
-			continue
+	topFeeds, hiddenTopFeeds := limitFeeds(topFeedCollections, "", opts)
+	for _, c := range topFeeds {
+		if !omitFeed {
+			rows = append(rows, Row{
+				Kind:  RowFeed,
+				Label: c.Label,
+				Feed:  c.Label,
+			})
+			if opts.CollapsedFeeds[FeedKey("", c.Label)] {
+				continue
+			}
 		}
 		if len(c.Feeds) == 0 {
 			continue
@@ -199,9 +409,69 @@ topFeeds:
 			})
 		}
 	}
+	if hiddenTopFeeds > 0 {
+		rows = append(rows, moreRow("", hiddenTopFeeds))
+	}
+	return rows
+}
+
+func appendPinnedRows(rows []Row, feeds []pinnedFeed, opts BuildOptions) []Row {
+	for _, pf := range feeds {
+		rows = append(rows, Row{
+			Kind:   RowFeed,
+			Label:  pf.Name,
+			Folder: pf.Folder,
+			Feed:   pf.Name,
+			Pinned: true,
+		})
+		if opts.CollapsedFeeds[FeedKey(pf.Folder, pf.Name)] {
+			continue
+		}
+		for _, idx := range pf.EntryIndices {
+			rows = append(rows, Row{
+				Kind:       RowArticle,
+				Folder:     pf.Folder,
+				Feed:       pf.Name,
+				EntryIndex: idx,
+				Pinned:     true,
+			})
+		}
+	}
 	return rows
 }
 
+// limitFeeds trims a folder's feeds, or the Feeds section's collections, to
+// opts.FeedLimit unless the list was expanded, returning how many it dropped.
+func limitFeeds[T any](feeds []T, key string, opts BuildOptions) ([]T, int) {
+	if opts.FeedLimit <= 0 || len(feeds) <= opts.FeedLimit || opts.ExpandedFeedLists[key] {
+		return feeds, 0
+	}
+	return feeds[:opts.FeedLimit], len(feeds) - opts.FeedLimit
+}
+
+func moreRow(folder string, hidden int) Row {
+	noun := "feeds"
+	if hidden == 1 {
+		noun = "feed"
+	}
+	return Row{
+		Kind:   RowMore,
+		Label:  fmt.Sprintf("… %d more %s (press + to show)", hidden, noun),
+		Folder: folder,
+		Hidden: hidden,
+	}
+}
+
+func unreadCount(fg feedGroup, entries []feedbin.Entry) int {
+	count := 0
+	for _, idx := range fg.EntryIndices {
+		if entries[idx].IsUnread {
+			count++
+		}
+	}
+	return count
+}
+
 func FirstArticleRow(rows []Row) int {
 	for i, row := range rows {
 		if row.Kind == RowArticle {
@@ -211,20 +481,79 @@ func FirstArticleRow(rows []Row) int {
 	return 0
 }
 
-func topCollectionLabelForEntry(entry feedbin.Entry) (label string, kind string) {
-	if folder := FolderName(entry); folder != "" {
+func topCollectionLabelForEntry(entry feedbin.Entry, defaultFolder string) (label string, kind string) {
+	if folder := FolderNameOrDefault(entry, defaultFolder); folder != "" {
 		return folder, "folder"
 	}
+	if IsUnknownFeed(entry) {
+		// Sorts after "top_feed" so the unknown group trails the Feeds section
+		// instead of interleaving with real feed names.
+		return UnknownFeedLabel, "unknown_feed"
+	}
 	return FeedName(entry), "top_feed"
 }
 
-func buildCollections(entries []feedbin.Entry) []collection {
+// buildPinnedFeeds groups the entries of pinned feeds by feed, each listed
+// once under its first folder even when TagsAsFolders is set.
+func buildPinnedFeeds(entries []feedbin.Entry, opts BuildOptions) []pinnedFeed {
+	if len(opts.PinnedFeeds) == 0 {
+		return nil
+	}
+	var feeds []pinnedFeed
+	index := make(map[int64]int)
+	for idx, entry := range entries {
+		if !opts.pinned(entry) {
+			continue
+		}
+		fi, ok := index[entry.FeedID]
+		if !ok {
+			feeds = append(feeds, pinnedFeed{
+				Folder:    FolderNameOrDefault(entry, opts.DefaultFolder),
+				feedGroup: feedGroup{Name: FeedName(entry)},
+			})
+			fi = len(feeds) - 1
+			index[entry.FeedID] = fi
+		}
+		feeds[fi].EntryIndices = append(feeds[fi].EntryIndices, idx)
+	}
+	for i := range feeds {
+		sortEntryIndices(entries, feeds[i].EntryIndices)
+	}
+	sort.SliceStable(feeds, func(a, b int) bool {
+		return feedNameLess(feeds[a].Name, feeds[b].Name)
+	})
+	return feeds
+}
+
+// sortEntryIndices orders a feed's entries newest first, then by title.
+func sortEntryIndices(entries []feedbin.Entry, indices []int) {
+	sort.SliceStable(indices, func(a, b int) bool {
+		ea := entries[indices[a]]
+		eb := entries[indices[b]]
+		if !ea.PublishedAt.Equal(eb.PublishedAt) {
+			return ea.PublishedAt.After(eb.PublishedAt)
+		}
+		return strings.ToLower(strings.TrimSpace(ea.Title)) < strings.ToLower(strings.TrimSpace(eb.Title))
+	})
+}
+
+func feedNameLess(a, b string) bool {
+	na := strings.ToLower(strings.TrimSpace(a))
+	nb := strings.ToLower(strings.TrimSpace(b))
+	if na != nb {
+		return na < nb
+	}
+	return a < b
+}
+
+// buildCollections groups the entries into folders and top-level feeds,
+// leaving out those skip reports (pinned feeds).
+func buildCollections(entries []feedbin.Entry, defaultFolder string, tagsAsFolders bool, skip func(feedbin.Entry) bool) []collection {
 	collections := make([]collection, 0, 16)
 	collectionIndex := make(map[string]int)
 	feedIndexByCollection := make(map[string]map[string]int)
 
-	for idx, entry := range entries {
-		collectionLabel, collectionKind := topCollectionLabelForEntry(entry)
+	add := func(idx int, entry feedbin.Entry, collectionLabel, collectionKind string) {
 		collectionKey := collectionKind + "\x00" + collectionLabel
 		ci, ok := collectionIndex[collectionKey]
 		if !ok {
@@ -240,7 +569,7 @@ func buildCollections(entries []feedbin.Entry) []collection {
 		}
 
 		feedName := FeedName(entry)
-		if collectionKind == "top_feed" {
+		if collectionKind != "folder" {
 			feedName = collectionLabel
 		}
 		fi, ok := feedIndexByCollection[collectionKey][feedName]
@@ -252,29 +581,34 @@ func buildCollections(entries []feedbin.Entry) []collection {
 		collections[ci].Feeds[fi].EntryIndices = append(collections[ci].Feeds[fi].EntryIndices, idx)
 	}
 
+	for idx, entry := range entries {
+		if skip(entry) {
+			continue
+		}
+		if folders := EntryFolders(entry, defaultFolder, tagsAsFolders); len(folders) > 1 {
+			for _, folder := range folders {
+				add(idx, entry, folder, "folder")
+			}
+			continue
+		}
+		collectionLabel, collectionKind := topCollectionLabelForEntry(entry, defaultFolder)
+		add(idx, entry, collectionLabel, collectionKind)
+	}
+
 	for i := range collections {
 		for j := range collections[i].Feeds {
-			sort.SliceStable(collections[i].Feeds[j].EntryIndices, func(a, b int) bool {
-				ea := entries[collections[i].Feeds[j].EntryIndices[a]]
-				eb := entries[collections[i].Feeds[j].EntryIndices[b]]
-				if !ea.PublishedAt.Equal(eb.PublishedAt) {
-					return ea.PublishedAt.After(eb.PublishedAt)
-				}
-				return strings.ToLower(strings.TrimSpace(ea.Title)) < strings.ToLower(strings.TrimSpace(eb.Title))
-			})
+			sortEntryIndices(entries, collections[i].Feeds[j].EntryIndices)
 		}
 
 		sort.SliceStable(collections[i].Feeds, func(a, b int) bool {
-			na := strings.ToLower(strings.TrimSpace(collections[i].Feeds[a].Name))
-			nb := strings.ToLower(strings.TrimSpace(collections[i].Feeds[b].Name))
-			if na != nb {
-				return na < nb
-			}
-			return collections[i].Feeds[a].Name < collections[i].Feeds[b].Name
+			return feedNameLess(collections[i].Feeds[a].Name, collections[i].Feeds[b].Name)
 		})
 	}
 
 	sort.SliceStable(collections, func(i, j int) bool {
+		if collections[i].Kind != collections[j].Kind {
+			return collections[i].Kind < collections[j].Kind
+		}
 		li := strings.ToLower(strings.TrimSpace(collections[i].Label))
 		lj := strings.ToLower(strings.TrimSpace(collections[j].Label))
 		if li != lj {
@@ -1,7 +1,9 @@
 package tree
 
 import (
+	"fmt"
 	"reflect"
+	"slices"
 	"testing"
 	"time"
 
@@ -23,6 +25,50 @@ func TestSortEntries_GroupsByCollectionAndFeed(t *testing.T) {
 	}
 }
 
+func TestMergeEntries_MatchesFullSortAndDedupes(t *testing.T) {
+	incoming := benchmarkEntries(300)
+	existing := append([]feedbin.Entry(nil), incoming[:200]...)
+	SortEntries(existing)
+	before := append([]feedbin.Entry(nil), existing...)
+	incoming[5].Title = "Updated"
+	incoming = append(incoming, incoming[250])
+
+	got := MergeEntries(existing, incoming, "")
+	want := append([]feedbin.Entry(nil), incoming[:300]...)
+	SortEntries(want)
+	if !reflect.DeepEqual(entryIDs(got), entryIDs(want)) {
+		t.Fatalf("merge order differs from full sort:\n got %v\nwant %v", entryIDs(got), entryIDs(want))
+	}
+	for _, entry := range got {
+		if entry.ID == incoming[5].ID && entry.Title != "Updated" {
+			t.Fatalf("expected existing entry replaced in place, got %q", entry.Title)
+		}
+	}
+	if !reflect.DeepEqual(existing, before) {
+		t.Fatal("expected existing slice left untouched")
+	}
+}
+
+func TestMergeEntries_FallsBackWhenExistingUnsorted(t *testing.T) {
+	now := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	existing := []feedbin.Entry{
+		{ID: 1, FeedTitle: "B Feed", PublishedAt: now},
+		{ID: 2, FeedTitle: "A Feed", PublishedAt: now},
+	}
+	got := MergeEntries(existing, []feedbin.Entry{{ID: 3, FeedTitle: "C Feed", PublishedAt: now}}, "")
+	if !reflect.DeepEqual(entryIDs(got), []int64{2, 1, 3}) {
+		t.Fatalf("expected full sort fallback, got %v", entryIDs(got))
+	}
+}
+
+func entryIDs(entries []feedbin.Entry) []int64 {
+	ids := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+	return ids
+}
+
 func TestBuildRows_SectionsAndCollapsedState(t *testing.T) {
 	entries := []feedbin.Entry{
 		{ID: 1, Title: "Folder 1", FeedFolder: "Formula 1", FeedTitle: "Race", PublishedAt: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)},
@@ -49,6 +95,81 @@ func TestBuildRows_SectionsAndCollapsedState(t *testing.T) {
 	}
 }
 
+func TestBuildRows_DefaultFolderGroupsUntaggedFeeds(t *testing.T) {
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Tagged", FeedFolder: "Formula 1", FeedTitle: "Race", PublishedAt: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Title: "Untagged", FeedTitle: "Top Feed", PublishedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	twoSections := BuildRows(entries, BuildOptions{})
+	var sections []string
+	for _, row := range twoSections {
+		if row.Kind == RowSection {
+			sections = append(sections, row.Label)
+		}
+	}
+	if !reflect.DeepEqual(sections, []string{"Folders", "Feeds"}) {
+		t.Fatalf("expected Folders and Feeds sections by default, got %v", sections)
+	}
+
+	rows := BuildRows(entries, BuildOptions{DefaultFolder: "Uncategorized"})
+	var got []string
+	for _, row := range rows {
+		got = append(got, string(row.Kind)+":"+row.Folder+"/"+row.Feed)
+	}
+	want := []string{
+		"section:/",
+		"folder:Formula 1/",
+		"feed:Formula 1/Race",
+		"article:Formula 1/Race",
+		"folder:Uncategorized/",
+		"feed:Uncategorized/Top Feed",
+		"article:Uncategorized/Top Feed",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected folder-only tree:\n got=%v\nwant=%v", got, want)
+	}
+
+	SortEntriesWithDefaultFolder(entries, "Alpha")
+	if entries[0].ID != 2 {
+		t.Fatalf("expected default-folder entries sorted among folders, got first ID %d", entries[0].ID)
+	}
+}
+
+func TestBuildRows_UnknownFeedsGroupedLast(t *testing.T) {
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Orphan", FeedID: 30, PublishedAt: time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Title: "Known", FeedTitle: "Zeta Feed", PublishedAt: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)},
+		{ID: 3, Title: "Tagged", FeedFolder: "Tech", FeedTitle: "Race", PublishedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	rows := BuildRows(entries, BuildOptions{DefaultFolder: "Uncategorized"})
+	var got []string
+	for _, row := range rows {
+		got = append(got, string(row.Kind)+":"+row.Folder+"/"+row.Feed)
+	}
+	want := []string{
+		"section:/",
+		"folder:Tech/",
+		"feed:Tech/Race",
+		"article:Tech/Race",
+		"folder:Uncategorized/",
+		"feed:Uncategorized/Zeta Feed",
+		"article:Uncategorized/Zeta Feed",
+		"section:/",
+		"feed:/Unknown feed",
+		"article:/Unknown feed",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected tree with unknown feed:\n got=%v\nwant=%v", got, want)
+	}
+
+	SortEntries(entries)
+	if entries[len(entries)-1].ID != 1 {
+		t.Fatalf("expected unknown feed entries sorted last, got last ID %d", entries[len(entries)-1].ID)
+	}
+}
+
 func TestBuildRows_CompactModeSortedByDateThenTitle(t *testing.T) {
 	entries := []feedbin.Entry{
 		{ID: 1, Title: "Bravo", FeedTitle: "Feed", PublishedAt: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)},
@@ -71,6 +192,30 @@ func TestBuildRows_CompactModeSortedByDateThenTitle(t *testing.T) {
 	}
 }
 
+func TestBuildRows_CompactModeUnreadFirstKeepsDateOrderWithinGroups(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 2, d, 0, 0, 0, 0, time.UTC) }
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Read newest", FeedTitle: "Feed", PublishedAt: day(5)},
+		{ID: 2, Title: "Unread old", FeedTitle: "Feed", PublishedAt: day(1), IsUnread: true},
+		{ID: 3, Title: "Read old", FeedTitle: "Feed", PublishedAt: day(2)},
+		{ID: 4, Title: "Unread new", FeedTitle: "Feed", PublishedAt: day(4), IsUnread: true},
+		{ID: 5, Title: "Unread middle", FeedTitle: "Feed", PublishedAt: day(3), IsUnread: true},
+	}
+	rows := BuildRows(entries, BuildOptions{Compact: true, UnreadFirst: true})
+	got := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		got = append(got, entries[row.EntryIndex].ID)
+	}
+	if want := []int64{4, 5, 2, 1, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected unread-first order: got=%v want=%v", got, want)
+	}
+
+	rows = BuildRows(entries, BuildOptions{Compact: true})
+	if first := entries[rows[0].EntryIndex].ID; first != 1 {
+		t.Fatalf("expected plain date order by default, got entry %d first", first)
+	}
+}
+
 func TestFirstArticleRow(t *testing.T) {
 	rows := []Row{
 		{Kind: RowSection, Label: "Folders"},
@@ -81,3 +226,183 @@ func TestFirstArticleRow(t *testing.T) {
 		t.Fatalf("expected first article row at index 2, got %d", got)
 	}
 }
+
+func TestBuildRows_OmitRedundantHeaders(t *testing.T) {
+	now := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	kinds := func(rows []Row) []RowKind {
+		out := make([]RowKind, 0, len(rows))
+		for _, row := range rows {
+			out = append(out, row.Kind)
+		}
+		return out
+	}
+	opts := BuildOptions{
+		OmitRedundantHeaders: true,
+		CollapsedFolders:     map[string]bool{"News": true},
+		CollapsedFeeds:       map[string]bool{FeedKey("", "Solo"): true},
+		CollapsedSections:    map[string]bool{"Feeds": true},
+	}
+
+	solo := []feedbin.Entry{{ID: 1, FeedTitle: "Solo", PublishedAt: now}, {ID: 2, FeedTitle: "Solo", PublishedAt: now}}
+	if got := kinds(BuildRows(solo, opts)); !reflect.DeepEqual(got, []RowKind{RowArticle, RowArticle}) {
+		t.Fatalf("expected articles only for a single feed, got %v", got)
+	}
+
+	folder := []feedbin.Entry{
+		{ID: 1, FeedFolder: "News", FeedTitle: "A", PublishedAt: now},
+		{ID: 2, FeedFolder: "News", FeedTitle: "B", PublishedAt: now},
+	}
+	want := []RowKind{RowFeed, RowArticle, RowFeed, RowArticle}
+	if got := kinds(BuildRows(folder, opts)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected feed headers under a single folder, got %v", got)
+	}
+
+	mixed := append(folder, feedbin.Entry{ID: 3, FeedTitle: "Solo", PublishedAt: now})
+	want = []RowKind{RowSection, RowFolder, RowSection}
+	if got := kinds(BuildRows(mixed, opts)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected full headers with two sections, got %v", got)
+	}
+}
+
+func TestBuildRows_TagsAsFoldersListsFeedUnderEachTag(t *testing.T) {
+	entries := []feedbin.Entry{
+		{ID: 1, Title: "Tagged twice", FeedFolder: "News", FeedFolders: []string{"News", "Tech"}, FeedTitle: "Wire", PublishedAt: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Title: "Tagged once", FeedFolder: "Tech", FeedFolders: []string{"Tech"}, FeedTitle: "Gadgets", PublishedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	describe := func(rows []Row) []string {
+		var got []string
+		for _, row := range rows {
+			if row.Kind == RowArticle {
+				got = append(got, row.Folder+"/"+row.Feed+"#"+entries[row.EntryIndex].Title)
+				continue
+			}
+			got = append(got, string(row.Kind)+":"+row.Folder+"/"+row.Feed)
+		}
+		return got
+	}
+
+	want := []string{
+		"section:/",
+		"folder:News/",
+		"feed:News/Wire",
+		"News/Wire#Tagged twice",
+		"folder:Tech/",
+		"feed:Tech/Gadgets",
+		"Tech/Gadgets#Tagged once",
+		"feed:Tech/Wire",
+		"Tech/Wire#Tagged twice",
+	}
+	if got := describe(BuildRows(entries, BuildOptions{TagsAsFolders: true})); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected rows:\n got %v\nwant %v", got, want)
+	}
+
+	single := describe(BuildRows(entries, BuildOptions{}))
+	if slices.Contains(single, "feed:Tech/Wire") {
+		t.Fatalf("expected feed only under its first tag by default, got %v", single)
+	}
+}
+
+func TestBuildRows_FeedLimitAddsMoreRowsUntilExpanded(t *testing.T) {
+	now := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	entries := []feedbin.Entry{
+		{ID: 1, FeedFolder: "Tech", FeedTitle: "Alpha", PublishedAt: now},
+		{ID: 2, FeedFolder: "Tech", FeedTitle: "Beta", PublishedAt: now, IsUnread: true},
+		{ID: 3, FeedFolder: "Tech", FeedTitle: "Gamma", PublishedAt: now, IsUnread: true},
+		{ID: 4, FeedFolder: "Tech", FeedTitle: "Gamma", PublishedAt: now.Add(-time.Hour), IsUnread: true},
+		{ID: 5, FeedTitle: "One", PublishedAt: now},
+		{ID: 6, FeedTitle: "Two", PublishedAt: now},
+	}
+
+	rows := BuildRows(entries, BuildOptions{FeedLimit: 1})
+	var feeds, more []string
+	for _, row := range rows {
+		switch row.Kind {
+		case RowFeed:
+			feeds = append(feeds, row.Feed)
+		case RowMore:
+			more = append(more, row.Folder+": "+row.Label)
+		}
+	}
+	if !reflect.DeepEqual(feeds, []string{"Alpha", "One"}) {
+		t.Fatalf("expected the first feed alphabetically per list, got %v", feeds)
+	}
+	wantMore := []string{"Tech: … 2 more feeds (press + to show)", ": … 1 more feed (press + to show)"}
+	if !reflect.DeepEqual(more, wantMore) {
+		t.Fatalf("unexpected more rows: %v", more)
+	}
+
+	rows = BuildRows(entries, BuildOptions{FeedLimit: 2, FeedOrder: FeedOrderUnread})
+	feeds = feeds[:0]
+	for _, row := range rows {
+		if row.Kind == RowFeed && row.Folder == "Tech" {
+			feeds = append(feeds, row.Feed)
+		}
+	}
+	if !reflect.DeepEqual(feeds, []string{"Gamma", "Beta"}) {
+		t.Fatalf("expected the feeds with the most unread entries, got %v", feeds)
+	}
+
+	rows = BuildRows(entries, BuildOptions{FeedLimit: 1, ExpandedFeedLists: map[string]bool{"Tech": true}})
+	feeds, more = feeds[:0], more[:0]
+	for _, row := range rows {
+		switch row.Kind {
+		case RowFeed:
+			feeds = append(feeds, row.Feed)
+		case RowMore:
+			more = append(more, row.Folder)
+		}
+	}
+	if !reflect.DeepEqual(feeds, []string{"Alpha", "Beta", "Gamma", "One"}) || !reflect.DeepEqual(more, []string{""}) {
+		t.Fatalf("expected Tech expanded and the Feeds section still limited, got feeds %v more %v", feeds, more)
+	}
+}
+
+func TestBuildRows_PinnedSectionListsPinnedFeedsFirst(t *testing.T) {
+	now := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	entries := []feedbin.Entry{
+		{ID: 1, FeedID: 10, FeedFolder: "Tech", FeedTitle: "Alpha", PublishedAt: now},
+		{ID: 2, FeedID: 20, FeedFolder: "Tech", FeedTitle: "Beta", PublishedAt: now},
+		{ID: 3, FeedID: 30, FeedTitle: "Zulu", PublishedAt: now.Add(-time.Hour)},
+		{ID: 4, FeedID: 30, FeedTitle: "Zulu", PublishedAt: now},
+		{ID: 5, FeedID: 40, FeedTitle: "One", PublishedAt: now},
+	}
+	opts := BuildOptions{PinnedFeeds: map[int64]bool{20: true, 30: true}}
+
+	var got []string
+	for _, row := range BuildRows(entries, opts) {
+		switch row.Kind {
+		case RowSection:
+			got = append(got, "section "+row.Label)
+		case RowFolder:
+			got = append(got, "folder "+row.Folder)
+		case RowFeed:
+			got = append(got, "feed "+row.Folder+"/"+row.Feed)
+		case RowArticle:
+			got = append(got, fmt.Sprintf("article %d", entries[row.EntryIndex].ID))
+		}
+	}
+	want := []string{
+		"section Pinned",
+		"feed Tech/Beta", "article 2",
+		"feed /Zulu", "article 4", "article 3",
+		"section Folders",
+		"folder Tech",
+		"feed Tech/Alpha", "article 1",
+		"section Feeds",
+		"feed /One", "article 5",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected pinned tree:\n got %v\nwant %v", got, want)
+	}
+
+	opts.CollapsedSections = map[string]bool{PinnedSection: true}
+	rows := BuildRows(entries, opts)
+	if rows[0].Label != PinnedSection || !rows[0].Pinned || rows[1].Label != "Folders" {
+		t.Fatalf("expected the collapsed Pinned section to hide its feeds, got %+v", rows[:2])
+	}
+
+	rows = BuildRows(entries, BuildOptions{OmitRedundantHeaders: true, PinnedFeeds: map[int64]bool{10: true, 20: true}})
+	if rows[0].Kind != RowSection || rows[0].Label != PinnedSection {
+		t.Fatalf("expected section headers kept next to the Pinned section, got %+v", rows[0])
+	}
+}
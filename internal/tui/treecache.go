@@ -0,0 +1,57 @@
+package tui
+
+import "github.com/glabrego/reeder-cli/internal/feedbin"
+
+// treeRowsKey identifies the inputs a cached tree was built from. The entries
+// slice is tracked by identity (backing array and length); in-place reorders
+// and collapse changes bump Model.treeVersion instead.
+type treeRowsKey struct {
+	first         *feedbin.Entry
+	count         int
+	version       int
+	compact       bool
+	compactTree   bool
+	tagsAsFolders bool
+	unreadFirst   bool
+	defaultFolder string
+}
+
+// treeRowsCache is shared by pointer across Model copies so View and Update
+// reuse rows built earlier in the same frame or by a previous message.
+type treeRowsCache struct {
+	valid bool
+	key   treeRowsKey
+	rows  []treeRow
+}
+
+func (m Model) treeRowsKey() treeRowsKey {
+	key := treeRowsKey{
+		count:         len(m.entries),
+		version:       m.treeVersion,
+		compact:       m.compact,
+		compactTree:   m.compactTree,
+		tagsAsFolders: m.tagsAsFolders,
+		unreadFirst:   m.compactUnreadFirst,
+		defaultFolder: m.defaultFolder,
+	}
+	if len(m.entries) > 0 {
+		key.first = &m.entries[0]
+	}
+	return key
+}
+
+// setCollapsed records a collapse-state change and invalidates cached rows.
+func (m *Model) setCollapsed(set map[string]bool, key string, collapsed bool) {
+	set[key] = collapsed
+	m.treeVersion++
+}
+
+// sortEntries reorders m.entries in place for the tree. The slice identity
+// does not change, so the cached rows are invalidated explicitly.
+func (m *Model) sortEntries() {
+	sortEntriesForTree(m.entries, m.defaultFolder)
+	m.treeVersion++
+	// Every entry-set change ends in a sort, so new folders and feeds pick up
+	// their configured collapse state here.
+	m.applyTreeRules()
+}
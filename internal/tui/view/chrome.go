@@ -20,15 +20,15 @@ func Toolbar(nerdMode, inDetail bool) string {
 	return "j/k move | enter open | / search | a/u/* filter | n more | r refresh | ? help"
 }
 
-func CompactFooter(mode, filter string, page, shown int, searchQuery string, searchMatchCount int, th tuitheme.Theme) string {
+func CompactFooter(mode, filter string, page, shown int, searchQuery string, searchMatchCount int, compactCounts bool, th tuitheme.Theme) string {
 	parts := []string{
 		th.MetaLabel.Render("mode") + " " + th.MetaValue.Render(mode),
 		th.MetaLabel.Render("filter") + " " + th.MetaValue.Render(filter),
 		th.MetaLabel.Render("page") + " " + th.MetaValue.Render(fmt.Sprintf("%d", page)),
-		th.MetaValue.Render(fmt.Sprintf("%d shown", shown)),
+		th.MetaValue.Render(FormatCount(shown, compactCounts) + " shown"),
 	}
 	if searchQuery != "" {
-		parts = append(parts, th.MetaLabel.Render("search")+" "+th.MetaValue.Render(fmt.Sprintf("%q (%d)", searchQuery, searchMatchCount)))
+		parts = append(parts, th.MetaLabel.Render("search")+" "+th.MetaValue.Render(fmt.Sprintf("%q (%s)", searchQuery, FormatCount(searchMatchCount, compactCounts))))
 	}
 	return strings.Join(parts, " • ")
 }
@@ -31,7 +31,7 @@ func TestToolbar(t *testing.T) {
 
 func TestCompactFooter(t *testing.T) {
 	th := tuitheme.Default()
-	got := stripANSI(CompactFooter("list", "all", 1, 42, "go", 3, th))
+	got := stripANSI(CompactFooter("list", "all", 1, 42, "go", 3, false, th))
 	for _, want := range []string{"mode list", "filter all", "page 1", "42 shown", `search "go" (3)`} {
 		if !strings.Contains(got, want) {
 			t.Fatalf("expected %q in footer, got %q", want, got)
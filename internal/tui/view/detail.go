@@ -9,7 +9,10 @@ import (
 
 type WrapFunc func(string, int) []string
 
-func DetailMetaLines(entry feedbin.Entry, width int, wrap WrapFunc) []string {
+// DetailMetaLines renders the header of the detail view. With shortenURLs a
+// long entry URL is elided to one line instead of wrapping; a non-empty
+// dateAge, such as "2 hours ago", follows the date.
+func DetailMetaLines(entry feedbin.Entry, width int, loc *time.Location, shortenURLs bool, dateAge string, wrap WrapFunc) []string {
 	lines := make([]string, 0, 16)
 	lines = append(lines, wrap(entry.Title, width)...)
 	lines = append(lines, strings.Repeat("=", max(1, min(width, len(entry.Title)))))
@@ -18,7 +21,11 @@ func DetailMetaLines(entry feedbin.Entry, width int, wrap WrapFunc) []string {
 	if entry.FeedTitle != "" {
 		lines = append(lines, wrap("Feed: "+entry.FeedTitle, width)...)
 	}
-	lines = append(lines, "Date: "+entry.PublishedAt.UTC().Format(time.RFC3339))
+	date := "Date: " + inLocation(entry.PublishedAt, loc).Format(time.RFC3339)
+	if dateAge != "" {
+		date += " (" + dateAge + ")"
+	}
+	lines = append(lines, date)
 	if entry.IsUnread {
 		lines = append(lines, "Unread: yes")
 	} else {
@@ -34,7 +41,11 @@ func DetailMetaLines(entry feedbin.Entry, width int, wrap WrapFunc) []string {
 		lines = append(lines, wrap("Author: "+entry.Author, width)...)
 	}
 	if entry.URL != "" {
-		lines = append(lines, wrap("URL: "+entry.URL, width)...)
+		if shortenURLs {
+			lines = append(lines, "URL: "+shortenURL(entry.URL, width-len("URL: ")))
+		} else {
+			lines = append(lines, wrap("URL: "+entry.URL, width)...)
+		}
 	}
 
 	return lines
@@ -1,31 +1,62 @@
 package view
 
 import (
+	"strconv"
 	"strings"
+	"time"
 
 	article "github.com/glabrego/reeder-cli/internal/render/article"
 
 	"github.com/glabrego/reeder-cli/internal/feedbin"
 )
 
-const inlineImagePreviewAnchor = "__INLINE_IMAGE_PREVIEW_ANCHOR__"
+// ImagePlacement controls where the inline image preview is drawn.
+type ImagePlacement int
+
+const (
+	// ImagePlacementInline draws the preview below the first image label.
+	ImagePlacementInline ImagePlacement = iota
+	// ImagePlacementEnd draws the preview after the article body.
+	ImagePlacementEnd
+)
+
+// ParseImagePlacement maps "end" to ImagePlacementEnd and anything else to
+// ImagePlacementInline.
+func ParseImagePlacement(v string) ImagePlacement {
+	if strings.EqualFold(strings.TrimSpace(v), "end") {
+		return ImagePlacementEnd
+	}
+	return ImagePlacementInline
+}
 
 type InlineImagePreviewState struct {
-	Enabled bool
-	Loading bool
-	Raw     string
-	Err     string
+	Enabled    bool
+	Loading    bool
+	Raw        string
+	Err        string
+	Placement  ImagePlacement
+	HideLabels bool
+	// Anchor is the image, as numbered by article.PreviewImage, whose anchor
+	// the inline placement draws at.
+	Anchor int
 }
 
 func DetailLines(
 	entry feedbin.Entry,
 	contentWidth int,
 	horizontalMargin int,
+	loc *time.Location,
 	opts article.Options,
 	wrap WrapFunc,
 	preview InlineImagePreviewState,
 ) []string {
-	lines := detailBaseLines(entry, contentWidth, opts, wrap)
+	if preview.Enabled {
+		opts.ImageAnchors = true
+		if preview.HideLabels && strings.TrimSpace(preview.Raw) != "" {
+			opts.ImageMode = article.ImageModeNone
+		}
+	}
+	lines := detailBaseLines(entry, contentWidth, loc, opts, wrap)
 	lines = appendInlineImagePreview(lines, preview, contentWidth)
 	return leftPadLines(lines, horizontalMargin)
 }
@@ -55,9 +86,20 @@ func RenderDetailLines(lines []string, top, maxLines int) string {
 	return strings.Join(lines[top:end], "\n") + "\n"
 }
 
-func detailBaseLines(entry feedbin.Entry, width int, opts article.Options, wrap WrapFunc) []string {
-	lines := DetailMetaLines(entry, width, wrap)
+func detailBaseLines(entry feedbin.Entry, width int, loc *time.Location, opts article.Options, wrap WrapFunc) []string {
+	lines := DetailMetaLines(entry, width, loc, opts.ShortenURLs, opts.DateAge, wrap)
+	if opts.CommentCounts {
+		if count, ok := entry.CommentCount(); ok {
+			lines = append(lines, "Comments: 💬 "+strconv.Itoa(count))
+		}
+	}
+	if opts.SourceLabel != "" {
+		lines = append(lines, "Source: "+opts.SourceLabel)
+	}
 	contentLines := article.ContentLinesWithOptions(entry, width, opts)
+	if opts.RightToLeft && article.LinesRTL(contentLines) {
+		lines = append(lines, "Direction: right-to-left ⇐")
+	}
 	if len(contentLines) > 0 {
 		lines = append(lines, "")
 		lines = append(lines, contentLines...)
@@ -91,21 +133,29 @@ func appendInlineImagePreview(lines []string, preview InlineImagePreviewState, c
 
 	anchored := false
 	out := make([]string, 0, len(lines)+len(previewLines)+1)
-	for _, line := range lines {
-		if line != inlineImagePreviewAnchor {
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		image, ok := article.ImageAnchor(line)
+		if !ok {
 			out = append(out, line)
 			continue
 		}
-		anchored = true
-		if len(previewLines) > 0 {
+		if !anchored && image == preview.Anchor && preview.Placement == ImagePlacementInline && len(previewLines) > 0 {
+			anchored = true
 			out = append(out, previewLines...)
+			continue
+		}
+		// Dropping an anchor that stood alone as a block would leave a
+		// doubled blank line behind.
+		if i+1 < len(lines) && lines[i+1] == "" && (len(out) == 0 || out[len(out)-1] == "") {
+			i++
 		}
 	}
-	if anchored {
+	if anchored || len(previewLines) == 0 {
 		return out
 	}
-	if len(previewLines) == 0 {
-		return lines
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
 	}
 	out = append(out, "")
 	out = append(out, previewLines...)
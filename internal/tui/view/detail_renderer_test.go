@@ -30,6 +30,7 @@ func TestDetailLines_UsesMarginsAndPreview(t *testing.T) {
 		entry,
 		60,
 		4,
+		nil,
 		article.DefaultOptions,
 		func(s string, _ int) []string { return []string{s} },
 		InlineImagePreviewState{
@@ -45,3 +46,162 @@ func TestDetailLines_UsesMarginsAndPreview(t *testing.T) {
 		t.Fatalf("expected preview fallback error line, got %q", joined)
 	}
 }
+
+func imagePreviewTestLines(preview InlineImagePreviewState) []string {
+	entry := feedbin.Entry{
+		Title:       "Entry",
+		Content:     `<p>Before</p><img src="https://example.com/a.png" alt="Diagram"><p>After</p>`,
+		PublishedAt: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC),
+	}
+	lines := DetailLines(entry, 60, 0, nil, article.Options{}, func(s string, _ int) []string { return []string{s} }, preview)
+	plain := make([]string, len(lines))
+	for i, line := range lines {
+		plain[i] = stripANSI(line)
+	}
+	return plain
+}
+
+func lineIndex(lines []string, needle string) int {
+	for i, line := range lines {
+		if strings.Contains(line, needle) {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestDetailLines_InlinePlacementAnchorsPreviewAtImage(t *testing.T) {
+	lines := imagePreviewTestLines(InlineImagePreviewState{Enabled: true, Raw: "PREVIEW"})
+	label, preview, after := lineIndex(lines, article.ImageLabelText), lineIndex(lines, "PREVIEW"), lineIndex(lines, "After")
+	if label < 0 || preview != label+1 || after < preview {
+		t.Fatalf("expected preview right below the image label, got %q", lines)
+	}
+	if lineIndex(lines, article.ImagePreviewAnchor) >= 0 {
+		t.Fatalf("expected anchor to be consumed, got %q", lines)
+	}
+}
+
+func TestDetailLines_InlinePlacementUsesChosenAnchor(t *testing.T) {
+	entry := feedbin.Entry{
+		Title:       "Entry",
+		Content:     `<p>Before</p><img src="https://example.com/logo.png" alt="Logo"><p>Middle</p><img src="https://example.com/hero.png" alt="Hero"><p>After</p>`,
+		PublishedAt: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC),
+	}
+	lines := DetailLines(entry, 60, 0, nil, article.Options{}, func(s string, _ int) []string { return []string{s} },
+		InlineImagePreviewState{Enabled: true, Raw: "PREVIEW", Anchor: 1})
+	for i, line := range lines {
+		lines[i] = stripANSI(line)
+	}
+	middle, hero, preview := lineIndex(lines, "Middle"), lineIndex(lines, "Hero"), lineIndex(lines, "PREVIEW")
+	if preview < 0 || preview != hero+1 || middle > hero {
+		t.Fatalf("expected preview below the second image, got %q", lines)
+	}
+	if strings.Count(strings.Join(lines, "\n"), "PREVIEW") != 1 || lineIndex(lines, article.ImagePreviewAnchor) >= 0 {
+		t.Fatalf("expected one preview and no leftover anchors, got %q", lines)
+	}
+}
+
+func TestDetailLines_InlinePlacementSkipsImagesTheRendererDrops(t *testing.T) {
+	content := `<noscript><img src="https://example.com/lazy.png"></noscript>` +
+		`<p>Before <a href="https://example.com"><img src="https://example.com/icon.png"></a></p>` +
+		`<p>Middle</p><img src="https://example.com/hero.png" alt="Hero"><p>After</p>`
+	url, anchor := article.PreviewImage(content, article.ImageChoiceLast)
+	if url != "https://example.com/hero.png" {
+		t.Fatalf("unexpected preview image %q", url)
+	}
+	entry := feedbin.Entry{Title: "Entry", Content: content, PublishedAt: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	lines := DetailLines(entry, 60, 0, nil, article.Options{}, func(s string, _ int) []string { return []string{s} },
+		InlineImagePreviewState{Enabled: true, Raw: "PREVIEW", Anchor: anchor})
+	for i, line := range lines {
+		lines[i] = stripANSI(line)
+	}
+	hero, preview := lineIndex(lines, "Hero"), lineIndex(lines, "PREVIEW")
+	if preview < 0 || preview != hero+1 {
+		t.Fatalf("expected preview below the hero image, got %q", lines)
+	}
+}
+
+func TestDetailLines_EndPlacementAppendsPreviewAfterBody(t *testing.T) {
+	lines := imagePreviewTestLines(InlineImagePreviewState{Enabled: true, Raw: "PREVIEW", Placement: ImagePlacementEnd})
+	after, preview := lineIndex(lines, "After"), lineIndex(lines, "PREVIEW")
+	if preview != len(lines)-1 || after < 0 || preview != after+2 || lines[after+1] != "" {
+		t.Fatalf("expected preview after the body separated by one blank line, got %q", lines)
+	}
+	if lineIndex(lines, article.ImageLabelText) < 0 {
+		t.Fatalf("expected image label kept in place, got %q", lines)
+	}
+	if lineIndex(lines, article.ImagePreviewAnchor) >= 0 {
+		t.Fatalf("expected anchor stripped, got %q", lines)
+	}
+}
+
+func TestDetailLines_HideLabelsOnlyWhenPreviewExists(t *testing.T) {
+	hidden := imagePreviewTestLines(InlineImagePreviewState{Enabled: true, Raw: "PREVIEW", HideLabels: true})
+	if lineIndex(hidden, article.ImageLabelText) >= 0 || lineIndex(hidden, "Diagram") >= 0 {
+		t.Fatalf("expected image label suppressed, got %q", hidden)
+	}
+	before, preview := lineIndex(hidden, "Before"), lineIndex(hidden, "PREVIEW")
+	if before < 0 || preview != before+2 {
+		t.Fatalf("expected preview in the image's place, got %q", hidden)
+	}
+	for i := 1; i < len(hidden); i++ {
+		if hidden[i] == "" && hidden[i-1] == "" {
+			t.Fatalf("expected no doubled blank lines, got %q", hidden)
+		}
+	}
+
+	loading := imagePreviewTestLines(InlineImagePreviewState{Enabled: true, Loading: true, HideLabels: true})
+	if lineIndex(loading, article.ImageLabelText) < 0 {
+		t.Fatalf("expected label kept while preview is loading, got %q", loading)
+	}
+}
+
+func TestDetailLines_CommentCountInHeader(t *testing.T) {
+	entry := feedbin.Entry{Title: "Ask HN", Content: "<p># Comments: 7</p>", PublishedAt: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	wrap := func(s string, _ int) []string { return []string{s} }
+	opts := article.DefaultOptions
+	opts.CommentCounts = true
+	if joined := strings.Join(DetailLines(entry, 60, 0, nil, opts, wrap, InlineImagePreviewState{}), "\n"); !strings.Contains(joined, "Comments: 💬 7") {
+		t.Fatalf("expected the comment count in the header, got %q", joined)
+	}
+	if joined := strings.Join(DetailLines(entry, 60, 0, nil, article.DefaultOptions, wrap, InlineImagePreviewState{}), "\n"); strings.Contains(joined, "💬") {
+		t.Fatalf("expected no comment count when disabled, got %q", joined)
+	}
+}
+
+func TestDetailLines_MarksRightToLeftArticles(t *testing.T) {
+	entry := feedbin.Entry{Title: "مقال", Content: "<p>مرحبا بالعالم، هذا مقال تجريبي.</p>", PublishedAt: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	wrap := func(s string, _ int) []string { return []string{s} }
+	opts := article.DefaultOptions
+	opts.RightToLeft = true
+	if joined := strings.Join(DetailLines(entry, 60, 0, nil, opts, wrap, InlineImagePreviewState{}), "\n"); !strings.Contains(joined, "Direction: right-to-left") {
+		t.Fatalf("expected the RTL marker in the header, got %q", joined)
+	}
+	if joined := strings.Join(DetailLines(entry, 60, 0, nil, article.DefaultOptions, wrap, InlineImagePreviewState{}), "\n"); strings.Contains(joined, "Direction:") {
+		t.Fatalf("expected no marker when the preference is off, got %q", joined)
+	}
+}
+
+func TestDetailMetaLines_DateInLocation(t *testing.T) {
+	entry := feedbin.Entry{Title: "Entry", PublishedAt: time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)}
+	wrap := func(s string, _ int) []string { return []string{s} }
+	cases := map[string]string{
+		"":                 "Date: 2026-07-01T12:00:00Z",
+		"Europe/Madrid":    "Date: 2026-07-01T14:00:00+02:00",
+		"America/Santiago": "Date: 2026-07-01T08:00:00-04:00",
+		"Asia/Kolkata":     "Date: 2026-07-01T17:30:00+05:30",
+	}
+	for zone, want := range cases {
+		var loc *time.Location
+		if zone != "" {
+			var err error
+			if loc, err = time.LoadLocation(zone); err != nil {
+				t.Fatalf("load %s: %v", zone, err)
+			}
+		}
+		joined := strings.Join(DetailMetaLines(entry, 60, loc, false, "", wrap), "\n")
+		if !strings.Contains(joined, want) {
+			t.Fatalf("zone %q: expected %q, got %q", zone, want, joined)
+		}
+	}
+}
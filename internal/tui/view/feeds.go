@@ -0,0 +1,72 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	tuistate "github.com/glabrego/reeder-cli/internal/tui/state"
+	tuitheme "github.com/glabrego/reeder-cli/internal/tui/theme"
+)
+
+// FeedManagerRow is one subscription line in the feed manager overlay.
+type FeedManagerRow struct {
+	Title   string
+	Folder  string
+	Unread  int
+	Updated string
+	Muted   bool
+}
+
+type FeedManagerInput struct {
+	Rows   []FeedManagerRow
+	Cursor int
+	Height int
+	Width  int
+	Prompt string
+}
+
+func RenderFeedManager(in FeedManagerInput, th tuitheme.Theme) string {
+	var b strings.Builder
+	b.WriteString(th.Section.Render(fmt.Sprintf("Feeds (%d)", len(in.Rows))))
+	b.WriteString("  e rename • m mute • x unsubscribe • r refresh feed • esc close\n")
+	if len(in.Rows) == 0 {
+		b.WriteString("No subscriptions cached yet. Refresh first.\n")
+	}
+
+	start, end := tuistate.CenteredWindow(len(in.Rows), in.Cursor, in.Height)
+	for i := start; i < end; i++ {
+		b.WriteString(renderFeedManagerRow(in.Rows[i], in.Width, i == in.Cursor, th))
+		b.WriteString("\n")
+	}
+	if in.Prompt != "" {
+		b.WriteString("\n")
+		b.WriteString(in.Prompt)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func renderFeedManagerRow(row FeedManagerRow, width int, active bool, th tuitheme.Theme) string {
+	left := row.Title
+	if row.Folder != "" {
+		left = row.Folder + " / " + row.Title
+	}
+	if row.Muted {
+		left += " [muted]"
+	}
+	meta := fmt.Sprintf("%d unread", row.Unread)
+	if row.Updated != "" {
+		meta += " • " + row.Updated
+	}
+	right := th.MetaValue.Render(meta)
+	available := width - visibleLen(right) - 1
+	if available < 1 {
+		available = 1
+	}
+	left = truncateRunes(left, available)
+	gap := width - visibleLen(left) - visibleLen(right)
+	if gap < 1 {
+		gap = 1
+	}
+	return th.RenderActiveLine(active, left+strings.Repeat(" ", gap)+right)
+}
@@ -2,36 +2,104 @@ package view
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 	"time"
 	"unicode/utf8"
 
+	article "github.com/glabrego/reeder-cli/internal/render/article"
 	tuitheme "github.com/glabrego/reeder-cli/internal/tui/theme"
+	tuitree "github.com/glabrego/reeder-cli/internal/tui/tree"
 
 	"github.com/glabrego/reeder-cli/internal/feedbin"
 )
 
-var reANSICodes = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+// DateColumn controls the date label at the right edge of article rows.
+type DateColumn string
 
-type EntryLineParams struct {
-	Entry        feedbin.Entry
-	Now          time.Time
-	RelativeTime bool
-	Compact      bool
-	ShowNumbers  bool
-	VisiblePos   int
-	Active       bool
-	Selected     bool
-	Width        int
+const (
+	// DateColumnFull shows "2026-02-09" or "2 hours ago".
+	DateColumnFull DateColumn = "full"
+	// DateColumnShort caps the label at a few cells: "Feb 9" or "2h".
+	DateColumnShort DateColumn = "short"
+	// DateColumnHidden drops the date and gives the title the whole row.
+	DateColumnHidden DateColumn = "hidden"
+)
+
+// NextDateColumn cycles full -> short -> hidden -> full.
+func NextDateColumn(mode DateColumn) DateColumn {
+	switch mode {
+	case DateColumnFull, "":
+		return DateColumnShort
+	case DateColumnShort:
+		return DateColumnHidden
+	default:
+		return DateColumnFull
+	}
 }
 
-func RenderEntryLine(p EntryLineParams, th tuitheme.Theme) string {
-	date := p.Entry.PublishedAt.UTC().Format(time.DateOnly)
-	if p.RelativeTime {
-		date = RelativeTimeLabel(p.Now, p.Entry.PublishedAt)
+// TimeFormat controls how entry dates are labeled.
+type TimeFormat string
+
+const (
+	// TimeFormatRelative shows "2 hours ago".
+	TimeFormatRelative TimeFormat = "relative"
+	// TimeFormatAbsolute shows "2026-02-09"; it is also what an empty value
+	// means.
+	TimeFormatAbsolute TimeFormat = "absolute"
+	// TimeFormatBoth shows the age and the day: "2h ago (Feb 9)".
+	TimeFormatBoth TimeFormat = "both"
+)
+
+// NextTimeFormat cycles relative -> absolute -> both -> relative.
+func NextTimeFormat(format TimeFormat) TimeFormat {
+	switch format {
+	case TimeFormatRelative:
+		return TimeFormatAbsolute
+	case TimeFormatAbsolute, "":
+		return TimeFormatBoth
+	default:
+		return TimeFormatRelative
 	}
+}
 
+// minTitleCellsForBothTimes is how narrow the title may get before a
+// combined date label gives up the day and shows only the age.
+const minTitleCellsForBothTimes = 12
+
+type EntryLineParams struct {
+	Entry       feedbin.Entry
+	Now         time.Time
+	TimeFormat  TimeFormat
+	DateColumn  DateColumn
+	Compact     bool
+	ShowNumbers bool
+	VisiblePos  int
+	Active      bool
+	Selected    bool
+	Width       int
+	// Location is the zone absolute dates are shown in; nil means UTC.
+	Location *time.Location
+	// Hyperlinks makes the title an OSC 8 link to the entry URL.
+	Hyperlinks bool
+	// ShortenURLs elides the middle of titles that are bare URLs instead of
+	// cutting off their end.
+	ShortenURLs bool
+	// Progress is how far a partially read article was scrolled (0 to 1);
+	// zero shows no marker.
+	Progress float64
+	// CommentCount is the entry's comment count, shown next to the date when
+	// HasCommentCount is set.
+	CommentCount    int
+	HasCommentCount bool
+	// Stacked gives the title the whole first line and puts the feed and
+	// date on a second one, for terminals too narrow to share a row.
+	Stacked bool
+	// StripFeedPrefix drops a leading "Feed name:" from titles, since the
+	// tree already shows which feed an entry is from.
+	StripFeedPrefix bool
+}
+
+func RenderEntryLine(p EntryLineParams, th tuitheme.Theme) string {
 	cursorMarker := " "
 	if p.Active {
 		cursorMarker = ">"
@@ -45,30 +113,164 @@ func RenderEntryLine(p EntryLineParams, th tuitheme.Theme) string {
 	if p.ShowNumbers {
 		prefix = fmt.Sprintf("    %s%s%2d. ", cursorMarker, selectedMarker, p.VisiblePos+1)
 	}
-	dateLabel := "[" + date + "]"
-	available := p.Width - visibleLen(prefix) - 1 - visibleLen(dateLabel)
+	if p.Stacked {
+		return renderStackedEntryLine(p, prefix, th)
+	}
+	dateLabel := entryDateLabel(p)
+	if p.TimeFormat == TimeFormatBoth && p.Width-visibleLen(prefix)-1-visibleLen(dateLabel) < minTitleCellsForBothTimes {
+		// The title shrinks first; past its minimum the day goes.
+		p.TimeFormat = TimeFormatRelative
+		dateLabel = entryDateLabel(p)
+	}
+	if progress := progressLabel(p.Progress); progress != "" {
+		if dateLabel != "" {
+			dateLabel = " " + dateLabel
+		}
+		dateLabel = th.MetaLabel.Render(progress) + dateLabel
+	}
+	// The comment icon is two cells wide but a single rune.
+	wideCells := 0
+	if comments := commentLabel(p); comments != "" {
+		if dateLabel != "" {
+			dateLabel = " " + dateLabel
+		}
+		dateLabel = th.MetaLabel.Render(comments) + dateLabel
+		wideCells = 1
+	}
+	// Keep one cell between title and date; without a date the title may run
+	// to the edge.
+	reserved := 0
+	if dateLabel != "" {
+		reserved = 1 + visibleLen(dateLabel) + wideCells
+	}
+	available := p.Width - visibleLen(prefix) - reserved
 	if available < 1 {
 		available = 1
 	}
 
-	label := strings.TrimSpace(p.Entry.Title)
+	label := entryTitle(p)
 	if p.Compact {
-		label = CompactEntryLabel(p.Entry)
+		entry := p.Entry
+		entry.Title = label
+		label = CompactEntryLabel(entry)
+	}
+	if p.ShortenURLs && looksLikeURL(label) {
+		label = shortenURL(label, available)
+	} else {
+		label = truncateRunes(label, available)
 	}
-	label = truncateRunes(label, available)
 	styledTitle := th.StyleArticleTitle(p.Entry, label)
-	gap := p.Width - visibleLen(prefix) - visibleLen(label) - visibleLen(dateLabel)
-	if gap < 1 {
+	if p.Hyperlinks {
+		styledTitle = article.Hyperlink(styledTitle, strings.TrimSpace(p.Entry.URL))
+	}
+	gap := p.Width - visibleLen(prefix) - visibleLen(label) - visibleLen(dateLabel) - wideCells
+	if gap < 1 && dateLabel != "" {
 		gap = 1
 	}
+	if gap < 0 {
+		gap = 0
+	}
 	return th.RenderActiveLine(p.Active, prefix+styledTitle+strings.Repeat(" ", gap)+dateLabel)
 }
 
-func RenderTreeNodeLine(left string, unreadCount, width int, active bool, th tuitheme.Theme) string {
+// renderStackedEntryLine renders the title after prefix, then the feed, date
+// and markers on a second line aligned under it. The feed line replaces the
+// compact "Feed | Title" label.
+func renderStackedEntryLine(p EntryLineParams, prefix string, th tuitheme.Theme) string {
+	available := max(p.Width-visibleLen(prefix), 1)
+	label := entryTitle(p)
+	if label == "" {
+		label = "(untitled)"
+	}
+	if p.ShortenURLs && looksLikeURL(label) {
+		label = shortenURL(label, available)
+	} else {
+		label = truncateRunes(label, available)
+	}
+	styledTitle := th.StyleArticleTitle(p.Entry, label)
+	if p.Hyperlinks {
+		styledTitle = article.Hyperlink(styledTitle, strings.TrimSpace(p.Entry.URL))
+	}
+	title := prefix + styledTitle + strings.Repeat(" ", max(available-visibleLen(label), 0))
+
+	meta := make([]string, 0, 4)
+	if feed := strings.TrimSpace(p.Entry.FeedTitle); feed != "" {
+		meta = append(meta, feed)
+	}
+	for _, label := range []string{entryDateLabel(p), progressLabel(p.Progress), commentLabel(p)} {
+		if label != "" {
+			meta = append(meta, label)
+		}
+	}
+	detail := truncateRunes(strings.Join(meta, " "), available)
+	detailLine := strings.Repeat(" ", visibleLen(prefix)) + th.MetaLabel.Render(detail) + strings.Repeat(" ", max(available-visibleLen(detail), 0))
+	return th.RenderActiveLine(p.Active, title) + "\n" + th.RenderActiveLine(p.Active, detailLine)
+}
+
+// commentLabel shows how many comments a discussion feed reported for the
+// entry.
+func commentLabel(p EntryLineParams) string {
+	if !p.HasCommentCount {
+		return ""
+	}
+	return "💬 " + FormatCount(p.CommentCount, true)
+}
+
+// progressLabel marks a partially read article with how far it was read.
+func progressLabel(fraction float64) string {
+	if fraction <= 0 || fraction >= 1 {
+		return ""
+	}
+	return fmt.Sprintf("◔%d%%", max(1, int(fraction*100)))
+}
+
+func entryDateLabel(p EntryLineParams) string {
+	switch p.DateColumn {
+	case DateColumnHidden:
+		return ""
+	case DateColumnShort:
+		switch p.TimeFormat {
+		case TimeFormatRelative:
+			return "[" + ShortRelativeTimeLabel(p.Now, p.Entry.PublishedAt) + "]"
+		case TimeFormatBoth:
+			return "[" + ShortRelativeTimeLabel(p.Now, p.Entry.PublishedAt) + " " + inLocation(p.Entry.PublishedAt, p.Location).Format("Jan 2") + "]"
+		}
+		return "[" + inLocation(p.Entry.PublishedAt, p.Location).Format("Jan 2") + "]"
+	}
+	switch p.TimeFormat {
+	case TimeFormatRelative:
+		return "[" + RelativeTimeLabel(p.Now, p.Entry.PublishedAt) + "]"
+	case TimeFormatBoth:
+		return "[" + BothTimesLabel(p.Now, p.Entry.PublishedAt, p.Location) + "]"
+	}
+	return "[" + inLocation(p.Entry.PublishedAt, p.Location).Format(time.DateOnly) + "]"
+}
+
+// BothTimesLabel puts the short age before the day: "2h ago (Feb 9)", or
+// "now (Feb 9)" within the last minute.
+func BothTimesLabel(now, then time.Time, loc *time.Location) string {
+	if then.IsZero() {
+		return "unknown"
+	}
+	age := ShortRelativeTimeLabel(now, then)
+	if age != "now" {
+		age += " ago"
+	}
+	return age + " (" + inLocation(then, loc).Format("Jan 2") + ")"
+}
+
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		return t.UTC()
+	}
+	return t.In(loc)
+}
+
+func RenderTreeNodeLine(left string, unreadCount, width int, active, compactCounts bool, th tuitheme.Theme) string {
 	if unreadCount <= 0 {
 		return th.RenderActiveLine(active, left)
 	}
-	right := th.UnreadCount.Render(fmt.Sprintf("%d", unreadCount))
+	right := th.UnreadCount.Render(FormatCount(unreadCount, compactCounts))
 	available := width - visibleLen(right) - 1
 	if available < 1 {
 		available = 1
@@ -81,20 +283,44 @@ func RenderTreeNodeLine(left string, unreadCount, width int, active bool, th tui
 	return th.RenderActiveLine(active, left+strings.Repeat(" ", gap)+right)
 }
 
-func RenderSectionLine(label string, unreadCount, width int, active bool, nerdIcons bool, th tuitheme.Theme) string {
+func RenderSectionLine(label string, unreadCount, width int, active, nerdIcons, compactCounts bool, th tuitheme.Theme) string {
 	icon := "■"
-	if label == "Folders" {
+	switch label {
+	case "Folders":
 		icon = "▦"
+	case "Pinned":
+		icon = "◆"
 	}
 	if nerdIcons {
-		if label == "Folders" {
+		switch label {
+		case "Folders":
 			icon = "󰉋"
-		} else {
+		case "Pinned":
+			icon = "󰐃"
+		default:
 			icon = "󰈙"
 		}
 	}
 	left := fmt.Sprintf("%s %s", icon, label)
-	return RenderTreeNodeLine(th.Section.Render(left), unreadCount, width, active, th)
+	return RenderTreeNodeLine(th.Section.Render(left), unreadCount, width, active, compactCounts, th)
+}
+
+// FormatCount renders n exactly below 1000; with compact set, larger counts
+// shorten to "1.2k", "12k" or "3.4M" so right-aligned columns stay narrow.
+func FormatCount(n int, compact bool) string {
+	if !compact || n < 1000 {
+		return fmt.Sprintf("%d", n)
+	}
+	switch {
+	case n < 10_000:
+		return fmt.Sprintf("%d.%dk", n/1000, n%1000/100)
+	case n < 1_000_000:
+		return fmt.Sprintf("%dk", n/1000)
+	case n < 10_000_000:
+		return fmt.Sprintf("%d.%dM", n/1_000_000, n%1_000_000/100_000)
+	default:
+		return fmt.Sprintf("%dM", n/1_000_000)
+	}
 }
 
 func CompactEntryLabel(entry feedbin.Entry) string {
@@ -107,14 +333,50 @@ func CompactEntryLabel(entry feedbin.Entry) string {
 	if folder := strings.TrimSpace(entry.FeedFolder); folder != "" {
 		parts = append(parts, folder)
 	}
-	feed := strings.TrimSpace(entry.FeedTitle)
-	if feed == "" {
-		feed = "unknown feed"
-	}
-	parts = append(parts, feed, title)
+	parts = append(parts, tuitree.FeedName(entry), title)
 	return strings.Join(parts, " | ")
 }
 
+// entryTitle is the title shown for the entry of p.
+func entryTitle(p EntryLineParams) string {
+	title := strings.TrimSpace(p.Entry.Title)
+	if p.StripFeedPrefix {
+		title = stripFeedPrefix(title, tuitree.FeedName(p.Entry))
+	}
+	return title
+}
+
+// feedPrefixSeparators may follow a feed name at the start of a title. A
+// colon can sit right after the name; the others need a space before them so
+// a hyphenated word is not taken apart.
+var feedPrefixSeparators = []string{":", "-", "–", "—", "|", "·", "»"}
+
+// stripFeedPrefix removes a leading "feedName:" or "feedName -" from title,
+// ignoring case. The title is kept as is when the name runs on into a longer
+// word, no separator follows it, or nothing would be left after it.
+func stripFeedPrefix(title, feedName string) string {
+	feedName = strings.TrimSpace(feedName)
+	if feedName == "" || len(title) <= len(feedName) || !strings.EqualFold(title[:len(feedName)], feedName) {
+		return title
+	}
+	rest := title[len(feedName):]
+	afterSpace := strings.TrimLeft(rest, " \t")
+	spaced := len(afterSpace) < len(rest)
+	for _, sep := range feedPrefixSeparators {
+		if !strings.HasPrefix(afterSpace, sep) || sep != ":" && !spaced {
+			continue
+		}
+		after := afterSpace[len(sep):]
+		stripped := strings.TrimSpace(after)
+		if stripped == "" || strings.TrimLeft(after, " \t") == after {
+			// A bare "Feed:" or "Feed:Title" is not a prefix.
+			return title
+		}
+		return stripped
+	}
+	return title
+}
+
 func RelativeTimeLabel(now, then time.Time) string {
 	if now.IsZero() {
 		now = time.Now()
@@ -150,6 +412,28 @@ func RelativeTimeLabel(now, then time.Time) string {
 	return fmt.Sprintf("%d days ago", n)
 }
 
+// ShortRelativeTimeLabel is the narrow form of RelativeTimeLabel: "now",
+// "5m", "3h", "2d".
+func ShortRelativeTimeLabel(now, then time.Time) string {
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if then.IsZero() {
+		return "?"
+	}
+	d := now.Sub(then)
+	switch {
+	case d < time.Minute:
+		return "now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+}
+
 func truncateRunes(s string, maxLen int) string {
 	if maxLen <= 0 {
 		return ""
@@ -169,5 +453,5 @@ func visibleLen(s string) int {
 }
 
 func stripANSIText(s string) string {
-	return reANSICodes.ReplaceAllString(s, "")
+	return article.ANSICodes.ReplaceAllString(s, "")
 }
@@ -28,30 +28,30 @@ func TestListRendering_Golden(t *testing.T) {
 	}
 
 	lines := []string{
-		RenderSectionLine("Folders", 6, 78, false, false, th),
-		RenderTreeNodeLine("▾ Design", 6, 78, false, th),
-		RenderTreeNodeLine("  ▾ 512 Pixels", 6, 78, true, th),
+		RenderSectionLine("Folders", 6, 78, false, false, false, th),
+		RenderTreeNodeLine("▾ Design", 6, 78, false, false, th),
+		RenderTreeNodeLine("  ▾ 512 Pixels", 6, 78, true, false, th),
 		RenderEntryLine(EntryLineParams{
-			Entry:        entry,
-			Now:          now,
-			RelativeTime: true,
-			Compact:      false,
-			ShowNumbers:  true,
-			VisiblePos:   0,
-			Active:       true,
-			Selected:     true,
-			Width:        78,
+			Entry:       entry,
+			Now:         now,
+			TimeFormat:  TimeFormatRelative,
+			Compact:     false,
+			ShowNumbers: true,
+			VisiblePos:  0,
+			Active:      true,
+			Selected:    true,
+			Width:       78,
 		}, th),
 		RenderEntryLine(EntryLineParams{
-			Entry:        entry,
-			Now:          now,
-			RelativeTime: false,
-			Compact:      true,
-			ShowNumbers:  false,
-			VisiblePos:   0,
-			Active:       false,
-			Selected:     false,
-			Width:        78,
+			Entry:       entry,
+			Now:         now,
+			TimeFormat:  TimeFormatAbsolute,
+			Compact:     true,
+			ShowNumbers: false,
+			VisiblePos:  0,
+			Active:      false,
+			Selected:    false,
+			Width:       78,
 		}, th),
 	}
 	got := stripANSI(strings.Join(lines, "\n"))
@@ -21,6 +21,9 @@ type ListRenderInput struct {
 	RenderSectionLine  func(label string, unreadCount int, active bool) string
 	RenderTreeNodeLine func(left string, unreadCount int, active bool) string
 	RenderEntryLine    func(entryIndex, visiblePos int, active bool) string
+	// RenderEntryPreview returns snippet lines written under an article;
+	// optional.
+	RenderEntryPreview func(entryIndex int) []string
 	FeedKeyFn          func(folder, feed string) string
 }
 
@@ -55,11 +58,24 @@ func RenderListBody(in ListRenderInput) string {
 					prefix = "  ▸ "
 				}
 			}
-			b.WriteString(in.RenderTreeNodeLine(prefix+row.Label, in.FeedUnreadCounts[in.FeedKeyFn(row.Folder, row.Feed)], i == in.TreeCursor))
+			b.WriteString(in.RenderTreeNodeLine(prefix+row.Label, in.FeedUnreadCounts[tuitree.FeedCountKey(row.Folder, row.Feed, row.Pinned)], i == in.TreeCursor))
+			b.WriteString("\n")
+		case tuitree.RowMore:
+			prefix := "  "
+			if row.Folder == "" {
+				prefix = ""
+			}
+			b.WriteString(in.RenderTreeNodeLine(prefix+row.Label, 0, i == in.TreeCursor))
 			b.WriteString("\n")
 		case tuitree.RowArticle:
 			b.WriteString(in.RenderEntryLine(row.EntryIndex, visiblePos, i == in.TreeCursor))
 			b.WriteString("\n")
+			if in.RenderEntryPreview != nil {
+				for _, line := range in.RenderEntryPreview(row.EntryIndex) {
+					b.WriteString(line)
+					b.WriteString("\n")
+				}
+			}
 			visiblePos++
 		}
 	}
@@ -21,9 +21,9 @@ func TestRenderEntryLine_AbsoluteDateWhenRelativeDisabled(t *testing.T) {
 			PublishedAt: now.Add(-2 * time.Hour),
 			IsUnread:    true,
 		},
-		Now:          now,
-		RelativeTime: false,
-		Width:        60,
+		Now:        now,
+		TimeFormat: TimeFormatAbsolute,
+		Width:      60,
 	}, th)
 	plain := stripANSI(line)
 	if !strings.HasSuffix(plain, "[2026-02-09]") {
@@ -31,6 +31,191 @@ func TestRenderEntryLine_AbsoluteDateWhenRelativeDisabled(t *testing.T) {
 	}
 }
 
+func TestRenderEntryLine_ShortDateColumn(t *testing.T) {
+	now := time.Date(2026, 2, 9, 12, 0, 0, 0, time.UTC)
+	th := tuitheme.Default()
+	entry := feedbin.Entry{ID: 1, Title: "Short date rendering", PublishedAt: now.Add(-3 * time.Hour)}
+
+	absolute := stripANSI(RenderEntryLine(EntryLineParams{Entry: entry, Now: now, DateColumn: DateColumnShort, Width: 60}, th))
+	if !strings.HasSuffix(absolute, "[Feb 9]") || visibleLen(absolute) != 60 {
+		t.Fatalf("expected short absolute date filling 60 cells, got %q (%d)", absolute, visibleLen(absolute))
+	}
+
+	relative := stripANSI(RenderEntryLine(EntryLineParams{Entry: entry, Now: now, TimeFormat: TimeFormatRelative, DateColumn: DateColumnShort, Width: 60}, th))
+	if !strings.HasSuffix(relative, "[3h]") || visibleLen(relative) != 60 {
+		t.Fatalf("expected short relative date filling 60 cells, got %q (%d)", relative, visibleLen(relative))
+	}
+}
+
+func TestRenderEntryLine_BothTimesShowAgeAndDay(t *testing.T) {
+	now := time.Date(2026, 2, 9, 12, 0, 0, 0, time.UTC)
+	th := tuitheme.Default()
+	entry := feedbin.Entry{ID: 1, Title: "Combined date rendering", PublishedAt: now.Add(-2 * time.Hour)}
+
+	full := stripANSI(RenderEntryLine(EntryLineParams{Entry: entry, Now: now, TimeFormat: TimeFormatBoth, Width: 60}, th))
+	if !strings.HasSuffix(full, "[2h ago (Feb 9)]") || visibleLen(full) != 60 {
+		t.Fatalf("expected combined date filling 60 cells, got %q (%d)", full, visibleLen(full))
+	}
+	short := stripANSI(RenderEntryLine(EntryLineParams{Entry: entry, Now: now, TimeFormat: TimeFormatBoth, DateColumn: DateColumnShort, Width: 60}, th))
+	if !strings.HasSuffix(short, "[2h Feb 9]") {
+		t.Fatalf("expected short combined date, got %q", short)
+	}
+	if got := BothTimesLabel(now, now.Add(-10*time.Second), nil); got != "now (Feb 9)" {
+		t.Fatalf("BothTimesLabel just now = %q", got)
+	}
+}
+
+func TestRenderEntryLine_BothTimesShrinkTitleThenDropDay(t *testing.T) {
+	now := time.Date(2026, 2, 9, 12, 0, 0, 0, time.UTC)
+	th := tuitheme.Default()
+	entry := feedbin.Entry{ID: 1, Title: strings.Repeat("x", 80), PublishedAt: now.Add(-2 * time.Hour)}
+
+	wide := stripANSI(RenderEntryLine(EntryLineParams{Entry: entry, Now: now, TimeFormat: TimeFormatBoth, Width: 50}, th))
+	if !strings.HasSuffix(wide, " [2h ago (Feb 9)]") || visibleLen(wide) != 50 {
+		t.Fatalf("expected the title truncated before the combined date, got %q (%d)", wide, visibleLen(wide))
+	}
+	narrow := stripANSI(RenderEntryLine(EntryLineParams{Entry: entry, Now: now, TimeFormat: TimeFormatBoth, Width: 34}, th))
+	if !strings.HasSuffix(narrow, " [2 hours ago]") || visibleLen(narrow) != 34 {
+		t.Fatalf("expected the day dropped once the title hits its minimum, got %q (%d)", narrow, visibleLen(narrow))
+	}
+}
+
+func TestNextTimeFormat_Cycles(t *testing.T) {
+	format := TimeFormatRelative
+	for _, want := range []TimeFormat{TimeFormatAbsolute, TimeFormatBoth, TimeFormatRelative} {
+		format = NextTimeFormat(format)
+		if format != want {
+			t.Fatalf("NextTimeFormat = %q, want %q", format, want)
+		}
+	}
+}
+
+func TestRenderEntryLine_HiddenDateGivesTitleFullWidth(t *testing.T) {
+	now := time.Date(2026, 2, 9, 12, 0, 0, 0, time.UTC)
+	th := tuitheme.Default()
+	title := strings.Repeat("x", 80)
+	entry := feedbin.Entry{ID: 1, Title: title, PublishedAt: now}
+
+	hidden := stripANSI(RenderEntryLine(EntryLineParams{Entry: entry, Now: now, DateColumn: DateColumnHidden, Width: 40}, th))
+	full := stripANSI(RenderEntryLine(EntryLineParams{Entry: entry, Now: now, Width: 40}, th))
+	if visibleLen(hidden) != 40 || visibleLen(full) != 40 {
+		t.Fatalf("expected both layouts to fill 40 cells, got hidden=%d full=%d", visibleLen(hidden), visibleLen(full))
+	}
+	if strings.Contains(hidden, "[") {
+		t.Fatalf("expected no date label, got %q", hidden)
+	}
+	if strings.Count(hidden, "x") != strings.Count(full, "x")+len("[2026-02-09]")+1 {
+		t.Fatalf("expected title to reclaim the date column, got hidden=%q full=%q", hidden, full)
+	}
+
+	short := stripANSI(RenderEntryLine(EntryLineParams{Entry: feedbin.Entry{ID: 2, Title: "Hi", PublishedAt: now}, Now: now, DateColumn: DateColumnHidden, Width: 40}, th))
+	if visibleLen(short) != 40 {
+		t.Fatalf("expected short title padded to 40 cells, got %d", visibleLen(short))
+	}
+}
+
+func TestRenderEntryLine_DatesFollowLocation(t *testing.T) {
+	published := time.Date(2026, 2, 9, 23, 30, 0, 0, time.UTC)
+	th := tuitheme.Default()
+	cases := []struct {
+		zone string
+		want string
+	}{
+		{"UTC", "[2026-02-09]"},
+		{"Asia/Tokyo", "[2026-02-10]"},
+		{"America/New_York", "[2026-02-09]"},
+		{"Pacific/Kiritimati", "[2026-02-10]"},
+	}
+	for _, tc := range cases {
+		loc, err := time.LoadLocation(tc.zone)
+		if err != nil {
+			t.Fatalf("load %s: %v", tc.zone, err)
+		}
+		line := stripANSI(RenderEntryLine(EntryLineParams{Entry: feedbin.Entry{ID: 1, Title: "Zone", PublishedAt: published}, Width: 60, Location: loc}, th))
+		if !strings.HasSuffix(line, tc.want) {
+			t.Fatalf("%s: expected %s, got %q", tc.zone, tc.want, line)
+		}
+	}
+}
+
+func TestRenderEntryLine_HyperlinkKeepsWidth(t *testing.T) {
+	now := time.Date(2026, 2, 9, 12, 0, 0, 0, time.UTC)
+	th := tuitheme.Default()
+	entry := feedbin.Entry{ID: 1, Title: "Linked title", URL: "https://example.com/post", PublishedAt: now}
+
+	plain := RenderEntryLine(EntryLineParams{Entry: entry, Now: now, Width: 50}, th)
+	linked := RenderEntryLine(EntryLineParams{Entry: entry, Now: now, Width: 50, Hyperlinks: true}, th)
+	if !strings.Contains(linked, "\x1b]8;;https://example.com/post\x1b\\") {
+		t.Fatalf("expected OSC 8 hyperlink in title, got %q", linked)
+	}
+	if visibleLen(linked) != visibleLen(plain) || stripANSIText(linked) != stripANSIText(plain) {
+		t.Fatalf("expected hyperlink to be zero-width, got %q vs %q", stripANSIText(linked), stripANSIText(plain))
+	}
+}
+
+func TestRenderEntryLine_StackedPutsFeedAndDateUnderTitle(t *testing.T) {
+	now := time.Date(2026, 2, 9, 12, 0, 0, 0, time.UTC)
+	th := tuitheme.Default()
+	entry := feedbin.Entry{ID: 1, Title: "A title that needs the whole row", FeedTitle: "Example Feed", PublishedAt: now}
+
+	lines := strings.Split(stripANSI(RenderEntryLine(EntryLineParams{Entry: entry, Now: now, Width: 40, Stacked: true}, th)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected two lines, got %q", lines)
+	}
+	if !strings.Contains(lines[0], "A title that needs the whole row") || strings.Contains(lines[0], "[") {
+		t.Fatalf("expected the untruncated title alone on the first line, got %q", lines[0])
+	}
+	if strings.TrimSpace(lines[1]) != "Example Feed [2026-02-09]" {
+		t.Fatalf("expected feed and date on the second line, got %q", lines[1])
+	}
+	for _, line := range lines {
+		if visibleLen(line) != 40 {
+			t.Fatalf("expected each line to fill 40 cells, got %q (%d)", line, visibleLen(line))
+		}
+	}
+}
+
+func TestNextDateColumn(t *testing.T) {
+	if NextDateColumn(DateColumnFull) != DateColumnShort || NextDateColumn(DateColumnShort) != DateColumnHidden || NextDateColumn(DateColumnHidden) != DateColumnFull {
+		t.Fatal("unexpected date column cycle")
+	}
+}
+
+func TestFormatCount(t *testing.T) {
+	cases := []struct {
+		n    int
+		want string
+	}{
+		{999, "999"},
+		{1000, "1.0k"},
+		{1250, "1.2k"},
+		{9999, "9.9k"},
+		{12345, "12k"},
+		{999999, "999k"},
+		{1500000, "1.5M"},
+		{25000000, "25M"},
+	}
+	for _, tc := range cases {
+		if got := FormatCount(tc.n, true); got != tc.want {
+			t.Fatalf("FormatCount(%d) = %q, want %q", tc.n, got, tc.want)
+		}
+	}
+	if got := FormatCount(12345, false); got != "12345" {
+		t.Fatalf("expected exact count when compact is off, got %q", got)
+	}
+}
+
+func TestRenderTreeNodeLine_CompactCountKeepsRightAlignment(t *testing.T) {
+	th := tuitheme.Default()
+	got := stripANSI(RenderTreeNodeLine("▾ Design", 12345, 40, false, true, th))
+	if visibleLen(got) != 40 {
+		t.Fatalf("expected line to fill 40 cells, got %d: %q", visibleLen(got), got)
+	}
+	if !strings.HasSuffix(got, " 12k") {
+		t.Fatalf("expected compact count right-aligned, got %q", got)
+	}
+}
+
 func TestCompactEntryLabel(t *testing.T) {
 	withFolder := CompactEntryLabel(feedbin.Entry{
 		Title:      "Article",
@@ -50,6 +235,53 @@ func TestCompactEntryLabel(t *testing.T) {
 	}
 }
 
+func TestStripFeedPrefix(t *testing.T) {
+	cases := []struct {
+		title, feed, want string
+	}{
+		{title: "TechBlog: New phone released", feed: "TechBlog", want: "New phone released"},
+		{title: "TechBlog - New phone released", feed: "TechBlog", want: "New phone released"},
+		{title: "TechBlog – New phone released", feed: "TechBlog", want: "New phone released"},
+		{title: "TechBlog — New phone released", feed: "TechBlog", want: "New phone released"},
+		{title: "TechBlog | New phone released", feed: "TechBlog", want: "New phone released"},
+		{title: "TechBlog » New phone released", feed: "TechBlog", want: "New phone released"},
+		{title: "techblog :  New phone released", feed: "TechBlog", want: "New phone released"},
+		{title: "TechBlog: New phone released", feed: " TechBlog ", want: "New phone released"},
+		// Not a feed-name prefix.
+		{title: "TechBlogger: New phone released", feed: "TechBlog", want: "TechBlogger: New phone released"},
+		{title: "TechBlog-powered phones", feed: "TechBlog", want: "TechBlog-powered phones"},
+		{title: "TechBlog:New phone", feed: "TechBlog", want: "TechBlog:New phone"},
+		{title: "TechBlog turns ten", feed: "TechBlog", want: "TechBlog turns ten"},
+		{title: "TechBlog:", feed: "TechBlog", want: "TechBlog:"},
+		{title: "TechBlog", feed: "TechBlog", want: "TechBlog"},
+		{title: "Weekly: TechBlog news", feed: "TechBlog", want: "Weekly: TechBlog news"},
+		{title: "TechBlog: New phone released", feed: "", want: "TechBlog: New phone released"},
+	}
+	for _, tc := range cases {
+		if got := stripFeedPrefix(tc.title, tc.feed); got != tc.want {
+			t.Errorf("stripFeedPrefix(%q, %q) = %q, want %q", tc.title, tc.feed, got, tc.want)
+		}
+	}
+}
+
+func TestRenderEntryLine_StripFeedPrefix(t *testing.T) {
+	th := tuitheme.Default()
+	entry := feedbin.Entry{ID: 1, Title: "TechBlog: New phone released", FeedTitle: "TechBlog"}
+
+	kept := stripANSI(RenderEntryLine(EntryLineParams{Entry: entry, DateColumn: DateColumnHidden, Width: 60}, th))
+	if !strings.Contains(kept, "TechBlog: New phone released") {
+		t.Fatalf("expected the full title without the option, got %q", kept)
+	}
+	stripped := stripANSI(RenderEntryLine(EntryLineParams{Entry: entry, DateColumn: DateColumnHidden, Width: 60, StripFeedPrefix: true}, th))
+	if strings.Contains(stripped, "TechBlog") || !strings.Contains(stripped, "New phone released") {
+		t.Fatalf("expected the feed prefix stripped, got %q", stripped)
+	}
+	compact := stripANSI(RenderEntryLine(EntryLineParams{Entry: entry, DateColumn: DateColumnHidden, Width: 60, Compact: true, StripFeedPrefix: true}, th))
+	if !strings.Contains(compact, "TechBlog | New phone released") {
+		t.Fatalf("expected the compact label to name the feed once, got %q", compact)
+	}
+}
+
 func TestRelativeTimeLabel(t *testing.T) {
 	now := time.Date(2026, 2, 9, 12, 0, 0, 0, time.UTC)
 	cases := []struct {
@@ -70,3 +302,35 @@ func TestRelativeTimeLabel(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderEntryLine_ShortensURLTitle(t *testing.T) {
+	now := time.Date(2026, 2, 9, 12, 0, 0, 0, time.UTC)
+	th := tuitheme.Default()
+	entry := feedbin.Entry{ID: 1, Title: "https://example.com/2026/02/09/links/daily-roundup", PublishedAt: now}
+	params := EntryLineParams{Entry: entry, Now: now, DateColumn: DateColumnHidden, Width: 48, ShortenURLs: true}
+
+	if got := stripANSI(RenderEntryLine(params, th)); !strings.Contains(got, "https://example.com/…/daily-roundup") {
+		t.Fatalf("expected shortened URL title, got %q", got)
+	}
+	params.ShortenURLs = false
+	if got := stripANSI(RenderEntryLine(params, th)); !strings.Contains(got, "https://example.com/2026/02/09/links/d...") {
+		t.Fatalf("expected plain truncation without shortening, got %q", got)
+	}
+}
+
+func TestRenderEntryLine_CommentCountOnlyWhenPresent(t *testing.T) {
+	now := time.Date(2026, 2, 9, 12, 0, 0, 0, time.UTC)
+	th := tuitheme.Default()
+	entry := feedbin.Entry{ID: 1, Title: "Show HN", PublishedAt: now.Add(-2 * time.Hour)}
+
+	line := stripANSI(RenderEntryLine(EntryLineParams{Entry: entry, Now: now, Width: 60, CommentCount: 42, HasCommentCount: true}, th))
+	if !strings.Contains(line, "💬 42 ") {
+		t.Fatalf("expected the comment count next to the date, got %q", line)
+	}
+	if visibleLen(line)+1 != 60 {
+		t.Fatalf("expected the wide icon to keep the line 60 cells, got %d", visibleLen(line)+1)
+	}
+	if line := stripANSI(RenderEntryLine(EntryLineParams{Entry: entry, Now: now, Width: 60}, th)); strings.Contains(line, "💬") {
+		t.Fatalf("expected nothing without a count, got %q", line)
+	}
+}
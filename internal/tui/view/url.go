@@ -0,0 +1,63 @@
+package view
+
+import (
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// shortenURL fits an absolute URL into maxWidth runes for display by eliding
+// the middle of its path, keeping the scheme, host and last path segment:
+// "https://example.com/…/article". The query and fragment go first, and a
+// last segment that still does not fit is cut at its end. Anything that is
+// not an absolute URL is truncated like other text.
+func shortenURL(raw string, maxWidth int) string {
+	if utf8.RuneCountInString(raw) <= maxWidth {
+		return raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return truncateRunes(raw, maxWidth)
+	}
+	base := u.Scheme + "://" + u.Host
+	path := strings.Trim(u.EscapedPath(), "/")
+	if path == "" {
+		return truncateRunes(base, maxWidth)
+	}
+	if fits(base+"/"+path, maxWidth) {
+		return base + "/" + path
+	}
+	segments := strings.Split(path, "/")
+	last := segments[len(segments)-1]
+	sep := "/"
+	if len(segments) > 1 {
+		sep = "/…/"
+	}
+	if fits(base+sep+last, maxWidth) {
+		return base + sep + last
+	}
+	// Keep at least a few characters of the last segment; below that the host
+	// alone says more.
+	budget := maxWidth - utf8.RuneCountInString(base+sep)
+	if budget < 4 {
+		if fits(base+"/…", maxWidth) {
+			return base + "/…"
+		}
+		return truncateRunes(base, maxWidth)
+	}
+	runes := []rune(last)
+	return base + sep + string(runes[:budget-1]) + "…"
+}
+
+func fits(s string, maxWidth int) bool {
+	return utf8.RuneCountInString(s) <= maxWidth
+}
+
+// looksLikeURL reports whether s is a single absolute http(s) URL, as some
+// feeds use for entry titles.
+func looksLikeURL(s string) bool {
+	if strings.ContainsAny(s, " \t\n") {
+		return false
+	}
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
@@ -0,0 +1,55 @@
+package view
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+)
+
+func TestShortenURL(t *testing.T) {
+	cases := []struct {
+		name  string
+		raw   string
+		width int
+		want  string
+	}{
+		{"fits", "https://example.com/a/b", 40, "https://example.com/a/b"},
+		{"elides middle", "https://example.com/2026/02/09/category/article-slug", 40, "https://example.com/…/article-slug"},
+		{"drops query first", "https://example.com/posts/article?utm_source=feed&utm_medium=rss", 40, "https://example.com/posts/article"},
+		{"trailing slash", "https://example.com/2026/02/09/category/article/", 36, "https://example.com/…/article"},
+		{"single segment cut", "https://example.com/a-very-long-article-slug-with-many-words", 36, "https://example.com/a-very-long-art…"},
+		{"long last segment cut", "https://example.com/2026/a-very-long-article-slug-with-many-words", 36, "https://example.com/…/a-very-long-a…"},
+		{"host only", "https://a-really-long-subdomain.example.com/", 20, "https://a-really-..."},
+		{"tiny width keeps host", "https://example.com/2026/02/article", 22, "https://example.com/…"},
+		{"not a url", strings.Repeat("x", 30), 10, "xxxxxxx..."},
+	}
+	for _, tc := range cases {
+		got := shortenURL(tc.raw, tc.width)
+		if got != tc.want {
+			t.Fatalf("%s: shortenURL(%q, %d) = %q, want %q", tc.name, tc.raw, tc.width, got, tc.want)
+		}
+		if n := len([]rune(got)); n > tc.width {
+			t.Fatalf("%s: %q is %d runes, over width %d", tc.name, got, n, tc.width)
+		}
+	}
+}
+
+func TestDetailMetaLines_ShortensLongURL(t *testing.T) {
+	entry := feedbin.Entry{
+		Title:       "Entry",
+		URL:         "https://example.com/2026/02/09/some/deeply/nested/path/final-article",
+		PublishedAt: time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC),
+	}
+	wrap := func(s string, width int) []string { return []string{s[:min(len(s), width)], s[min(len(s), width):]} }
+
+	lines := DetailMetaLines(entry, 40, nil, true, "", wrap)
+	if got := lines[len(lines)-1]; got != "URL: https://example.com/…/final-article" {
+		t.Fatalf("expected shortened URL line, got %q", got)
+	}
+	lines = DetailMetaLines(entry, 40, nil, false, "", wrap)
+	if got := lines[len(lines)-1]; !strings.HasSuffix(entry.URL, got) {
+		t.Fatalf("expected wrapped full URL without shortening, got %q", got)
+	}
+}
@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/glabrego/reeder-cli/internal/feedbin"
+	tuiactions "github.com/glabrego/reeder-cli/internal/tui/actions"
+	tuistate "github.com/glabrego/reeder-cli/internal/tui/state"
+)
+
+// EntryWindowLoader reads one window of the cache for windowed loading.
+type EntryWindowLoader interface {
+	ListCachedWindow(ctx context.Context, filter string, offset, limit int) ([]feedbin.Entry, error)
+}
+
+// entryWindowEdgeRows is how close to either end of the tree the cursor
+// gets before the next or previous window is read.
+const entryWindowEdgeRows = 3
+
+// SetEntryWindow holds at most size entries in memory; once the loaded
+// pages fill that many, scrolling near either end of the list reads the
+// adjacent window of the cache instead of growing the list. Zero holds every
+// loaded entry.
+func (m *Model) SetEntryWindow(loader EntryWindowLoader, size int) {
+	m.windowLoader = loader
+	m.windowSize = max(size, 0)
+	m.windowOffset = 0
+	m.windowFilled = m.windowSize > 0 && len(m.entries) >= m.windowSize
+}
+
+func (m Model) windowed() bool {
+	return m.windowSize > 0 && m.windowLoader != nil
+}
+
+// capToWindow keeps a cache read within the entry window.
+func (m Model) capToWindow(limit int) int {
+	if m.windowSize > 0 {
+		return min(limit, m.windowSize)
+	}
+	return limit
+}
+
+// resetEntryWindow records that the entries were read from the newest end of
+// the cache again, fetched of them before filtering.
+func (m *Model) resetEntryWindow(fetched int) {
+	m.windowOffset = 0
+	m.windowFilled = m.windowSize > 0 && fetched >= m.windowSize
+}
+
+// slideEntryWindow reads the adjacent window of the cache when the list
+// cursor comes near either end of the window: older entries while the window
+// is full, newer ones whenever it does not start at the newest entry, so a
+// partial tail window can still be scrolled back up. Only the date-ordered
+// compact list slides: the cache is read newest first, and the ends of the
+// folder tree say nothing about which entries are oldest.
+func (m Model) slideEntryWindow() (Model, tea.Cmd) {
+	if !m.windowed() || m.loading || m.searchQuery != "" || m.inDetail || !m.dateOrdered() {
+		return m, nil
+	}
+	rows := m.treeRows()
+	switch {
+	case m.treeCursor >= len(rows)-entryWindowEdgeRows && m.windowFilled:
+		return m.shiftEntryWindow(1)
+	case m.treeCursor < entryWindowEdgeRows && m.windowOffset > 0:
+		return m.shiftEntryWindow(-1)
+	}
+	return m, nil
+}
+
+// dateOrdered reports whether the list shows entries in cache order, newest
+// first.
+func (m Model) dateOrdered() bool {
+	return m.compact && !m.compactUnreadFirst
+}
+
+// shiftEntryWindow moves the window half its size towards older (direction
+// > 0) or newer entries, so the entries around the cursor stay loaded. In the
+// folder tree, whose order does not follow the cache, a move towards older
+// entries stops short of dropping the selected entry when that still makes
+// progress.
+func (m Model) shiftEntryWindow(direction int) (Model, tea.Cmd) {
+	offset := max(m.windowOffset+direction*m.windowSize/2, 0)
+	if direction > 0 && !m.dateOrdered() {
+		if pos, ok := m.anchorWindowPosition(); ok && pos > m.windowOffset {
+			offset = min(offset, pos)
+		}
+	}
+	m.loading = true
+	m.err = nil
+	return m, tuiactions.LoadEntryWindowCmd(m.windowLoader, m.filter, offset, m.windowSize)
+}
+
+// anchorWindowPosition is a lower bound on the cache offset of the anchor
+// entry: the window offset plus the loaded entries published after it.
+func (m Model) anchorWindowPosition() (int, bool) {
+	anchorID := m.anchorEntryID()
+	if anchorID == 0 {
+		return 0, false
+	}
+	i := tuistate.EntryIndexByID(m.entries, anchorID)
+	if i < 0 {
+		return 0, false
+	}
+	pos := m.windowOffset
+	for _, entry := range m.entries {
+		if entry.PublishedAt.After(m.entries[i].PublishedAt) {
+			pos++
+		}
+	}
+	return pos, true
+}
+
+func (m Model) finishEntryWindow(msg tuiactions.EntryWindowSuccessMsg) (tea.Model, tea.Cmd) {
+	m.loading = false
+	if msg.Filter != m.filter || m.searchQuery != "" {
+		return m, nil
+	}
+	if len(msg.Entries) == 0 {
+		m.windowFilled = false
+		m.status = "No more entries"
+		return m, nil
+	}
+	anchorID := m.anchorEntryID()
+	m.windowOffset = msg.Offset
+	m.windowFilled = len(msg.Entries) >= m.windowSize
+	m.entries = msg.Entries
+	m.mutedCount = 0
+	m.reapplyPendingToggles()
+	m.applyCurrentFilter()
+	m.restoreSelection(anchorID)
+	m.status = fmt.Sprintf("Showing entries %d–%d", msg.Offset+1, msg.Offset+len(msg.Entries))
+	return m, nil
+}